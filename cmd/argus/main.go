@@ -21,6 +21,7 @@ import (
 	"github.com/Priyans-hu/argus/internal/ai"
 	"github.com/Priyans-hu/argus/internal/analyzer"
 	"github.com/Priyans-hu/argus/internal/config"
+	"github.com/Priyans-hu/argus/internal/detector"
 	"github.com/Priyans-hu/argus/internal/generator"
 	"github.com/Priyans-hu/argus/internal/merger"
 	"github.com/Priyans-hu/argus/internal/usage"
@@ -48,6 +49,14 @@ var (
 	insightsFormat    string
 	insightsSubagents bool
 	aiMode            bool
+	diagramStyle      string
+	diagramFormat     string
+	diagramOutput     string
+	diagramMaxNodes   int
+	nestedMode        bool
+	affectedSince     string
+	affectedFormat    string
+	explainFormat     string
 )
 
 var rootCmd = &cobra.Command{
@@ -125,6 +134,68 @@ Data is read locally from ~/.claude/projects/ and never sent anywhere.`,
 	RunE: runInsights,
 }
 
+var archCmd = &cobra.Command{
+	Use:   "arch",
+	Short: "Work with architecture rules",
+	Long: `Work with the architecture layers and dependency rules declared
+in the architecture section of .argus.yaml.`,
+}
+
+var archCheckCmd = &cobra.Command{
+	Use:   "check [path]",
+	Short: "Check imports against architecture rules",
+	Long: `Check the Go, TypeScript/JavaScript, Python and Rust imports in the
+specified directory (or current directory) against the layers declared in
+.argus.yaml. Reports every import that crosses a forbidden layer boundary
+and every import cycle, and exits non-zero when any are found so it can
+run in CI.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runArchCheck,
+}
+
+var diagramCmd = &cobra.Command{
+	Use:   "diagram [path]",
+	Short: "Draw the architecture as a Mermaid or Graphviz diagram",
+	Long: `Draw the layers and package dependency graph of the specified directory
+(or current directory) as a Mermaid flowchart or a Graphviz DOT digraph.
+
+Large graphs are kept readable by folding the deepest leaf packages into
+their parent until at most --max-nodes packages remain (25 by default,
+0 draws every package).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDiagram,
+}
+
+var affectedCmd = &cobra.Command{
+	Use:   "affected [path]",
+	Short: "List monorepo workspaces affected by changes since a git ref",
+	Long: `List the workspaces in the specified monorepo (or current directory)
+touched since the current branch forked from --since (the merge base, as
+in git diff <ref>...HEAD) or by uncommitted and untracked files, plus every
+workspace that depends on them through internal package dependencies, with
+the command that runs each workspace's tests.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runAffected,
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <convention> [path]",
+	Short: "Show the evidence behind a detected convention or pattern",
+	Long: `Show why argus reports a convention, code pattern, error handling
+practice or logger for the specified directory (or current directory): its
+confidence, the counts it was inferred from, sample file:line locations and
+the config file it was read from. Matches every finding whose description
+or name contains the given text, case-insensitively.`,
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runExplain,
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
@@ -161,6 +232,8 @@ func init() {
 	scanCmd.Flags().BoolVar(&usageMode, "usage", false, "Include AI usage insights from Claude Code session logs")
 	scanCmd.Flags().BoolVar(&aiMode, "ai", false, "Enrich output with AI-generated insights via local Ollama")
 	scanCmd.Flags().BoolVar(&monorepoMode, "monorepo", false, "Generate output per workspace in monorepo projects")
+	scanCmd.Flags().StringVar(&diagramStyle, "diagram", "", "Architecture diagram in CLAUDE.md: ascii, mermaid, dot")
+	scanCmd.Flags().BoolVar(&nestedMode, "nested", false, "Write local CLAUDE.md files in significant subdirectories")
 
 	// Sync command flags
 	syncCmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would be generated without writing files")
@@ -171,12 +244,25 @@ func init() {
 	syncCmd.Flags().BoolVarP(&parallel, "parallel", "p", true, "Run detectors in parallel for faster analysis (default: true)")
 	syncCmd.Flags().BoolVar(&usageMode, "usage", false, "Include AI usage insights from Claude Code session logs")
 	syncCmd.Flags().BoolVar(&aiMode, "ai", false, "Enrich output with AI-generated insights via local Ollama")
+	syncCmd.Flags().StringVar(&diagramStyle, "diagram", "", "Architecture diagram in CLAUDE.md: ascii, mermaid, dot")
 
 	// Insights command flags
 	insightsCmd.Flags().StringVarP(&insightsSince, "since", "s", "", "Date filter (e.g., 7d, 30d, 2025-01-01)")
 	insightsCmd.Flags().StringVarP(&insightsFormat, "format", "f", "text", "Output format: text, json")
 	insightsCmd.Flags().BoolVar(&insightsSubagents, "subagents", true, "Include subagent data")
 
+	// Diagram command flags
+	diagramCmd.Flags().StringVarP(&diagramFormat, "format", "f", "mermaid", "Diagram format: mermaid, dot, ascii")
+	diagramCmd.Flags().StringVarP(&diagramOutput, "output", "o", "", "Write the diagram to a file instead of stdout")
+	diagramCmd.Flags().IntVar(&diagramMaxNodes, "max-nodes", generator.DefaultDiagramNodes, "Maximum packages to draw before folding leaf packages into their parent (0 = no limit)")
+
+	// Affected command flags
+	affectedCmd.Flags().StringVarP(&affectedSince, "since", "s", "", "Git ref to diff against (e.g., main, origin/main, HEAD~3)")
+	affectedCmd.Flags().StringVarP(&affectedFormat, "format", "f", "text", "Output format: text, json")
+
+	// Explain command flags
+	explainCmd.Flags().StringVarP(&explainFormat, "format", "f", "text", "Output format: text, json")
+
 	// Watch command flags
 	watchCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed output")
 	watchCmd.Flags().BoolVarP(&mergeMode, "merge", "m", true, "Preserve custom sections when regenerating (default: true)")
@@ -186,6 +272,11 @@ func init() {
 	rootCmd.AddCommand(syncCmd)
 	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(insightsCmd)
+	archCmd.AddCommand(archCheckCmd)
+	rootCmd.AddCommand(archCmd)
+	rootCmd.AddCommand(diagramCmd)
+	rootCmd.AddCommand(affectedCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(upgradeCmd)
 }
@@ -308,6 +399,13 @@ func runScan(cmd *cobra.Command, args []string) error {
 			Description: conv,
 		})
 	}
+	analysis.ArchitectureRules = cfg.Architecture.Rules()
+	applyChurnWindow(absPath, cfg, analysis)
+	applyConfidenceThreshold(cfg, analysis)
+	applyNotebookSummaries(cfg, analysis)
+	if diagramStyle == "" {
+		diagramStyle = cfg.Architecture.DiagramFormat()
+	}
 
 	if verbose {
 		fmt.Printf("\n📊 Analysis Results:\n")
@@ -344,6 +442,13 @@ func runScan(cmd *cobra.Command, args []string) error {
 		return runMonorepoScan(ctx, absPath, cfg, formats, analysis)
 	}
 
+	// Local context files in significant subdirectories
+	if nestedMode || cfg.Nested.IsEnabled() {
+		if err := generateNestedContexts(ctx, absPath, cfg, analysis); err != nil {
+			fmt.Printf("⚠️  Nested context: %v\n", err)
+		}
+	}
+
 	// Generate output for each format
 	for _, format := range formats {
 		if err := generateOutput(absPath, format, analysis, dryRun, compactMode); err != nil {
@@ -375,6 +480,11 @@ func runMonorepoScan(ctx context.Context, absPath string, cfg *config.Config, fo
 
 	fmt.Printf("   Found %d workspaces\n", len(wsResults))
 
+	rootOverview := true
+	if cfg.Monorepo != nil {
+		rootOverview = cfg.Monorepo.RootOverview
+	}
+
 	// Generate per-workspace output
 	successCount := 0
 	for _, ws := range wsResults {
@@ -398,9 +508,15 @@ func runMonorepoScan(ctx context.Context, absPath string, cfg *config.Config, fo
 				}
 			}
 		}
+		applyConfidenceThreshold(cfg, ws.Analysis)
+		applyNotebookSummaries(cfg, ws.Analysis)
 
 		wsAbsPath := filepath.Join(absPath, ws.Path)
 		for _, format := range wsFormats {
+			// the root overview takes the root workspace's CLAUDE.md
+			if ws.Path == "." && rootOverview && format == "claude" {
+				continue
+			}
 			if err := generateOutput(wsAbsPath, format, ws.Analysis, dryRun, compactMode); err != nil {
 				fmt.Printf("   [warn] %s (%s): %v\n", ws.Path, format, err)
 			}
@@ -409,11 +525,6 @@ func runMonorepoScan(ctx context.Context, absPath string, cfg *config.Config, fo
 	}
 
 	// Generate root overview
-	rootOverview := true
-	if cfg.Monorepo != nil {
-		rootOverview = cfg.Monorepo.RootOverview
-	}
-
 	if rootOverview {
 		var wsInfos []generator.WorkspaceInfo
 		for _, ws := range wsResults {
@@ -433,6 +544,8 @@ func runMonorepoScan(ctx context.Context, absPath string, cfg *config.Config, fo
 			})
 		}
 
+		rootAnalysis.MonorepoInfo.Dependencies = ma.DependencyGraph(rootAnalysis.MonorepoInfo)
+
 		overviewGen := generator.NewMonorepoOverviewGenerator("claude")
 		overview, err := overviewGen.Generate(rootAnalysis, wsInfos)
 		if err != nil {
@@ -457,6 +570,72 @@ func runMonorepoScan(ctx context.Context, absPath string, cfg *config.Config, fo
 	return nil
 }
 
+// generateNestedContexts picks significant subdirectories, analyzes each on
+// its own and writes a concise CLAUDE.md there. The selected directories are
+// recorded on the root analysis so the root file links to them.
+func generateNestedContexts(ctx context.Context, absPath string, cfg *config.Config, analysis *types.Analysis) error {
+	opts := analyzer.NestedOptions{}
+	if cfg.Nested != nil {
+		opts = analyzer.NestedOptions{
+			MinFiles: cfg.Nested.MinFiles,
+			MaxDirs:  cfg.Nested.MaxDirs,
+			Include:  cfg.Nested.Include,
+			Exclude:  cfg.Nested.Exclude,
+		}
+	}
+
+	dirs := analyzer.SelectNestedDirs(absPath, analysis.Structure.Directories, opts)
+	if len(dirs) == 0 {
+		if verbose {
+			fmt.Println("   No subdirectories large or distinct enough for a nested context file")
+		}
+		return nil
+	}
+
+	paths := make([]string, len(dirs))
+	for i, dir := range dirs {
+		paths[i] = dir.Path
+	}
+	results := analyzer.NewMonorepoAnalyzer(absPath, parallel, 4).AnalyzeDirs(ctx, paths)
+
+	gen := generator.NewNestedContextGenerator()
+	var written []analyzer.NestedDir
+	for i, res := range results {
+		if res.Error != nil {
+			fmt.Printf("   [skip] %s: %v\n", res.Path, res.Error)
+			continue
+		}
+		nested := types.NestedContext{Path: dirs[i].Path, Purpose: dirs[i].Purpose}
+		content, err := gen.Generate(nested, res.Analysis, analysis)
+		if err != nil {
+			return fmt.Errorf("%s: %w", dirs[i].Path, err)
+		}
+
+		outPath := filepath.Join(absPath, filepath.FromSlash(dirs[i].Path), gen.OutputFile())
+		if mergeMode {
+			if existing, err := os.ReadFile(outPath); err == nil && len(existing) > 0 {
+				content = merger.NewMerger(true).Merge(existing, content)
+			}
+		}
+
+		if dryRun {
+			fmt.Printf("\n📄 Would write to %s (%s):\n", outPath, dirs[i].Reason)
+			fmt.Println("---")
+			fmt.Println(string(content))
+			fmt.Println("---")
+		} else {
+			if err := os.WriteFile(outPath, content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outPath, err)
+			}
+			fmt.Printf("✅ Generated %s\n", outPath)
+		}
+		written = append(written, dirs[i])
+	}
+
+	analysis.NestedContexts = analyzer.NestedContexts(written)
+	return nil
+}
+
 func runSync(cmd *cobra.Command, args []string) error {
 	// Determine target path
 	targetPath := "."
@@ -518,6 +697,13 @@ func runSync(cmd *cobra.Command, args []string) error {
 			Description: conv,
 		})
 	}
+	analysis.ArchitectureRules = cfg.Architecture.Rules()
+	applyChurnWindow(absPath, cfg, analysis)
+	applyConfidenceThreshold(cfg, analysis)
+	applyNotebookSummaries(cfg, analysis)
+	if diagramStyle == "" {
+		diagramStyle = cfg.Architecture.DiagramFormat()
+	}
 
 	if verbose {
 		fmt.Printf("\n📊 Analysis Results:\n")
@@ -541,6 +727,13 @@ func runSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Local context files in significant subdirectories
+	if cfg.Nested.IsEnabled() {
+		if err := generateNestedContexts(ctx, absPath, cfg, analysis); err != nil {
+			fmt.Printf("⚠️  Nested context: %v\n", err)
+		}
+	}
+
 	// Generate output for each format in config
 	for _, format := range cfg.Output {
 		if err := generateOutput(absPath, format, analysis, dryRun, compactMode); err != nil {
@@ -570,6 +763,7 @@ func generateOutput(absPath, format string, analysis *types.Analysis, dryRun, co
 	case "claude":
 		g := generator.NewClaudeGenerator()
 		g.SetCompact(compact)
+		g.SetDiagramFormat(diagramStyle)
 		gen = g
 		outputFile = g.OutputFile()
 	case "cursor":
@@ -843,6 +1037,13 @@ func regenerateWithAnalyzer(ctx context.Context, absPath string, cfg *config.Con
 			Description: conv,
 		})
 	}
+	analysis.ArchitectureRules = cfg.Architecture.Rules()
+	applyChurnWindow(absPath, cfg, analysis)
+	applyConfidenceThreshold(cfg, analysis)
+	applyNotebookSummaries(cfg, analysis)
+	if diagramStyle == "" {
+		diagramStyle = cfg.Architecture.DiagramFormat()
+	}
 
 	// Generate output for each format
 	for _, format := range cfg.Output {
@@ -982,6 +1183,363 @@ func runInsights(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runArchCheck(cmd *cobra.Command, args []string) error {
+	targetPath := "."
+	if len(args) > 0 {
+		targetPath = args[0]
+	}
+
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	cfg, err := config.ValidateAndLoad(absPath)
+	if err != nil {
+		if fix := config.SuggestFix(err); fix != "" {
+			return fmt.Errorf("%w\n%s", err, fix)
+		}
+		return err
+	}
+
+	rules := cfg.Architecture.Rules()
+	if rules == nil {
+		return fmt.Errorf("no architecture layers declared in %s\nAdd an 'architecture' section (see 'argus init')", config.ConfigFileName)
+	}
+
+	files, err := analyzer.NewWalker(absPath).Walk(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	report := detector.NewArchitectureAnalyzer(absPath, files).Check(rules)
+
+	for _, v := range report.Violations {
+		fmt.Printf("%s:%d: %s must not import %s (%q)\n", v.File, v.Line, v.FromLayer, v.ToLayer, v.Import)
+	}
+	for _, c := range report.Cycles {
+		fmt.Printf("import cycle: %s -> %s\n", strings.Join(c.Packages, " -> "), c.Packages[0])
+		for i, loc := range c.Imports {
+			fmt.Printf("    %s -> %s at %s\n", c.Packages[i], c.Packages[(i+1)%len(c.Packages)], loc)
+		}
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("architecture check failed: %d violation(s), %d cycle(s)", len(report.Violations), len(report.Cycles))
+	}
+
+	fmt.Printf("✅ No architecture violations across %d layer(s)\n", len(rules.Layers))
+	return nil
+}
+
+func runAffected(cmd *cobra.Command, args []string) error {
+	if affectedSince == "" {
+		return fmt.Errorf("--since is required (e.g., --since main)")
+	}
+
+	targetPath := "."
+	if len(args) > 0 {
+		targetPath = args[0]
+	}
+
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	files, err := analyzer.NewWalker(absPath).Walk(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	info := detector.NewMonorepoDetector(absPath, files).Detect()
+	if info == nil {
+		return fmt.Errorf("%s is not a monorepo: no workspaces detected", absPath)
+	}
+
+	changed, err := analyzer.ChangedFilesSince(absPath, affectedSince)
+	if err != nil {
+		return err
+	}
+
+	ma := analyzer.NewMonorepoAnalyzer(absPath, false, 0)
+	affected, outside := ma.Affected(info, changed)
+
+	if affectedFormat == "json" {
+		data, err := json.MarshalIndent(struct {
+			Since      string                       `json:"since"`
+			Workspaces []analyzer.AffectedWorkspace `json:"workspaces"`
+			Outside    []string                     `json:"outside_workspaces,omitempty"`
+		}{affectedSince, affected, outside}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(affected) == 0 {
+		fmt.Printf("No workspaces affected since %s\n", affectedSince)
+	} else {
+		fmt.Printf("Affected workspaces since %s:\n", affectedSince)
+		for _, ws := range affected {
+			fmt.Printf("  %s (%s) - %s\n", ws.Path, ws.Name, ws.Reason)
+		}
+	}
+
+	if len(outside) > 0 {
+		fmt.Printf("\nChanged files outside any workspace:\n")
+		for _, file := range outside {
+			fmt.Printf("  %s\n", file)
+		}
+	}
+
+	var commands []string
+	for _, ws := range affected {
+		if ws.TestCommand != "" {
+			commands = append(commands, ws.TestCommand)
+		}
+	}
+	if len(commands) > 0 {
+		fmt.Printf("\nRun their tests:\n")
+		for _, c := range commands {
+			fmt.Printf("  %s\n", c)
+		}
+	}
+	return nil
+}
+
+// explainedFinding is one convention or pattern matched by argus explain
+type explainedFinding struct {
+	Kind        string          `json:"kind"` // "convention", "pattern", "error-practice" or "logger"
+	Category    string          `json:"category"`
+	Description string          `json:"description"`
+	Confidence  float64         `json:"confidence"`
+	Shown       bool            `json:"shown"`
+	Evidence    *types.Evidence `json:"evidence,omitempty"`
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	query := strings.ToLower(strings.TrimSpace(args[0]))
+	if query == "" {
+		return fmt.Errorf("convention text must not be empty")
+	}
+
+	targetPath := "."
+	if len(args) > 1 {
+		targetPath = args[1]
+	}
+
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	cfg, err := config.ValidateAndLoad(absPath)
+	if err != nil {
+		if fix := config.SuggestFix(err); fix != "" {
+			return fmt.Errorf("%w\n%s", err, fix)
+		}
+		return err
+	}
+	threshold := cfg.ConfidenceThreshold()
+
+	analysis, err := analyzer.NewAnalyzer(absPath, nil).Analyze(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+
+	var findings []explainedFinding
+	for _, conv := range analysis.Conventions {
+		if strings.Contains(strings.ToLower(conv.Description), query) {
+			findings = append(findings, explainedFinding{
+				Kind:        "convention",
+				Category:    conv.Category,
+				Description: conv.Description,
+				Confidence:  conv.Confidence,
+				Shown:       detector.AboveConfidence(conv.Confidence, threshold),
+				Evidence:    conv.Evidence,
+			})
+		}
+	}
+	if cp := analysis.CodePatterns; cp != nil {
+		for _, group := range [][]types.PatternInfo{
+			cp.StateManagement, cp.DataFetching, cp.Routing, cp.Forms, cp.Testing, cp.Styling,
+			cp.Authentication, cp.APIPatterns, cp.DatabaseORM, cp.Utilities, cp.GoPatterns,
+			cp.RustPatterns, cp.PythonPatterns, cp.MLPatterns,
+		} {
+			for _, p := range group {
+				if strings.Contains(strings.ToLower(p.Name), query) || strings.Contains(strings.ToLower(p.Description), query) {
+					findings = append(findings, explainedFinding{
+						Kind:        "pattern",
+						Category:    p.Category,
+						Description: fmt.Sprintf("%s - %s", p.Name, p.Description),
+						Confidence:  p.Confidence,
+						Shown:       detector.AboveConfidence(p.Confidence, threshold),
+						Evidence:    p.Evidence,
+					})
+				}
+			}
+		}
+	}
+
+	if eh := analysis.ErrorHandling; eh != nil {
+		for _, p := range eh.Practices {
+			if strings.Contains(strings.ToLower(p.Instruction), query) || strings.Contains(p.Kind, query) {
+				findings = append(findings, explainedFinding{
+					Kind:        "error-practice",
+					Category:    p.Kind,
+					Description: fmt.Sprintf("%s: %s", p.Language, p.Instruction),
+					Confidence:  p.Confidence,
+					Shown:       detector.AboveConfidence(p.Confidence, threshold),
+					Evidence:    p.Evidence,
+				})
+			}
+		}
+		for _, l := range eh.Loggers {
+			if strings.Contains(strings.ToLower(l.Instruction), query) || strings.Contains(l.Library, query) {
+				findings = append(findings, explainedFinding{
+					Kind:        "logger",
+					Category:    "logging",
+					Description: fmt.Sprintf("%s: %s", l.Language, l.Instruction),
+					Confidence:  l.Confidence,
+					Shown:       detector.AboveConfidence(l.Confidence, threshold),
+					Evidence:    l.Evidence,
+				})
+			}
+		}
+	}
+
+	if len(findings) == 0 {
+		return fmt.Errorf("no detected convention, pattern, error practice or logger matches %q", args[0])
+	}
+
+	if explainFormat == "json" {
+		data, err := json.MarshalIndent(struct {
+			Threshold float64            `json:"threshold"`
+			Findings  []explainedFinding `json:"findings"`
+		}{threshold, findings}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for i, f := range findings {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", f.Description)
+		fmt.Printf("  Kind:       %s (%s)\n", f.Kind, f.Category)
+		status := "shown"
+		if !f.Shown {
+			status = "hidden"
+		}
+		fmt.Printf("  Confidence: %.2f - %s, threshold %.2f\n", f.Confidence, status, threshold)
+		if e := f.Evidence; e != nil {
+			if e.Source != "" {
+				fmt.Printf("  Source:     %s\n", e.Source)
+			}
+			if summary := describeEvidence(e); summary != "" {
+				fmt.Printf("  Evidence:   %s\n", summary)
+			}
+			for j, sample := range e.Samples {
+				label := ""
+				if j == 0 {
+					label = "Samples:"
+				}
+				fmt.Printf("  %-11s %s\n", label, sample)
+			}
+		}
+	}
+	return nil
+}
+
+// describeEvidence summarizes the counts behind a finding
+func describeEvidence(e *types.Evidence) string {
+	var parts []string
+	switch {
+	case e.Total > 0:
+		parts = append(parts, fmt.Sprintf("%d of %d occurrences agree", e.Matches, e.Total))
+	case e.Matches > 0 && e.Matches != e.Files:
+		parts = append(parts, fmt.Sprintf("%d occurrences", e.Matches))
+	}
+	if e.Files > 0 {
+		parts = append(parts, fmt.Sprintf("seen in %d files", e.Files))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runDiagram(cmd *cobra.Command, args []string) error {
+	targetPath := "."
+	if len(args) > 0 {
+		targetPath = args[0]
+	}
+
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	files, err := analyzer.NewWalker(absPath).Walk(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	arch := detector.NewArchitectureDetector(absPath, files).Detect()
+	diagram, err := generator.RenderDiagram(arch, diagramFormat, diagramMaxNodes)
+	if err != nil {
+		return err
+	}
+
+	if diagramOutput == "" {
+		fmt.Print(diagram)
+		return nil
+	}
+
+	if err := os.WriteFile(diagramOutput, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("failed to write diagram: %w", err)
+	}
+	fmt.Printf("✅ Wrote %s diagram to %s\n", diagramFormat, diagramOutput)
+	return nil
+}
+
+// applyChurnWindow re-runs churn analysis when the config overrides the
+// default window. GitConventions is copied since watch mode caches it.
+func applyChurnWindow(absPath string, cfg *config.Config, analysis *types.Analysis) {
+	days := cfg.Git.ChurnWindow()
+	if days <= 0 || days == detector.DefaultChurnWindowDays || analysis.GitConventions == nil {
+		return
+	}
+	conventions := *analysis.GitConventions
+	conventions.Churn = detector.NewGitDetectorGoGit(absPath).DetectChurn(days)
+	analysis.GitConventions = &conventions
+}
+
+// applyConfidenceThreshold drops conventions and patterns scored below the
+// configured minimum confidence. CodePatterns is copied since watch mode caches it.
+func applyConfidenceThreshold(cfg *config.Config, analysis *types.Analysis) {
+	threshold := cfg.ConfidenceThreshold()
+	analysis.Conventions = detector.ConventionsAbove(analysis.Conventions, threshold)
+	analysis.CodePatterns = detector.CodePatternsAbove(analysis.CodePatterns, threshold)
+	analysis.ErrorHandling = detector.ErrorHandlingAbove(analysis.ErrorHandling, threshold)
+}
+
+// applyNotebookSummaries drops notebook titles and sections unless the config
+// asks for summaries. Notebooks is copied since watch mode caches it.
+func applyNotebookSummaries(cfg *config.Config, analysis *types.Analysis) {
+	if cfg.Notebooks.SummariesEnabled() || len(analysis.Notebooks) == 0 {
+		return
+	}
+	notebooks := make([]types.Notebook, len(analysis.Notebooks))
+	for i, nb := range analysis.Notebooks {
+		nb.Title, nb.Sections = "", nil
+		notebooks[i] = nb
+	}
+	analysis.Notebooks = notebooks
+}
+
 func attachUsageInsights(ctx context.Context, absPath string, analysis *types.Analysis) error {
 	opts := usage.Options{
 		Since:            time.Now().AddDate(0, -1, 0), // Last 30 days
@@ -81,6 +81,27 @@ func (a *Analyzer) Analyze(ctx context.Context) (*types.Analysis, error) {
 	// Detect key files
 	analysis.KeyFiles = structureDetector.DetectKeyFiles()
 
+	// Detect code owners and annotate directories and key files
+	ownershipDetector := detector.NewOwnershipDetector(absPath, files)
+	analysis.Ownership = ownershipDetector.Detect()
+	detector.AnnotateOwners(analysis.Ownership, &analysis.Structure, analysis.KeyFiles)
+
+	// Detect release tooling, changelog and version tags
+	releaseDetector := detector.NewReleaseDetector(absPath, files)
+	analysis.Release = releaseDetector.Detect()
+
+	// Detect containers, deployment manifests and infrastructure-as-code
+	infraDetector := detector.NewInfraDetector(absPath, files)
+	analysis.Infrastructure = infraDetector.Detect()
+
+	// Inventory tests, test support files and coverage
+	testSuiteDetector := detector.NewTestSuiteDetector(absPath, files)
+	analysis.Tests = testSuiteDetector.Detect()
+
+	// Detect how errors are reported and which logger is used
+	errorHandlingDetector := detector.NewErrorHandlingDetector(absPath, files)
+	analysis.ErrorHandling = errorHandlingDetector.Detect()
+
 	// Detect commands
 	analysis.Commands = detector.DetectCommands(absPath)
 
@@ -98,6 +119,11 @@ func (a *Analyzer) Analyze(ctx context.Context) (*types.Analysis, error) {
 		analysis.Commands = append(analysis.Commands, cargoDetector.DetectCargoCommands()...)
 	}
 
+	// Detect CI pipelines and rank the commands CI runs first
+	ciDetector := detector.NewCIDetector(absPath, files)
+	analysis.CIInfo = ciDetector.Detect()
+	analysis.Commands = detector.ApplyCICommands(analysis.Commands, analysis.CIInfo)
+
 	// Detect dependencies
 	analysis.Dependencies = a.detectDependencies(absPath)
 
@@ -133,6 +159,18 @@ func (a *Analyzer) Analyze(ctx context.Context) (*types.Analysis, error) {
 	}
 	analysis.Endpoints = endpoints
 
+	// Detect message queue / event bus producers and consumers
+	eventDetector := detector.NewEventDetector(absPath, files)
+	analysis.Events = eventDetector.Detect()
+
+	// Parse database migrations and ORM models
+	schemaDetector := detector.NewSchemaDetector(absPath, files)
+	analysis.DatabaseSchema = schemaDetector.Detect()
+
+	// Inventory environment variables read by the code
+	envVarDetector := detector.NewEnvVarDetector(absPath, files)
+	analysis.EnvVars = envVarDetector.Detect()
+
 	// Parse README for project overview
 	readmeDetector := detector.NewReadmeDetector(absPath)
 	analysis.ReadmeContent = readmeDetector.Detect()
@@ -150,6 +188,11 @@ func (a *Analyzer) Analyze(ctx context.Context) (*types.Analysis, error) {
 	if mlPatterns := mlDetector.GetMLPatterns(); len(mlPatterns) > 0 {
 		analysis.CodePatterns.MLPatterns = mlPatterns
 	}
+	analysis.MLWorkflow = mlDetector.DetectWorkflow()
+
+	// Jupyter notebook contents
+	notebookDetector := detector.NewNotebookDetector(absPath, files)
+	analysis.Notebooks = notebookDetector.Detect()
 
 	// Detect git conventions (commit messages, branch naming) - using go-git library
 	gitDetector := detector.NewGitDetectorGoGit(absPath)
@@ -18,10 +18,20 @@ const (
 	ImpactCommands    = "commands"
 	ImpactConventions = "conventions"
 	ImpactEndpoints   = "endpoints"
+	ImpactEvents      = "events"
+	ImpactSchema      = "schema"
+	ImpactEnvVars     = "envvars"
 	ImpactConfig      = "config"
 	ImpactDevelopment = "development"
 	ImpactReadme      = "readme"
 	ImpactGit         = "git"
+	ImpactOwnership   = "ownership"
+	ImpactRelease     = "release"
+	ImpactCI          = "ci"
+	ImpactInfra       = "infra"
+	ImpactTests       = "tests"
+	ImpactML          = "ml"
+	ImpactCLI         = "cli"
 	ImpactAll         = "all"
 )
 
@@ -150,6 +160,42 @@ func DetermineImpact(changedFile string) []string {
 		return []string{ImpactTechStack, ImpactDevelopment}
 	}
 
+	// Workspace and build system manifests define monorepo packages
+	workspaceFiles := map[string]bool{
+		"go.work": true, "settings.gradle": true, "settings.gradle.kts": true,
+		"MODULE.bazel": true, "WORKSPACE": true, "WORKSPACE.bazel": true,
+		"BUILD": true, "BUILD.bazel": true, "pants.toml": true,
+	}
+	if workspaceFiles[name] {
+		return []string{ImpactStructure}
+	}
+
+	// Code owners
+	if name == "CODEOWNERS" {
+		return []string{ImpactOwnership}
+	}
+
+	// Release tooling and changelog
+	releaseFiles := map[string]bool{
+		".releaserc": true, ".releaserc.json": true, ".releaserc.yaml": true, ".releaserc.yml": true,
+		"release.config.js": true, "release.config.cjs": true, "release.config.mjs": true,
+		"release-please-config.json": true, ".release-please-manifest.json": true,
+		"release.toml": true, "VERSION": true, "version.txt": true,
+		"CHANGELOG.md": true, "CHANGELOG": true, "CHANGES.md": true, "HISTORY.md": true,
+	}
+	if releaseFiles[name] || strings.HasPrefix(name, ".releaserc.") || filepath.Base(dir) == ".changeset" {
+		return []string{ImpactRelease}
+	}
+	if name == ".goreleaser.yml" || name == ".goreleaser.yaml" {
+		return []string{ImpactConfig, ImpactDevelopment, ImpactRelease}
+	}
+
+	// Coverage reports
+	if name == "coverage.out" || name == "cover.out" || name == "lcov.info" || name == "coverage.xml" ||
+		name == "cobertura-coverage.xml" || name == ".mockery.yaml" || name == ".mockery.yml" {
+		return []string{ImpactTests}
+	}
+
 	// Makefile changes
 	if name == "Makefile" || name == "makefile" || name == "GNUmakefile" {
 		return []string{ImpactCommands, ImpactDevelopment}
@@ -168,28 +214,70 @@ func DetermineImpact(changedFile string) []string {
 		return []string{ImpactDevelopment}
 	}
 
-	// GitHub workflows and config
-	if strings.Contains(dir, ".github") {
-		return []string{ImpactConfig}
+	// CI pipelines (which may also run the release tool)
+	if strings.Contains(dir, ".github") || strings.Contains(dir, ".circleci") ||
+		name == ".gitlab-ci.yml" || name == "Jenkinsfile" || strings.HasPrefix(name, "azure-pipelines.") {
+		return []string{ImpactConfig, ImpactRelease, ImpactCI}
+	}
+
+	// Containers, orchestration and infrastructure-as-code
+	lowerName := strings.ToLower(name)
+	if lowerName == "dockerfile" || strings.HasPrefix(lowerName, "dockerfile.") || strings.HasSuffix(lowerName, ".dockerfile") {
+		return []string{ImpactConfig, ImpactDevelopment, ImpactInfra}
+	}
+	if (strings.HasPrefix(name, "docker-compose") || strings.HasPrefix(name, "compose.")) && (ext == ".yml" || ext == ".yaml") {
+		// Compose files also declare environment variables
+		return []string{ImpactConfig, ImpactDevelopment, ImpactEnvVars, ImpactInfra}
+	}
+	if ext == ".tf" || name == "Chart.yaml" || name == "kustomization.yaml" || name == "kustomization.yml" || name == "Kustomization" {
+		return []string{ImpactInfra}
+	}
+
+	// Linter and formatter configs also define code style conventions
+	styleConfigFiles := map[string]bool{
+		".golangci.yml": true, ".golangci.yaml": true, ".golangci.json": true, ".golangci.toml": true,
+		".eslintrc": true, ".eslintrc.json": true, ".eslintrc.js": true, ".eslintrc.cjs": true,
+		".eslintrc.yml": true, ".eslintrc.yaml": true,
+		"eslint.config.js": true, "eslint.config.mjs": true, "eslint.config.cjs": true, "eslint.config.ts": true,
+		".prettierrc": true, ".prettierrc.json": true, ".prettierrc.yml": true, ".prettierrc.yaml": true,
+		".prettierrc.js": true, "prettier.config.js": true, "prettier.config.mjs": true,
+		"rustfmt.toml": true, ".rustfmt.toml": true, "clippy.toml": true, ".clippy.toml": true,
+		".editorconfig": true, "tsconfig.json": true,
+	}
+	if styleConfigFiles[name] {
+		return []string{ImpactConfig, ImpactConventions, ImpactDevelopment}
 	}
 
 	// Config files
 	configFiles := map[string]bool{
-		".golangci.yml": true, ".golangci.yaml": true,
-		".eslintrc.json": true, ".eslintrc.js": true, ".eslintrc.yml": true,
-		"eslint.config.js": true, "eslint.config.mjs": true,
-		".prettierrc": true, ".prettierrc.json": true,
-		"tsconfig.json": true, "jest.config.js": true, "jest.config.ts": true,
+		"jest.config.js": true, "jest.config.ts": true,
 		"vite.config.ts": true, "vite.config.js": true,
-		"Dockerfile": true, "docker-compose.yml": true, "docker-compose.yaml": true,
-		".goreleaser.yml": true, ".goreleaser.yaml": true,
-		".env.example": true, ".editorconfig": true,
-		".nvmrc": true, ".python-version": true, ".tool-versions": true,
+		".env.example": true,
+		".nvmrc":       true, ".python-version": true, ".tool-versions": true,
 	}
 	if configFiles[name] {
+		// Env templates also declare environment variables
+		if strings.HasPrefix(name, ".env") {
+			return []string{ImpactConfig, ImpactDevelopment, ImpactEnvVars}
+		}
 		return []string{ImpactConfig, ImpactDevelopment}
 	}
 
+	// Notebook cells feed conventions and the ML workflow
+	if ext == ".ipynb" {
+		return []string{ImpactConventions, ImpactML}
+	}
+
+	// DVC pipelines and their parameters
+	if name == "dvc.yaml" || name == "dvc.lock" || name == "params.yaml" {
+		return []string{ImpactML}
+	}
+
+	// Migrations and schema definitions
+	if ext == ".sql" || ext == ".prisma" || name == "schema.rb" {
+		return []string{ImpactSchema}
+	}
+
 	// Source code changes - affects patterns and endpoints
 	sourceExts := map[string]bool{
 		".go": true, ".js": true, ".ts": true, ".jsx": true, ".tsx": true,
@@ -198,7 +286,29 @@ func DetermineImpact(changedFile string) []string {
 		".swift": true, ".php": true, ".vue": true, ".svelte": true,
 	}
 	if sourceExts[ext] {
-		return []string{ImpactConventions, ImpactEndpoints}
+		impacts := []string{ImpactConventions, ImpactEndpoints, ImpactEvents, ImpactEnvVars, ImpactTests}
+		// ORM models and Alembic revisions live in source files
+		if ext == ".py" || ext == ".ts" || ext == ".js" || ext == ".rb" {
+			impacts = append(impacts, ImpactSchema)
+		}
+		// Training scripts define the ML workflow
+		if ext == ".py" {
+			impacts = append(impacts, ImpactML)
+		}
+		// Cobra/urfave, Click/Typer/argparse and Clap command trees
+		if ext == ".go" || ext == ".py" || ext == ".rs" {
+			impacts = append(impacts, ImpactCLI)
+		}
+		return impacts
+	}
+
+	// Kubernetes manifests declare workloads and environment variables, and
+	// Hydra config groups are YAML files
+	if ext == ".yaml" || ext == ".yml" {
+		return []string{ImpactEnvVars, ImpactInfra, ImpactML}
+	}
+	if strings.HasPrefix(name, ".env.") {
+		return []string{ImpactEnvVars}
 	}
 
 	// Directory structure changes (new/deleted directories)
@@ -235,6 +345,7 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 		}
 		analysis.Structure = *structure
 		analysis.KeyFiles = structureDetector.DetectKeyFiles()
+		detector.AnnotateOwners(analysis.Ownership, &analysis.Structure, analysis.KeyFiles)
 
 		// Also update monorepo info
 		monorepoDetector := detector.NewMonorepoDetector(ia.rootPath, files)
@@ -260,7 +371,13 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 			commands = append(commands, cargoDetector.DetectCargoCommands()...)
 		}
 
-		analysis.Commands = commands
+		analysis.Commands = detector.ApplyCICommands(commands, analysis.CIInfo)
+
+	case ImpactCI:
+		ciDetector := detector.NewCIDetector(ia.rootPath, files)
+		analysis.CIInfo = ciDetector.Detect()
+		// Re-rank commands against the new pipelines
+		return ia.runDetector(ImpactCommands, files, analysis)
 
 	case ImpactConventions:
 		conventionDetector := detector.NewConventionDetector(ia.rootPath, files)
@@ -290,6 +407,10 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 		codePatternDetector := detector.NewCodePatternDetector(ia.rootPath, files)
 		analysis.CodePatterns = codePatternDetector.Detect()
 
+		// Error handling and logging
+		errorHandlingDetector := detector.NewErrorHandlingDetector(ia.rootPath, files)
+		analysis.ErrorHandling = errorHandlingDetector.Detect()
+
 	case ImpactEndpoints:
 		endpointDetector := detector.NewEndpointDetector(ia.rootPath, files)
 		endpoints, err := endpointDetector.Detect()
@@ -298,6 +419,18 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 		}
 		analysis.Endpoints = endpoints
 
+	case ImpactEvents:
+		eventDetector := detector.NewEventDetector(ia.rootPath, files)
+		analysis.Events = eventDetector.Detect()
+
+	case ImpactSchema:
+		schemaDetector := detector.NewSchemaDetector(ia.rootPath, files)
+		analysis.DatabaseSchema = schemaDetector.Detect()
+
+	case ImpactEnvVars:
+		envVarDetector := detector.NewEnvVarDetector(ia.rootPath, files)
+		analysis.EnvVars = envVarDetector.Detect()
+
 	case ImpactConfig:
 		configDetector := detector.NewConfigDetector(ia.rootPath, files)
 		analysis.ConfigFiles = configDetector.Detect()
@@ -307,6 +440,9 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 		analysis.DevelopmentInfo = devDetector.Detect()
 
 		// CLI info depends on tech stack
+		return ia.runDetector(ImpactCLI, files, analysis)
+
+	case ImpactCLI:
 		cliDetector := detector.NewCLIDetector(ia.rootPath, files, &analysis.TechStack)
 		analysis.CLIInfo = cliDetector.Detect()
 
@@ -317,6 +453,29 @@ func (ia *IncrementalAnalyzer) runDetector(impact string, files []types.FileInfo
 	case ImpactGit:
 		gitDetector := detector.NewGitDetectorGoGit(ia.rootPath)
 		analysis.GitConventions = gitDetector.Detect()
+
+	case ImpactOwnership:
+		ownershipDetector := detector.NewOwnershipDetector(ia.rootPath, files)
+		analysis.Ownership = ownershipDetector.Detect()
+		detector.AnnotateOwners(analysis.Ownership, &analysis.Structure, analysis.KeyFiles)
+
+	case ImpactRelease:
+		releaseDetector := detector.NewReleaseDetector(ia.rootPath, files)
+		analysis.Release = releaseDetector.Detect()
+
+	case ImpactInfra:
+		infraDetector := detector.NewInfraDetector(ia.rootPath, files)
+		analysis.Infrastructure = infraDetector.Detect()
+
+	case ImpactTests:
+		testSuiteDetector := detector.NewTestSuiteDetector(ia.rootPath, files)
+		analysis.Tests = testSuiteDetector.Detect()
+
+	case ImpactML:
+		mlDetector := detector.NewMLDetector(ia.rootPath, files)
+		analysis.MLWorkflow = mlDetector.DetectWorkflow()
+		notebookDetector := detector.NewNotebookDetector(ia.rootPath, files)
+		analysis.Notebooks = notebookDetector.Detect()
 	}
 
 	return nil
@@ -338,6 +497,10 @@ func (ia *IncrementalAnalyzer) cloneAnalysis(src *types.Analysis) *types.Analysi
 	}
 
 	// Copy slices
+	if src.Structure.Directories != nil {
+		dst.Structure.Directories = make([]types.Directory, len(src.Structure.Directories))
+		copy(dst.Structure.Directories, src.Structure.Directories)
+	}
 	if src.KeyFiles != nil {
 		dst.KeyFiles = make([]types.KeyFile, len(src.KeyFiles))
 		copy(dst.KeyFiles, src.KeyFiles)
@@ -358,6 +521,14 @@ func (ia *IncrementalAnalyzer) cloneAnalysis(src *types.Analysis) *types.Analysi
 		dst.Endpoints = make([]types.Endpoint, len(src.Endpoints))
 		copy(dst.Endpoints, src.Endpoints)
 	}
+	if src.Events != nil {
+		dst.Events = make([]types.Event, len(src.Events))
+		copy(dst.Events, src.Events)
+	}
+	if src.EnvVars != nil {
+		dst.EnvVars = make([]types.EnvVar, len(src.EnvVars))
+		copy(dst.EnvVars, src.EnvVars)
+	}
 	if src.ConfigFiles != nil {
 		dst.ConfigFiles = make([]types.ConfigFileInfo, len(src.ConfigFiles))
 		copy(dst.ConfigFiles, src.ConfigFiles)
@@ -370,6 +541,15 @@ func (ia *IncrementalAnalyzer) cloneAnalysis(src *types.Analysis) *types.Analysi
 	dst.ArchitectureInfo = src.ArchitectureInfo
 	dst.DevelopmentInfo = src.DevelopmentInfo
 	dst.CLIInfo = src.CLIInfo
+	dst.DatabaseSchema = src.DatabaseSchema
+	dst.Ownership = src.Ownership
+	dst.Release = src.Release
+	dst.CIInfo = src.CIInfo
+	dst.Infrastructure = src.Infrastructure
+	dst.Tests = src.Tests
+	dst.ErrorHandling = src.ErrorHandling
+	dst.MLWorkflow = src.MLWorkflow
+	dst.Notebooks = src.Notebooks
 
 	return dst
 }
@@ -395,6 +575,12 @@ func ImpactDescription(impacts []string) string {
 			descriptions = append(descriptions, "conventions")
 		case ImpactEndpoints:
 			descriptions = append(descriptions, "endpoints")
+		case ImpactEvents:
+			descriptions = append(descriptions, "events")
+		case ImpactSchema:
+			descriptions = append(descriptions, "database schema")
+		case ImpactEnvVars:
+			descriptions = append(descriptions, "environment variables")
 		case ImpactConfig:
 			descriptions = append(descriptions, "config")
 		case ImpactDevelopment:
@@ -403,6 +589,20 @@ func ImpactDescription(impacts []string) string {
 			descriptions = append(descriptions, "readme")
 		case ImpactGit:
 			descriptions = append(descriptions, "git")
+		case ImpactOwnership:
+			descriptions = append(descriptions, "ownership")
+		case ImpactRelease:
+			descriptions = append(descriptions, "release")
+		case ImpactCI:
+			descriptions = append(descriptions, "ci")
+		case ImpactInfra:
+			descriptions = append(descriptions, "infrastructure")
+		case ImpactTests:
+			descriptions = append(descriptions, "tests")
+		case ImpactML:
+			descriptions = append(descriptions, "ml workflow")
+		case ImpactCLI:
+			descriptions = append(descriptions, "cli commands")
 		}
 	}
 
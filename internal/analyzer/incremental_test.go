@@ -166,6 +166,34 @@ func TestDetermineImpact_GitHubWorkflows(t *testing.T) {
 	}
 }
 
+func TestDetermineImpact_ReleaseFiles(t *testing.T) {
+	for _, file := range []string{"CHANGELOG.md", ".releaserc.json", ".changeset/config.json", "release-please-config.json", ".goreleaser.yml"} {
+		hasRelease := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactRelease {
+				hasRelease = true
+			}
+		}
+		if !hasRelease {
+			t.Errorf("expected ImpactRelease for %s", file)
+		}
+	}
+}
+
+func TestDetermineImpact_CIFiles(t *testing.T) {
+	for _, file := range []string{".github/workflows/ci.yml", ".gitlab-ci.yml", ".circleci/config.yml", "Jenkinsfile", "azure-pipelines.yml"} {
+		hasCI := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactCI {
+				hasCI = true
+			}
+		}
+		if !hasCI {
+			t.Errorf("expected ImpactCI for %s", file)
+		}
+	}
+}
+
 func TestImpactDescription(t *testing.T) {
 	tests := []struct {
 		impacts  []string
@@ -325,3 +353,87 @@ func TestIncrementalAnalyzer_NoCacheFallsBackToFull(t *testing.T) {
 		t.Errorf("expected ImpactAll for no-cache scenario, got %v", impacts)
 	}
 }
+
+func TestDetermineImpact_InfraFiles(t *testing.T) {
+	for _, file := range []string{"Dockerfile", "api.Dockerfile", "docker-compose.yml", "infra/main.tf", "charts/app/Chart.yaml", "deploy/app.yaml"} {
+		hasInfra := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactInfra {
+				hasInfra = true
+			}
+		}
+		if !hasInfra {
+			t.Errorf("expected ImpactInfra for %s", file)
+		}
+	}
+}
+
+func TestDetermineImpact_TestFiles(t *testing.T) {
+	for _, file := range []string{"internal/api/handler_test.go", "src/app.test.ts", "coverage.out", "coverage/lcov.info"} {
+		hasTests := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactTests {
+				hasTests = true
+			}
+		}
+		if !hasTests {
+			t.Errorf("expected ImpactTests for %s", file)
+		}
+	}
+}
+
+func TestDetermineImpact_WorkspaceFiles(t *testing.T) {
+	for _, file := range []string{"go.work", "settings.gradle.kts", "MODULE.bazel", "services/api/BUILD.bazel", "pants.toml"} {
+		impacts := DetermineImpact(file)
+		if len(impacts) != 1 || impacts[0] != ImpactStructure {
+			t.Errorf("expected ImpactStructure for %s, got %v", file, impacts)
+		}
+	}
+}
+
+func TestDetermineImpact_StyleConfigFiles(t *testing.T) {
+	for _, file := range []string{".golangci.yml", "eslint.config.mjs", ".prettierrc", "rustfmt.toml", "clippy.toml", ".editorconfig"} {
+		hasConventions := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactConventions {
+				hasConventions = true
+			}
+		}
+		if !hasConventions {
+			t.Errorf("expected ImpactConventions for %s, got %v", file, DetermineImpact(file))
+		}
+	}
+}
+
+func TestDetermineImpact_MLFiles(t *testing.T) {
+	for _, file := range []string{"dvc.yaml", "params.yaml", "src/train.py", "conf/model/resnet.yaml", "notebooks/eda.ipynb"} {
+		hasML := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactML {
+				hasML = true
+			}
+		}
+		if !hasML {
+			t.Errorf("expected ImpactML for %s", file)
+		}
+	}
+}
+
+func TestDetermineImpact_CLISources(t *testing.T) {
+	for _, file := range []string{"cmd/root.go", "cli/main.py", "src/cli.rs"} {
+		hasCLI := false
+		for _, imp := range DetermineImpact(file) {
+			if imp == ImpactCLI {
+				hasCLI = true
+			}
+		}
+		if !hasCLI {
+			t.Errorf("expected ImpactCLI for %s, got %v", file, DetermineImpact(file))
+		}
+	}
+	for _, imp := range DetermineImpact("src/app.ts") {
+		if imp == ImpactCLI {
+			t.Error("expected no ImpactCLI for TypeScript sources")
+		}
+	}
+}
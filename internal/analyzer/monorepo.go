@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
+var goModuleRegex = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+
 // WorkspaceResult holds the analysis result for a single workspace
 type WorkspaceResult struct {
 	Path     string          // relative path from monorepo root
@@ -51,10 +56,15 @@ func (ma *MonorepoAnalyzer) AnalyzeWorkspaces(ctx context.Context, monoInfo *typ
 	slog.Debug("MonorepoAnalyzer: analyzing workspaces",
 		"count", len(workspaceDirs), "parallel", ma.parallel)
 
+	return ma.AnalyzeDirs(ctx, workspaceDirs)
+}
+
+// AnalyzeDirs runs a scoped analysis of each directory, given relative to the root
+func (ma *MonorepoAnalyzer) AnalyzeDirs(ctx context.Context, dirs []string) []WorkspaceResult {
 	if !ma.parallel {
-		return ma.analyzeSequential(ctx, workspaceDirs)
+		return ma.analyzeSequential(ctx, dirs)
 	}
-	return ma.analyzeParallel(ctx, workspaceDirs)
+	return ma.analyzeParallel(ctx, dirs)
 }
 
 // resolveWorkspaces expands glob patterns in WorkspacePaths and Packages into actual directories
@@ -75,7 +85,7 @@ func (ma *MonorepoAnalyzer) resolveWorkspaces(info *types.MonorepoInfo) []string
 				continue
 			}
 			rel, err := filepath.Rel(ma.rootPath, match)
-			if err != nil {
+			if err != nil || isExcludedWorkspace(rel, info.ExcludePaths) {
 				continue
 			}
 			if !seen[rel] {
@@ -104,6 +114,18 @@ func (ma *MonorepoAnalyzer) resolveWorkspaces(info *types.MonorepoInfo) []string
 	return resolved
 }
 
+// isExcludedWorkspace reports whether a workspace matches an exclude glob
+func isExcludedWorkspace(rel string, excludes []string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range excludes {
+		pattern = strings.Trim(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+		if matched, _ := path.Match(pattern, rel); matched || pattern == rel {
+			return true
+		}
+	}
+	return false
+}
+
 func (ma *MonorepoAnalyzer) analyzeSequential(ctx context.Context, dirs []string) []WorkspaceResult {
 	results := make([]WorkspaceResult, 0, len(dirs))
 	for _, dir := range dirs {
@@ -190,6 +212,39 @@ func workspaceName(absDir, relDir string) string {
 		}
 	}
 
+	// Try the module or package name from Go, Cargo and Python manifests
+	if data, err := os.ReadFile(filepath.Join(absDir, "go.mod")); err == nil {
+		if m := goModuleRegex.FindSubmatch(data); m != nil {
+			return string(m[1])
+		}
+	}
+	var cargo struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(absDir, "Cargo.toml"), &cargo); err == nil && cargo.Package.Name != "" {
+		return cargo.Package.Name
+	}
+	var pyproject struct {
+		Project struct {
+			Name string `toml:"name"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name string `toml:"name"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(absDir, "pyproject.toml"), &pyproject); err == nil {
+		if pyproject.Project.Name != "" {
+			return pyproject.Project.Name
+		}
+		if pyproject.Tool.Poetry.Name != "" {
+			return pyproject.Tool.Poetry.Name
+		}
+	}
+
 	// Fallback to directory name
 	return filepath.Base(relDir)
 }
@@ -220,6 +220,43 @@ func TestWorkspaceName_FromPackageJSON(t *testing.T) {
 	}
 }
 
+func TestWorkspaceName_FromManifests(t *testing.T) {
+	root := t.TempDir()
+	manifests := map[string]string{
+		"go.mod":         "module github.com/acme/api\n\ngo 1.22\n",
+		"Cargo.toml":     "[package]\nname = \"acme-core\"\n",
+		"pyproject.toml": "[project]\nname = \"acme-ml\"\n",
+	}
+	want := map[string]string{"go.mod": "github.com/acme/api", "Cargo.toml": "acme-core", "pyproject.toml": "acme-ml"}
+
+	for file, content := range manifests {
+		wsDir := filepath.Join(root, file)
+		mkdirAll(t, wsDir)
+		writeFile(t, filepath.Join(wsDir, file), []byte(content))
+
+		if name := workspaceName(wsDir, file); name != want[file] {
+			t.Errorf("expected %q from %s, got %q", want[file], file, name)
+		}
+	}
+}
+
+func TestResolveWorkspaces_Exclude(t *testing.T) {
+	root := t.TempDir()
+	mkdirAll(t, filepath.Join(root, "crates", "core"))
+	mkdirAll(t, filepath.Join(root, "crates", "experimental"))
+
+	info := &types.MonorepoInfo{
+		IsMonorepo:     true,
+		WorkspacePaths: []string{"crates/*"},
+		ExcludePaths:   []string{"crates/experimental"},
+	}
+
+	resolved := NewMonorepoAnalyzer(root, false, 4).resolveWorkspaces(info)
+	if len(resolved) != 1 || resolved[0] != filepath.Join("crates", "core") {
+		t.Errorf("expected only crates/core, got %v", resolved)
+	}
+}
+
 func TestWorkspaceName_FallbackToDir(t *testing.T) {
 	root := t.TempDir()
 	wsDir := filepath.Join(root, "packages", "utils")
@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+const (
+	defaultNestedMinFiles = 40
+	defaultNestedMaxDirs  = 8
+)
+
+// nestedSkipDirs never get a local context file: they hold no code worth
+// describing or are generated
+var nestedSkipDirs = map[string]bool{
+	"vendor": true, "node_modules": true, "third_party": true,
+	"docs": true, "doc": true, "examples": true, "testdata": true,
+	"fixtures": true, "__mocks__": true, "mocks": true,
+	"public": true, "static": true, "assets": true, "images": true, "img": true,
+	"dist": true, "build": true, "out": true, "coverage": true,
+}
+
+// genericPurposes describe containers rather than what a directory does, so
+// they don't make a directory distinct on their own
+var genericPurposes = map[string]bool{
+	"Source code":       true,
+	"Library code":      true,
+	"Packages":          true,
+	"Internal packages": true,
+	"Tests":             true,
+}
+
+// NestedOptions controls which subdirectories get their own context file
+type NestedOptions struct {
+	MinFiles int      // Files a directory needs on size alone (default 40)
+	MaxDirs  int      // Maximum number of directories selected (default 8)
+	Include  []string // Globs that are always selected
+	Exclude  []string // Globs that are never selected
+}
+
+// NestedDir is a subdirectory chosen for a local context file
+type NestedDir struct {
+	Path      string
+	Purpose   string
+	FileCount int
+	Reason    string // "configured", "size" or "distinct purpose"
+}
+
+// SelectNestedDirs picks the significant subdirectories of a single-module
+// project: configured globs first, then large directories, then mid-sized
+// directories whose purpose no other directory shares
+func SelectNestedDirs(rootPath string, dirs []types.Directory, opts NestedOptions) []NestedDir {
+	if opts.MinFiles <= 0 {
+		opts.MinFiles = defaultNestedMinFiles
+	}
+	if opts.MaxDirs <= 0 {
+		opts.MaxDirs = defaultNestedMaxDirs
+	}
+	purposeFiles := opts.MinFiles / 4
+	if purposeFiles < 5 {
+		purposeFiles = 5
+	}
+
+	byPath := make(map[string]types.Directory)
+	purposeCount := make(map[string]int)
+	for _, dir := range dirs {
+		byPath[dir.Path] = dir
+		purposeCount[dir.Purpose]++
+	}
+
+	var selected []NestedDir
+	seen := make(map[string]bool)
+	add := func(nd NestedDir) {
+		if seen[nd.Path] || len(selected) >= opts.MaxDirs || matchesAny(nd.Path, opts.Exclude) {
+			return
+		}
+		seen[nd.Path] = true
+		selected = append(selected, nd)
+	}
+
+	for _, pattern := range opts.Include {
+		matches, _ := filepath.Glob(filepath.Join(rootPath, filepath.FromSlash(pattern)))
+		sort.Strings(matches)
+		for _, match := range matches {
+			fi, err := os.Stat(match)
+			if err != nil || !fi.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(rootPath, match)
+			if err != nil || rel == "." {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			dir := byPath[rel]
+			add(NestedDir{Path: rel, Purpose: dir.Purpose, FileCount: dir.FileCount, Reason: "configured"})
+		}
+	}
+
+	// Largest first so the size-based picks win when MaxDirs is tight
+	candidates := make([]types.Directory, 0, len(dirs))
+	for _, dir := range dirs {
+		if !isNestedCandidate(dir.Path) {
+			continue
+		}
+		candidates = append(candidates, dir)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].FileCount != candidates[j].FileCount {
+			return candidates[i].FileCount > candidates[j].FileCount
+		}
+		return candidates[i].Path < candidates[j].Path
+	})
+
+	for _, dir := range candidates {
+		if dir.FileCount >= opts.MinFiles {
+			add(NestedDir{Path: dir.Path, Purpose: dir.Purpose, FileCount: dir.FileCount, Reason: "size"})
+		}
+	}
+	for _, dir := range candidates {
+		if dir.FileCount >= purposeFiles && dir.Purpose != "" && !genericPurposes[dir.Purpose] && purposeCount[dir.Purpose] == 1 {
+			add(NestedDir{Path: dir.Path, Purpose: dir.Purpose, FileCount: dir.FileCount, Reason: "distinct purpose"})
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Path < selected[j].Path })
+	return selected
+}
+
+// NestedContexts converts selected directories into the links carried on the root analysis
+func NestedContexts(dirs []NestedDir) []types.NestedContext {
+	var contexts []types.NestedContext
+	for _, dir := range dirs {
+		contexts = append(contexts, types.NestedContext{Path: dir.Path, Purpose: dir.Purpose})
+	}
+	return contexts
+}
+
+// isNestedCandidate rejects hidden, generated and non-code directories
+func isNestedCandidate(dir string) bool {
+	for _, part := range strings.Split(dir, "/") {
+		if strings.HasPrefix(part, ".") || nestedSkipDirs[part] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether dir matches one of the globs, or sits below a plain path
+func matchesAny(dir string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.Trim(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+		if matched, _ := path.Match(pattern, dir); matched || dir == pattern || strings.HasPrefix(dir, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
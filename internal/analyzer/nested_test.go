@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestSelectNestedDirs(t *testing.T) {
+	root := t.TempDir()
+	mkdirAll(t, filepath.Join(root, "plugins", "auth"))
+	mkdirAll(t, filepath.Join(root, "plugins", "billing"))
+
+	dirs := []types.Directory{
+		{Path: "internal/api", Purpose: "API endpoints", FileCount: 60},
+		{Path: "internal/store", Purpose: "Data layer", FileCount: 45},
+		{Path: "internal/handlers", Purpose: "Request handlers", FileCount: 12},
+		{Path: "internal/utils", Purpose: "Utilities", FileCount: 12},
+		{Path: "pkg/utils", Purpose: "Utilities", FileCount: 11},
+		{Path: "internal/small", Purpose: "Core functionality", FileCount: 3},
+		{Path: "src", Purpose: "Source code", FileCount: 20},
+		{Path: "vendor", Purpose: "Vendored dependencies", FileCount: 500},
+		{Path: "docs", Purpose: "Documentation", FileCount: 80},
+		{Path: "internal/legacy", FileCount: 41},
+	}
+
+	got := SelectNestedDirs(root, dirs, NestedOptions{
+		Include: []string{"plugins/*"},
+		Exclude: []string{"internal/legacy"},
+	})
+	want := []NestedDir{
+		{Path: "internal/api", Purpose: "API endpoints", FileCount: 60, Reason: "size"},
+		{Path: "internal/handlers", Purpose: "Request handlers", FileCount: 12, Reason: "distinct purpose"},
+		{Path: "internal/store", Purpose: "Data layer", FileCount: 45, Reason: "size"},
+		{Path: "plugins/auth", Reason: "configured"},
+		{Path: "plugins/billing", Reason: "configured"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	// MaxDirs keeps configured and largest directories first
+	got = SelectNestedDirs(root, dirs, NestedOptions{MaxDirs: 3, Include: []string{"plugins/auth"}})
+	var paths []string
+	for _, d := range got {
+		paths = append(paths, d.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"internal/api", "internal/store", "plugins/auth"}) {
+		t.Errorf("expected the configured and two largest directories, got %v", paths)
+	}
+}
@@ -175,8 +175,14 @@ func (pa *ParallelAnalyzer) runPhase2(ctx context.Context, files []types.FileInf
 			commands = append(commands, cargoDetector.DetectCargoCommands()...)
 		}
 
+		// Rank commands CI runs first
+		ciDetector := detector.NewCIDetector(pa.rootPath, files)
+		ciInfo := ciDetector.Detect()
+		commands = detector.ApplyCICommands(commands, ciInfo)
+
 		mu.Lock()
 		analysis.Commands = commands
+		analysis.CIInfo = ciInfo
 		mu.Unlock()
 	}()
 
@@ -251,6 +257,39 @@ func (pa *ParallelAnalyzer) runPhase2(ctx context.Context, files []types.FileInf
 		mu.Unlock()
 	}()
 
+	// Events (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		eventDetector := detector.NewEventDetector(pa.rootPath, files)
+		events := eventDetector.Detect()
+		mu.Lock()
+		analysis.Events = events
+		mu.Unlock()
+	}()
+
+	// Database schema (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		schemaDetector := detector.NewSchemaDetector(pa.rootPath, files)
+		schema := schemaDetector.Detect()
+		mu.Lock()
+		analysis.DatabaseSchema = schema
+		mu.Unlock()
+	}()
+
+	// Environment variables (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		envVarDetector := detector.NewEnvVarDetector(pa.rootPath, files)
+		envVars := envVarDetector.Detect()
+		mu.Lock()
+		analysis.EnvVars = envVars
+		mu.Unlock()
+	}()
+
 	// README (no dependencies)
 	wg.Add(1)
 	go func() {
@@ -304,6 +343,28 @@ func (pa *ParallelAnalyzer) runPhase2(ctx context.Context, files []types.FileInf
 		mu.Unlock()
 	}()
 
+	// ML training workflow (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		mlDetector := detector.NewMLDetector(pa.rootPath, files)
+		workflow := mlDetector.DetectWorkflow()
+		mu.Lock()
+		analysis.MLWorkflow = workflow
+		mu.Unlock()
+	}()
+
+	// Jupyter notebooks (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		notebookDetector := detector.NewNotebookDetector(pa.rootPath, files)
+		notebooks := notebookDetector.Detect()
+		mu.Lock()
+		analysis.Notebooks = notebooks
+		mu.Unlock()
+	}()
+
 	// Git conventions (no dependencies)
 	wg.Add(1)
 	go func() {
@@ -315,6 +376,62 @@ func (pa *ParallelAnalyzer) runPhase2(ctx context.Context, files []types.FileInf
 		mu.Unlock()
 	}()
 
+	// Code ownership (annotates structure from phase 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ownershipDetector := detector.NewOwnershipDetector(pa.rootPath, files)
+		ownership := ownershipDetector.Detect()
+		mu.Lock()
+		analysis.Ownership = ownership
+		detector.AnnotateOwners(ownership, &analysis.Structure, analysis.KeyFiles)
+		mu.Unlock()
+	}()
+
+	// Release process (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		releaseDetector := detector.NewReleaseDetector(pa.rootPath, files)
+		release := releaseDetector.Detect()
+		mu.Lock()
+		analysis.Release = release
+		mu.Unlock()
+	}()
+
+	// Infrastructure and deployment (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		infraDetector := detector.NewInfraDetector(pa.rootPath, files)
+		infra := infraDetector.Detect()
+		mu.Lock()
+		analysis.Infrastructure = infra
+		mu.Unlock()
+	}()
+
+	// Test inventory (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		testSuiteDetector := detector.NewTestSuiteDetector(pa.rootPath, files)
+		tests := testSuiteDetector.Detect()
+		mu.Lock()
+		analysis.Tests = tests
+		mu.Unlock()
+	}()
+
+	// Error handling and logging (no dependencies)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errorHandlingDetector := detector.NewErrorHandlingDetector(pa.rootPath, files)
+		errorHandling := errorHandlingDetector.Detect()
+		mu.Lock()
+		analysis.ErrorHandling = errorHandling
+		mu.Unlock()
+	}()
+
 	// Architecture (no dependencies)
 	wg.Add(1)
 	go func() {
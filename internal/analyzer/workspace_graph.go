@@ -0,0 +1,483 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+var (
+	goRequireRegex     = regexp.MustCompile(`(?m)^\s*(?:require\s+)?([\w.\-]+(?:/[\w.\-~]+)+)\s+v[\w.\-+]+`)
+	goReplaceRegex     = regexp.MustCompile(`(?m)^\s*(?:replace\s+)?([\w.\-]+(?:/[\w.\-~]+)+)(?:\s+v[\w.\-+]+)?\s+=>\s+(\.\.?/\S*)`)
+	gradleProjectRegex = regexp.MustCompile(`project\(\s*(?:path\s*[:=]\s*)?["'](:[\w.\-:]+)["']`)
+	pythonReqRegex     = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._\-]*)`)
+)
+
+// workspaceRef is a dependency declared in a workspace manifest, either by
+// package name or by a path relative to the monorepo root
+type workspaceRef struct {
+	kind string
+	name string
+	path string
+}
+
+// workspaceManifest holds the names a workspace publishes and the
+// dependencies it declares across the manifests found in its directory
+type workspaceManifest struct {
+	dir   string
+	names map[string]string // kind -> name
+	refs  []workspaceRef
+}
+
+// AffectedWorkspace is a workspace touched by a change, directly or through
+// one of its internal dependencies
+type AffectedWorkspace struct {
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Reason      string `json:"reason"` // "changed" or "depends on <path>"
+	TestCommand string `json:"test_command,omitempty"`
+}
+
+// DependencyGraph builds the internal dependency edges between workspaces
+// from package.json, go.mod/go.work, Cargo.toml, pyproject.toml and Gradle
+// build files. Dependencies on packages outside the monorepo are ignored.
+func (ma *MonorepoAnalyzer) DependencyGraph(info *types.MonorepoInfo) []types.WorkspaceDependency {
+	if info == nil {
+		return nil
+	}
+
+	manifests := ma.readManifests(info)
+
+	isWorkspace := make(map[string]bool)
+	byName := make(map[string]string) // kind + ":" + name -> dir
+	for _, m := range manifests {
+		isWorkspace[m.dir] = true
+		for kind, name := range m.names {
+			byName[kind+":"+name] = m.dir
+		}
+	}
+
+	// go.work replace directives point module paths at workspace directories
+	if data, err := os.ReadFile(filepath.Join(ma.rootPath, "go.work")); err == nil {
+		for _, m := range goReplaceRegex.FindAllStringSubmatch(string(data), -1) {
+			if dir := path.Clean(m[2]); isWorkspace[dir] {
+				byName["go:"+m[1]] = dir
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var deps []types.WorkspaceDependency
+	for _, m := range manifests {
+		for _, ref := range m.refs {
+			target := ref.path
+			if target == "" {
+				target = byName[ref.kind+":"+ref.name]
+			}
+			if target == "" || target == m.dir || !isWorkspace[target] {
+				continue
+			}
+			key := m.dir + "\x00" + target
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			deps = append(deps, types.WorkspaceDependency{From: m.dir, To: target, Kind: ref.kind})
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].From != deps[j].From {
+			return deps[i].From < deps[j].From
+		}
+		return deps[i].To < deps[j].To
+	})
+	return deps
+}
+
+// Affected maps changed files (relative to the root) to the workspaces they
+// belong to and adds every workspace that transitively depends on them.
+// Directly changed workspaces come first. Files outside any workspace are
+// returned separately.
+func (ma *MonorepoAnalyzer) Affected(info *types.MonorepoInfo, changedFiles []string) ([]AffectedWorkspace, []string) {
+	var dirs []string
+	for _, dir := range ma.resolveWorkspaces(info) {
+		dirs = append(dirs, filepath.ToSlash(dir))
+	}
+	sort.Strings(dirs)
+
+	var outside []string
+	changed := make(map[string]bool)
+	for _, file := range changedFiles {
+		file = filepath.ToSlash(file)
+		owner := ""
+		for _, dir := range dirs {
+			// the root workspace "." owns whatever no nested workspace claims
+			if dir == "." && owner == "" {
+				owner = dir
+			} else if (file == dir || strings.HasPrefix(file, dir+"/")) && (owner == "." || len(dir) > len(owner)) {
+				owner = dir
+			}
+		}
+		if owner == "" {
+			outside = append(outside, file)
+			continue
+		}
+		changed[owner] = true
+	}
+
+	dependents := make(map[string][]string)
+	for _, dep := range ma.DependencyGraph(info) {
+		dependents[dep.To] = append(dependents[dep.To], dep.From)
+	}
+
+	var affected []AffectedWorkspace
+	seen := make(map[string]bool)
+	add := func(dir, reason string) {
+		seen[dir] = true
+		affected = append(affected, AffectedWorkspace{
+			Path:        dir,
+			Name:        workspaceName(filepath.Join(ma.rootPath, dir), dir),
+			Reason:      reason,
+			TestCommand: ma.testCommand(info, dir),
+		})
+	}
+
+	for _, dir := range dirs {
+		if changed[dir] {
+			add(dir, "changed")
+		}
+	}
+
+	// Walk reverse edges breadth-first so each dependent names the closest changed workspace
+	for i := 0; i < len(affected); i++ {
+		for _, from := range dependents[affected[i].Path] {
+			if !seen[from] {
+				add(from, "depends on "+affected[i].Path)
+			}
+		}
+	}
+
+	return affected, outside
+}
+
+// ChangedFilesSince lists files changed since HEAD branched off a git ref,
+// including uncommitted and untracked files, as paths relative to rootPath.
+// Like `git diff ref...HEAD`, it diffs against the merge base, so commits
+// that landed on ref after the branch point are not reported. Refs starting
+// with "-" are rejected so they cannot be parsed as git options.
+func ChangedFilesSince(rootPath, ref string) ([]string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref %q", ref)
+	}
+
+	cmd := exec.Command("git", "merge-base", ref, "HEAD")
+	cmd.Dir = rootPath
+	base, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git merge-base of %s and HEAD failed: %w", ref, err)
+	}
+
+	// diffing the working tree against the merge base covers both commits
+	// since the branch point and uncommitted changes
+	cmd = exec.Command("git", "diff", "--name-only", "--relative", strings.TrimSpace(string(base)), "--")
+	cmd.Dir = rootPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff against %s failed: %w", ref, err)
+	}
+
+	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard")
+	cmd.Dir = rootPath
+	untracked, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(output)+"\n"+string(untracked), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !seen[line] {
+			seen[line] = true
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// readManifests reads the manifests of every resolved workspace
+func (ma *MonorepoAnalyzer) readManifests(info *types.MonorepoInfo) []workspaceManifest {
+	var rootCargo struct {
+		Workspace struct {
+			Dependencies map[string]interface{} `toml:"dependencies"`
+		} `toml:"workspace"`
+	}
+	_, _ = toml.DecodeFile(filepath.Join(ma.rootPath, "Cargo.toml"), &rootCargo)
+
+	var manifests []workspaceManifest
+	for _, dir := range ma.resolveWorkspaces(info) {
+		dir = filepath.ToSlash(dir)
+		m := workspaceManifest{dir: dir, names: make(map[string]string)}
+		absDir := filepath.Join(ma.rootPath, dir)
+
+		m.readPackageJSON(absDir)
+		m.readGoMod(absDir)
+		m.readCargo(absDir, rootCargo.Workspace.Dependencies)
+		m.readPyproject(absDir)
+		m.readGradle(absDir)
+		m.names["gradle"] = gradleProjectPath(dir)
+
+		manifests = append(manifests, m)
+	}
+	return manifests
+}
+
+func (m *workspaceManifest) readPackageJSON(absDir string) {
+	data, err := os.ReadFile(filepath.Join(absDir, "package.json"))
+	if err != nil {
+		return
+	}
+	var pkg struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return
+	}
+	if pkg.Name != "" {
+		m.names["npm"] = pkg.Name
+	}
+	for _, group := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for _, name := range sortedDependencyNames(group) {
+			m.refs = append(m.refs, workspaceRef{kind: "npm", name: name})
+		}
+	}
+}
+
+func (m *workspaceManifest) readGoMod(absDir string) {
+	data, err := os.ReadFile(filepath.Join(absDir, "go.mod"))
+	if err != nil {
+		return
+	}
+	content := string(data)
+	if match := goModuleRegex.FindStringSubmatch(content); match != nil {
+		m.names["go"] = match[1]
+	}
+	for _, match := range goReplaceRegex.FindAllStringSubmatch(content, -1) {
+		m.refs = append(m.refs, workspaceRef{kind: "go", path: path.Join(m.dir, match[2])})
+	}
+	for _, match := range goRequireRegex.FindAllStringSubmatch(content, -1) {
+		m.refs = append(m.refs, workspaceRef{kind: "go", name: match[1]})
+	}
+}
+
+func (m *workspaceManifest) readCargo(absDir string, workspaceDeps map[string]interface{}) {
+	var cargo struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+		Dependencies      map[string]interface{} `toml:"dependencies"`
+		DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+		BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(absDir, "Cargo.toml"), &cargo); err != nil {
+		return
+	}
+	if cargo.Package.Name != "" {
+		m.names["cargo"] = cargo.Package.Name
+	}
+
+	for _, group := range []map[string]interface{}{cargo.Dependencies, cargo.DevDependencies, cargo.BuildDependencies} {
+		for _, name := range sortedInterfaceKeys(group) {
+			spec, ok := group[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if p, ok := spec["path"].(string); ok {
+				m.refs = append(m.refs, workspaceRef{kind: "cargo", path: path.Join(m.dir, p)})
+				continue
+			}
+			// `foo = { workspace = true }` inherits the path from [workspace.dependencies]
+			if inherit, _ := spec["workspace"].(bool); inherit {
+				if root, ok := workspaceDeps[name].(map[string]interface{}); ok {
+					if p, ok := root["path"].(string); ok {
+						m.refs = append(m.refs, workspaceRef{kind: "cargo", path: path.Clean(p)})
+					}
+				}
+			}
+		}
+	}
+}
+
+func (m *workspaceManifest) readPyproject(absDir string) {
+	var pyproject struct {
+		Project struct {
+			Name         string   `toml:"name"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name         string                 `toml:"name"`
+				Dependencies map[string]interface{} `toml:"dependencies"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(absDir, "pyproject.toml"), &pyproject); err != nil {
+		return
+	}
+
+	name := pyproject.Project.Name
+	if name == "" {
+		name = pyproject.Tool.Poetry.Name
+	}
+	if name != "" {
+		m.names["python"] = normalizePythonName(name)
+	}
+
+	for _, req := range pyproject.Project.Dependencies {
+		if match := pythonReqRegex.FindStringSubmatch(req); match != nil {
+			m.refs = append(m.refs, workspaceRef{kind: "python", name: normalizePythonName(match[1])})
+		}
+	}
+	deps := pyproject.Tool.Poetry.Dependencies
+	for _, dep := range sortedInterfaceKeys(deps) {
+		if spec, ok := deps[dep].(map[string]interface{}); ok {
+			if p, ok := spec["path"].(string); ok {
+				m.refs = append(m.refs, workspaceRef{kind: "python", path: path.Join(m.dir, p)})
+			}
+		}
+	}
+}
+
+func (m *workspaceManifest) readGradle(absDir string) {
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		data, err := os.ReadFile(filepath.Join(absDir, name))
+		if err != nil {
+			continue
+		}
+		for _, match := range gradleProjectRegex.FindAllStringSubmatch(string(data), -1) {
+			m.refs = append(m.refs, workspaceRef{kind: "gradle", name: match[1]})
+		}
+	}
+}
+
+// testCommand returns the command that runs one workspace's tests from the
+// monorepo root, or an empty string when no test setup is recognised
+func (ma *MonorepoAnalyzer) testCommand(info *types.MonorepoInfo, dir string) string {
+	absDir := filepath.Join(ma.rootPath, dir)
+	name := workspaceName(absDir, dir)
+	rootHas := func(file string) bool {
+		_, err := os.Stat(filepath.Join(ma.rootPath, file))
+		return err == nil
+	}
+	has := func(file string) bool {
+		_, err := os.Stat(filepath.Join(absDir, file))
+		return err == nil
+	}
+
+	// commands for the root workspace run in place rather than from "./."
+	subdir, inDir := dir+"/", func(cmd string) string { return fmt.Sprintf("(cd %s && %s)", dir, cmd) }
+	if dir == "." {
+		subdir, inDir = "", func(cmd string) string { return cmd }
+	}
+
+	switch info.Tool {
+	case "Bazel":
+		return fmt.Sprintf("bazel test //%s...", subdir)
+	case "Pants":
+		return fmt.Sprintf("pants test %s::", strings.TrimSuffix(subdir, "/"))
+	}
+
+	if data, err := os.ReadFile(filepath.Join(absDir, "package.json")); err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Scripts["test"] != "" {
+			switch {
+			case info.Tool == "Turborepo":
+				return fmt.Sprintf("npx turbo run test --filter=%s", name)
+			case info.Tool == "Nx":
+				return fmt.Sprintf("npx nx test %s", name)
+			case info.PackageManager == "pnpm" || rootHas("pnpm-lock.yaml"):
+				return fmt.Sprintf("pnpm --filter %s test", name)
+			case rootHas("yarn.lock"):
+				return fmt.Sprintf("yarn workspace %s test", name)
+			default:
+				return fmt.Sprintf("npm test --workspace=%s", dir)
+			}
+		}
+	}
+
+	switch {
+	case has("go.mod"):
+		if rootHas("go.work") {
+			return fmt.Sprintf("go test ./%s...", subdir)
+		}
+		return inDir("go test ./...")
+	case has("Cargo.toml"):
+		return fmt.Sprintf("cargo test -p %s", name)
+	case has("pyproject.toml"):
+		switch info.Tool {
+		case "uv workspace":
+			return fmt.Sprintf("uv run --package %s pytest", name)
+		case "Poetry":
+			return inDir("poetry run pytest")
+		case "Hatch workspace":
+			return inDir("hatch test")
+		}
+		return inDir("pytest")
+	case has("build.gradle") || has("build.gradle.kts"):
+		gradle := "gradle"
+		if rootHas("gradlew") {
+			gradle = "./gradlew"
+		}
+		return fmt.Sprintf("%s %s", gradle, strings.TrimSuffix(gradleProjectPath(dir), ":")+":test")
+	}
+	return ""
+}
+
+// gradleProjectPath converts a workspace directory to its Gradle project
+// path, ":" for the root project
+func gradleProjectPath(dir string) string {
+	if dir == "." {
+		return ":"
+	}
+	return ":" + strings.ReplaceAll(dir, "/", ":")
+}
+
+// normalizePythonName applies PEP 503 normalization so "My_Pkg" matches "my-pkg"
+func normalizePythonName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+func sortedDependencyNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedInterfaceKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,268 @@
+package analyzer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func writeWorkspaceFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		mkdirAll(t, filepath.Dir(filepath.Join(root, name)))
+		writeFile(t, filepath.Join(root, name), []byte(content))
+	}
+}
+
+func TestDependencyGraph(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  *types.MonorepoInfo
+		files map[string]string
+		want  []types.WorkspaceDependency
+	}{
+		{
+			name: "npm workspace protocol",
+			info: &types.MonorepoInfo{WorkspacePaths: []string{"apps/*", "packages/*"}},
+			files: map[string]string{
+				"apps/web/package.json":        `{"name": "web", "dependencies": {"@acme/ui": "workspace:*", "react": "^18.0.0"}}`,
+				"packages/ui/package.json":     `{"name": "@acme/ui", "devDependencies": {"@acme/config": "workspace:^"}}`,
+				"packages/config/package.json": `{"name": "@acme/config"}`,
+			},
+			want: []types.WorkspaceDependency{
+				{From: "apps/web", To: "packages/ui", Kind: "npm"},
+				{From: "packages/ui", To: "packages/config", Kind: "npm"},
+			},
+		},
+		{
+			name: "go work replace",
+			info: &types.MonorepoInfo{WorkspacePaths: []string{"api", "lib", "tools"}},
+			files: map[string]string{
+				"go.work":      "go 1.22\n\nuse (\n\t./api\n\t./lib\n\t./tools\n)\n\nreplace example.com/shared => ./lib\n",
+				"api/go.mod":   "module example.com/api\n\ngo 1.22\n\nrequire (\n\texample.com/shared v0.0.0\n\tgithub.com/spf13/cobra v1.8.0\n)\n",
+				"lib/go.mod":   "module example.com/lib\n\ngo 1.22\n",
+				"tools/go.mod": "module example.com/tools\n\ngo 1.22\n\nrequire example.com/api v0.1.0\n\nreplace example.com/x => ../lib\n",
+			},
+			want: []types.WorkspaceDependency{
+				{From: "api", To: "lib", Kind: "go"},
+				{From: "tools", To: "api", Kind: "go"},
+				{From: "tools", To: "lib", Kind: "go"},
+			},
+		},
+		{
+			name: "cargo path and workspace deps",
+			info: &types.MonorepoInfo{WorkspacePaths: []string{"crates/*"}},
+			files: map[string]string{
+				"Cargo.toml":               "[workspace]\nmembers = [\"crates/*\"]\n\n[workspace.dependencies]\ncore = { path = \"crates/core\" }\n",
+				"crates/core/Cargo.toml":   "[package]\nname = \"core\"\n",
+				"crates/cli/Cargo.toml":    "[package]\nname = \"cli\"\n\n[dependencies]\ncore = { workspace = true }\nserde = \"1\"\n\n[dev-dependencies]\nmacros = { path = \"../macros\" }\n",
+				"crates/macros/Cargo.toml": "[package]\nname = \"macros\"\n",
+			},
+			want: []types.WorkspaceDependency{
+				{From: "crates/cli", To: "crates/core", Kind: "cargo"},
+				{From: "crates/cli", To: "crates/macros", Kind: "cargo"},
+			},
+		},
+		{
+			name: "python and gradle",
+			info: &types.MonorepoInfo{WorkspacePaths: []string{"py/*", "libs/core", "app"}},
+			files: map[string]string{
+				"py/svc/pyproject.toml":    "[project]\nname = \"svc\"\ndependencies = [\"Acme_Common>=1.0\", \"requests\"]\n",
+				"py/common/pyproject.toml": "[project]\nname = \"acme-common\"\n",
+				"libs/core/build.gradle":   "plugins { id 'java' }\n",
+				"app/build.gradle.kts":     "dependencies {\n    implementation(project(\":libs:core\"))\n}\n",
+			},
+			want: []types.WorkspaceDependency{
+				{From: "app", To: "libs/core", Kind: "gradle"},
+				{From: "py/svc", To: "py/common", Kind: "python"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceFiles(t, root, tt.files)
+
+			got := NewMonorepoAnalyzer(root, false, 4).DependencyGraph(tt.info)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAffected(t *testing.T) {
+	root := t.TempDir()
+	writeWorkspaceFiles(t, root, map[string]string{
+		"pnpm-lock.yaml":             "",
+		"apps/web/package.json":      `{"name": "web", "scripts": {"test": "vitest"}, "dependencies": {"@acme/ui": "workspace:*"}}`,
+		"apps/docs/package.json":     `{"name": "docs", "dependencies": {"web": "workspace:*"}}`,
+		"packages/ui/package.json":   `{"name": "@acme/ui", "scripts": {"test": "vitest"}}`,
+		"packages/ui/src/Button.tsx": "export {}\n",
+		"packages/util/package.json": `{"name": "@acme/util"}`,
+	})
+	info := &types.MonorepoInfo{PackageManager: "pnpm", WorkspacePaths: []string{"apps/*", "packages/*"}}
+
+	affected, outside := NewMonorepoAnalyzer(root, false, 4).Affected(info, []string{"packages/ui/src/Button.tsx", "README.md"})
+	want := []AffectedWorkspace{
+		{Path: "packages/ui", Name: "@acme/ui", Reason: "changed", TestCommand: "pnpm --filter @acme/ui test"},
+		{Path: "apps/web", Name: "web", Reason: "depends on packages/ui", TestCommand: "pnpm --filter web test"},
+		{Path: "apps/docs", Name: "docs", Reason: "depends on apps/web"},
+	}
+	if !reflect.DeepEqual(affected, want) {
+		t.Errorf("expected %+v, got %+v", want, affected)
+	}
+	if !reflect.DeepEqual(outside, []string{"README.md"}) {
+		t.Errorf("expected README.md outside workspaces, got %v", outside)
+	}
+}
+
+func TestAffected_RootModule(t *testing.T) {
+	root := t.TempDir()
+	writeWorkspaceFiles(t, root, map[string]string{
+		"go.work":       "go 1.22\n\nuse (\n\t.\n\t./lib\n\t./tools\n)\n",
+		"go.mod":        "module example.com/app\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n",
+		"main.go":       "package main\n",
+		"lib/go.mod":    "module example.com/lib\n\ngo 1.22\n",
+		"lib/lib.go":    "package lib\n",
+		"tools/go.mod":  "module example.com/tools\n\ngo 1.22\n\nrequire example.com/app v0.0.0\n",
+		"tools/main.go": "package main\n",
+	})
+	info := &types.MonorepoInfo{Tool: "Go workspace", WorkspacePaths: []string{".", "lib", "tools"}}
+	ma := NewMonorepoAnalyzer(root, false, 4)
+
+	affected, outside := ma.Affected(info, []string{"lib/lib.go"})
+	want := []AffectedWorkspace{
+		{Path: "lib", Name: "example.com/lib", Reason: "changed", TestCommand: "go test ./lib/..."},
+		{Path: ".", Name: "example.com/app", Reason: "depends on lib", TestCommand: "go test ./..."},
+		{Path: "tools", Name: "example.com/tools", Reason: "depends on .", TestCommand: "go test ./tools/..."},
+	}
+	if !reflect.DeepEqual(affected, want) {
+		t.Errorf("expected the root module to depend on lib, got %+v", affected)
+	}
+	if len(outside) != 0 {
+		t.Errorf("expected no files outside workspaces, got %v", outside)
+	}
+
+	affected, outside = ma.Affected(info, []string{"main.go"})
+	if len(affected) != 2 || affected[0].Path != "." || affected[0].Reason != "changed" || len(outside) != 0 {
+		t.Errorf("expected root changes to belong to the root module, got %+v outside %v", affected, outside)
+	}
+}
+
+func TestWorkspaceTestCommand(t *testing.T) {
+	root := t.TempDir()
+	writeWorkspaceFiles(t, root, map[string]string{
+		"go.work":                "go 1.22\n",
+		"gradlew":                "",
+		"svc/go.mod":             "module example.com/svc\n",
+		"crates/cli/Cargo.toml":  "[package]\nname = \"cli\"\n",
+		"py/api/pyproject.toml":  "[project]\nname = \"api\"\n",
+		"libs/core/build.gradle": "",
+	})
+	ma := NewMonorepoAnalyzer(root, false, 4)
+
+	tests := []struct {
+		info *types.MonorepoInfo
+		dir  string
+		want string
+	}{
+		{&types.MonorepoInfo{}, "svc", "go test ./svc/..."},
+		{&types.MonorepoInfo{}, "crates/cli", "cargo test -p cli"},
+		{&types.MonorepoInfo{Tool: "uv workspace"}, "py/api", "uv run --package api pytest"},
+		{&types.MonorepoInfo{}, "py/api", "(cd py/api && pytest)"},
+		{&types.MonorepoInfo{}, "libs/core", "./gradlew :libs:core:test"},
+		{&types.MonorepoInfo{Tool: "Bazel"}, "libs/core", "bazel test //libs/core/..."},
+		{&types.MonorepoInfo{}, "missing", ""},
+	}
+	for _, tt := range tests {
+		if got := ma.testCommand(tt.info, tt.dir); got != tt.want {
+			t.Errorf("testCommand(%s, %q) = %q, want %q", tt.info.Tool, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestChangedFilesSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	writeWorkspaceFiles(t, root, map[string]string{"a/one.txt": "1\n", "b/two.txt": "2\n"})
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	writeWorkspaceFiles(t, root, map[string]string{"a/one.txt": "changed\n", "c/new.txt": "new\n"})
+
+	files, err := ChangedFilesSince(root, "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFilesSince: %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"a/one.txt", "c/new.txt"}) {
+		t.Errorf("expected modified and untracked files, got %v", files)
+	}
+
+	if _, err := ChangedFilesSince(root, "no-such-ref"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+
+	out := filepath.Join(t.TempDir(), "diff.txt")
+	if _, err := ChangedFilesSince(root, "--output="+out); err == nil {
+		t.Error("expected refs starting with - to be rejected")
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("expected git not to write the --output file")
+	}
+}
+
+func TestChangedFilesSince_RefMovedAhead(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	writeWorkspaceFiles(t, root, map[string]string{"a/one.txt": "1\n", "b/two.txt": "2\n"})
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	git("branch", "upstream")
+
+	// the branch under test changes a/
+	writeWorkspaceFiles(t, root, map[string]string{"a/one.txt": "feature\n"})
+	git("commit", "-q", "-am", "feature")
+
+	// upstream moves ahead and changes b/
+	git("checkout", "-q", "upstream")
+	writeWorkspaceFiles(t, root, map[string]string{"b/two.txt": "upstream\n"})
+	git("commit", "-q", "-am", "upstream")
+	git("checkout", "-q", "-")
+
+	files, err := ChangedFilesSince(root, "upstream")
+	if err != nil {
+		t.Fatalf("ChangedFilesSince: %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"a/one.txt"}) {
+		t.Errorf("expected only changes since the merge base, got %v", files)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/Priyans-hu/argus/pkg/types"
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	ConfigFileName = ".argus.yaml"
+
+	// DefaultMinConfidence hides conventions and patterns with little supporting evidence
+	DefaultMinConfidence = 0.4
 )
 
 // ClaudeCodeConfig controls what Claude Code configs to generate
@@ -44,6 +48,81 @@ type WorkspaceOverride struct {
 	Ignore            []string `yaml:"ignore,omitempty"`
 }
 
+// NestedConfig controls local context files in subdirectories of single-module projects
+type NestedConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	MinFiles int      `yaml:"min_files,omitempty"` // Files a directory needs to get its own context file (default 40)
+	MaxDirs  int      `yaml:"max_dirs,omitempty"`  // Maximum number of nested context files (default 8)
+	Include  []string `yaml:"include,omitempty"`   // Directory globs that always get a context file
+	Exclude  []string `yaml:"exclude,omitempty"`   // Directory globs that never get one
+}
+
+// IsEnabled reports whether nested context files are turned on
+func (c *NestedConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// ArchitectureConfig declares layer boundaries enforced by argus arch check
+type ArchitectureConfig struct {
+	Layers      []ArchitectureLayerConfig `yaml:"layers"`
+	AllowCycles bool                      `yaml:"allow_cycles,omitempty"` // Don't fail on import cycles
+	Diagram     string                    `yaml:"diagram,omitempty"`      // Diagram in CLAUDE.md: ascii, mermaid, dot
+}
+
+// GitConfig controls git history analysis
+type GitConfig struct {
+	ChurnWindowDays int `yaml:"churn_window_days,omitempty"` // History window for hotspots and co-change (default 90)
+}
+
+// ChurnWindow returns the configured churn window in days, or 0 for the default
+func (c *GitConfig) ChurnWindow() int {
+	if c == nil {
+		return 0
+	}
+	return c.ChurnWindowDays
+}
+
+// NotebookConfig controls how Jupyter notebooks are described
+type NotebookConfig struct {
+	Summarize bool `yaml:"summarize"` // Describe each notebook's purpose from its markdown headings
+}
+
+// SummariesEnabled reports whether notebook summaries are turned on
+func (c *NotebookConfig) SummariesEnabled() bool {
+	return c != nil && c.Summarize
+}
+
+// ArchitectureLayerConfig maps package paths to a named layer
+type ArchitectureLayerConfig struct {
+	Name  string   `yaml:"name"`
+	Paths []string `yaml:"paths"`           // Globs, e.g. internal/domain/**
+	Allow []string `yaml:"allow,omitempty"` // Layers this layer may import
+}
+
+// DiagramFormat returns the configured diagram format, if any
+func (c *ArchitectureConfig) DiagramFormat() string {
+	if c == nil {
+		return ""
+	}
+	return c.Diagram
+}
+
+// Rules converts the config into the rule set carried on the analysis
+func (c *ArchitectureConfig) Rules() *types.ArchitectureRules {
+	if c == nil || len(c.Layers) == 0 {
+		return nil
+	}
+	rules := &types.ArchitectureRules{AllowCycles: c.AllowCycles}
+	for _, layer := range c.Layers {
+		rules.Layers = append(rules.Layers, types.ArchitectureRule{
+			Name:  layer.Name,
+			Paths: layer.Paths,
+			Allow: layer.Allow,
+		})
+	}
+	return rules
+}
+
 // Config represents Argus configuration
 type Config struct {
 	// Output formats to generate
@@ -69,6 +148,30 @@ type Config struct {
 
 	// Monorepo per-workspace generation
 	Monorepo *MonorepoConfig `yaml:"monorepo,omitempty"`
+
+	// Architecture layers and allowed dependency directions
+	Architecture *ArchitectureConfig `yaml:"architecture,omitempty"`
+
+	// Git history analysis
+	Git *GitConfig `yaml:"git,omitempty"`
+
+	// Local context files in significant subdirectories
+	Nested *NestedConfig `yaml:"nested,omitempty"`
+
+	// Jupyter notebook analysis
+	Notebooks *NotebookConfig `yaml:"notebooks,omitempty"`
+
+	// Conventions and patterns scored below this confidence (0-1) are left out.
+	// Unset uses DefaultMinConfidence; 0 keeps everything.
+	MinConfidence *float64 `yaml:"min_confidence,omitempty"`
+}
+
+// ConfidenceThreshold returns the configured minimum confidence, or the default
+func (c *Config) ConfidenceThreshold() float64 {
+	if c == nil || c.MinConfidence == nil {
+		return DefaultMinConfidence
+	}
+	return *c.MinConfidence
 }
 
 // UsageConfig controls AI usage analysis behavior
@@ -223,5 +326,35 @@ custom_conventions:
 #         - "Frontend app - use React patterns"
 #     packages/shared:
 #       output: [claude]
+
+# Architecture rules (checked by 'argus arch check', added to .claude/rules/architecture.md)
+# Each layer may import itself and the layers in its allow list.
+# Packages outside every layer are unrestricted.
+# architecture:
+#   layers:
+#     - name: domain
+#       paths: ["internal/domain/**"]
+#     - name: app
+#       paths: ["internal/app/**"]
+#       allow: [domain]
+#     - name: adapters
+#       paths: ["internal/adapters/**", "cmd/**"]
+#       allow: [app, domain]
+#   allow_cycles: false      # Report import cycles as failures
+#   diagram: mermaid         # Diagram style in CLAUDE.md: ascii (default), mermaid, dot
+
+# Git history analysis
+# Frequently changed files and files that change together are listed in CLAUDE.md
+# git:
+#   churn_window_days: 90    # How far back from the latest commit to look
+
+# Jupyter notebooks
+# Imports, magics and data files of each notebook are listed in CLAUDE.md
+# notebooks:
+#   summarize: true          # Describe each notebook's purpose from its markdown headings
+
+# Leave out conventions and patterns with weak evidence (0-1, default 0.4, 0 keeps all)
+# Run 'argus explain <convention>' to see why argus reports something
+# min_confidence: 0.6
 `
 }
@@ -92,6 +92,25 @@ func (v *Validator) Validate(cfg *Config) error {
 		}
 	}
 
+	errors = append(errors, validateArchitecture(cfg.Architecture)...)
+
+	if cfg.Git != nil && cfg.Git.ChurnWindowDays < 0 {
+		errors = append(errors, fmt.Sprintf("git churn_window_days must be positive, got %d", cfg.Git.ChurnWindowDays))
+	}
+
+	if cfg.Nested != nil {
+		if cfg.Nested.MinFiles < 0 {
+			errors = append(errors, fmt.Sprintf("nested min_files must be positive, got %d", cfg.Nested.MinFiles))
+		}
+		if cfg.Nested.MaxDirs < 0 {
+			errors = append(errors, fmt.Sprintf("nested max_dirs must be positive, got %d", cfg.Nested.MaxDirs))
+		}
+	}
+
+	if cfg.MinConfidence != nil && (*cfg.MinConfidence < 0 || *cfg.MinConfidence > 1) {
+		errors = append(errors, fmt.Sprintf("min_confidence must be between 0 and 1, got %g", *cfg.MinConfidence))
+	}
+
 	// Note: ClaudeCode config fields are all bools, validation is handled by YAML parsing
 
 	if len(errors) > 0 {
@@ -101,6 +120,50 @@ func (v *Validator) Validate(cfg *Config) error {
 	return nil
 }
 
+// validateArchitecture checks layer names, globs and allow references
+func validateArchitecture(arch *ArchitectureConfig) []string {
+	if arch == nil {
+		return nil
+	}
+
+	var errors []string
+	switch arch.Diagram {
+	case "", "ascii", "mermaid", "dot":
+	default:
+		errors = append(errors, fmt.Sprintf("invalid architecture diagram '%s', must be one of: ascii, mermaid, dot", arch.Diagram))
+	}
+
+	names := make(map[string]bool)
+	for i, layer := range arch.Layers {
+		if layer.Name == "" {
+			errors = append(errors, fmt.Sprintf("architecture layer #%d has no name", i+1))
+			continue
+		}
+		if names[layer.Name] {
+			errors = append(errors, fmt.Sprintf("duplicate architecture layer '%s'", layer.Name))
+		}
+		names[layer.Name] = true
+		if len(layer.Paths) == 0 {
+			errors = append(errors, fmt.Sprintf("architecture layer '%s' has no paths", layer.Name))
+		}
+		for _, p := range layer.Paths {
+			if strings.TrimSpace(p) == "" {
+				errors = append(errors, fmt.Sprintf("architecture layer '%s' has an empty path", layer.Name))
+			}
+		}
+	}
+
+	for _, layer := range arch.Layers {
+		for _, allowed := range layer.Allow {
+			if !names[allowed] {
+				errors = append(errors, fmt.Sprintf("architecture layer '%s' allows unknown layer '%s'", layer.Name, allowed))
+			}
+		}
+	}
+
+	return errors
+}
+
 // ValidationError holds multiple validation errors
 type ValidationError struct {
 	Errors []string
@@ -157,6 +220,8 @@ func SuggestFix(err error) string {
 				suggestions = append(suggestions, "Keep conventions concise and actionable")
 			case strings.Contains(e, "invalid override key"):
 				suggestions = append(suggestions, "Valid override keys: project_name, framework, language, description")
+			case strings.Contains(e, "allows unknown layer"):
+				suggestions = append(suggestions, "Allow lists must reference layer names declared under architecture.layers")
 			}
 		}
 		if len(suggestions) > 0 {
@@ -3,7 +3,6 @@ package detector
 import (
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
@@ -31,8 +30,12 @@ func (d *ArchitectureDetector) Detect() *types.ArchitectureInfo {
 	// Detect architecture style based on directory structure
 	info.Style = d.detectArchitectureStyle()
 
-	// Detect layers and their relationships
-	info.Layers = d.detectLayers()
+	// Build the module import graph and derive layers from it
+	info.Dependencies = NewArchitectureAnalyzer(d.rootPath, d.files).dependencyGraph()
+	info.Layers = d.detectLayers(info.Dependencies)
+	if len(info.Dependencies) == 0 {
+		info.Dependencies = nil
+	}
 
 	// Find entry point
 	info.EntryPoint = d.findEntryPoint()
@@ -360,7 +363,7 @@ func hasPyFile(rootPath, filename string) bool {
 }
 
 // detectLayers identifies architectural layers and their dependencies
-func (d *ArchitectureDetector) detectLayers() []types.ArchitectureLayer {
+func (d *ArchitectureDetector) detectLayers(deps map[string][]string) []types.ArchitectureLayer {
 	var layers []types.ArchitectureLayer
 
 	// Group directories by depth and purpose, deduplicating
@@ -428,8 +431,8 @@ func (d *ArchitectureDetector) detectLayers() []types.ArchitectureLayer {
 			Packages: subDirs,
 		}
 
-		// Detect dependencies by analyzing imports
-		layer.DependsOn = d.detectLayerDependencies(layerDef.name)
+		// Detect dependencies from the import graph
+		layer.DependsOn = layerDependencies(layerDef.name, deps)
 
 		layers = append(layers, layer)
 	}
@@ -437,47 +440,32 @@ func (d *ArchitectureDetector) detectLayers() []types.ArchitectureLayer {
 	return layers
 }
 
-// detectLayerDependencies analyzes imports to find layer dependencies
-func (d *ArchitectureDetector) detectLayerDependencies(layer string) []string {
-	deps := make(map[string]bool)
-	// Match individual import lines: "path/to/pkg" or alias "path/to/pkg"
-	importLineRegex := regexp.MustCompile(`"([^"]+/internal/([^"/]+))"`)
-	// Valid Go package names: alphanumeric and underscore only
-	validPkgRegex := regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
-
-	layerPath := filepath.Join(d.rootPath, layer)
-	_ = filepath.WalkDir(layerPath, func(path string, entry os.DirEntry, err error) error {
-		if err != nil || entry.IsDir() {
-			return nil
-		}
-
-		if !strings.HasSuffix(path, ".go") {
-			return nil
+// layerDependencies lists the packages a layer imports, from the module
+// import graph. Packages are named by their first two path segments
+// (internal/config, models/user) and imports within one package are left out.
+func layerDependencies(layer string, deps map[string][]string) []string {
+	packageKey := func(module string) string {
+		parts := strings.SplitN(module, "/", 3)
+		if len(parts) > 2 {
+			parts = parts[:2]
 		}
+		return strings.Join(parts, "/")
+	}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil
+	seen := make(map[string]bool)
+	var result []string
+	for from, imports := range deps {
+		if strings.SplitN(from, "/", 2)[0] != layer {
+			continue
 		}
-
-		// Find all imports that reference /internal/
-		matches := importLineRegex.FindAllStringSubmatch(string(content), -1)
-		for _, match := range matches {
-			if len(match) >= 3 {
-				pkg := match[2]
-				// Validate it's a real package name (not regex artifacts)
-				if pkg != "" && pkg != layer && validPkgRegex.MatchString(pkg) {
-					deps[pkg] = true
-				}
+		for _, to := range imports {
+			key := packageKey(to)
+			if key == packageKey(from) || seen[key] {
+				continue
 			}
+			seen[key] = true
+			result = append(result, key)
 		}
-
-		return nil
-	})
-
-	var result []string
-	for dep := range deps {
-		result = append(result, dep)
 	}
 	sort.Strings(result)
 	return result
@@ -1,10 +1,10 @@
 package detector
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -12,7 +12,8 @@ import (
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
-// ArchitectureAnalyzer performs deep architecture analysis on Go projects
+// ArchitectureAnalyzer performs deep architecture analysis on Go, TypeScript,
+// Python and Rust projects
 type ArchitectureAnalyzer struct {
 	rootPath string
 	files    []types.FileInfo
@@ -26,10 +27,12 @@ func NewArchitectureAnalyzer(rootPath string, files []types.FileInfo) *Architect
 	}
 }
 
-// PackageInfo holds information about a Go package
+// PackageInfo holds information about a Go package or a module of another
+// language in the import graph
 type PackageInfo struct {
 	Path       string
 	Name       string
+	Language   string
 	ImportPath string
 	Imports    []string
 	Files      []string
@@ -42,8 +45,8 @@ type PackageInfo struct {
 // DependencyInfo holds dependency analysis results
 type DependencyInfo struct {
 	Packages        []PackageInfo
-	Dependencies    map[string][]string // package -> dependencies
-	Dependents      map[string][]string // package -> packages that depend on it
+	Dependencies    map[string][]string // package dir or module -> dependencies
+	Dependents      map[string][]string // package dir or module -> packages that depend on it
 	CyclicDeps      [][]string          // groups of packages in cyclic dependencies
 	LayerViolations []LayerViolation
 }
@@ -64,18 +67,21 @@ func (a *ArchitectureAnalyzer) Analyze() *DependencyInfo {
 		Dependents:   make(map[string][]string),
 	}
 
-	// Find all Go packages
-	packages := a.findPackages()
-	info.Packages = packages
+	// Find all Go packages and modules of other languages
+	edges, modules := a.buildImportGraph()
+	info.Packages = append(a.findPackages(), modules...)
 
-	// Build dependency graph
-	for _, pkg := range packages {
-		for _, imp := range pkg.Imports {
-			// Only track internal imports
-			if a.isInternalImport(imp) {
-				info.Dependencies[pkg.Path] = append(info.Dependencies[pkg.Path], imp)
-				info.Dependents[imp] = append(info.Dependents[imp], pkg.Path)
-			}
+	// Build dependency graph from internal imports, leaving out tests
+	for _, e := range edges {
+		if e.isTest || sliceContainsString(info.Dependencies[e.from], e.to) {
+			continue
+		}
+		info.Dependencies[e.from] = append(info.Dependencies[e.from], e.to)
+		info.Dependents[e.to] = append(info.Dependents[e.to], e.from)
+	}
+	for _, m := range []map[string][]string{info.Dependencies, info.Dependents} {
+		for k := range m {
+			sort.Strings(m[k])
 		}
 	}
 
@@ -105,9 +111,10 @@ func (a *ArchitectureAnalyzer) findPackages() []PackageInfo {
 		// Initialize package if not seen
 		if _, ok := packageMap[dir]; !ok {
 			packageMap[dir] = &PackageInfo{
-				Path:    dir,
-				Imports: []string{},
-				Files:   []string{},
+				Path:     dir,
+				Language: "Go",
+				Imports:  []string{},
+				Files:    []string{},
 			}
 		}
 
@@ -177,29 +184,6 @@ func (a *ArchitectureAnalyzer) findPackages() []PackageInfo {
 	return packages
 }
 
-// isInternalImport checks if an import is internal to the project
-func (a *ArchitectureAnalyzer) isInternalImport(importPath string) bool {
-	// Read go.mod to get module path
-	goModPath := filepath.Join(a.rootPath, "go.mod")
-	content, err := os.ReadFile(goModPath)
-	if err != nil {
-		return false
-	}
-
-	// Extract module path from go.mod
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "module ") {
-			modulePath := strings.TrimPrefix(line, "module ")
-			modulePath = strings.TrimSpace(modulePath)
-			return strings.HasPrefix(importPath, modulePath)
-		}
-	}
-
-	return false
-}
-
 // detectCycles finds cyclic dependencies using DFS
 func (a *ArchitectureAnalyzer) detectCycles(deps map[string][]string) [][]string {
 	var cycles [][]string
@@ -237,7 +221,7 @@ func (a *ArchitectureAnalyzer) detectCycles(deps map[string][]string) [][]string
 		recStack[node] = false
 	}
 
-	for node := range deps {
+	for _, node := range sortedKeys(deps) {
 		if !visited[node] {
 			dfs(node)
 		}
@@ -263,7 +247,7 @@ func (a *ArchitectureAnalyzer) detectLayerViolations(deps map[string][]string) [
 
 	// Get layer for a package path
 	getLayer := func(path string) (string, int) {
-		parts := strings.Split(path, string(filepath.Separator))
+		parts := strings.Split(filepath.ToSlash(path), "/")
 		for _, part := range parts {
 			if level, ok := layers[part]; ok {
 				return part, level
@@ -273,7 +257,8 @@ func (a *ArchitectureAnalyzer) detectLayerViolations(deps map[string][]string) [
 	}
 
 	// Check each dependency
-	for from, imports := range deps {
+	for _, from := range sortedKeys(deps) {
+		imports := deps[from]
 		fromLayer, fromLevel := getLayer(from)
 		if fromLevel < 0 {
 			continue
@@ -392,6 +377,15 @@ func sliceContainsString(slice []string, item string) bool {
 	return false
 }
 
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func formatCycles(cycles [][]string, max int) []string {
 	var result []string
 	for i, cycle := range cycles {
@@ -470,16 +464,16 @@ func findHighlyCoupledPackages(deps, dependents map[string][]string) []string {
 	threshold := 5 // Package with more than 5 deps or dependents is considered highly coupled
 
 	seen := make(map[string]bool)
-	for pkg, imports := range deps {
-		if len(imports) > threshold && !seen[pkg] {
-			result = append(result, pkg+" ("+string(rune('0'+len(imports)))+" deps)")
+	for _, pkg := range sortedKeys(deps) {
+		if imports := deps[pkg]; len(imports) > threshold && !seen[pkg] {
+			result = append(result, fmt.Sprintf("%s (%d deps)", pkg, len(imports)))
 			seen[pkg] = true
 		}
 	}
 
-	for pkg, users := range dependents {
-		if len(users) > threshold && !seen[pkg] {
-			result = append(result, pkg+" ("+string(rune('0'+len(users)))+" dependents)")
+	for _, pkg := range sortedKeys(dependents) {
+		if users := dependents[pkg]; len(users) > threshold && !seen[pkg] {
+			result = append(result, fmt.Sprintf("%s (%d dependents)", pkg, len(users)))
 			seen[pkg] = true
 		}
 	}
@@ -0,0 +1,186 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// RuleReport holds the result of checking declared architecture rules
+type RuleReport struct {
+	Violations []RuleViolation
+	Cycles     []ImportCycle
+}
+
+// RuleViolation is an import that crosses a layer boundary the rules forbid
+type RuleViolation struct {
+	File      string
+	Line      int
+	Import    string
+	FromLayer string
+	ToLayer   string
+}
+
+// ImportCycle is a cycle between packages with the import that closes each edge
+type ImportCycle struct {
+	Packages []string
+	Imports  []string // file:line of each package -> next package edge
+}
+
+// Failed reports whether the check should fail
+func (r *RuleReport) Failed() bool {
+	return len(r.Violations) > 0 || len(r.Cycles) > 0
+}
+
+// Check evaluates the import graph against declared layer rules. Test files
+// are excluded: they may reach across layers to build fixtures.
+func (a *ArchitectureAnalyzer) Check(rules *types.ArchitectureRules) *RuleReport {
+	report := &RuleReport{}
+	if rules == nil {
+		return report
+	}
+
+	edges := a.importEdges()
+	layers := compileLayers(rules)
+
+	allowed := make(map[string]map[string]bool)
+	for _, layer := range rules.Layers {
+		allowed[layer.Name] = map[string]bool{layer.Name: true}
+		for _, other := range layer.Allow {
+			allowed[layer.Name][other] = true
+		}
+	}
+
+	deps := make(map[string][]string)
+	firstEdge := make(map[string]importEdge)
+	for _, e := range edges {
+		if e.isTest {
+			continue
+		}
+
+		if !sliceContainsString(deps[e.from], e.to) {
+			deps[e.from] = append(deps[e.from], e.to)
+			firstEdge[e.from+"->"+e.to] = e
+		}
+
+		fromLayer := layerFor(layers, e.from)
+		toLayer := layerFor(layers, e.to)
+		if fromLayer == "" || toLayer == "" || allowed[fromLayer][toLayer] {
+			continue
+		}
+		report.Violations = append(report.Violations, RuleViolation{
+			File:      e.file,
+			Line:      e.line,
+			Import:    e.path,
+			FromLayer: fromLayer,
+			ToLayer:   toLayer,
+		})
+	}
+
+	if !rules.AllowCycles {
+		for _, cycle := range a.detectCycles(deps) {
+			ic := ImportCycle{Packages: cycle}
+			for i, pkg := range cycle {
+				next := cycle[(i+1)%len(cycle)]
+				e := firstEdge[pkg+"->"+next]
+				ic.Imports = append(ic.Imports, fmt.Sprintf("%s:%d", e.file, e.line))
+			}
+			report.Cycles = append(report.Cycles, ic)
+		}
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		if report.Violations[i].File != report.Violations[j].File {
+			return report.Violations[i].File < report.Violations[j].File
+		}
+		return report.Violations[i].Line < report.Violations[j].Line
+	})
+
+	return report
+}
+
+// modulePath returns the module path declared in go.mod
+func (a *ArchitectureAnalyzer) modulePath() string {
+	content, err := os.ReadFile(filepath.Join(a.rootPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// layerGlob is a layer path pattern compiled once per check. Plain paths
+// match the directory and everything below it.
+type layerGlob struct {
+	plain   string
+	regexes []*regexp.Regexp
+}
+
+// compiledLayer is a declared layer with its path globs compiled
+type compiledLayer struct {
+	name  string
+	globs []layerGlob
+}
+
+// compileLayers compiles the path globs of every declared layer
+func compileLayers(rules *types.ArchitectureRules) []compiledLayer {
+	layers := make([]compiledLayer, 0, len(rules.Layers))
+	for _, layer := range rules.Layers {
+		cl := compiledLayer{name: layer.Name}
+		for _, pattern := range layer.Paths {
+			cl.globs = append(cl.globs, compileLayerGlob(pattern))
+		}
+		layers = append(layers, cl)
+	}
+	return layers
+}
+
+// compileLayerGlob compiles a layer path pattern; a leading **/ also matches
+// at the root
+func compileLayerGlob(pattern string) layerGlob {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+	if !strings.Contains(pattern, "*") {
+		return layerGlob{plain: pattern}
+	}
+	var glob layerGlob
+	if strings.HasPrefix(pattern, "**/") {
+		glob.regexes = append(glob.regexes, antPathRegex(strings.TrimPrefix(pattern, "**/")))
+	}
+	glob.regexes = append(glob.regexes, antPathRegex(pattern))
+	return glob
+}
+
+// match reports whether a package dir falls under the glob
+func (g layerGlob) match(dir string) bool {
+	if g.regexes == nil {
+		return dir == g.plain || strings.HasPrefix(dir, g.plain+"/")
+	}
+	for _, re := range g.regexes {
+		if re.MatchString(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// layerFor returns the first declared layer whose paths match the package dir
+func layerFor(layers []compiledLayer, dir string) string {
+	for _, layer := range layers {
+		for _, glob := range layer.globs {
+			if glob.match(dir) {
+				return layer.name
+			}
+		}
+	}
+	return ""
+}
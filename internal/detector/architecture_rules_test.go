@@ -0,0 +1,107 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestArchitectureAnalyzer_Check(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"internal/domain/order.go": `package domain
+
+import (
+	"fmt"
+
+	"example.com/shop/internal/adapters/db"
+)
+
+var _ = fmt.Sprint
+var _ = db.Open
+`,
+		"internal/domain/order_test.go": `package domain
+
+import "example.com/shop/internal/app"
+
+var _ = app.Run
+`,
+		"internal/app/service.go": `package app
+
+import "example.com/shop/internal/domain"
+
+var _ = domain.Order{}
+`,
+		"internal/adapters/db/db.go": `package db
+
+import (
+	"example.com/shop/internal/app"
+	"example.com/shop/internal/domain"
+	"example.com/shop/pkg/util"
+)
+
+var _ = app.Run
+var _ = domain.Order{}
+var _ = util.X
+`,
+		"pkg/util/util.go": "package util\n\nvar X = 1\n",
+	})
+
+	rules := &types.ArchitectureRules{
+		Layers: []types.ArchitectureRule{
+			{Name: "domain", Paths: []string{"internal/domain/**"}},
+			{Name: "app", Paths: []string{"internal/app"}, Allow: []string{"domain"}},
+			{Name: "adapters", Paths: []string{"internal/adapters/**"}, Allow: []string{"app", "domain"}},
+		},
+	}
+
+	report := NewArchitectureAnalyzer(tmpDir, files).Check(rules)
+
+	if len(report.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", report.Violations)
+	}
+	v := report.Violations[0]
+	if v.File != "internal/domain/order.go" || v.Line != 6 || v.FromLayer != "domain" || v.ToLayer != "adapters" {
+		t.Errorf("unexpected violation %+v", v)
+	}
+	if v.Import != "example.com/shop/internal/adapters/db" {
+		t.Errorf("expected import path to be reported, got %q", v.Import)
+	}
+
+	if len(report.Cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %+v", report.Cycles)
+	}
+	if c := report.Cycles[0]; len(c.Packages) != 3 || len(c.Imports) != 3 {
+		t.Errorf("expected a 3-package cycle with locations, got %+v", c)
+	}
+	if !report.Failed() {
+		t.Error("expected report to fail")
+	}
+
+	rules.AllowCycles = true
+	if report := NewArchitectureAnalyzer(tmpDir, files).Check(rules); len(report.Cycles) != 0 {
+		t.Errorf("expected cycles to be ignored, got %+v", report.Cycles)
+	}
+}
+
+func TestCompileLayerGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		dir     string
+		want    bool
+	}{
+		{"internal/domain/**", "internal/domain", true},
+		{"internal/domain/**", "internal/domain/order", true},
+		{"internal/domain", "internal/domain/order", true},
+		{"internal/domain", "internal/domainx", false},
+		{"internal/*/ports", "internal/billing/ports", true},
+		{"**/adapters/**", "adapters/http", true},
+		{"**/adapters/**", "internal/adapters", true},
+		{"./cmd/", "cmd", true},
+	}
+	for _, tt := range tests {
+		if got := compileLayerGlob(tt.pattern).match(tt.dir); got != tt.want {
+			t.Errorf("compileLayerGlob(%q).match(%q) = %v, want %v", tt.pattern, tt.dir, got, tt.want)
+		}
+	}
+}
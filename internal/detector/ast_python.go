@@ -74,6 +74,13 @@ func (d *PythonASTDetector) Detect() []types.PatternInfo {
 		d.extractFromModule(module, f.Path, imports, decorators, classPatterns, funcPatterns)
 	}
 
+	// Notebook code cells feed the same patterns as .py files
+	for _, nb := range readNotebooks(d.rootPath, d.files) {
+		for _, module := range nb.modules() {
+			d.extractFromModule(module, nb.path, imports, decorators, classPatterns, funcPatterns)
+		}
+	}
+
 	// Convert to PatternInfo
 	var patterns []types.PatternInfo
 	patterns = append(patterns, d.importsToPatterns(imports)...)
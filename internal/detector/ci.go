@@ -0,0 +1,637 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// CI providers
+const (
+	CIProviderGitHub   = "GitHub Actions"
+	CIProviderGitLab   = "GitLab CI"
+	CIProviderCircleCI = "CircleCI"
+	CIProviderJenkins  = "Jenkins"
+	CIProviderAzure    = "Azure Pipelines"
+)
+
+// ciActionCommands maps GitHub Actions that wrap a check to the command they run
+var ciActionCommands = map[string]string{
+	"golangci/golangci-lint-action": "golangci-lint run",
+	"pre-commit/action":             "pre-commit run --all-files",
+	"astral-sh/ruff-action":         "ruff check",
+}
+
+// gitlabReservedKeys are top-level .gitlab-ci.yml keys that are not jobs
+var gitlabReservedKeys = map[string]bool{
+	"stages": true, "variables": true, "default": true, "include": true, "workflow": true,
+	"image": true, "services": true, "cache": true, "before_script": true, "after_script": true,
+	"types": true,
+}
+
+// ciSubdirCommandRegex matches a command run in a subdirectory: (cd docs && npm run build)
+var ciSubdirCommandRegex = regexp.MustCompile(`^\(cd (\S+) && (.+)\)$`)
+
+// jenkinsStepRegex matches stage('Name') and sh/bat/powershell steps in a Jenkinsfile
+var jenkinsStepRegex = regexp.MustCompile(`(?s)stage\s*\(\s*['"]([^'"]+)['"]\s*\)|\b(?:sh|bat|powershell)\s*\(?\s*(?:script:\s*)?('''|"""|'|")(.*?)('''|"""|'|")`)
+
+// gitlabTagsOnlyRegex matches "- tags" under only/except
+var gitlabTagsOnlyRegex = regexp.MustCompile(`(?m)^\s*-\s*tags\s*$`)
+
+// ciConfigFile is a CI configuration file and the provider that reads it
+type ciConfigFile struct {
+	provider string
+	path     string
+}
+
+// ciConfigFiles returns the CI configuration files present in rootPath.
+// They are read from disk because the file walker skips .github and .gitlab-ci.yml.
+func ciConfigFiles(rootPath string) []ciConfigFile {
+	var configs []ciConfigFile
+	if entries, err := os.ReadDir(filepath.Join(rootPath, ".github", "workflows")); err == nil {
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				configs = append(configs, ciConfigFile{CIProviderGitHub, ".github/workflows/" + entry.Name()})
+			}
+		}
+	}
+
+	for _, cfg := range []ciConfigFile{
+		{CIProviderGitLab, ".gitlab-ci.yml"},
+		{CIProviderCircleCI, ".circleci/config.yml"},
+		{CIProviderJenkins, "Jenkinsfile"},
+		{CIProviderAzure, "azure-pipelines.yml"},
+		{CIProviderAzure, "azure-pipelines.yaml"},
+	} {
+		if fileExists(filepath.Join(rootPath, cfg.path)) {
+			configs = append(configs, cfg)
+		}
+	}
+	return configs
+}
+
+// CIDetector parses CI pipelines into jobs, triggers and the commands they run
+type CIDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewCIDetector creates a new CI detector
+func NewCIDetector(rootPath string, files []types.FileInfo) *CIDetector {
+	return &CIDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// Detect returns the project's CI pipelines, or nil when there are none
+func (d *CIDetector) Detect() *types.CIInfo {
+	info := &types.CIInfo{}
+	for _, cfg := range ciConfigFiles(d.rootPath) {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, cfg.path))
+		if err != nil {
+			continue
+		}
+
+		var pipeline *types.CIPipeline
+		switch cfg.provider {
+		case CIProviderGitHub:
+			pipeline = parseGitHubWorkflow(content)
+		case CIProviderGitLab:
+			pipeline = parseGitLabCI(content)
+		case CIProviderCircleCI:
+			pipeline = parseCircleCI(content)
+		case CIProviderJenkins:
+			pipeline = parseJenkinsfile(string(content))
+		case CIProviderAzure:
+			pipeline = parseAzurePipeline(content)
+		}
+		if pipeline == nil {
+			continue
+		}
+		pipeline.Provider = cfg.provider
+		pipeline.File = cfg.path
+		info.Pipelines = append(info.Pipelines, *pipeline)
+	}
+
+	if len(info.Pipelines) == 0 {
+		return nil
+	}
+	info.Commands = ciCommands(info.Pipelines)
+	info.RequiredChecks = d.requiredChecks()
+	return info
+}
+
+// requiredChecks reads required status checks from the Probot settings app config
+func (d *CIDetector) requiredChecks() []string {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, ".github", "settings.yml"))
+	if err != nil {
+		return nil
+	}
+
+	var settings struct {
+		Branches []struct {
+			Protection struct {
+				RequiredStatusChecks struct {
+					Contexts []string `yaml:"contexts"`
+					Checks   []struct {
+						Context string `yaml:"context"`
+					} `yaml:"checks"`
+				} `yaml:"required_status_checks"`
+			} `yaml:"protection"`
+		} `yaml:"branches"`
+	}
+	if yaml.Unmarshal(content, &settings) != nil {
+		return nil
+	}
+
+	var checks []string
+	for _, branch := range settings.Branches {
+		status := branch.Protection.RequiredStatusChecks
+		for _, context := range status.Contexts {
+			checks = appendUnique(checks, context)
+		}
+		for _, check := range status.Checks {
+			checks = appendUnique(checks, check.Context)
+		}
+	}
+	return checks
+}
+
+// parseGitHubWorkflow parses a GitHub Actions workflow
+func parseGitHubWorkflow(content []byte) *types.CIPipeline {
+	var workflow struct {
+		Name string    `yaml:"name"`
+		On   yaml.Node `yaml:"on"`
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if yaml.Unmarshal(content, &workflow) != nil || workflow.Jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	pipeline := &types.CIPipeline{Name: workflow.Name, Triggers: githubTriggers(&workflow.On)}
+	for i := 0; i+1 < len(workflow.Jobs.Content); i += 2 {
+		var job struct {
+			Name     string `yaml:"name"`
+			Defaults struct {
+				Run struct {
+					WorkingDirectory string `yaml:"working-directory"`
+				} `yaml:"run"`
+			} `yaml:"defaults"`
+			Steps []struct {
+				Uses             string `yaml:"uses"`
+				Run              string `yaml:"run"`
+				WorkingDirectory string `yaml:"working-directory"`
+			} `yaml:"steps"`
+		}
+		if workflow.Jobs.Content[i+1].Decode(&job) != nil {
+			continue
+		}
+
+		ciJob := types.CIJob{Name: workflow.Jobs.Content[i].Value}
+		if job.Name != "" && !strings.Contains(job.Name, "${{") {
+			ciJob.Name = job.Name
+		}
+		for _, step := range job.Steps {
+			dir := step.WorkingDirectory
+			if dir == "" {
+				dir = job.Defaults.Run.WorkingDirectory
+			}
+			if step.Uses != "" {
+				action, _, _ := strings.Cut(step.Uses, "@")
+				if command, ok := ciActionCommands[action]; ok {
+					ciJob.Commands = append(ciJob.Commands, inDir(dir, command))
+				}
+				continue
+			}
+			for _, line := range shellLines(step.Run) {
+				ciJob.Commands = append(ciJob.Commands, inDir(dir, line))
+			}
+		}
+		pipeline.Jobs = append(pipeline.Jobs, ciJob)
+	}
+	return pipeline
+}
+
+// githubTriggers lists the events in a workflow's on: key. A push filtered
+// to tags only is reported as "tags".
+func githubTriggers(on *yaml.Node) []string {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return []string{on.Value}
+	case yaml.SequenceNode:
+		var triggers []string
+		for _, n := range on.Content {
+			triggers = append(triggers, n.Value)
+		}
+		return triggers
+	case yaml.MappingNode:
+		var triggers []string
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			event, filters := on.Content[i].Value, on.Content[i+1]
+			if event == "push" && filters.Kind == yaml.MappingNode {
+				keys := make(map[string]bool)
+				for j := 0; j < len(filters.Content); j += 2 {
+					keys[filters.Content[j].Value] = true
+				}
+				if keys["tags"] && !keys["branches"] && !keys["branches-ignore"] {
+					event = "tags"
+				}
+			}
+			triggers = append(triggers, event)
+		}
+		return triggers
+	}
+	return nil
+}
+
+// parseGitLabCI parses .gitlab-ci.yml
+func parseGitLabCI(content []byte) *types.CIPipeline {
+	var root yaml.Node
+	if yaml.Unmarshal(content, &root) != nil || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	doc := root.Content[0]
+
+	pipeline := &types.CIPipeline{Triggers: gitlabTriggers(string(content))}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		name := doc.Content[i].Value
+		if gitlabReservedKeys[name] || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		var job struct {
+			Stage        string    `yaml:"stage"`
+			BeforeScript yaml.Node `yaml:"before_script"`
+			Script       yaml.Node `yaml:"script"`
+			Trigger      yaml.Node `yaml:"trigger"`
+		}
+		if doc.Content[i+1].Kind != yaml.MappingNode || doc.Content[i+1].Decode(&job) != nil {
+			continue
+		}
+		if job.Script.Kind == 0 && job.Trigger.Kind == 0 {
+			continue
+		}
+
+		ciJob := types.CIJob{Name: name, Stage: job.Stage}
+		if ciJob.Stage == "" {
+			ciJob.Stage = "test"
+		}
+		ciJob.Commands = append(scriptNodeLines(&job.BeforeScript), scriptNodeLines(&job.Script)...)
+		pipeline.Jobs = append(pipeline.Jobs, ciJob)
+	}
+
+	if len(pipeline.Jobs) == 0 {
+		return nil
+	}
+	return pipeline
+}
+
+// gitlabTriggers infers pipeline sources from rules and only/except clauses
+func gitlabTriggers(content string) []string {
+	triggers := []string{"push"}
+	if strings.Contains(content, "merge_request_event") || strings.Contains(content, "merge_requests") {
+		triggers = append(triggers, "merge_request")
+	}
+	if strings.Contains(content, "$CI_COMMIT_TAG") || gitlabTagsOnlyRegex.MatchString(content) {
+		triggers = append(triggers, "tags")
+	}
+	if strings.Contains(content, `"schedule"`) || strings.Contains(content, "schedules") {
+		triggers = append(triggers, "schedule")
+	}
+	return triggers
+}
+
+// parseCircleCI parses .circleci/config.yml
+func parseCircleCI(content []byte) *types.CIPipeline {
+	var config struct {
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if yaml.Unmarshal(content, &config) != nil || config.Jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	pipeline := &types.CIPipeline{Triggers: []string{"push"}}
+	if strings.Contains(string(content), "cron:") {
+		pipeline.Triggers = append(pipeline.Triggers, "schedule")
+	}
+
+	for i := 0; i+1 < len(config.Jobs.Content); i += 2 {
+		var job struct {
+			Steps []yaml.Node `yaml:"steps"`
+		}
+		if config.Jobs.Content[i+1].Decode(&job) != nil {
+			continue
+		}
+
+		ciJob := types.CIJob{Name: config.Jobs.Content[i].Value}
+		for _, step := range job.Steps {
+			var run struct {
+				Run yaml.Node `yaml:"run"`
+			}
+			if step.Kind != yaml.MappingNode || step.Decode(&run) != nil {
+				continue
+			}
+			script := run.Run.Value
+			if run.Run.Kind == yaml.MappingNode {
+				var detailed struct {
+					Command string `yaml:"command"`
+				}
+				_ = run.Run.Decode(&detailed)
+				script = detailed.Command
+			}
+			ciJob.Commands = append(ciJob.Commands, shellLines(script)...)
+		}
+		pipeline.Jobs = append(pipeline.Jobs, ciJob)
+	}
+	return pipeline
+}
+
+// parseJenkinsfile extracts stages and shell steps from a declarative or
+// scripted Jenkinsfile
+func parseJenkinsfile(content string) *types.CIPipeline {
+	pipeline := &types.CIPipeline{Triggers: []string{"push"}}
+	if strings.Contains(content, "cron(") {
+		pipeline.Triggers = append(pipeline.Triggers, "schedule")
+	}
+
+	for _, m := range jenkinsStepRegex.FindAllStringSubmatch(content, -1) {
+		if m[1] != "" {
+			pipeline.Jobs = append(pipeline.Jobs, types.CIJob{Name: m[1]})
+			continue
+		}
+		if m[2] != m[4] {
+			continue
+		}
+		if len(pipeline.Jobs) == 0 {
+			pipeline.Jobs = append(pipeline.Jobs, types.CIJob{Name: "pipeline"})
+		}
+		job := &pipeline.Jobs[len(pipeline.Jobs)-1]
+		job.Commands = append(job.Commands, shellLines(m[3])...)
+	}
+
+	if len(pipeline.Jobs) == 0 {
+		return nil
+	}
+	return pipeline
+}
+
+// azureStep is a step in an Azure Pipelines job
+type azureStep struct {
+	Script           string `yaml:"script"`
+	Bash             string `yaml:"bash"`
+	Pwsh             string `yaml:"pwsh"`
+	PowerShell       string `yaml:"powershell"`
+	WorkingDirectory string `yaml:"workingDirectory"`
+}
+
+// azureJob is a job in an Azure Pipelines file
+type azureJob struct {
+	Job         string      `yaml:"job"`
+	DisplayName string      `yaml:"displayName"`
+	Steps       []azureStep `yaml:"steps"`
+}
+
+// parseAzurePipeline parses azure-pipelines.yml with stages, jobs or bare steps
+func parseAzurePipeline(content []byte) *types.CIPipeline {
+	var config struct {
+		Name      string    `yaml:"name"`
+		Trigger   yaml.Node `yaml:"trigger"`
+		PR        yaml.Node `yaml:"pr"`
+		Schedules yaml.Node `yaml:"schedules"`
+		Stages    []struct {
+			Stage string     `yaml:"stage"`
+			Jobs  []azureJob `yaml:"jobs"`
+		} `yaml:"stages"`
+		Jobs  []azureJob  `yaml:"jobs"`
+		Steps []azureStep `yaml:"steps"`
+	}
+	if yaml.Unmarshal(content, &config) != nil {
+		return nil
+	}
+
+	pipeline := &types.CIPipeline{Name: config.Name}
+	if config.Trigger.Value != "none" {
+		pipeline.Triggers = append(pipeline.Triggers, "push")
+	}
+	if config.PR.Value != "none" {
+		pipeline.Triggers = append(pipeline.Triggers, "pull_request")
+	}
+	if config.Schedules.Kind != 0 {
+		pipeline.Triggers = append(pipeline.Triggers, "schedule")
+	}
+
+	addJob := func(stage string, job azureJob) {
+		ciJob := types.CIJob{Name: job.Job, Stage: stage}
+		if ciJob.Name == "" {
+			ciJob.Name = job.DisplayName
+		}
+		for _, step := range job.Steps {
+			script := step.Script + step.Bash + step.Pwsh + step.PowerShell
+			for _, line := range shellLines(script) {
+				ciJob.Commands = append(ciJob.Commands, inDir(step.WorkingDirectory, line))
+			}
+		}
+		pipeline.Jobs = append(pipeline.Jobs, ciJob)
+	}
+
+	for _, stage := range config.Stages {
+		for _, job := range stage.Jobs {
+			addJob(stage.Stage, job)
+		}
+	}
+	for _, job := range config.Jobs {
+		addJob("", job)
+	}
+	if len(config.Steps) > 0 {
+		addJob("", azureJob{Job: "build", Steps: config.Steps})
+	}
+
+	if len(pipeline.Jobs) == 0 {
+		return nil
+	}
+	return pipeline
+}
+
+// scriptNodeLines returns the commands in a GitLab script, which is a string
+// or a list of strings
+func scriptNodeLines(node *yaml.Node) []string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return shellLines(node.Value)
+	case yaml.SequenceNode:
+		var lines []string
+		for _, n := range node.Content {
+			if n.Kind == yaml.ScalarNode {
+				lines = append(lines, shellLines(n.Value)...)
+			}
+		}
+		return lines
+	}
+	return nil
+}
+
+// shellLines splits a shell script into commands, joining continuation lines
+// and dropping blanks and comments
+func shellLines(script string) []string {
+	var lines []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSpace(strings.TrimSuffix(line, "\\")) + " ")
+			continue
+		}
+		current.WriteString(line)
+		joined := strings.TrimSpace(current.String())
+		current.Reset()
+		if joined == "" || strings.HasPrefix(joined, "#") {
+			continue
+		}
+		lines = append(lines, joined)
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		lines = append(lines, rest)
+	}
+	return lines
+}
+
+// inDir prefixes a command with a cd into dir, the form ciCommands understands
+func inDir(dir, command string) string {
+	if dir == "" || dir == "." {
+		return command
+	}
+	return "cd " + dir + " && " + command
+}
+
+// ciCommands collects the build, test, lint and format commands the
+// pipelines run, in pipeline order and without duplicates
+func ciCommands(pipelines []types.CIPipeline) []types.CICommand {
+	var commands []types.CICommand
+	seen := make(map[string]bool)
+
+	for _, pipeline := range pipelines {
+		for _, job := range pipeline.Jobs {
+			for _, line := range job.Commands {
+				dir := ""
+				for _, part := range strings.Split(line, "&&") {
+					part = strings.TrimSpace(part)
+					if target, ok := strings.CutPrefix(part, "cd "); ok {
+						dir = path.Clean(path.Join(dir, strings.Trim(strings.TrimSpace(target), `"'`)))
+						if dir == "." {
+							dir = ""
+						}
+						continue
+					}
+					// Shell conditionals look like "make test" to the category patterns
+					if strings.HasPrefix(part, "test -") || strings.HasPrefix(part, "[") {
+						continue
+					}
+
+					category := categorizeCommand(types.Command{Name: part})
+					switch category {
+					case CategoryBuild, CategoryTest, CategoryLint, CategoryFormat:
+					default:
+						continue
+					}
+
+					key := dir + "|" + normalizeCommand(part)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					commands = append(commands, types.CICommand{
+						Category: GetCategoryName(category),
+						Command:  part,
+						Dir:      dir,
+						Job:      job.Name,
+						File:     pipeline.File,
+					})
+				}
+			}
+		}
+	}
+	return commands
+}
+
+// CICommandName formats a CI command as it is run from the project root,
+// as (cd dir && command) outside the root
+func CICommandName(c types.CICommand) string {
+	if c.Dir == "" {
+		return c.Command
+	}
+	return fmt.Sprintf("(cd %s && %s)", c.Dir, c.Command)
+}
+
+// ApplyCICommands marks commands that CI runs as verified, adds CI commands
+// that were not detected locally, and moves verified commands first
+func ApplyCICommands(commands []types.Command, ci *types.CIInfo) []types.Command {
+	if ci == nil || len(ci.Commands) == 0 {
+		return commands
+	}
+
+	ciByKey := make(map[string]types.CICommand)
+	var keys []string
+	for _, c := range ci.Commands {
+		key := ciCommandKey(CICommandName(c))
+		if _, ok := ciByKey[key]; !ok {
+			ciByKey[key] = c
+			keys = append(keys, key)
+		}
+	}
+
+	result := make([]types.Command, 0, len(commands)+len(keys))
+	matched := make(map[string]bool)
+	lastJob := ""
+	for _, cmd := range commands {
+		key := ciCommandKey(cmd.Name)
+		_, cmd.CIVerified = ciByKey[key]
+		if cmd.CIVerified {
+			matched[key] = true
+		}
+		result = append(result, cmd)
+	}
+	for _, key := range keys {
+		if matched[key] {
+			continue
+		}
+		c := ciByKey[key]
+		// only the first command of a job is headed with it
+		description := c.Job + " job"
+		if c.Job == lastJob {
+			description = ""
+		}
+		lastJob = c.Job
+		result = append(result, types.Command{
+			Name:        CICommandName(c),
+			Description: description,
+			CIVerified:  true,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CIVerified && !result[j].CIVerified
+	})
+	return result
+}
+
+// ciCommandKey normalizes a command name for matching, keeping its directory.
+// "cmd (in dir)" and "(cd dir && cmd)" name the same command.
+func ciCommandKey(name string) string {
+	if m := ciSubdirCommandRegex.FindStringSubmatch(name); m != nil {
+		name = m[2] + " (in " + m[1] + ")"
+	}
+	key := normalizeCommand(name)
+	if idx := strings.Index(name, " (in "); idx > 0 {
+		key += strings.ToLower(name[idx:])
+	}
+	return strings.Join(strings.Fields(key), " ")
+}
@@ -0,0 +1,231 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestCIDetector_GitHubActions(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		".github/workflows/ci.yml": `name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: golangci/golangci-lint-action@v6
+  test:
+    name: Unit tests
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: go mod download
+      - run: |
+          # race detector on
+          go test -race \
+            ./...
+      - run: npm ci && npm run lint
+        working-directory: web
+`,
+		".github/workflows/release.yml": "on:\n  push:\n    tags: ['v*']\njobs:\n  release:\n    steps:\n      - run: go build ./...\n",
+		".github/settings.yml":          "branches:\n  - name: main\n    protection:\n      required_status_checks:\n        contexts: [lint, Unit tests]\n",
+	})
+
+	info := NewCIDetector(tmpDir, files).Detect()
+	if info == nil || len(info.Pipelines) != 2 {
+		t.Fatalf("expected two pipelines, got %+v", info)
+	}
+
+	ci := info.Pipelines[0]
+	if ci.Provider != CIProviderGitHub || ci.File != ".github/workflows/ci.yml" || ci.Name != "CI" {
+		t.Errorf("unexpected pipeline %+v", ci)
+	}
+	if !reflect.DeepEqual(ci.Triggers, []string{"push", "pull_request"}) {
+		t.Errorf("expected push and pull_request triggers, got %v", ci.Triggers)
+	}
+	if len(ci.Jobs) != 2 || ci.Jobs[1].Name != "Unit tests" {
+		t.Fatalf("expected lint and Unit tests jobs in order, got %+v", ci.Jobs)
+	}
+	wantTest := []string{"go mod download", "go test -race ./...", "cd web && npm ci && npm run lint"}
+	if !reflect.DeepEqual(ci.Jobs[1].Commands, wantTest) {
+		t.Errorf("expected commands %v, got %v", wantTest, ci.Jobs[1].Commands)
+	}
+	if got := info.Pipelines[1].Triggers; !reflect.DeepEqual(got, []string{"tags"}) {
+		t.Errorf("expected tag-only push to be reported as tags, got %v", got)
+	}
+
+	wantCommands := []types.CICommand{
+		{Category: "Lint", Command: "golangci-lint run", Job: "lint", File: ".github/workflows/ci.yml"},
+		{Category: "Test", Command: "go test -race ./...", Job: "Unit tests", File: ".github/workflows/ci.yml"},
+		{Category: "Lint", Command: "npm run lint", Dir: "web", Job: "Unit tests", File: ".github/workflows/ci.yml"},
+		{Category: "Build", Command: "go build ./...", Job: "release", File: ".github/workflows/release.yml"},
+	}
+	if !reflect.DeepEqual(info.Commands, wantCommands) {
+		t.Errorf("expected CI commands %+v, got %+v", wantCommands, info.Commands)
+	}
+	if !reflect.DeepEqual(info.RequiredChecks, []string{"lint", "Unit tests"}) {
+		t.Errorf("expected required checks, got %v", info.RequiredChecks)
+	}
+}
+
+func TestCIDetector_OtherProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		provider string
+		triggers []string
+		jobs     []types.CIJob
+	}{
+		{
+			name: "gitlab",
+			file: ".gitlab-ci.yml",
+			content: `stages: [lint, test]
+variables:
+  GO_VERSION: "1.22"
+.go-template:
+  image: golang
+lint:
+  stage: lint
+  script: golangci-lint run
+unit:
+  extends: .go-template
+  before_script:
+    - go mod download
+  script:
+    - go test ./...
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+`,
+			provider: CIProviderGitLab,
+			triggers: []string{"push", "merge_request"},
+			jobs: []types.CIJob{
+				{Name: "lint", Stage: "lint", Commands: []string{"golangci-lint run"}},
+				{Name: "unit", Stage: "test", Commands: []string{"go mod download", "go test ./..."}},
+			},
+		},
+		{
+			name: "circleci",
+			file: ".circleci/config.yml",
+			content: `version: 2.1
+jobs:
+  test:
+    docker:
+      - image: cimg/node:20.0
+    steps:
+      - checkout
+      - run: npm ci
+      - run:
+          name: Test
+          command: npm test
+`,
+			provider: CIProviderCircleCI,
+			triggers: []string{"push"},
+			jobs:     []types.CIJob{{Name: "test", Commands: []string{"npm ci", "npm test"}}},
+		},
+		{
+			name: "jenkins",
+			file: "Jenkinsfile",
+			content: `pipeline {
+  agent any
+  triggers { cron('H 2 * * *') }
+  stages {
+    stage('Build') {
+      steps { sh 'mvn package' }
+    }
+    stage('Test') {
+      steps {
+        sh '''
+          mvn test
+        '''
+      }
+    }
+  }
+}
+`,
+			provider: CIProviderJenkins,
+			triggers: []string{"push", "schedule"},
+			jobs: []types.CIJob{
+				{Name: "Build", Commands: []string{"mvn package"}},
+				{Name: "Test", Commands: []string{"mvn test"}},
+			},
+		},
+		{
+			name: "azure",
+			file: "azure-pipelines.yml",
+			content: `trigger:
+  - main
+pr: none
+stages:
+  - stage: CI
+    jobs:
+      - job: Test
+        steps:
+          - script: dotnet test
+            workingDirectory: src
+`,
+			provider: CIProviderAzure,
+			triggers: []string{"push"},
+			jobs:     []types.CIJob{{Name: "Test", Stage: "CI", Commands: []string{"cd src && dotnet test"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, files := writeProjectFixture(t, map[string]string{tt.file: tt.content})
+			info := NewCIDetector(tmpDir, files).Detect()
+			if info == nil || len(info.Pipelines) != 1 {
+				t.Fatalf("expected one pipeline, got %+v", info)
+			}
+			pipeline := info.Pipelines[0]
+			if pipeline.Provider != tt.provider {
+				t.Errorf("expected provider %s, got %s", tt.provider, pipeline.Provider)
+			}
+			if !reflect.DeepEqual(pipeline.Triggers, tt.triggers) {
+				t.Errorf("expected triggers %v, got %v", tt.triggers, pipeline.Triggers)
+			}
+			if !reflect.DeepEqual(pipeline.Jobs, tt.jobs) {
+				t.Errorf("expected jobs %+v, got %+v", tt.jobs, pipeline.Jobs)
+			}
+		})
+	}
+}
+
+func TestApplyCICommands(t *testing.T) {
+	commands := []types.Command{
+		{Name: "make build", Description: "Build the project"},
+		{Name: "npm run lint (in web)"},
+		{Name: "go test ./..."},
+	}
+	ci := &types.CIInfo{Commands: []types.CICommand{
+		{Category: "Test", Command: "go test ./...", Job: "test"},
+		{Category: "Lint", Command: "npm lint", Dir: "web", Job: "lint"},
+		{Category: "Lint", Command: "golangci-lint run", Job: "lint"},
+		{Category: "Build", Command: "npm run build", Dir: "docs", Job: "lint"},
+		{Category: "Build", Command: "npm run build", Dir: "site", Job: "docs"},
+	}}
+
+	got := ApplyCICommands(commands, ci)
+	want := []types.Command{
+		{Name: "npm run lint (in web)", CIVerified: true},
+		{Name: "go test ./...", CIVerified: true},
+		{Name: "golangci-lint run", Description: "lint job", CIVerified: true},
+		{Name: "(cd docs && npm run build)", CIVerified: true},
+		{Name: "(cd site && npm run build)", Description: "docs job", CIVerified: true},
+		{Name: "make build", Description: "Build the project"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	// Re-applying must not add the CI-only command twice
+	if again := ApplyCICommands(got, ci); len(again) != len(want) {
+		t.Errorf("expected ApplyCICommands to be idempotent, got %+v", again)
+	}
+}
@@ -14,6 +14,9 @@ type CLIDetector struct {
 	rootPath  string
 	files     []types.FileInfo
 	techStack *types.TechStack
+
+	// consts caches the project's literal package-level constants
+	consts map[string]string
 }
 
 // NewCLIDetector creates a new CLI detector
@@ -40,8 +43,11 @@ func (d *CLIDetector) Detect() *types.CLIInfo {
 	// Detect output indicators
 	info.Indicators = d.detectIndicators()
 
+	// Extract the command tree with flags and arguments
+	info.Framework, info.Commands = d.detectCommandTree()
+
 	// Return nil if nothing detected
-	if info.VerboseFlag == "" && info.DryRunFlag == "" && len(info.Indicators) == 0 {
+	if info.VerboseFlag == "" && info.DryRunFlag == "" && len(info.Indicators) == 0 && len(info.Commands) == 0 {
 		return nil
 	}
 
@@ -78,6 +84,16 @@ func (d *CLIDetector) isCLIProject() bool {
 		}
 	}
 
+	// Python packages installed as console scripts or runnable with -m
+	if len(d.pythonScripts()) > 0 {
+		return true
+	}
+	for _, f := range d.files {
+		if f.Name == "__main__.py" {
+			return true
+		}
+	}
+
 	return false
 }
 
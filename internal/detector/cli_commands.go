@@ -0,0 +1,1936 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Command tree extraction for the CLI frameworks the detector recognizes.
+// Each framework parser builds a cliTree keyed by the identifier the source
+// code uses to refer to a command (variable, constructor or function name),
+// links parents to children, and returns the root commands.
+
+var (
+	cobraLiteralRegex   = regexp.MustCompile(`&cobra\.Command\s*\{`)
+	goAssignTargetRegex = regexp.MustCompile(`(\w+)\s*(?::=|=)\s*$`)
+	goFuncDeclRegex     = regexp.MustCompile(`(?m)^func\s+(?:\([^)]*\)\s*)?(\w+)\s*\(([^\n]*)\{\s*$`)
+	goLocalCallRegex    = regexp.MustCompile(`\b(\w+)\s*:=\s*([\w.]+)\(`)
+	pflagCallRegex      = regexp.MustCompile(`\b(\w+)\.(PersistentFlags|Flags)\(\)\.(\w+)\(`)
+	pflagAliasRegex     = regexp.MustCompile(`\b(\w+)\s*:=\s*(\w+)\.(PersistentFlags|Flags)\(\)\s*\n`)
+	goMethodCallRegex   = regexp.MustCompile(`\b(\w+)\.(\w+)\(`)
+	cobraAddRegex       = regexp.MustCompile(`\b(\w+)\.AddCommand\(`)
+	pflagMethodRegex    = regexp.MustCompile(`^(Bool|String|Int(?:8|16|32|64)?|Uint(?:8|16|32|64)?|Float(?:32|64)|Duration|Count|StringSlice|StringArray|StringToString|IntSlice|BoolSlice|IP)?(Var)?(P)?$`)
+
+	urfaveLiteralRegex = regexp.MustCompile(`&?cli\.(App|Command)\s*\{`)
+	urfaveFlagRegex    = regexp.MustCompile(`^&?cli\.(\w+)Flag\s*\{`)
+
+	pyDecoratorRegex  = regexp.MustCompile(`^\s*@([\w.]+)\.(command|group|option|argument|callback)\b(\()?`)
+	pyDefRegex        = regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)\s*\(`)
+	pyFromImportRegex = regexp.MustCompile(`^\s*from\s+([\w.]+)\s+import\s+(.+)$`)
+	typerAppRegex     = regexp.MustCompile(`^\s*(\w+)\s*=\s*typer\.Typer\(`)
+	pyAddCommandRegex = regexp.MustCompile(`\b([\w.]+)\.(add_command|add_typer)\(`)
+
+	argparseParserRegex     = regexp.MustCompile(`(\w+)\s*=\s*argparse\.ArgumentParser\(`)
+	argparseSubparsersRegex = regexp.MustCompile(`(\w+)\s*=\s*(\w+)\.add_subparsers\(`)
+	argparseAddParserRegex  = regexp.MustCompile(`(?:(\w+)\s*=\s*)?(\w+)\.add_parser\(`)
+	argparseGroupRegex      = regexp.MustCompile(`(\w+)\s*=\s*(\w+)\.add_(?:argument_group|mutually_exclusive_group)\(`)
+	argparseArgumentRegex   = regexp.MustCompile(`(\w+)\.add_argument\(`)
+
+	rustItemRegex    = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(struct|enum)\s+(\w+)[^{;]*\{`)
+	rustFieldRegex   = regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?(\w+)\s*:\s*(.+?),?$`)
+	rustVariantRegex = regexp.MustCompile(`^(\w+)\s*(\{|\(([^)]*)\))?`)
+	rustAttrRegex    = regexp.MustCompile(`(?s)^#\[(\w+)\((.*)\)\]$`)
+
+	pyScriptEntryRegex = regexp.MustCompile(`["']?([\w.-]+)["']?\s*=\s*["']([\w.]+):(\w+)["']`)
+)
+
+// cliTree collects commands and parent/child links before the tree is built
+type cliTree struct {
+	nodes   map[string]*types.CLICommand
+	order   []string
+	aliases map[string]string
+	links   [][2]string
+}
+
+func newCLITree() *cliTree {
+	return &cliTree{
+		nodes:   make(map[string]*types.CLICommand),
+		aliases: make(map[string]string),
+	}
+}
+
+// add registers a command under key, keeping the first definition
+func (t *cliTree) add(key string, cmd types.CLICommand) {
+	if _, exists := t.nodes[key]; exists {
+		return
+	}
+	t.nodes[key] = &cmd
+	t.order = append(t.order, key)
+}
+
+// alias makes name refer to the command registered under key
+func (t *cliTree) alias(name, key string) {
+	if name != key {
+		t.aliases[name] = key
+	}
+}
+
+// has reports whether key names a command or an alias
+func (t *cliTree) has(key string) bool {
+	_, node := t.nodes[key]
+	_, alias := t.aliases[key]
+	return node || alias
+}
+
+// canonical follows aliases to the key a command is registered under
+func (t *cliTree) canonical(key string) string {
+	for i := 0; i < 8; i++ {
+		next, ok := t.aliases[key]
+		if !ok {
+			break
+		}
+		key = next
+	}
+	return key
+}
+
+// get returns the command key refers to, or nil
+func (t *cliTree) get(key string) *types.CLICommand {
+	return t.nodes[t.canonical(key)]
+}
+
+// link records child as a subcommand of parent
+func (t *cliTree) link(parent, child string) {
+	t.links = append(t.links, [2]string{parent, child})
+}
+
+// roots builds the command trees. Commands that are never linked as children
+// are roots; when some roots have subcommands, orphans without any (usually
+// commands whose registration could not be resolved) are dropped.
+func (t *cliTree) roots() []types.CLICommand {
+	children := make(map[string][]string)
+	isChild := make(map[string]bool)
+	seen := make(map[[2]string]bool)
+	for _, l := range t.links {
+		parent, child := t.canonical(l[0]), t.canonical(l[1])
+		if t.nodes[parent] == nil || t.nodes[child] == nil || parent == child || seen[[2]string{parent, child}] {
+			continue
+		}
+		seen[[2]string{parent, child}] = true
+		children[parent] = append(children[parent], child)
+		isChild[child] = true
+	}
+
+	var roots []types.CLICommand
+	hasTree := false
+	for _, key := range t.order {
+		if isChild[key] {
+			continue
+		}
+		root := t.build(key, children, map[string]bool{})
+		if len(root.Subcommands) > 0 {
+			hasTree = true
+		}
+		roots = append(roots, root)
+	}
+
+	if !hasTree {
+		return roots
+	}
+	var trees []types.CLICommand
+	for _, root := range roots {
+		if len(root.Subcommands) > 0 {
+			trees = append(trees, root)
+		}
+	}
+	return trees
+}
+
+func (t *cliTree) build(key string, children map[string][]string, visiting map[string]bool) types.CLICommand {
+	cmd := *t.nodes[key]
+	visiting[key] = true
+	for _, child := range children[key] {
+		if !visiting[child] {
+			cmd.Subcommands = append(cmd.Subcommands, t.build(child, children, visiting))
+		}
+	}
+	delete(visiting, key)
+	return cmd
+}
+
+// cliSource is a source file considered by a command tree parser
+type cliSource struct {
+	path    string
+	content string
+}
+
+// detectCommandTree extracts the command tree of the first framework that
+// yields commands
+func (d *CLIDetector) detectCommandTree() (string, []types.CLICommand) {
+	parsers := []struct {
+		framework string
+		parse     func() []types.CLICommand
+	}{
+		{"Cobra", d.cobraCommands},
+		{"urfave/cli", d.urfaveCommands},
+		{"Clap", d.clapCommands},
+		{"Typer", func() []types.CLICommand { return d.pythonDecoratorCommands(true) }},
+		{"Click", func() []types.CLICommand { return d.pythonDecoratorCommands(false) }},
+		{"argparse", d.argparseCommands},
+	}
+
+	for _, p := range parsers {
+		if commands := p.parse(); len(commands) > 0 {
+			return p.framework, commands
+		}
+	}
+	return "", nil
+}
+
+// sourcesContaining returns the non-test files with the given extension whose
+// content contains marker
+func (d *CLIDetector) sourcesContaining(ext, marker string) []cliSource {
+	var sources []cliSource
+	for _, f := range d.files {
+		if f.IsDir || f.Extension != ext || f.Size > 500000 || isTestSourceFile(f.Path) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil || !strings.Contains(string(content), marker) {
+			continue
+		}
+		sources = append(sources, cliSource{path: f.Path, content: string(content)})
+	}
+	return sources
+}
+
+// isTestSourceFile reports whether path is a Go, Python or JS/TS test file
+func isTestSourceFile(path string) bool {
+	base := filepath.Base(path)
+	slashed := filepath.ToSlash(path)
+	return strings.HasSuffix(base, "_test.go") || strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py") ||
+		strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.HasPrefix(slashed, "tests/") || strings.Contains(slashed, "/tests/") || strings.Contains(slashed, "__tests__/")
+}
+
+// defaultCommandName guesses the binary name for a root command defined in path
+func (d *CLIDetector) defaultCommandName(path string) string {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	if len(parts) >= 3 && parts[0] == "cmd" {
+		return parts[1]
+	}
+	if filepath.Base(path) == "__main__.py" && len(parts) >= 2 {
+		return parts[len(parts)-2]
+	}
+	if abs, err := filepath.Abs(d.rootPath); err == nil {
+		return filepath.Base(abs)
+	}
+	return filepath.Base(d.rootPath)
+}
+
+// literalEnd returns the index just past the delimiter that closes a call or
+// literal whose opening delimiter has already been consumed, or -1
+func literalEnd(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return i + 1
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// quotedValue returns the unquoted string literal v, or "" if v is not one
+func quotedValue(v string) string {
+	v = strings.TrimSpace(v)
+	if !isQuoted(v) {
+		return ""
+	}
+	return strings.TrimSpace(unquote(v))
+}
+
+// quotedList returns the string literals of a list literal such as
+// []string{"a", "b"}, ["a", "b"] or ("a", "b")
+func quotedList(v string) []string {
+	open := strings.IndexAny(v, "{[(")
+	if strings.HasPrefix(v, "[]") {
+		open = strings.Index(v, "{")
+	}
+	if open < 0 {
+		if s := quotedValue(v); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	elems, _ := splitCallArgs(v[open+1:])
+	var values []string
+	for _, e := range elems {
+		if s := quotedValue(e); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// flagDefault normalizes a default value, dropping zero values that add no
+// information to a flag reference. Identifiers and selectors such as
+// generator.DefaultDiagramNodes are resolved through the project's
+// package-level constants, or dropped when they name anything else.
+func (d *CLIDetector) flagDefault(v string) string {
+	v = strings.TrimSpace(v)
+	if isQuoted(v) {
+		return unquote(v)
+	}
+	switch v {
+	case "nil", "None", "false", "False", "[]", "{}", "...", "Ellipsis":
+		return ""
+	}
+	if strings.HasSuffix(v, "{}") || strings.HasSuffix(v, "[]") || strings.HasSuffix(v, "()") && !strings.Contains(v, "(\"") {
+		return ""
+	}
+	if cliIdentifierRegex.MatchString(v) && !cliLiteralRegex.MatchString(v) {
+		return unquote(d.constants()[v])
+	}
+	return v
+}
+
+var (
+	// cliIdentifierRegex matches names and selectors: Port, config.DefaultPort, limits::MAX
+	cliIdentifierRegex = regexp.MustCompile(`^[A-Za-z_]\w*(?:(?:\.|::)[A-Za-z_]\w*)*$`)
+	// cliLiteralRegex matches literal values a constant can be resolved to
+	cliLiteralRegex = regexp.MustCompile(`^(?:"[^"]*"|'[^']*'|-?\d[\d_]*(?:\.\d+)?(?:[eE][-+]?\d+)?|0[xX][\da-fA-F_]+|true|True)$`)
+
+	goPackageRegex    = regexp.MustCompile(`(?m)^package\s+(\w+)`)
+	goConstLineRegex  = regexp.MustCompile(`(?m)^const\s+(\w+)\s*(?:[\w.]+\s*)?=\s*(.+?)\s*(?://.*)?$`)
+	goConstBlockRegex = regexp.MustCompile(`(?ms)^const\s*\((.*?)^\)`)
+	goConstSpecRegex  = regexp.MustCompile(`(?m)^\s*(\w+)\s*(?:[\w.]+\s*)?=\s*(.+?)\s*(?://.*)?$`)
+	pyConstRegex      = regexp.MustCompile(`(?m)^([A-Z][A-Z0-9_]*)\s*(?::\s*\w+\s*)?=\s*(.+?)\s*(?:#.*)?$`)
+	rustConstRegex    = regexp.MustCompile(`(?m)^\s*(?:pub(?:\([^)]*\))?\s+)?const\s+([A-Z][A-Z0-9_]*)\s*:\s*[^=]+=\s*(.+?);`)
+)
+
+// constants returns the literal package-level constants of the project's Go,
+// Python and Rust sources, keyed by name and by package or module qualified
+// name. Names defined with different values in several places are left out.
+func (d *CLIDetector) constants() map[string]string {
+	if d.consts != nil {
+		return d.consts
+	}
+	d.consts = make(map[string]string)
+	ambiguous := make(map[string]bool)
+	add := func(key, value string) {
+		if ambiguous[key] || !cliLiteralRegex.MatchString(value) {
+			return
+		}
+		if existing, ok := d.consts[key]; ok && existing != value {
+			delete(d.consts, key)
+			ambiguous[key] = true
+			return
+		}
+		d.consts[key] = value
+	}
+
+	for _, f := range d.files {
+		if f.IsDir || f.Size > 500000 || isTestSourceFile(f.Path) {
+			continue
+		}
+		if f.Extension != ".go" && f.Extension != ".py" && f.Extension != ".rs" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		module := strings.TrimSuffix(filepath.Base(f.Path), f.Extension)
+
+		switch f.Extension {
+		case ".go":
+			if m := goPackageRegex.FindStringSubmatch(content); m != nil {
+				module = m[1]
+			}
+			var specs [][]string
+			specs = append(specs, goConstLineRegex.FindAllStringSubmatch(content, -1)...)
+			for _, block := range goConstBlockRegex.FindAllStringSubmatch(content, -1) {
+				specs = append(specs, goConstSpecRegex.FindAllStringSubmatch(block[1], -1)...)
+			}
+			for _, m := range specs {
+				add(m[1], m[2])
+				add(module+"."+m[1], m[2])
+			}
+		case ".py":
+			for _, m := range pyConstRegex.FindAllStringSubmatch(content, -1) {
+				add(m[1], m[2])
+				add(module+"."+m[1], m[2])
+			}
+		case ".rs":
+			for _, m := range rustConstRegex.FindAllStringSubmatch(content, -1) {
+				add(m[1], m[2])
+				add(module+"::"+m[1], m[2])
+			}
+		}
+	}
+	return d.consts
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// kebabCase converts CamelCase and snake_case identifiers to kebab-case
+func kebabCase(s string) string {
+	var b strings.Builder
+	r := []rune(s)
+	for i, c := range r {
+		switch {
+		case c == '_':
+			b.WriteByte('-')
+		case unicode.IsUpper(c):
+			if i > 0 && r[i-1] != '_' && (unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]))) {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(c))
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// ---------------------------------------------------------------------------
+// Cobra
+
+// goFunc is the extent of a top-level Go function
+type goFunc struct {
+	name           string
+	start, end     int
+	returnsCommand bool
+}
+
+func goFuncs(content string) []goFunc {
+	var funcs []goFunc
+	for _, m := range goFuncDeclRegex.FindAllStringSubmatchIndex(content, -1) {
+		end := strings.Index(content[m[1]:], "\n}")
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += m[1]
+		}
+		funcs = append(funcs, goFunc{
+			name:           content[m[2]:m[3]],
+			start:          m[0],
+			end:            end,
+			returnsCommand: strings.Contains(content[m[4]:m[5]], "*cobra.Command"),
+		})
+	}
+	return funcs
+}
+
+func enclosingGoFunc(funcs []goFunc, pos int) *goFunc {
+	for i := range funcs {
+		if pos >= funcs[i].start && pos < funcs[i].end {
+			return &funcs[i]
+		}
+	}
+	return nil
+}
+
+// goScopedKey qualifies variables declared inside a function so that the
+// common `cmd := &cobra.Command{...}` in many constructors stays distinct
+func goScopedKey(path string, fn *goFunc, name string) string {
+	if fn == nil {
+		return name
+	}
+	return path + ":" + fn.name + "." + name
+}
+
+// resolveGoRef resolves an identifier or call expression used at fn to a key
+func resolveGoRef(t *cliTree, path string, fn *goFunc, expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.Contains(expr, "(") {
+		name := calleeName(expr)
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			name = name[idx+1:]
+		}
+		return name + "()"
+	}
+	if scoped := goScopedKey(path, fn, expr); fn != nil && t.has(scoped) {
+		return scoped
+	}
+	if idx := strings.LastIndex(expr, "."); idx >= 0 {
+		expr = expr[idx+1:]
+	}
+	return expr
+}
+
+// cobraCommands extracts the command tree from &cobra.Command{} literals,
+// pflag definitions and AddCommand calls
+func (d *CLIDetector) cobraCommands() []types.CLICommand {
+	sources := d.sourcesContaining(".go", "cobra.Command")
+	if len(sources) == 0 {
+		return nil
+	}
+	tree := newCLITree()
+	funcs := make([][]goFunc, len(sources))
+
+	// Pass 1: command literals and local variables holding constructed commands
+	for i, src := range sources {
+		funcs[i] = goFuncs(src.content)
+		for _, loc := range cobraLiteralRegex.FindAllStringIndex(src.content, -1) {
+			fields, _ := splitCallArgs(src.content[loc[1]:])
+			use, _ := keyedArgValue(fields, "Use")
+			use = quotedValue(use)
+			if use == "" {
+				continue
+			}
+			cmd := types.CLICommand{File: src.path}
+			parts := strings.Fields(use)
+			cmd.Name = parts[0]
+			cmd.Args = strings.Join(parts[1:], " ")
+			if short, ok := keyedArgValue(fields, "Short"); ok {
+				cmd.Description = quotedValue(short)
+			}
+			if aliases, ok := keyedArgValue(fields, "Aliases"); ok {
+				cmd.Aliases = quotedList(aliases)
+			}
+
+			fn := enclosingGoFunc(funcs[i], loc[0])
+			lineStart := strings.LastIndex(src.content[:loc[0]], "\n") + 1
+			key := fmt.Sprintf("%s:%d", src.path, loc[0])
+			if m := goAssignTargetRegex.FindStringSubmatch(src.content[lineStart:loc[0]]); m != nil {
+				key = goScopedKey(src.path, fn, m[1])
+			}
+			tree.add(key, cmd)
+			if fn != nil && fn.returnsCommand {
+				tree.alias(fn.name+"()", key)
+			}
+		}
+	}
+	for i, src := range sources {
+		for _, m := range goLocalCallRegex.FindAllStringSubmatchIndex(src.content, -1) {
+			fn := enclosingGoFunc(funcs[i], m[0])
+			callee := src.content[m[4]:m[5]]
+			if idx := strings.LastIndex(callee, "."); idx >= 0 {
+				callee = callee[idx+1:]
+			}
+			if fn != nil && tree.has(callee+"()") {
+				tree.alias(goScopedKey(src.path, fn, src.content[m[2]:m[3]]), callee+"()")
+			}
+		}
+	}
+
+	// Pass 2: flags and subcommand registration
+	for i, src := range sources {
+		content := src.content
+		addFlag := func(fn *goFunc, receiver, method string, persistent bool, argStart int) {
+			args, _ := splitCallArgs(content[argStart:])
+			flag, ok := d.pflagFlag(method, args)
+			if !ok {
+				return
+			}
+			flag.Persistent = persistent
+			if cmd := tree.get(resolveGoRef(tree, src.path, fn, receiver)); cmd != nil {
+				cmd.Flags = append(cmd.Flags, flag)
+			}
+		}
+
+		for _, m := range pflagCallRegex.FindAllStringSubmatchIndex(content, -1) {
+			fn := enclosingGoFunc(funcs[i], m[0])
+			addFlag(fn, content[m[2]:m[3]], content[m[6]:m[7]], content[m[4]:m[5]] == "PersistentFlags", m[1])
+		}
+
+		// flags := cmd.Flags(); flags.StringP(...)
+		type flagSet struct {
+			receiver   string
+			persistent bool
+		}
+		flagSets := make(map[string]flagSet)
+		for _, m := range pflagAliasRegex.FindAllStringSubmatchIndex(content, -1) {
+			fn := enclosingGoFunc(funcs[i], m[0])
+			flagSets[goScopedKey(src.path, fn, content[m[2]:m[3]])] = flagSet{content[m[4]:m[5]], content[m[6]:m[7]] == "PersistentFlags"}
+		}
+		if len(flagSets) > 0 {
+			for _, m := range goMethodCallRegex.FindAllStringSubmatchIndex(content, -1) {
+				fn := enclosingGoFunc(funcs[i], m[0])
+				if set, ok := flagSets[goScopedKey(src.path, fn, content[m[2]:m[3]])]; ok {
+					addFlag(fn, set.receiver, content[m[4]:m[5]], set.persistent, m[1])
+				}
+			}
+		}
+
+		for _, m := range cobraAddRegex.FindAllStringSubmatchIndex(content, -1) {
+			fn := enclosingGoFunc(funcs[i], m[0])
+			parent := resolveGoRef(tree, src.path, fn, content[m[2]:m[3]])
+			args, _ := splitCallArgs(content[m[1]:])
+			for _, arg := range args {
+				tree.link(parent, resolveGoRef(tree, src.path, fn, arg))
+			}
+		}
+	}
+
+	return tree.roots()
+}
+
+// pflagFlag builds a flag from a pflag/flag definition such as
+// StringVarP(&cfg, "config", "c", "", "config file")
+func (d *CLIDetector) pflagFlag(method string, args []string) (types.CLIFlag, bool) {
+	m := pflagMethodRegex.FindStringSubmatch(method)
+	if m == nil || (m[1] == "" && m[2] == "") {
+		return types.CLIFlag{}, false
+	}
+	if m[2] != "" {
+		if len(args) == 0 {
+			return types.CLIFlag{}, false
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 || quotedValue(args[0]) == "" {
+		return types.CLIFlag{}, false
+	}
+
+	flag := types.CLIFlag{Name: quotedValue(args[0]), Type: lowerFirst(m[1])}
+	if flag.Type == "" {
+		flag.Type = "value"
+	}
+	rest := args[1:]
+	if m[3] != "" && len(rest) > 0 {
+		flag.Shorthand = quotedValue(rest[0])
+		rest = rest[1:]
+	}
+	// Count and Var flags carry no default value
+	if m[1] != "" && m[1] != "Count" && len(rest) > 1 {
+		flag.Default = d.flagDefault(rest[0])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		flag.Usage = quotedValue(rest[len(rest)-1])
+	}
+	return flag, true
+}
+
+// ---------------------------------------------------------------------------
+// urfave/cli
+
+// urfaveCommands extracts the command tree from cli.App and cli.Command literals
+func (d *CLIDetector) urfaveCommands() []types.CLICommand {
+	sources := d.sourcesContaining(".go", "urfave/cli")
+	if len(sources) == 0 {
+		return nil
+	}
+	tree := newCLITree()
+
+	for _, src := range sources {
+		funcs := goFuncs(src.content)
+		consumed := 0
+		for _, loc := range urfaveLiteralRegex.FindAllStringSubmatchIndex(src.content, -1) {
+			if loc[0] < consumed {
+				continue
+			}
+			body := src.content[loc[1]:]
+			if end := literalEnd(body); end >= 0 {
+				consumed = loc[1] + end
+			}
+
+			fn := enclosingGoFunc(funcs, loc[0])
+			lineStart := strings.LastIndex(src.content[:loc[0]], "\n") + 1
+			key := fmt.Sprintf("%s:%d", src.path, loc[0])
+			if m := goAssignTargetRegex.FindStringSubmatch(src.content[lineStart:loc[0]]); m != nil {
+				key = m[1]
+			}
+			fields, _ := splitCallArgs(body)
+			d.urfaveCommand(tree, src.path, key, fields, src.content[loc[2]:loc[3]] == "App")
+			if fn != nil && strings.Contains(src.content[lineStart:loc[0]], "return") {
+				tree.alias(fn.name+"()", key)
+			}
+		}
+	}
+
+	return tree.roots()
+}
+
+// urfaveCommand registers the command described by the fields of a cli.App
+// or cli.Command literal and recurses into its subcommands
+func (d *CLIDetector) urfaveCommand(tree *cliTree, path, key string, fields []string, isApp bool) {
+	cmd := types.CLICommand{File: path}
+	if v, ok := keyedArgValue(fields, "Name"); ok {
+		cmd.Name = quotedValue(v)
+	}
+	if cmd.Name == "" {
+		if !isApp {
+			return
+		}
+		cmd.Name = d.defaultCommandName(path)
+	}
+	if v, ok := keyedArgValue(fields, "Usage"); ok {
+		cmd.Description = quotedValue(v)
+	}
+	if v, ok := keyedArgValue(fields, "ArgsUsage"); ok {
+		cmd.Args = quotedValue(v)
+	}
+	if v, ok := keyedArgValue(fields, "Aliases"); ok {
+		cmd.Aliases = quotedList(v)
+	}
+	if v, ok := keyedArgValue(fields, "Flags"); ok {
+		if open := strings.Index(v, "{"); open >= 0 {
+			elems, _ := splitCallArgs(v[open+1:])
+			for _, elem := range elems {
+				if flag, ok := d.urfaveFlag(elem); ok {
+					cmd.Flags = append(cmd.Flags, flag)
+				}
+			}
+		}
+	}
+	tree.add(key, cmd)
+
+	for _, listKey := range []string{"Commands", "Subcommands"} {
+		v, ok := keyedArgValue(fields, listKey)
+		if !ok {
+			continue
+		}
+		open := strings.Index(v, "{")
+		if open < 0 {
+			continue
+		}
+		elems, _ := splitCallArgs(v[open+1:])
+		for i, elem := range elems {
+			brace := strings.Index(elem, "{")
+			if brace < 0 {
+				tree.link(key, resolveGoRef(tree, path, nil, elem))
+				continue
+			}
+			childKey := fmt.Sprintf("%s/%d", key, i)
+			childFields, _ := splitCallArgs(elem[brace+1:])
+			d.urfaveCommand(tree, path, childKey, childFields, false)
+			tree.link(key, childKey)
+		}
+	}
+}
+
+// urfaveFlag builds a flag from a literal such as
+// &cli.StringFlag{Name: "config", Aliases: []string{"c"}, Usage: "..."}
+func (d *CLIDetector) urfaveFlag(elem string) (types.CLIFlag, bool) {
+	m := urfaveFlagRegex.FindStringSubmatchIndex(elem)
+	if m == nil {
+		return types.CLIFlag{}, false
+	}
+	fields, _ := splitCallArgs(elem[m[1]:])
+	name, _ := keyedArgValue(fields, "Name")
+	flag := types.CLIFlag{Name: quotedValue(name), Type: lowerFirst(elem[m[2]:m[3]])}
+	if flag.Name == "" {
+		return types.CLIFlag{}, false
+	}
+	// urfave/cli v1 declares aliases inline: Name: "config, c"
+	if names := strings.Split(flag.Name, ","); len(names) > 1 {
+		flag.Name = strings.TrimSpace(names[0])
+		flag.Shorthand = strings.TrimSpace(names[1])
+	}
+	if v, ok := keyedArgValue(fields, "Aliases"); ok {
+		for _, alias := range quotedList(v) {
+			if len(alias) == 1 {
+				flag.Shorthand = alias
+				break
+			}
+		}
+	}
+	if v, ok := keyedArgValue(fields, "Value"); ok {
+		flag.Default = d.flagDefault(v)
+	}
+	if v, ok := keyedArgValue(fields, "Usage"); ok {
+		flag.Usage = quotedValue(v)
+	}
+	return flag, true
+}
+
+// ---------------------------------------------------------------------------
+// Click and Typer
+
+// pyModuleKey qualifies a name with the module that defines it
+func pyModuleKey(path, name string) string {
+	stem := strings.TrimSuffix(filepath.Base(path), ".py")
+	if stem == "__init__" || stem == "__main__" {
+		stem = filepath.Base(filepath.Dir(path))
+	}
+	return stem + ":" + name
+}
+
+// pyImports maps names imported with `from module import name as alias` to
+// their module-qualified keys
+func pyImports(lines []string) map[string]string {
+	imports := make(map[string]string)
+	for _, line := range lines {
+		m := pyFromImportRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		module := m[1]
+		if idx := strings.LastIndex(module, "."); idx >= 0 {
+			module = module[idx+1:]
+		}
+		if module == "" {
+			continue
+		}
+		for _, name := range strings.Split(strings.Trim(m[2], "() "), ",") {
+			parts := strings.Fields(name)
+			switch {
+			case len(parts) == 1:
+				imports[parts[0]] = module + ":" + parts[0]
+			case len(parts) == 3 && parts[1] == "as":
+				imports[parts[2]] = module + ":" + parts[0]
+			}
+		}
+	}
+	return imports
+}
+
+// resolvePyRef resolves a name or module.name reference used in path
+func resolvePyRef(path string, imports map[string]string, ref string) string {
+	if idx := strings.LastIndex(ref, "."); idx >= 0 {
+		module := ref[:idx]
+		if j := strings.LastIndex(module, "."); j >= 0 {
+			module = module[j+1:]
+		}
+		return module + ":" + ref[idx+1:]
+	}
+	if key, ok := imports[ref]; ok {
+		return key
+	}
+	return pyModuleKey(path, ref)
+}
+
+// pyDecorator is a click/typer decorator awaiting the function it decorates
+type pyDecorator struct {
+	target string
+	kind   string
+	args   []string
+}
+
+// pythonDecoratorCommands extracts Typer (typer=true) or Click command trees
+// from decorated functions
+func (d *CLIDetector) pythonDecoratorCommands(typer bool) []types.CLICommand {
+	marker := "click"
+	if typer {
+		marker = "typer"
+	}
+	sources := d.sourcesContaining(".py", marker)
+	if len(sources) == 0 {
+		return nil
+	}
+	tree := newCLITree()
+
+	// Typer applications are declared as variables
+	typerApps := make(map[string]bool)
+	if typer {
+		for _, src := range sources {
+			for _, line := range strings.Split(src.content, "\n") {
+				if m := typerAppRegex.FindStringSubmatch(line); m != nil {
+					key := pyModuleKey(src.path, m[1])
+					typerApps[key] = true
+					args, _ := splitCallArgs(line[strings.Index(line, "typer.Typer(")+len("typer.Typer("):])
+					cmd := types.CLICommand{Name: kebabCase(m[1]), File: src.path}
+					if v, ok := keyedArgValue(args, "help"); ok {
+						cmd.Description = firstLine(quotedValue(v))
+					}
+					if v, ok := keyedArgValue(args, "name"); ok && quotedValue(v) != "" {
+						cmd.Name = quotedValue(v)
+					}
+					tree.add(key, cmd)
+				}
+			}
+		}
+		if len(typerApps) == 0 {
+			return nil
+		}
+	}
+
+	for _, src := range sources {
+		lines := strings.Split(src.content, "\n")
+		imports := pyImports(lines)
+		var pending []pyDecorator
+
+		for i := 0; i < len(lines); i++ {
+			line := lines[i]
+			if m := pyDecoratorRegex.FindStringSubmatchIndex(line); m != nil {
+				dec := pyDecorator{target: line[m[2]:m[3]], kind: line[m[4]:m[5]]}
+				if m[6] >= 0 {
+					dec.args, i = callArgsFrom(lines, i, m[7])
+				}
+				pending = append(pending, dec)
+				continue
+			}
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "@") || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if m := pyDefRegex.FindStringSubmatchIndex(line); m != nil && len(pending) > 0 {
+				d.pythonDecoratedCommand(tree, typerApps, src.path, imports, lines, i, line[m[2]:m[3]], m[1], pending, typer)
+			}
+			pending = nil
+		}
+
+		for _, m := range pyAddCommandRegex.FindAllStringSubmatchIndex(src.content, -1) {
+			args, _ := splitCallArgs(src.content[m[1]:])
+			if len(args) == 0 || isKeyedArg(args[0]) {
+				continue
+			}
+			parent := resolvePyRef(src.path, imports, src.content[m[2]:m[3]])
+			child := resolvePyRef(src.path, imports, strings.TrimSpace(args[0]))
+			if v, ok := keyedArgValue(args, "name"); ok && quotedValue(v) != "" {
+				if cmd := tree.get(child); cmd != nil {
+					cmd.Name = quotedValue(v)
+				}
+			}
+			tree.link(parent, child)
+		}
+	}
+
+	d.applyPythonScriptNames(tree)
+	return tree.roots()
+}
+
+// pythonDecoratedCommand registers the command declared by the decorators
+// preceding the function defined on lines[defLine]
+func (d *CLIDetector) pythonDecoratedCommand(tree *cliTree, typerApps map[string]bool, path string, imports map[string]string, lines []string, defLine int, funcName string, sigStart int, decorators []pyDecorator, typer bool) {
+	var command *pyDecorator
+	for i := range decorators {
+		switch decorators[i].kind {
+		case "command", "group":
+			command = &decorators[i]
+		case "callback":
+			if typer {
+				command = &decorators[i]
+			}
+		}
+	}
+	if command == nil {
+		return
+	}
+
+	parentKey := ""
+	if command.target != "click" {
+		parentKey = resolvePyRef(path, imports, command.target)
+	}
+	if typer != typerApps[parentKey] {
+		return
+	}
+
+	cmd := types.CLICommand{Name: kebabCase(funcName), File: path}
+	if len(command.args) > 0 && quotedValue(command.args[0]) != "" && !isKeyedArg(command.args[0]) {
+		cmd.Name = quotedValue(command.args[0])
+	}
+	if v, ok := keyedArgValue(command.args, "name"); ok && quotedValue(v) != "" {
+		cmd.Name = quotedValue(v)
+	}
+	if v, ok := keyedArgValue(command.args, "help"); ok {
+		cmd.Description = firstLine(quotedValue(v))
+	}
+	if cmd.Description == "" {
+		cmd.Description = pyDocstring(lines, defLine)
+	}
+
+	var args []string
+	if typer {
+		sig := strings.Join(lines[defLine:min(defLine+40, len(lines))], "\n")
+		params, _ := splitCallArgs(sig[sigStart:])
+		for _, param := range params {
+			flag, arg := d.typerParam(param)
+			if flag != nil {
+				cmd.Flags = append(cmd.Flags, *flag)
+			} else if arg != "" {
+				args = append(args, arg)
+			}
+		}
+	} else {
+		for _, dec := range decorators {
+			switch dec.kind {
+			case "option":
+				if flag, ok := d.clickOption(dec.args); ok {
+					cmd.Flags = append(cmd.Flags, flag)
+				}
+			case "argument":
+				if arg := clickArgument(dec.args); arg != "" {
+					args = append(args, arg)
+				}
+			}
+		}
+	}
+	cmd.Args = strings.Join(args, " ")
+
+	// A Typer callback configures the application itself
+	if command.kind == "callback" {
+		if app := tree.get(parentKey); app != nil {
+			if app.Description == "" {
+				app.Description = cmd.Description
+			}
+			for i := range cmd.Flags {
+				cmd.Flags[i].Persistent = true
+			}
+			app.Flags = append(app.Flags, cmd.Flags...)
+		}
+		return
+	}
+
+	key := pyModuleKey(path, funcName)
+	tree.add(key, cmd)
+	if parentKey != "" {
+		tree.link(parentKey, key)
+	}
+}
+
+// clickOption builds a flag from @click.option arguments
+func (d *CLIDetector) clickOption(args []string) (types.CLIFlag, bool) {
+	flag := types.CLIFlag{Type: "string"}
+	for _, arg := range args {
+		name := quotedValue(arg)
+		if !strings.HasPrefix(name, "-") || isKeyedArg(arg) {
+			continue
+		}
+		// --flag/--no-flag declares a boolean switch
+		if idx := strings.Index(name, "/"); idx >= 0 {
+			name = strings.TrimSpace(name[:idx])
+			flag.Type = "bool"
+		}
+		if strings.HasPrefix(name, "--") {
+			if flag.Name == "" {
+				flag.Name = strings.TrimPrefix(name, "--")
+			}
+		} else if flag.Shorthand == "" {
+			flag.Shorthand = strings.TrimPrefix(name, "-")
+		}
+	}
+	if flag.Name == "" {
+		if flag.Shorthand == "" {
+			return flag, false
+		}
+		flag.Name, flag.Shorthand = flag.Shorthand, ""
+	}
+	if v, ok := keyedArgValue(args, "is_flag"); ok && v == "True" {
+		flag.Type = "bool"
+	}
+	if v, ok := keyedArgValue(args, "count"); ok && v == "True" {
+		flag.Type = "count"
+	}
+	if v, ok := keyedArgValue(args, "type"); ok {
+		flag.Type = pythonFlagType(v)
+	}
+	if v, ok := keyedArgValue(args, "default"); ok {
+		flag.Default = d.flagDefault(v)
+	}
+	if v, ok := keyedArgValue(args, "help"); ok {
+		flag.Usage = quotedValue(v)
+	}
+	return flag, true
+}
+
+// clickArgument renders an @click.argument declaration as usage text
+func clickArgument(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	name := quotedValue(args[0])
+	if name == "" {
+		return ""
+	}
+	name = strings.ReplaceAll(name, "_", "-")
+	optional := false
+	if v, ok := keyedArgValue(args, "required"); ok && v == "False" {
+		optional = true
+	}
+	if _, ok := keyedArgValue(args, "default"); ok {
+		optional = true
+	}
+	if v, ok := keyedArgValue(args, "nargs"); ok && v == "-1" {
+		name += "..."
+	}
+	if optional {
+		return "[" + name + "]"
+	}
+	return "<" + name + ">"
+}
+
+// typerParam classifies a Typer function parameter as an option or an argument
+func (d *CLIDetector) typerParam(param string) (*types.CLIFlag, string) {
+	param = strings.TrimSpace(param)
+	if param == "" || strings.HasPrefix(param, "*") || strings.HasPrefix(param, "self") {
+		return nil, ""
+	}
+	nameAndType, defaultValue, hasDefault := splitTopLevel(param, '=')
+	name, annotation, _ := splitTopLevel(nameAndType, ':')
+	name = strings.TrimSpace(name)
+	annotation = strings.TrimSpace(annotation)
+	if strings.Contains(annotation, "Context") {
+		return nil, ""
+	}
+
+	// typer.Option(...)/typer.Argument(...) as default or inside Annotated[...]
+	kind := ""
+	var callArgs []string
+	for _, source := range []string{annotation, defaultValue} {
+		for _, k := range []string{"Option", "Argument"} {
+			if idx := strings.Index(source, "typer."+k+"("); idx >= 0 {
+				kind = k
+				callArgs, _ = splitCallArgs(source[idx+len("typer."+k+"("):])
+			}
+		}
+	}
+
+	typ := pythonAnnotationType(annotation)
+	if kind == "" {
+		if hasDefault {
+			kind = "Option"
+		} else {
+			kind = "Argument"
+		}
+	} else if strings.Contains(defaultValue, "typer.") {
+		// Legacy style: the first positional argument is the default
+		defaultValue, hasDefault = "", false
+		if len(callArgs) > 0 && !isKeyedArg(callArgs[0]) && !strings.HasPrefix(quotedValue(callArgs[0]), "-") {
+			defaultValue = callArgs[0]
+			hasDefault = callArgs[0] != "..."
+		}
+	}
+	if v, ok := keyedArgValue(callArgs, "default"); ok {
+		defaultValue, hasDefault = v, true
+	}
+
+	if kind == "Argument" {
+		arg := strings.ReplaceAll(name, "_", "-")
+		if strings.HasSuffix(typ, "[]") {
+			arg += "..."
+		}
+		if hasDefault {
+			return nil, "[" + arg + "]"
+		}
+		return nil, "<" + arg + ">"
+	}
+
+	flag := &types.CLIFlag{Name: kebabCase(name), Type: typ, Default: d.flagDefault(defaultValue)}
+	for _, arg := range callArgs {
+		opt := quotedValue(arg)
+		if !strings.HasPrefix(opt, "-") || isKeyedArg(arg) {
+			continue
+		}
+		if idx := strings.Index(opt, "/"); idx >= 0 {
+			opt = opt[:idx]
+		}
+		if strings.HasPrefix(opt, "--") {
+			flag.Name = strings.TrimPrefix(opt, "--")
+		} else {
+			flag.Shorthand = strings.TrimPrefix(opt, "-")
+		}
+	}
+	if v, ok := keyedArgValue(callArgs, "help"); ok {
+		flag.Usage = quotedValue(v)
+	}
+	return flag, ""
+}
+
+// splitTopLevel splits s at the first sep outside brackets and quotes
+func splitTopLevel(s string, sep byte) (string, string, bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case sep:
+			if depth == 0 {
+				return s[:i], strings.TrimSpace(s[i+1:]), true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// pythonAnnotationType maps a parameter annotation to a flag type
+func pythonAnnotationType(annotation string) string {
+	annotation = strings.TrimSpace(annotation)
+	if strings.HasPrefix(annotation, "Annotated[") {
+		inner, _ := splitCallArgs(annotation[len("Annotated["):])
+		if len(inner) > 0 {
+			annotation = inner[0]
+		}
+	}
+	annotation = strings.TrimPrefix(annotation, "typing.")
+	if strings.HasPrefix(annotation, "Optional[") {
+		annotation = strings.TrimSuffix(strings.TrimPrefix(annotation, "Optional["), "]")
+	}
+	if idx := strings.Index(annotation, "|"); idx >= 0 {
+		annotation = strings.TrimSpace(annotation[:idx])
+	}
+	for _, list := range []string{"List[", "list["} {
+		if strings.HasPrefix(annotation, list) {
+			return pythonFlagType(strings.TrimSuffix(strings.TrimPrefix(annotation, list), "]")) + "[]"
+		}
+	}
+	if annotation == "" {
+		return "string"
+	}
+	return pythonFlagType(annotation)
+}
+
+// pythonFlagType maps a Python type expression to a flag type
+func pythonFlagType(t string) string {
+	t = strings.TrimSpace(t)
+	name := calleeName(t)
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	switch name {
+	case "str", "":
+		return "string"
+	case "int", "IntRange":
+		return "int"
+	case "float", "FloatRange":
+		return "float"
+	case "bool":
+		return "bool"
+	case "Path", "File":
+		return "path"
+	case "Choice":
+		return "choice"
+	}
+	return lowerFirst(name)
+}
+
+// pyDocstring returns the first line of the docstring of the function
+// defined on lines[defLine]
+func pyDocstring(lines []string, defLine int) string {
+	for i := defLine; i < len(lines) && i < defLine+40; i++ {
+		if !strings.HasSuffix(strings.TrimSpace(stripPyComment(lines[i])), ":") {
+			continue
+		}
+		for j := i + 1; j < len(lines); j++ {
+			t := strings.TrimSpace(lines[j])
+			if t == "" {
+				continue
+			}
+			for _, q := range []string{`"""`, `'''`} {
+				if strings.HasPrefix(t, q) {
+					t = strings.TrimSpace(strings.TrimPrefix(t, q))
+					if t == "" && j+1 < len(lines) {
+						t = strings.TrimSpace(lines[j+1])
+					}
+					return strings.TrimSpace(strings.TrimSuffix(t, q))
+				}
+			}
+			return ""
+		}
+		return ""
+	}
+	return ""
+}
+
+func stripPyComment(line string) string {
+	if idx := strings.Index(line, " #"); idx >= 0 {
+		return line[:idx]
+	}
+	return line
+}
+
+func firstLine(s string) string {
+	if idx := strings.Index(s, "\n"); idx >= 0 {
+		return strings.TrimSpace(s[:idx])
+	}
+	return s
+}
+
+// pythonScripts maps console script targets (module:function keys) to the
+// script names declared in pyproject.toml or setup.py
+func (d *CLIDetector) pythonScripts() map[string]string {
+	scripts := make(map[string]string)
+	if content, err := os.ReadFile(filepath.Join(d.rootPath, "pyproject.toml")); err == nil {
+		inScripts := false
+		for _, line := range strings.Split(string(content), "\n") {
+			t := strings.TrimSpace(line)
+			if strings.HasPrefix(t, "[") {
+				inScripts = t == "[project.scripts]" || t == "[tool.poetry.scripts]"
+				continue
+			}
+			if m := pyScriptEntryRegex.FindStringSubmatch(t); inScripts && m != nil {
+				scripts[pyScriptKey(m[2], m[3])] = m[1]
+			}
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(d.rootPath, "setup.py")); err == nil {
+		if idx := strings.Index(string(content), "console_scripts"); idx >= 0 {
+			entryRegex := regexp.MustCompile(`["']([\w.-]+)\s*=\s*([\w.]+):(\w+)["']`)
+			for _, m := range entryRegex.FindAllStringSubmatch(string(content)[idx:], -1) {
+				scripts[pyScriptKey(m[2], m[3])] = m[1]
+			}
+		}
+	}
+	return scripts
+}
+
+func pyScriptKey(module, attr string) string {
+	if idx := strings.LastIndex(module, "."); idx >= 0 {
+		module = module[idx+1:]
+	}
+	return module + ":" + attr
+}
+
+// applyPythonScriptNames renames commands installed as console scripts
+func (d *CLIDetector) applyPythonScriptNames(tree *cliTree) {
+	for key, name := range d.pythonScripts() {
+		if cmd := tree.get(key); cmd != nil {
+			cmd.Name = name
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// argparse
+
+// argparseCommands extracts parsers, subparsers and arguments defined with argparse
+func (d *CLIDetector) argparseCommands() []types.CLICommand {
+	sources := d.sourcesContaining(".py", "argparse")
+	if len(sources) == 0 {
+		return nil
+	}
+	tree := newCLITree()
+	unnamed := make(map[string]bool)
+
+	for _, src := range sources {
+		lines := strings.Split(src.content, "\n")
+		key := func(name string) string { return src.path + ":" + name }
+		subparsers := make(map[string]string) // subparsers action -> parser
+
+		for i := 0; i < len(lines); i++ {
+			line := lines[i]
+			if m := argparseParserRegex.FindStringSubmatchIndex(line); m != nil {
+				args, _ := callArgsFrom(lines, i, m[1])
+				cmd := types.CLICommand{File: src.path}
+				if v, ok := keyedArgValue(args, "prog"); ok {
+					cmd.Name = quotedValue(v)
+				}
+				if cmd.Name == "" {
+					unnamed[key(line[m[2]:m[3]])] = true
+				}
+				if v, ok := keyedArgValue(args, "description"); ok {
+					cmd.Description = firstLine(quotedValue(v))
+				}
+				tree.add(key(line[m[2]:m[3]]), cmd)
+				continue
+			}
+			if m := argparseSubparsersRegex.FindStringSubmatch(line); m != nil {
+				subparsers[m[1]] = key(m[2])
+				continue
+			}
+			if m := argparseGroupRegex.FindStringSubmatch(line); m != nil {
+				tree.alias(key(m[1]), key(m[2]))
+				continue
+			}
+			if m := argparseAddParserRegex.FindStringSubmatchIndex(line); m != nil {
+				parent, ok := subparsers[line[m[4]:m[5]]]
+				if !ok {
+					continue
+				}
+				args, end := callArgsFrom(lines, i, m[1])
+				if len(args) == 0 || quotedValue(args[0]) == "" {
+					continue
+				}
+				cmd := types.CLICommand{Name: quotedValue(args[0]), File: src.path}
+				for _, k := range []string{"help", "description"} {
+					if v, ok := keyedArgValue(args, k); ok && cmd.Description == "" {
+						cmd.Description = firstLine(quotedValue(v))
+					}
+				}
+				if v, ok := keyedArgValue(args, "aliases"); ok {
+					cmd.Aliases = quotedList(v)
+				}
+				childKey := fmt.Sprintf("%s:%d", src.path, i)
+				if m[2] >= 0 {
+					childKey = key(line[m[2]:m[3]])
+				}
+				tree.add(childKey, cmd)
+				tree.link(parent, childKey)
+				i = end
+				continue
+			}
+			if m := argparseArgumentRegex.FindStringSubmatchIndex(line); m != nil {
+				args, end := callArgsFrom(lines, i, m[1])
+				if cmd := tree.get(key(line[m[2]:m[3]])); cmd != nil {
+					d.argparseArgument(cmd, args)
+				}
+				i = end
+			}
+		}
+	}
+
+	// Parsers without prog= are named after the script or project
+	scripts := d.pythonScripts()
+	for key := range unnamed {
+		cmd := tree.nodes[key]
+		cmd.Name = d.defaultCommandName(cmd.File)
+		if len(unnamed) > 1 && filepath.Base(cmd.File) != "__main__.py" {
+			cmd.Name = strings.TrimSuffix(filepath.Base(cmd.File), ".py")
+		} else if len(scripts) == 1 {
+			for _, name := range scripts {
+				cmd.Name = name
+			}
+		}
+	}
+
+	return tree.roots()
+}
+
+// argparseArgument adds an add_argument call to cmd as a flag or positional
+func (d *CLIDetector) argparseArgument(cmd *types.CLICommand, args []string) {
+	var names []string
+	for _, arg := range args {
+		if isKeyedArg(arg) {
+			break
+		}
+		if name := quotedValue(arg); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	nargs, _ := keyedArgValue(args, "nargs")
+	nargs = quotedValue(nargs)
+	if !strings.HasPrefix(names[0], "-") {
+		arg := names[0]
+		if v, ok := keyedArgValue(args, "metavar"); ok && quotedValue(v) != "" {
+			arg = quotedValue(v)
+		}
+		arg = strings.ReplaceAll(arg, "_", "-")
+		switch nargs {
+		case "?":
+			arg = "[" + arg + "]"
+		case "*":
+			arg = "[" + arg + "...]"
+		case "+":
+			arg = "<" + arg + "...>"
+		default:
+			arg = "<" + arg + ">"
+		}
+		cmd.Args = strings.TrimSpace(cmd.Args + " " + arg)
+		return
+	}
+
+	flag := types.CLIFlag{Type: "string"}
+	for _, name := range names {
+		if strings.HasPrefix(name, "--") {
+			if flag.Name == "" {
+				flag.Name = strings.TrimPrefix(name, "--")
+			}
+		} else if flag.Shorthand == "" {
+			flag.Shorthand = strings.TrimPrefix(name, "-")
+		}
+	}
+	if flag.Name == "" {
+		flag.Name, flag.Shorthand = flag.Shorthand, ""
+	}
+	if v, ok := keyedArgValue(args, "action"); ok {
+		switch quotedValue(v) {
+		case "store_true", "store_false", "BooleanOptionalAction":
+			flag.Type = "bool"
+		case "count":
+			flag.Type = "count"
+		case "append":
+			flag.Type = "string[]"
+		}
+		if strings.HasSuffix(v, "BooleanOptionalAction") {
+			flag.Type = "bool"
+		}
+	}
+	if v, ok := keyedArgValue(args, "type"); ok {
+		flag.Type = pythonFlagType(v)
+	}
+	if nargs == "*" || nargs == "+" {
+		flag.Type += "[]"
+	}
+	if v, ok := keyedArgValue(args, "default"); ok {
+		flag.Default = d.flagDefault(v)
+	}
+	if v, ok := keyedArgValue(args, "help"); ok {
+		flag.Usage = quotedValue(v)
+	}
+	cmd.Flags = append(cmd.Flags, flag)
+}
+
+// ---------------------------------------------------------------------------
+// Clap (derive API)
+
+// rustMember is a struct field or enum variant with its attributes and docs
+type rustMember struct {
+	name   string
+	typ    string // field type, or the type wrapped by a tuple variant
+	attrs  []string
+	doc    string
+	fields []rustMember // fields of a struct variant
+}
+
+// rustItem is a struct or enum deriving clap traits
+type rustItem struct {
+	kind    string
+	derives string
+	attrs   []string
+	doc     string
+	members []rustMember
+	file    string
+}
+
+// clapCommands extracts commands from #[derive(Parser)] structs and their
+// #[derive(Subcommand)] enums
+func (d *CLIDetector) clapCommands() []types.CLICommand {
+	sources := d.sourcesContaining(".rs", "clap")
+	sources = append(sources, d.sourcesContaining(".rs", "structopt")...)
+	if len(sources) == 0 {
+		return nil
+	}
+
+	items := make(map[string]*rustItem)
+	var parsers []string
+	for _, src := range sources {
+		lines := strings.Split(src.content, "\n")
+		for i, line := range lines {
+			m := rustItemRegex.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			attrs, doc := rustLeadingAttrs(lines, i)
+			derives := ""
+			for _, attr := range attrs {
+				if strings.HasPrefix(attr, "#[derive(") {
+					derives += attr
+				}
+			}
+			if !strings.Contains(derives, "Parser") && !strings.Contains(derives, "Subcommand") &&
+				!strings.Contains(derives, "Args") && !strings.Contains(derives, "StructOpt") {
+				continue
+			}
+			if _, exists := items[m[2]]; exists {
+				continue
+			}
+			body := rustBody(lines, i)
+			items[m[2]] = &rustItem{
+				kind:    m[1],
+				derives: derives,
+				attrs:   attrs,
+				doc:     doc,
+				members: rustMembers(body, m[1] == "enum"),
+				file:    src.path,
+			}
+			if m[1] == "struct" && (strings.Contains(derives, "Parser") || strings.Contains(derives, "StructOpt")) {
+				parsers = append(parsers, m[2])
+			}
+		}
+	}
+
+	var roots []types.CLICommand
+	for _, name := range parsers {
+		item := items[name]
+		cmd := types.CLICommand{Name: d.cargoPackageName(item.file), File: item.file}
+		if v, ok := clapAttrValue(item.attrs, "name"); ok {
+			cmd.Name = v
+		}
+		cmd.Description = item.doc
+		if v, ok := clapAttrValue(item.attrs, "about"); ok && v != "" {
+			cmd.Description = v
+		}
+		d.clapApplyFields(&cmd, item.members, items, 0)
+		roots = append(roots, cmd)
+	}
+	return roots
+}
+
+// clapApplyFields adds the flags, positionals and subcommands declared by
+// struct fields to cmd
+func (d *CLIDetector) clapApplyFields(cmd *types.CLICommand, fields []rustMember, items map[string]*rustItem, depth int) {
+	if depth > 6 {
+		return
+	}
+	var args []string
+	for _, field := range fields {
+		attrArgs := clapAttrArgs(field.attrs)
+		inner := rustInnerType(field.typ)
+		switch {
+		case hasBareArg(attrArgs, "subcommand"):
+			if enum := items[inner]; enum != nil && enum.kind == "enum" {
+				cmd.Subcommands = append(cmd.Subcommands, d.clapVariants(enum, items, depth+1)...)
+			}
+			continue
+		case hasBareArg(attrArgs, "flatten"):
+			if item := items[inner]; item != nil && item.kind == "struct" {
+				d.clapApplyFields(cmd, item.members, items, depth+1)
+			}
+			continue
+		}
+
+		short, hasShort := clapArgValue(attrArgs, "short")
+		long, hasLong := clapArgValue(attrArgs, "long")
+		if !hasShort && !hasLong {
+			arg := strings.ReplaceAll(field.name, "_", "-")
+			if v, ok := clapArgValue(attrArgs, "value_name"); ok && v != "" {
+				arg = v
+			}
+			switch {
+			case strings.HasPrefix(field.typ, "Vec<"):
+				args = append(args, "["+arg+"...]")
+			case strings.HasPrefix(field.typ, "Option<"):
+				args = append(args, "["+arg+"]")
+			default:
+				args = append(args, "<"+arg+">")
+			}
+			continue
+		}
+
+		flag := types.CLIFlag{Name: kebabCase(field.name), Type: rustFlagType(field.typ), Usage: field.doc}
+		if hasLong && long != "" {
+			flag.Name = long
+		}
+		if hasShort {
+			flag.Shorthand = short
+			if short == "" {
+				flag.Shorthand = field.name[:1]
+			}
+		}
+		if !hasLong && hasShort {
+			flag.Name, flag.Shorthand = flag.Shorthand, ""
+		}
+		// default_value is always a string; default_value_t is an expression
+		if v, ok := clapArgValue(attrArgs, "default_value"); ok && v != "" {
+			flag.Default = v
+		}
+		if v, ok := clapArgValue(attrArgs, "default_value_t"); ok && v != "" {
+			flag.Default = d.flagDefault(v)
+		}
+		if v, ok := clapArgValue(attrArgs, "help"); ok && v != "" {
+			flag.Usage = v
+		}
+		if v, ok := clapArgValue(attrArgs, "action"); ok && strings.HasSuffix(v, "Count") {
+			flag.Type = "count"
+		}
+		if v, ok := clapArgValue(attrArgs, "global"); ok && v == "true" {
+			flag.Persistent = true
+		}
+		cmd.Flags = append(cmd.Flags, flag)
+	}
+	if len(args) > 0 {
+		cmd.Args = strings.TrimSpace(cmd.Args + " " + strings.Join(args, " "))
+	}
+}
+
+// clapVariants builds the subcommands declared by a Subcommand enum
+func (d *CLIDetector) clapVariants(enum *rustItem, items map[string]*rustItem, depth int) []types.CLICommand {
+	var commands []types.CLICommand
+	for _, v := range enum.members {
+		cmd := types.CLICommand{Name: kebabCase(v.name), Description: v.doc, File: enum.file}
+		if name, ok := clapAttrValue(v.attrs, "name"); ok && name != "" {
+			cmd.Name = name
+		}
+		if about, ok := clapAttrValue(v.attrs, "about"); ok && about != "" {
+			cmd.Description = about
+		}
+		for _, k := range []string{"alias", "visible_alias"} {
+			if alias, ok := clapAttrValue(v.attrs, k); ok && alias != "" {
+				cmd.Aliases = append(cmd.Aliases, alias)
+			}
+		}
+		if hasBareArg(clapAttrArgs(v.attrs), "external_subcommand") {
+			continue
+		}
+
+		d.clapApplyFields(&cmd, v.fields, items, depth)
+		if item := items[rustInnerType(v.typ)]; item != nil && depth <= 6 {
+			if item.kind == "enum" {
+				cmd.Subcommands = append(cmd.Subcommands, d.clapVariants(item, items, depth+1)...)
+			} else {
+				if cmd.Description == "" {
+					cmd.Description = item.doc
+				}
+				d.clapApplyFields(&cmd, item.members, items, depth+1)
+			}
+		}
+		commands = append(commands, cmd)
+	}
+	return commands
+}
+
+// rustLeadingAttrs collects the attributes and doc comment above lines[idx]
+func rustLeadingAttrs(lines []string, idx int) ([]string, string) {
+	// Walk back over doc comments and attributes, including the inner lines
+	// of multi-line attributes (unbalanced closing brackets)
+	start := idx
+	balance := 0
+	for i := idx - 1; i >= 0; i-- {
+		t := strings.TrimSpace(lines[i])
+		balance += strings.Count(t, "]") - strings.Count(t, "[")
+		if balance > 0 || strings.HasPrefix(t, "#[") || strings.HasPrefix(t, "///") {
+			start = i
+			continue
+		}
+		break
+	}
+
+	var attrs, doc []string
+	for i := start; i < idx; i++ {
+		t := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(t, "///") {
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(t, "///")))
+			continue
+		}
+		for strings.Count(t, "[") > strings.Count(t, "]") && i+1 < idx {
+			i++
+			t += " " + strings.TrimSpace(lines[i])
+		}
+		attrs = append(attrs, t)
+	}
+	return attrs, docSummary(doc)
+}
+
+func docSummary(doc []string) string {
+	for _, line := range doc {
+		if line != "" {
+			return strings.TrimSuffix(line, ".")
+		}
+	}
+	return ""
+}
+
+// rustBody returns the lines between the braces of the item declared on lines[idx]
+func rustBody(lines []string, idx int) []string {
+	depth := 0
+	for i := idx; i < len(lines); i++ {
+		code := lines[i]
+		if c := strings.Index(code, "//"); c >= 0 {
+			code = code[:c]
+		}
+		depth += strings.Count(code, "{") - strings.Count(code, "}")
+		if depth <= 0 && i > idx {
+			return lines[idx+1 : i]
+		}
+		if depth <= 0 && strings.Contains(code, "}") {
+			return nil
+		}
+	}
+	return lines[idx+1:]
+}
+
+// rustMembers parses struct fields or enum variants with their attributes
+func rustMembers(body []string, enum bool) []rustMember {
+	var members []rustMember
+	var attrs, doc []string
+	for i := 0; i < len(body); i++ {
+		t := strings.TrimSpace(body[i])
+		switch {
+		case t == "" || (strings.HasPrefix(t, "//") && !strings.HasPrefix(t, "///")):
+			continue
+		case strings.HasPrefix(t, "///"):
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(t, "///")))
+			continue
+		case strings.HasPrefix(t, "#["):
+			attr := t
+			for strings.Count(attr, "[") > strings.Count(attr, "]") && i+1 < len(body) {
+				i++
+				attr += " " + strings.TrimSpace(body[i])
+			}
+			attrs = append(attrs, attr)
+			continue
+		}
+
+		member := rustMember{attrs: attrs, doc: docSummary(doc)}
+		attrs, doc = nil, nil
+		if !enum {
+			m := rustFieldRegex.FindStringSubmatch(t)
+			if m == nil {
+				continue
+			}
+			member.name, member.typ = m[1], strings.TrimSpace(m[2])
+			members = append(members, member)
+			continue
+		}
+
+		m := rustVariantRegex.FindStringSubmatch(t)
+		if m == nil {
+			continue
+		}
+		member.name = m[1]
+		switch {
+		case m[2] == "{":
+			depth := strings.Count(t, "{") - strings.Count(t, "}")
+			var inner []string
+			if idx := strings.Index(t, "{"); idx >= 0 && depth == 0 {
+				inner = strings.Split(strings.TrimSuffix(strings.TrimRight(t[idx+1:], ", "), "}"), ",")
+			}
+			for depth > 0 && i+1 < len(body) {
+				i++
+				depth += strings.Count(body[i], "{") - strings.Count(body[i], "}")
+				if depth > 0 {
+					inner = append(inner, body[i])
+				}
+			}
+			member.fields = rustMembers(inner, false)
+		case m[3] != "":
+			member.typ = strings.TrimSpace(m[3])
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// clapAttrArgs returns the arguments of #[arg(...)], #[clap(...)],
+// #[command(...)] and #[structopt(...)] attributes
+func clapAttrArgs(attrs []string) []string {
+	var args []string
+	for _, attr := range attrs {
+		m := rustAttrRegex.FindStringSubmatch(attr)
+		if m == nil {
+			continue
+		}
+		switch m[1] {
+		case "arg", "clap", "command", "structopt":
+			parts, _ := splitCallArgs(m[2])
+			args = append(args, parts...)
+		}
+	}
+	return args
+}
+
+// clapAttrValue returns the value of key in the clap attributes
+func clapAttrValue(attrs []string, key string) (string, bool) {
+	return clapArgValue(clapAttrArgs(attrs), key)
+}
+
+// clapArgValue returns the value of a key = value or bare key attribute
+// argument. Bare keys (short, long) report an empty value.
+func clapArgValue(args []string, key string) (string, bool) {
+	for _, arg := range args {
+		if arg == key {
+			return "", true
+		}
+		if k, v, ok := splitKeyedArg(arg); ok && k == key {
+			if isQuoted(v) {
+				return unquote(v), true
+			}
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func hasBareArg(args []string, key string) bool {
+	for _, arg := range args {
+		if arg == key {
+			return true
+		}
+	}
+	return false
+}
+
+// rustInnerType strips Option<> and Box<> wrappers
+func rustInnerType(t string) string {
+	t = strings.TrimSpace(t)
+	for _, wrapper := range []string{"Option<", "Box<"} {
+		if strings.HasPrefix(t, wrapper) && strings.HasSuffix(t, ">") {
+			t = strings.TrimSpace(t[len(wrapper) : len(t)-1])
+		}
+	}
+	return t
+}
+
+// rustFlagType maps a field type to a flag type
+func rustFlagType(t string) string {
+	t = rustInnerType(t)
+	if strings.HasPrefix(t, "Vec<") && strings.HasSuffix(t, ">") {
+		return rustFlagType(t[4:len(t)-1]) + "[]"
+	}
+	switch t {
+	case "bool":
+		return "bool"
+	case "String", "&str", "OsString":
+		return "string"
+	case "PathBuf", "Path":
+		return "path"
+	case "u8", "u16", "u32", "u64", "usize", "i8", "i16", "i32", "i64", "isize":
+		return "int"
+	case "f32", "f64":
+		return "float"
+	}
+	if idx := strings.LastIndex(t, "::"); idx >= 0 {
+		t = t[idx+2:]
+	}
+	return lowerFirst(t)
+}
+
+// cargoPackageName returns the package or binary name from the Cargo.toml
+// nearest to path, falling back to the project directory name
+func (d *CLIDetector) cargoPackageName(path string) string {
+	dir := filepath.Dir(path)
+	for {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, dir, "Cargo.toml"))
+		if err == nil {
+			section := ""
+			name := ""
+			for _, line := range strings.Split(string(content), "\n") {
+				t := strings.TrimSpace(line)
+				if strings.HasPrefix(t, "[") {
+					section = t
+					continue
+				}
+				k, v, ok := splitKeyedArg(t)
+				if !ok || k != "name" {
+					continue
+				}
+				// A [[bin]] name is the installed command
+				if section == "[[bin]]" {
+					return unquote(v)
+				}
+				if section == "[package]" && name == "" {
+					name = unquote(v)
+				}
+			}
+			if name != "" {
+				return name
+			}
+		}
+		if dir == "." || dir == "/" || dir == "" {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return d.defaultCommandName(path)
+}
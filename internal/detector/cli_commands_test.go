@@ -0,0 +1,433 @@
+package detector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func findCLICommand(commands []types.CLICommand, path string) *types.CLICommand {
+	parts := strings.Fields(path)
+	for i := range commands {
+		if commands[i].Name != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			return &commands[i]
+		}
+		return findCLICommand(commands[i].Subcommands, strings.Join(parts[1:], " "))
+	}
+	return nil
+}
+
+func findCLIFlag(cmd *types.CLICommand, name string) *types.CLIFlag {
+	if cmd == nil {
+		return nil
+	}
+	for i := range cmd.Flags {
+		if cmd.Flags[i].Name == name {
+			return &cmd.Flags[i]
+		}
+	}
+	return nil
+}
+
+func TestCLIDetector_Cobra(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"cmd/tool/main.go": `package main
+
+import "github.com/spf13/cobra"
+
+var cfgFile string
+
+var rootCmd = &cobra.Command{
+	Use:   "tool",
+	Short: "Tool does things",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file")
+	rootCmd.AddCommand(newServeCmd(), versionCmd)
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version",
+}
+`,
+		"cmd/tool/serve.go": `package main
+
+import "github.com/spf13/cobra"
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "serve [addr]",
+		Short:   "Start the server",
+		Aliases: []string{"s"},
+	}
+	flags := cmd.Flags()
+	flags.IntP("port", "p", 8080, "port to listen on")
+	cmd.Flags().Bool("tls", false, "enable TLS")
+	return cmd
+}
+`,
+	})
+
+	info := NewCLIDetector(tmpDir, files, &types.TechStack{}).Detect()
+	if info == nil || info.Framework != "Cobra" {
+		t.Fatalf("expected Cobra command tree, got %+v", info)
+	}
+	if len(info.Commands) != 1 {
+		t.Fatalf("expected a single root command, got %+v", info.Commands)
+	}
+
+	root := findCLICommand(info.Commands, "tool")
+	if f := findCLIFlag(root, "config"); f == nil || f.Shorthand != "c" || !f.Persistent || f.Type != "string" {
+		t.Errorf("expected persistent --config/-c flag, got %+v", f)
+	}
+
+	serve := findCLICommand(info.Commands, "tool serve")
+	if serve == nil || serve.Args != "[addr]" || serve.Description != "Start the server" || len(serve.Aliases) != 1 {
+		t.Fatalf("expected serve subcommand from constructor, got %+v", serve)
+	}
+	if f := findCLIFlag(serve, "port"); f == nil || f.Shorthand != "p" || f.Type != "int" || f.Default != "8080" {
+		t.Errorf("expected --port flag via flag set alias, got %+v", f)
+	}
+	if f := findCLIFlag(serve, "tls"); f == nil || f.Type != "bool" || f.Usage != "enable TLS" {
+		t.Errorf("expected --tls flag, got %+v", f)
+	}
+	if findCLICommand(info.Commands, "tool version") == nil {
+		t.Error("expected version subcommand")
+	}
+}
+
+func TestCLIDetector_IdentifierDefaults(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"cmd/tool/main.go": `package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"example.com/tool/internal/generator"
+)
+
+var rootCmd = &cobra.Command{
+	Use: "tool",
+}
+
+func init() {
+	rootCmd.Flags().Int("max-nodes", generator.DefaultDiagramNodes, "diagram node limit")
+	rootCmd.Flags().String("format", defaultFormat, "output format")
+	rootCmd.Flags().String("output", os.Getenv("OUT"), "output file")
+	rootCmd.Flags().Duration("timeout", timeout, "request timeout")
+}
+
+var timeout = 5 * time.Second
+`,
+		"cmd/tool/defaults.go": `package main
+
+const (
+	defaultFormat = "markdown" // rendered by default
+	other         = 2
+)
+`,
+		"internal/generator/diagram.go": `package generator
+
+// DefaultDiagramNodes caps the rendered graph
+const DefaultDiagramNodes = 25
+`,
+	})
+
+	_, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	root := findCLICommand(commands, "tool")
+	for name, want := range map[string]string{
+		"max-nodes": "25",
+		"format":    "markdown",
+		"timeout":   "",
+	} {
+		if f := findCLIFlag(root, name); f == nil || f.Default != want {
+			t.Errorf("expected --%s default %q, got %+v", name, want, f)
+		}
+	}
+}
+
+func TestCLIDetector_UrfaveCLI(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/urfave/cli/v2"
+
+func main() {
+	app := &cli.App{
+		Name:  "greet",
+		Usage: "say hello",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "lang", Aliases: []string{"l"}, Value: "en", Usage: "greeting language"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "add a greeting",
+				ArgsUsage: "<name>",
+				Subcommands: []*cli.Command{
+					{Name: "bulk", Usage: "add many"},
+				},
+			},
+		},
+	}
+	app.Run(os.Args)
+}
+`,
+	})
+
+	framework, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	if framework != "urfave/cli" {
+		t.Fatalf("expected urfave/cli, got %q", framework)
+	}
+	root := findCLICommand(commands, "greet")
+	if f := findCLIFlag(root, "lang"); f == nil || f.Shorthand != "l" || f.Default != "en" || f.Type != "string" {
+		t.Errorf("expected --lang flag, got %+v", f)
+	}
+	if add := findCLICommand(commands, "greet add"); add == nil || add.Args != "<name>" {
+		t.Errorf("expected add command with args, got %+v", add)
+	}
+	if findCLICommand(commands, "greet add bulk") == nil {
+		t.Error("expected nested bulk subcommand")
+	}
+}
+
+func TestCLIDetector_Click(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"pyproject.toml": `[project]
+name = "mytool"
+
+[project.scripts]
+mytool = "mytool.cli:cli"
+`,
+		"mytool/cli.py": `import click
+
+
+@click.group()
+@click.option("-v", "--verbose", is_flag=True, help="Verbose output")
+def cli(verbose):
+    """Manage things."""
+
+
+@cli.command()
+@click.argument("src")
+@click.argument("dest", required=False)
+@click.option("--dry-run/--no-dry-run", default=False, help="Preview only")
+@click.option("--retries", type=int, default=3)
+def copy_files(src, dest, dry_run, retries):
+    """Copy files between places."""
+`,
+		"mytool/db.py": `import click
+
+from .cli import cli
+
+
+@click.command("migrate", help="Run migrations")
+def migrate():
+    pass
+
+
+cli.add_command(migrate)
+`,
+	})
+
+	framework, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	if framework != "Click" {
+		t.Fatalf("expected Click, got %q", framework)
+	}
+	root := findCLICommand(commands, "mytool")
+	if root == nil || root.Description != "Manage things." {
+		t.Fatalf("expected root named after the console script, got %+v", commands)
+	}
+	if f := findCLIFlag(root, "verbose"); f == nil || f.Shorthand != "v" || f.Type != "bool" {
+		t.Errorf("expected --verbose flag, got %+v", f)
+	}
+
+	cp := findCLICommand(commands, "mytool copy-files")
+	if cp == nil || cp.Args != "<src> [dest]" || cp.Description != "Copy files between places." {
+		t.Fatalf("expected copy-files command, got %+v", cp)
+	}
+	if f := findCLIFlag(cp, "dry-run"); f == nil || f.Type != "bool" {
+		t.Errorf("expected boolean --dry-run switch, got %+v", f)
+	}
+	if f := findCLIFlag(cp, "retries"); f == nil || f.Type != "int" || f.Default != "3" {
+		t.Errorf("expected int --retries, got %+v", f)
+	}
+	if m := findCLICommand(commands, "mytool migrate"); m == nil || m.Description != "Run migrations" {
+		t.Errorf("expected migrate registered with add_command, got %+v", m)
+	}
+}
+
+func TestCLIDetector_Typer(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"app/main.py": `import typer
+from typing import Annotated
+
+from app import users
+
+app = typer.Typer(help="Admin CLI")
+app.add_typer(users.app, name="users")
+
+
+@app.command()
+def deploy(
+    env: str,
+    force: bool = typer.Option(False, "--force", "-f", help="Skip checks"),
+    replicas: Annotated[int, typer.Option(help="Replica count")] = 2,
+):
+    """Deploy the service."""
+`,
+		"app/users.py": `import typer
+
+app = typer.Typer()
+
+
+@app.command("add")
+def add_user(name: str, admin: bool = False):
+    """Create a user."""
+`,
+	})
+
+	framework, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	if framework != "Typer" {
+		t.Fatalf("expected Typer, got %q", framework)
+	}
+	if len(commands) != 1 {
+		t.Fatalf("expected users app nested under the main app, got %+v", commands)
+	}
+	root := commands[0]
+	if root.Description != "Admin CLI" {
+		t.Errorf("expected Typer help as description, got %q", root.Description)
+	}
+
+	deploy := findCLICommand(commands, root.Name+" deploy")
+	if deploy == nil || deploy.Args != "<env>" {
+		t.Fatalf("expected deploy with env argument, got %+v", deploy)
+	}
+	if f := findCLIFlag(deploy, "force"); f == nil || f.Shorthand != "f" || f.Type != "bool" || f.Usage != "Skip checks" {
+		t.Errorf("expected --force option, got %+v", f)
+	}
+	if f := findCLIFlag(deploy, "replicas"); f == nil || f.Type != "int" || f.Default != "2" {
+		t.Errorf("expected Annotated --replicas option, got %+v", f)
+	}
+
+	add := findCLICommand(commands, root.Name+" users add")
+	if add == nil || add.Args != "<name>" || findCLIFlag(add, "admin") == nil {
+		t.Errorf("expected users add command, got %+v", add)
+	}
+}
+
+func TestCLIDetector_Argparse(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"bench/__main__.py": `import argparse
+
+parser = argparse.ArgumentParser(description="Run benchmarks")
+parser.add_argument("--iterations", "-n", type=int, default=10, help="Iterations")
+sub = parser.add_subparsers(dest="cmd")
+
+run = sub.add_parser("run", help="Run a suite")
+run.add_argument("suite")
+run.add_argument("--profile", action="store_true")
+
+sub.add_parser("list", help="List suites")
+`,
+	})
+
+	framework, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	if framework != "argparse" {
+		t.Fatalf("expected argparse, got %q", framework)
+	}
+	root := findCLICommand(commands, "bench")
+	if root == nil || root.Description != "Run benchmarks" {
+		t.Fatalf("expected bench root from __main__.py, got %+v", commands)
+	}
+	if f := findCLIFlag(root, "iterations"); f == nil || f.Shorthand != "n" || f.Type != "int" || f.Default != "10" {
+		t.Errorf("expected --iterations flag, got %+v", f)
+	}
+	run := findCLICommand(commands, "bench run")
+	if run == nil || run.Args != "<suite>" {
+		t.Fatalf("expected run subparser, got %+v", run)
+	}
+	if f := findCLIFlag(run, "profile"); f == nil || f.Type != "bool" {
+		t.Errorf("expected store_true --profile, got %+v", f)
+	}
+	if findCLICommand(commands, "bench list") == nil {
+		t.Error("expected unassigned add_parser to register list")
+	}
+}
+
+func TestCLIDetector_Clap(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"Cargo.toml": `[package]
+name = "rsync-lite"
+version = "0.1.0"
+`,
+		"src/main.rs": `use clap::{Args, Parser, Subcommand};
+
+/// Sync directories, quickly.
+#[derive(Parser)]
+#[command(version)]
+struct Cli {
+    /// Increase verbosity
+    #[arg(short, long, action = clap::ArgAction::Count, global = true)]
+    verbose: u8,
+
+    #[command(subcommand)]
+    command: Commands,
+}
+
+#[derive(Subcommand)]
+enum Commands {
+    /// Copy files to a destination
+    Push(PushArgs),
+    /// Show the remote's status
+    #[command(alias = "st")]
+    Status {
+        #[arg(long, default_value = "origin")]
+        remote: String,
+    },
+}
+
+#[derive(Args)]
+struct PushArgs {
+    /// Source directory
+    source: PathBuf,
+    #[arg(long = "dry")]
+    dry_run: bool,
+}
+`,
+	})
+
+	framework, commands := NewCLIDetector(tmpDir, files, &types.TechStack{}).detectCommandTree()
+	if framework != "Clap" {
+		t.Fatalf("expected Clap, got %q", framework)
+	}
+	root := findCLICommand(commands, "rsync-lite")
+	if root == nil || root.Description != "Sync directories, quickly" {
+		t.Fatalf("expected root named after the Cargo package, got %+v", commands)
+	}
+	if f := findCLIFlag(root, "verbose"); f == nil || f.Shorthand != "v" || f.Type != "count" || !f.Persistent {
+		t.Errorf("expected global counted --verbose, got %+v", f)
+	}
+
+	push := findCLICommand(commands, "rsync-lite push")
+	if push == nil || push.Args != "<source>" || push.Description != "Copy files to a destination" {
+		t.Fatalf("expected push command with positional source, got %+v", push)
+	}
+	if f := findCLIFlag(push, "dry"); f == nil || f.Type != "bool" {
+		t.Errorf("expected --dry flag, got %+v", f)
+	}
+
+	status := findCLICommand(commands, "rsync-lite status")
+	if status == nil || len(status.Aliases) != 1 || status.Aliases[0] != "st" {
+		t.Fatalf("expected status command with alias, got %+v", status)
+	}
+	if f := findCLIFlag(status, "remote"); f == nil || f.Default != "origin" {
+		t.Errorf("expected --remote default, got %+v", f)
+	}
+}
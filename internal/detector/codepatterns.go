@@ -57,6 +57,7 @@ func (d *CodePatternDetector) Detect() *types.CodePatterns {
 		PythonPatterns:  d.detectPythonPatterns(),
 	}
 
+	scoreCodePatterns(d.rootPath, patterns)
 	return patterns
 }
 
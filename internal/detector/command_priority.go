@@ -85,6 +85,9 @@ var categoryPatterns = []struct {
 	{regexp.MustCompile(`(?i)^(ruff|flake8|pylint|mypy)\s+check`), CategoryLint},
 	{regexp.MustCompile(`(?i)^poetry\s+run\s+(ruff|flake8|pylint|mypy)`), CategoryLint},
 	{regexp.MustCompile(`(?i)^rubocop`), CategoryLint},
+	{regexp.MustCompile(`(?i)^go\s+vet`), CategoryLint},
+	{regexp.MustCompile(`(?i)^staticcheck`), CategoryLint},
+	{regexp.MustCompile(`(?i)^pre-commit\s+run`), CategoryLint},
 	{regexp.MustCompile(`(?i)^check`), CategoryLint},
 
 	// Format commands
@@ -214,11 +217,15 @@ func PrioritizeCommands(commands []types.Command) []types.Command {
 		})
 	}
 
-	// Sort by priority, then alphabetically within same priority
+	// Sort by priority, then CI-verified, then alphabetically within same priority
 	sort.SliceStable(prioritized, func(i, j int) bool {
 		if prioritized[i].Priority != prioritized[j].Priority {
 			return prioritized[i].Priority < prioritized[j].Priority
 		}
+		// Commands CI runs come first within a category
+		if prioritized[i].CIVerified != prioritized[j].CIVerified {
+			return prioritized[i].CIVerified
+		}
 		return prioritized[i].Name < prioritized[j].Name
 	})
 
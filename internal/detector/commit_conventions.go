@@ -0,0 +1,507 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Priyans-hu/argus/pkg/types"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"gopkg.in/yaml.v3"
+)
+
+// Commit subject styles
+var (
+	conventionalCommitRegex = regexp.MustCompile(`^(feat|fix|docs|style|refactor|test|chore|perf|ci|build|revert)(\(([^)]+)\))?!?:\s*(.+)`)
+	gitmojiCommitRegex      = regexp.MustCompile(`^:[a-z_]+:\s*.+`)
+	jiraCommitRegex         = regexp.MustCompile(`^[A-Z]+-\d+\s*.+`)
+	angularCommitRegex      = regexp.MustCompile(`^(feat|fix|docs|style|refactor|test|chore)\(.+\)!?:\s*.+`)
+)
+
+// Breaking changes, tickets and trailers
+var (
+	breakingBangRegex   = regexp.MustCompile(`^\w+(\([^)]*\))?!:`)
+	breakingFooterRegex = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+	jiraKeyRegex        = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-[1-9]\d*\b`)
+	issueRefRegex       = regexp.MustCompile(`(?:^|[\s(\[])#[1-9]\d*\b`)
+	ticketTrailerRegex  = regexp.MustCompile(`(?im)^(refs?|references|closes|fixes|resolves|issue|ticket|jira|related)\s*:\s*(\S.*)$`)
+	signedOffRegex      = regexp.MustCompile(`(?m)^Signed-off-by: .+ <.+>`)
+)
+
+// Prefixes that look like ticket keys but aren't
+var notTicketKeys = map[string]bool{
+	"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true, "HTTP": true, "TLS": true, "AES": true,
+}
+
+// Commitlint and commitizen config files, in commitlint's resolution order
+var commitlintFiles = []string{
+	".commitlintrc", ".commitlintrc.json", ".commitlintrc.yaml", ".commitlintrc.yml",
+	".commitlintrc.js", ".commitlintrc.cjs", ".commitlintrc.mjs", ".commitlintrc.ts",
+	"commitlint.config.js", "commitlint.config.cjs", "commitlint.config.mjs", "commitlint.config.ts",
+}
+
+var (
+	jsExtendsRegex      = regexp.MustCompile(`extends\s*:\s*(\[[^\]]*\]|['"][^'"]+['"])`)
+	jsQuotedRegex       = regexp.MustCompile(`['"]([^'"]+)['"]`)
+	jsTypeEnumRegex     = regexp.MustCompile(`['"]?type-enum['"]?\s*:\s*\[\s*[12]\s*,\s*['"]always['"]\s*,\s*\[([^\]]*)\]`)
+	jsScopeEnumRegex    = regexp.MustCompile(`['"]?scope-enum['"]?\s*:\s*\[\s*[12]\s*,\s*['"]always['"]\s*,\s*\[([^\]]*)\]`)
+	jsHeaderLengthRegex = regexp.MustCompile(`['"]?header-max-length['"]?\s*:\s*\[\s*[12]\s*,\s*['"]always['"]\s*,\s*(\d+)`)
+)
+
+// DefaultConventionalTypes are the types allowed by @commitlint/config-conventional
+var DefaultConventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// recentHistory returns up to limit commits reachable from HEAD
+func (d *GitDetectorGoGit) recentHistory(limit int) []*object.Commit {
+	if d.repo == nil {
+		return nil
+	}
+
+	ref, err := d.repo.Head()
+	if err != nil {
+		return nil
+	}
+
+	commitIter, err := d.repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil
+	}
+	defer commitIter.Close()
+
+	var history []*object.Commit
+	_ = commitIter.ForEach(func(c *object.Commit) error {
+		if len(history) >= limit {
+			return fmt.Errorf("limit reached")
+		}
+		history = append(history, c)
+		return nil
+	})
+
+	return history
+}
+
+// commitMessages returns the full message of each commit
+func commitMessages(history []*object.Commit) []string {
+	messages := make([]string, 0, len(history))
+	for _, c := range history {
+		messages = append(messages, strings.TrimSpace(c.Message))
+	}
+	return messages
+}
+
+// mapScopesToDirs maps each commit scope to the directory its commits
+// usually touch, falling back to a directory named after the scope
+func (d *GitDetectorGoGit) mapScopesToDirs(history []*object.Commit, scopes []string) map[string]string {
+	if len(scopes) == 0 {
+		return nil
+	}
+	wanted := make(map[string]bool)
+	for _, scope := range scopes {
+		wanted[scope] = true
+	}
+
+	dirCounts := make(map[string]map[string]int)
+	scopeTotal := make(map[string]int)
+	for _, c := range history {
+		m := conventionalCommitRegex.FindStringSubmatch(strings.Split(c.Message, "\n")[0])
+		if m == nil || !wanted[m[3]] {
+			continue
+		}
+		scopeTotal[m[3]]++
+		if dir := commonDir(changedFiles(c)); dir != "" {
+			if dirCounts[m[3]] == nil {
+				dirCounts[m[3]] = make(map[string]int)
+			}
+			dirCounts[m[3]][dir]++
+		}
+	}
+
+	scopeDirs := make(map[string]string)
+	for _, scope := range scopes {
+		// The directory must account for at least half the scope's commits
+		if top := getTopKeysFromMap(dirCounts[scope], 1); len(top) == 1 && dirCounts[scope][top[0]]*2 >= scopeTotal[scope] {
+			scopeDirs[scope] = top[0]
+			continue
+		}
+		for _, parent := range []string{"", "internal", "pkg", "src", "lib", "app", "apps", "packages", "cmd"} {
+			candidate := filepath.ToSlash(filepath.Join(parent, scope))
+			if info, err := os.Stat(filepath.Join(d.rootPath, candidate)); err == nil && info.IsDir() {
+				scopeDirs[scope] = candidate
+				break
+			}
+		}
+	}
+
+	if len(scopeDirs) == 0 {
+		return nil
+	}
+	return scopeDirs
+}
+
+// commonDir returns the deepest directory containing every path, or "" when
+// the paths only share the repository root
+func commonDir(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	common := strings.Split(filepath.ToSlash(filepath.Dir(paths[0])), "/")
+	for _, p := range paths[1:] {
+		parts := strings.Split(filepath.ToSlash(filepath.Dir(p)), "/")
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	dir := strings.Join(common, "/")
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// detectBreakingMarkers reports which breaking change markers commits use
+func detectBreakingMarkers(messages []string) []string {
+	var bang, footer bool
+	for _, msg := range messages {
+		if breakingBangRegex.MatchString(msg) {
+			bang = true
+		}
+		if breakingFooterRegex.MatchString(msg) {
+			footer = true
+		}
+	}
+
+	var markers []string
+	if bang {
+		markers = append(markers, "!")
+	}
+	if footer {
+		markers = append(markers, "BREAKING CHANGE:")
+	}
+	return markers
+}
+
+// detectTicketReferences finds ticket formats used by at least a fifth of
+// commits, in the subject or in trailers like Refs: or Closes:
+func detectTicketReferences(messages []string) []types.TicketReference {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	type ref struct {
+		types.TicketReference
+		count int
+	}
+	refs := make(map[string]*ref)
+	add := func(key string, r types.TicketReference) {
+		if refs[key] == nil {
+			refs[key] = &ref{TicketReference: r}
+		}
+		refs[key].count++
+	}
+
+	for _, msg := range messages {
+		subject, body, _ := strings.Cut(msg, "\n")
+		seen := make(map[string]bool)
+		once := func(key string, r types.TicketReference) {
+			if !seen[key] {
+				seen[key] = true
+				add(key, r)
+			}
+		}
+
+		for _, m := range jiraKeyRegex.FindAllStringSubmatch(subject, -1) {
+			if !notTicketKeys[m[1]] {
+				once("subject:"+m[1], types.TicketReference{Format: m[1] + "-123", Location: "subject", Example: subject})
+			}
+		}
+		if issueRefRegex.MatchString(subject) {
+			once("subject:#", types.TicketReference{Format: "#123", Location: "subject", Example: subject})
+		}
+
+		for _, m := range ticketTrailerRegex.FindAllStringSubmatch(body, -1) {
+			trailer := strings.ToUpper(m[1][:1]) + strings.ToLower(m[1][1:])
+			var format string
+			if k := jiraKeyRegex.FindStringSubmatch(m[2]); k != nil && !notTicketKeys[k[1]] {
+				format = k[1] + "-123"
+			} else if strings.Contains(m[2], "#") {
+				format = "#123"
+			} else {
+				continue
+			}
+			once("trailer:"+trailer+":"+format, types.TicketReference{
+				Format:   format,
+				Location: "trailer",
+				Trailer:  trailer,
+				Example:  trailer + ": " + strings.TrimSpace(m[2]),
+			})
+		}
+	}
+
+	minCount := max(2, len(messages)/5)
+	var found []*ref
+	for _, r := range refs {
+		if r.count >= minCount {
+			found = append(found, r)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].count != found[j].count {
+			return found[i].count > found[j].count
+		}
+		if found[i].Location != found[j].Location {
+			return found[i].Location == "subject"
+		}
+		return found[i].Format+found[i].Trailer < found[j].Format+found[j].Trailer
+	})
+
+	tickets := make([]types.TicketReference, 0, len(found))
+	for _, r := range found {
+		tickets = append(tickets, r.TicketReference)
+	}
+	if len(tickets) == 0 {
+		return nil
+	}
+	return tickets
+}
+
+// detectSignOff reports whether commits need a Signed-off-by trailer: most
+// commits carry one, a DCO app config exists, or CONTRIBUTING asks for it
+func (d *GitDetectorGoGit) detectSignOff(messages []string) bool {
+	signed := 0
+	for _, msg := range messages {
+		if signedOffRegex.MatchString(msg) {
+			signed++
+		}
+	}
+	if signed >= 3 && signed*5 >= len(messages)*4 {
+		return true
+	}
+
+	if _, err := os.Stat(filepath.Join(d.rootPath, ".github", "dco.yml")); err == nil {
+		return true
+	}
+	for _, name := range []string{"CONTRIBUTING.md", ".github/CONTRIBUTING.md", "docs/CONTRIBUTING.md"} {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, name))
+		if err != nil {
+			continue
+		}
+		if strings.Contains(string(content), "Signed-off-by") || strings.Contains(string(content), "Developer Certificate of Origin") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectCommitLint parses commitlint and commitizen configuration
+func detectCommitLint(rootPath string) *types.CommitLint {
+	var lint *types.CommitLint
+
+	for _, name := range commitlintFiles {
+		content, err := os.ReadFile(filepath.Join(rootPath, name))
+		if err != nil {
+			continue
+		}
+		lint = &types.CommitLint{ConfigFile: name}
+		switch filepath.Ext(name) {
+		case ".js", ".cjs", ".mjs", ".ts":
+			parseCommitlintJS(string(content), lint)
+		default:
+			var config map[string]interface{}
+			if err := yaml.Unmarshal(content, &config); err == nil {
+				parseCommitlintConfig(config, lint)
+			}
+		}
+		break
+	}
+
+	var pkg map[string]interface{}
+	if content, err := os.ReadFile(filepath.Join(rootPath, "package.json")); err == nil {
+		_ = json.Unmarshal(content, &pkg)
+	}
+	if config, ok := pkg["commitlint"].(map[string]interface{}); ok && lint == nil {
+		lint = &types.CommitLint{ConfigFile: "package.json"}
+		parseCommitlintConfig(config, lint)
+	}
+
+	if adapter, file := commitizenAdapter(rootPath, pkg); adapter != "" {
+		if lint == nil {
+			lint = &types.CommitLint{ConfigFile: file}
+		}
+		lint.Commitizen = adapter
+	}
+
+	return lint
+}
+
+// parseCommitlintConfig reads extends and rules from a JSON or YAML config
+func parseCommitlintConfig(config map[string]interface{}, lint *types.CommitLint) {
+	switch extends := config["extends"].(type) {
+	case string:
+		lint.Extends = []string{extends}
+	case []interface{}:
+		lint.Extends = stringValues(extends)
+	}
+
+	rules, _ := config["rules"].(map[string]interface{})
+	if value, ok := commitlintRule(rules, "type-enum"); ok {
+		if values, ok := value.([]interface{}); ok {
+			lint.Types = stringValues(values)
+		}
+	}
+	if value, ok := commitlintRule(rules, "scope-enum"); ok {
+		if values, ok := value.([]interface{}); ok {
+			lint.Scopes = stringValues(values)
+		}
+	}
+	if value, ok := commitlintRule(rules, "header-max-length"); ok {
+		switch n := value.(type) {
+		case int:
+			lint.HeaderMaxLength = n
+		case float64:
+			lint.HeaderMaxLength = int(n)
+		}
+	}
+}
+
+// commitlintRule returns the value of an enabled [level, "always", value] rule
+func commitlintRule(rules map[string]interface{}, name string) (interface{}, bool) {
+	rule, ok := rules[name].([]interface{})
+	if !ok || len(rule) < 3 || rule[1] != "always" {
+		return nil, false
+	}
+	switch level := rule[0].(type) {
+	case int:
+		if level == 0 {
+			return nil, false
+		}
+	case float64:
+		if level == 0 {
+			return nil, false
+		}
+	}
+	return rule[2], true
+}
+
+// parseCommitlintJS extracts extends and rules from a JavaScript config
+func parseCommitlintJS(content string, lint *types.CommitLint) {
+	if m := jsExtendsRegex.FindStringSubmatch(content); m != nil {
+		for _, q := range jsQuotedRegex.FindAllStringSubmatch(m[1], -1) {
+			lint.Extends = append(lint.Extends, q[1])
+		}
+	}
+	if m := jsTypeEnumRegex.FindStringSubmatch(content); m != nil {
+		for _, q := range jsQuotedRegex.FindAllStringSubmatch(m[1], -1) {
+			lint.Types = append(lint.Types, q[1])
+		}
+	}
+	if m := jsScopeEnumRegex.FindStringSubmatch(content); m != nil {
+		for _, q := range jsQuotedRegex.FindAllStringSubmatch(m[1], -1) {
+			lint.Scopes = append(lint.Scopes, q[1])
+		}
+	}
+	if m := jsHeaderLengthRegex.FindStringSubmatch(content); m != nil {
+		_, _ = fmt.Sscanf(m[1], "%d", &lint.HeaderMaxLength)
+	}
+}
+
+// commitizenAdapter returns the configured commitizen adapter and its config file
+func commitizenAdapter(rootPath string, pkg map[string]interface{}) (string, string) {
+	for _, name := range []string{".czrc", ".cz.json"} {
+		content, err := os.ReadFile(filepath.Join(rootPath, name))
+		if err != nil {
+			continue
+		}
+		var config struct {
+			Path string `json:"path"`
+		}
+		if json.Unmarshal(content, &config) == nil && config.Path != "" {
+			return strings.TrimPrefix(config.Path, "./node_modules/"), name
+		}
+	}
+
+	if config, ok := pkg["config"].(map[string]interface{}); ok {
+		if cz, ok := config["commitizen"].(map[string]interface{}); ok {
+			if path, ok := cz["path"].(string); ok && path != "" {
+				return strings.TrimPrefix(path, "./node_modules/"), "package.json"
+			}
+		}
+	}
+
+	// Python commitizen
+	for _, name := range []string{".cz.toml", "pyproject.toml"} {
+		var config struct {
+			Tool struct {
+				Commitizen struct {
+					Name string `toml:"name"`
+				} `toml:"commitizen"`
+			} `toml:"tool"`
+		}
+		if _, err := toml.DecodeFile(filepath.Join(rootPath, name), &config); err == nil && config.Tool.Commitizen.Name != "" {
+			return config.Tool.Commitizen.Name, name
+		}
+	}
+
+	return "", ""
+}
+
+// stringValues returns the string elements of a decoded list
+func stringValues(values []interface{}) []string {
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// applyCommitLint merges configured commit rules into the convention detected
+// from history. Config wins: it is what CI enforces.
+func applyCommitLint(convention *types.CommitConvention, lint *types.CommitLint) *types.CommitConvention {
+	if lint == nil {
+		return convention
+	}
+
+	conventional := len(lint.Types) > 0 || strings.Contains(lint.Commitizen, "conventional")
+	for _, ext := range lint.Extends {
+		if strings.Contains(ext, "conventional") || strings.Contains(ext, "angular") {
+			conventional = true
+		}
+	}
+	if !conventional {
+		return convention
+	}
+
+	if convention == nil || (convention.Style != "conventional" && convention.Style != "angular") {
+		convention = &types.CommitConvention{
+			Style:  "conventional",
+			Format: "<type>(<scope>): <description>",
+			Types:  slices.Clone(DefaultConventionalTypes),
+		}
+	}
+	if len(lint.Types) > 0 {
+		convention.Types = lint.Types
+	}
+	if len(lint.Scopes) > 0 {
+		convention.Scopes = lint.Scopes
+	}
+	if convention.Example == "" && len(convention.Types) > 0 {
+		convention.Example = convention.Types[0] + ": add new feature"
+		if len(convention.Scopes) > 0 {
+			convention.Example = convention.Types[0] + "(" + convention.Scopes[0] + "): add new feature"
+		}
+	}
+
+	return convention
+}
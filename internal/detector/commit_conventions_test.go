@@ -0,0 +1,179 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestAnalyzeCommitPatterns_Scopes(t *testing.T) {
+	cc := analyzeCommitPatterns([]string{
+		"feat(api): add users endpoint",
+		"fix(api): handle empty body",
+		"feat(cli)!: rename scan flags",
+		"docs: update readme",
+		"wip",
+	})
+	if cc == nil || cc.Style != "conventional" {
+		t.Fatalf("expected conventional style, got %+v", cc)
+	}
+	if !reflect.DeepEqual(cc.Scopes, []string{"api", "cli"}) {
+		t.Errorf("expected scopes [api cli], got %v", cc.Scopes)
+	}
+	if cc.Example != "feat(api): add new feature" {
+		t.Errorf("unexpected example %q", cc.Example)
+	}
+}
+
+func TestDetectTicketReferences(t *testing.T) {
+	messages := []string{
+		"PAY-101 add refund endpoint",
+		"PAY-102 fix rounding\n\nRefs: #44",
+		"fix: utf-8 handling for UTF-8 input",
+		"chore: bump deps\n\nRefs: #45\nSigned-off-by: A <a@example.com>",
+		"PAY-110 retry webhooks\n\nRefs: #46",
+	}
+
+	got := detectTicketReferences(messages)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 ticket formats, got %+v", got)
+	}
+	if got[0].Format != "PAY-123" || got[0].Location != "subject" {
+		t.Errorf("expected Jira key in subject first, got %+v", got[0])
+	}
+	if got[1].Format != "#123" || got[1].Location != "trailer" || got[1].Trailer != "Refs" {
+		t.Errorf("expected Refs trailer, got %+v", got[1])
+	}
+
+	if markers := detectBreakingMarkers([]string{"feat(api)!: drop v1", "fix: x\n\nBREAKING CHANGE: y"}); !reflect.DeepEqual(markers, []string{"!", "BREAKING CHANGE:"}) {
+		t.Errorf("expected both breaking markers, got %v", markers)
+	}
+}
+
+func TestDetectCommitLint(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  *types.CommitLint
+	}{
+		{
+			name: "javascript config",
+			files: map[string]string{
+				"commitlint.config.js": `module.exports = {
+  extends: ['@commitlint/config-conventional'],
+  rules: {
+    'type-enum': [2, 'always', ['feat', 'fix', 'chore']],
+    'scope-enum': [2, 'always', ['api', 'web']],
+    'header-max-length': [2, 'always', 72],
+  },
+};`,
+			},
+			want: &types.CommitLint{
+				ConfigFile:      "commitlint.config.js",
+				Extends:         []string{"@commitlint/config-conventional"},
+				Types:           []string{"feat", "fix", "chore"},
+				Scopes:          []string{"api", "web"},
+				HeaderMaxLength: 72,
+			},
+		},
+		{
+			name: "yaml config with czrc",
+			files: map[string]string{
+				".commitlintrc.yml": "extends: '@commitlint/config-conventional'\nrules:\n  type-enum: [0, always, [feat]]\n  header-max-length: [1, always, 100]\n",
+				".czrc":             `{ "path": "cz-conventional-changelog" }`,
+			},
+			want: &types.CommitLint{
+				ConfigFile:      ".commitlintrc.yml",
+				Extends:         []string{"@commitlint/config-conventional"},
+				HeaderMaxLength: 100,
+				Commitizen:      "cz-conventional-changelog",
+			},
+		},
+		{
+			name: "package.json",
+			files: map[string]string{
+				"package.json": `{"commitlint": {"extends": ["@commitlint/config-angular"]}, "config": {"commitizen": {"path": "./node_modules/cz-conventional-changelog"}}}`,
+			},
+			want: &types.CommitLint{
+				ConfigFile: "package.json",
+				Extends:    []string{"@commitlint/config-angular"},
+				Commitizen: "cz-conventional-changelog",
+			},
+		},
+		{
+			name:  "none",
+			files: map[string]string{"package.json": `{"name": "app"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, _ := writeProjectFixture(t, tt.files)
+			if got := detectCommitLint(tmpDir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyCommitLint(t *testing.T) {
+	cc := applyCommitLint(nil, &types.CommitLint{Extends: []string{"@commitlint/config-conventional"}, Scopes: []string{"api"}})
+	if cc == nil || cc.Style != "conventional" || len(cc.Types) != len(DefaultConventionalTypes) {
+		t.Fatalf("expected conventional defaults from config, got %+v", cc)
+	}
+	if cc.Example != "feat(api): add new feature" {
+		t.Errorf("unexpected example %q", cc.Example)
+	}
+
+	gitmoji := &types.CommitConvention{Style: "gitmoji"}
+	if got := applyCommitLint(gitmoji, &types.CommitLint{Commitizen: "cz-emoji"}); got != gitmoji {
+		t.Errorf("expected non-conventional config to leave convention alone, got %+v", got)
+	}
+}
+
+func TestGitDetectorGoGit_CommitScopesAndSignOff(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := runGitCommand(tmpDir, "init"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	commit := func(file, message string) {
+		t.Helper()
+		path := filepath.Join(tmpDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = f.WriteString("x\n")
+		_ = f.Close()
+		if err := runGitCommand(tmpDir, "add", "-A"); err != nil {
+			t.Fatal(err)
+		}
+		if err := runGitCommand(tmpDir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-s", "-m", message); err != nil {
+			t.Fatalf("commit failed: %v", err)
+		}
+	}
+
+	commit("internal/api/users.go", "feat(api): add users")
+	commit("internal/api/orders/orders.go", "feat(api): add orders")
+	commit("web/src/app.tsx", "fix(ui): center header")
+	commit("README.md", "docs: explain setup")
+
+	conventions := NewGitDetectorGoGit(tmpDir).Detect()
+	cc := conventions.CommitConvention
+	if cc == nil || cc.Style != "conventional" {
+		t.Fatalf("expected conventional commits, got %+v", cc)
+	}
+	if want := map[string]string{"api": "internal/api", "ui": "web/src"}; !reflect.DeepEqual(cc.ScopeDirs, want) {
+		t.Errorf("expected scope dirs %v, got %v", want, cc.ScopeDirs)
+	}
+	if !conventions.SignOff {
+		t.Error("expected sign-off to be required")
+	}
+}
@@ -86,9 +86,11 @@ var configFileMapping = map[string]struct {
 	"docker-compose.yaml": {"Docker", "Docker Compose services"},
 
 	// CI/CD
-	".travis.yml":    {"CI/CD", "Travis CI configuration"},
-	".gitlab-ci.yml": {"CI/CD", "GitLab CI configuration"},
-	"Jenkinsfile":    {"CI/CD", "Jenkins pipeline"},
+	".travis.yml":          {"CI/CD", "Travis CI configuration"},
+	".gitlab-ci.yml":       {"CI/CD", "GitLab CI configuration"},
+	"Jenkinsfile":          {"CI/CD", "Jenkins pipeline"},
+	".circleci/config.yml": {"CI/CD", "CircleCI configuration"},
+	"azure-pipelines.yml":  {"CI/CD", "Azure Pipelines configuration"},
 
 	// Build
 	"Makefile":         {"Build", "Make build automation"},
@@ -12,8 +12,9 @@ import (
 
 // ConventionDetector detects coding conventions in a codebase
 type ConventionDetector struct {
-	rootPath string
-	files    []types.FileInfo
+	rootPath     string
+	files        []types.FileInfo
+	styleSamples map[string][]sampledFile
 }
 
 // NewConventionDetector creates a new convention detector
@@ -42,6 +43,9 @@ func (d *ConventionDetector) Detect() ([]types.Convention, error) {
 	// Detect file naming conventions
 	conventions = append(conventions, d.detectFileNaming()...)
 
+	// Detect identifier naming from declarations in the source
+	conventions = append(conventions, d.detectIdentifierNaming()...)
+
 	// Detect import styles
 	conventions = append(conventions, d.detectImportStyles()...)
 
@@ -54,9 +58,19 @@ func (d *ConventionDetector) Detect() ([]types.Convention, error) {
 	// Detect code style tools
 	conventions = append(conventions, d.detectCodeStyleTools()...)
 
+	// Detect concrete rules from linter and formatter configs
+	styleRules := d.detectLinterRules()
+	conventions = append(conventions, styleRules.conventions...)
+
+	// Infer the remaining style choices from the source itself
+	conventions = append(conventions, d.inferCodeStyle(styleRules)...)
+
 	// Detect component patterns
 	conventions = append(conventions, d.detectComponentPatterns()...)
 
+	// Detect notebook logic duplicated in the project's modules
+	conventions = append(conventions, d.detectNotebookDuplication()...)
+
 	return conventions, nil
 }
 
@@ -139,19 +153,19 @@ func (d *ConventionDetector) detectFileNaming() []types.Convention {
 
 	// Report dominant patterns
 	if pattern, count := dominantPattern(componentPatterns); count >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "naming",
 			Description: formatNamingConvention("Components", pattern),
 			Example:     getPatternExample(pattern, "UserCard"),
-		})
+		}, count, patternTotal(componentPatterns), count))
 	}
 
 	if pattern, count := dominantPattern(utilityPatterns); count >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "naming",
 			Description: formatNamingConvention("Utility files", pattern),
 			Example:     getPatternExample(pattern, "formatDate"),
-		})
+		}, count, patternTotal(utilityPatterns), count))
 	}
 
 	return conventions
@@ -182,19 +196,19 @@ func (d *ConventionDetector) detectImportStyles() []types.Convention {
 				}
 
 				if len(aliases) > 0 {
-					conventions = append(conventions, types.Convention{
+					conventions = append(conventions, configConvention(types.Convention{
 						Category:    "imports",
 						Description: "Path aliases configured: " + strings.Join(aliases, ", "),
 						Example:     "import { Button } from '" + aliases[0] + "/components/Button'",
-					})
+					}, "tsconfig.json"))
 				}
 			}
 
 			if tsconfig.CompilerOptions.BaseURL != "" {
-				conventions = append(conventions, types.Convention{
+				conventions = append(conventions, configConvention(types.Convention{
 					Category:    "imports",
 					Description: "Absolute imports enabled with baseUrl: " + tsconfig.CompilerOptions.BaseURL,
-				})
+				}, "tsconfig.json"))
 			}
 		}
 	}
@@ -203,6 +217,8 @@ func (d *ConventionDetector) detectImportStyles() []types.Convention {
 	atImports := 0
 	tildeImports := 0
 	relativeImports := 0
+	atFiles := 0
+	tildeFiles := 0
 	sampledFiles := 0
 
 	importRegex := regexp.MustCompile(`(?:import|from)\s+['"]([^'"]+)['"]`)
@@ -222,6 +238,7 @@ func (d *ConventionDetector) detectImportStyles() []types.Convention {
 		}
 
 		matches := importRegex.FindAllStringSubmatch(string(content), -1)
+		fileAt, fileTilde := atImports, tildeImports
 		for _, match := range matches {
 			if len(match) < 2 {
 				continue
@@ -236,22 +253,28 @@ func (d *ConventionDetector) detectImportStyles() []types.Convention {
 				relativeImports++
 			}
 		}
+		if atImports > fileAt {
+			atFiles++
+		}
+		if tildeImports > fileTilde {
+			tildeFiles++
+		}
 		sampledFiles++
 	}
 
 	// Report import style if clear pattern emerges
 	if atImports > relativeImports && atImports >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "imports",
 			Description: "Prefer @/ path alias for imports over relative paths",
 			Example:     "import { utils } from '@/lib/utils'",
-		})
+		}, atImports, atImports+relativeImports, atFiles))
 	} else if tildeImports > relativeImports && tildeImports >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "imports",
 			Description: "Prefer ~/ path alias for imports over relative paths",
 			Example:     "import { utils } from '~/lib/utils'",
-		})
+		}, tildeImports, tildeImports+relativeImports, tildeFiles))
 	}
 
 	return conventions
@@ -285,24 +308,24 @@ func (d *ConventionDetector) detectTypeScriptConfig() []types.Convention {
 	opts := tsconfig.CompilerOptions
 
 	if opts.Strict {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, configConvention(types.Convention{
 			Category:    "typescript",
 			Description: "TypeScript strict mode enabled - maintain strict type safety",
-		})
+		}, "tsconfig.json"))
 	}
 
 	if opts.NoImplicitAny {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, configConvention(types.Convention{
 			Category:    "typescript",
 			Description: "Explicit types required - avoid 'any' type",
-		})
+		}, "tsconfig.json"))
 	}
 
 	if opts.NoUnusedLocals || opts.NoUnusedParameters {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, configConvention(types.Convention{
 			Category:    "typescript",
 			Description: "Unused variables/parameters not allowed - clean up dead code",
-		})
+		}, "tsconfig.json"))
 	}
 
 	return conventions
@@ -347,37 +370,38 @@ func (d *ConventionDetector) detectTestPatterns() []types.Convention {
 	}
 
 	// Report test naming convention
+	testNamed := testFileCount + specFileCount + underscoreTestCount
 	if testFileCount > specFileCount && testFileCount > underscoreTestCount && testFileCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "testing",
 			Description: "Test files use .test suffix",
 			Example:     "Button.test.tsx, utils.test.ts",
-		})
+		}, testFileCount, testNamed, testFileCount))
 	} else if specFileCount > testFileCount && specFileCount > underscoreTestCount && specFileCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "testing",
 			Description: "Test files use .spec suffix",
 			Example:     "Button.spec.tsx, utils.spec.ts",
-		})
+		}, specFileCount, testNamed, specFileCount))
 	} else if underscoreTestCount > testFileCount && underscoreTestCount > specFileCount && underscoreTestCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "testing",
 			Description: "Test files use _test suffix (Go style)",
 			Example:     "handler_test.go, utils_test.go",
-		})
+		}, underscoreTestCount, testNamed, underscoreTestCount))
 	}
 
 	// Report test location convention
 	if colocatedTests > separateTestDir && colocatedTests >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "testing",
 			Description: "Tests are colocated with source files",
-		})
+		}, colocatedTests, colocatedTests+separateTestDir, colocatedTests))
 	} else if separateTestDir > colocatedTests && separateTestDir >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "testing",
 			Description: "Tests are in dedicated test directories",
-		})
+		}, separateTestDir, colocatedTests+separateTestDir, separateTestDir))
 	}
 
 	return conventions
@@ -394,10 +418,10 @@ func (d *ConventionDetector) detectCodeStyleTools() []types.Convention {
 	}
 	for _, f := range eslintFiles {
 		if _, err := os.Stat(filepath.Join(d.rootPath, f)); err == nil {
-			conventions = append(conventions, types.Convention{
+			conventions = append(conventions, configConvention(types.Convention{
 				Category:    "code-style",
 				Description: "ESLint configured - follow linting rules",
-			})
+			}, f))
 			break
 		}
 	}
@@ -409,35 +433,34 @@ func (d *ConventionDetector) detectCodeStyleTools() []types.Convention {
 	}
 	for _, f := range prettierFiles {
 		if _, err := os.Stat(filepath.Join(d.rootPath, f)); err == nil {
-			conventions = append(conventions, types.Convention{
+			conventions = append(conventions, configConvention(types.Convention{
 				Category:    "code-style",
 				Description: "Prettier configured - code formatting is automated",
-			})
+			}, f))
 			break
 		}
 	}
 
 	// Check for EditorConfig
 	if _, err := os.Stat(filepath.Join(d.rootPath, ".editorconfig")); err == nil {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, configConvention(types.Convention{
 			Category:    "code-style",
 			Description: "EditorConfig present - editor settings are standardized",
-		})
+		}, ".editorconfig"))
 	}
 
 	// Check for Go formatting
-	hasGo := false
+	goFiles := 0
 	for _, f := range d.files {
 		if f.Extension == ".go" {
-			hasGo = true
-			break
+			goFiles++
 		}
 	}
-	if hasGo {
-		conventions = append(conventions, types.Convention{
+	if goFiles > 0 {
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "code-style",
 			Description: "Go project - use 'go fmt' or 'gofmt' for formatting",
-		})
+		}, goFiles, 0, goFiles))
 	}
 
 	return conventions
@@ -479,10 +502,10 @@ func (d *ConventionDetector) detectComponentPatterns() []types.Convention {
 	// React conventions
 	if tsxCount > 0 || jsxCount > 0 {
 		if tsxCount > jsxCount {
-			conventions = append(conventions, types.Convention{
+			conventions = append(conventions, countedConvention(types.Convention{
 				Category:    "components",
 				Description: "React components use TypeScript (.tsx)",
-			})
+			}, tsxCount, tsxCount+jsxCount, tsxCount))
 		}
 
 		// Check for function vs class components by sampling files
@@ -511,36 +534,36 @@ func (d *ConventionDetector) detectComponentPatterns() []types.Convention {
 		}
 
 		if funcComponents >= 3 {
-			conventions = append(conventions, types.Convention{
+			conventions = append(conventions, countedConvention(types.Convention{
 				Category:    "components",
 				Description: "Use functional components (not class components)",
-			})
+			}, funcComponents, 0, funcComponents))
 		}
 	}
 
 	// Vue conventions
 	if vueCount > 0 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "components",
 			Description: "Vue single-file components (.vue)",
-		})
+		}, vueCount, 0, vueCount))
 	}
 
 	// Svelte conventions
 	if svelteCount > 0 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "components",
 			Description: "Svelte components (.svelte)",
-		})
+		}, svelteCount, 0, svelteCount))
 	}
 
 	// Barrel exports
 	if barrelExports >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "structure",
 			Description: "Components use barrel exports (index.ts) for cleaner imports",
 			Example:     "import { Button, Card } from '@/components'",
-		})
+		}, barrelExports, 0, barrelExports))
 	}
 
 	return conventions
@@ -608,6 +631,15 @@ func dominantPattern(patterns map[NamingPattern]int) (NamingPattern, int) {
 	return maxPattern, maxCount
 }
 
+// patternTotal counts every file that matched some naming pattern
+func patternTotal(patterns map[NamingPattern]int) int {
+	total := 0
+	for _, count := range patterns {
+		total += count
+	}
+	return total
+}
+
 func formatNamingConvention(fileType string, pattern NamingPattern) string {
 	return fileType + " use " + string(pattern) + " naming"
 }
@@ -0,0 +1,879 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Auth resolution works on middleware chains, decorators and dependency
+// declarations rather than on raw line content. authNameRegex is only ever
+// applied to the callee of a middleware, guard, decorator or dependency, so a
+// handler called getAuthorProfile no longer marks a route as protected.
+var (
+	authNameRegex = regexp.MustCompile(`(?i)(auth|jwt|token|login|logged_?in|signed_?in|session|guard|protect|permission|role|secured|api_?key|bearer|oauth|passport|clerk|claims|identity|current_?user|require_?user)`)
+
+	// nonAuthNameRegex excludes middleware that merely mention a session or token
+	nonAuthNameRegex = regexp.MustCompile(`(?i)(throttl|rate_?limit|cors|csrf|logger|logging|cache|compress|body_?parser|validat)`)
+
+	// publicMarkerRegex matches decorators/annotations that explicitly opt out of auth
+	publicMarkerRegex = regexp.MustCompile(`(?i)(^@(Public|SkipAuth|AllowAnonymous|IsPublic|PermitAll|AnonymousAllowed)\b|permitAll\(\)|\bAllowAny\b)`)
+
+	// Receiver-level middleware registration: app.use(...), r.Use(...)
+	jsUseRegex  = regexp.MustCompile(`(\w+)\.use\s*\(`)
+	jsHookRegex = regexp.MustCompile(`(\w+)\.addHook\s*\(\s*['"](?:onRequest|preHandler|preValidation)['"]\s*,`)
+	goUseRegex  = regexp.MustCompile(`(\w+)\.Use\s*\(`)
+
+	// Route groups that inherit (and may add) middleware: api := r.Group("/api", mw)
+	goGroupRegex = regexp.MustCompile(`(\w+)\s*:?=\s*(\w+)\.Group\s*\(`)
+
+	// Chi inline middleware: r.With(mw).Get(...)
+	chiWithRegex = regexp.MustCompile(`\.With\s*\(`)
+
+	// Python dependency injection: Depends(get_current_user), Security(oauth2_scheme)
+	pyDependsRegex = regexp.MustCompile(`\b(Depends|Security)\s*\(\s*([\w.]+)`)
+
+	// Route decorators stacked with guards; never treated as guards themselves
+	pyRouteDecoratorRegex = regexp.MustCompile(`^@[\w.]+\.(?:route|api_route|get|post|put|patch|delete)\s*\(`)
+
+	// FastAPI router-level dependencies: router = APIRouter(dependencies=[...])
+	pyRouterRegex = regexp.MustCompile(`(\w+)\s*=\s*(?:APIRouter|FastAPI)\s*\(`)
+
+	// Fastify route options hooks: { preHandler: [fastify.authenticate] }
+	fastifyHookRegex = regexp.MustCompile(`(?:preHandler|onRequest|preValidation)\s*:\s*(\[[^\]]*\]|[\w.]+(?:\([^)]*\))?)`)
+
+	// Next.js server-side session helpers used inside route handlers
+	nextAuthCallRegex = regexp.MustCompile(`\b(getServerSession|auth|currentUser|getAuth|withAuth|withApiAuthRequired|getToken)\s*\(`)
+
+	// Rust middleware applied to a router/scope builder chain
+	rustLayerRegex = regexp.MustCompile(`\.(?:wrap|layer|route_layer)\s*\(`)
+
+	// Rust function parameters: name: Type
+	rustParamRegex = regexp.MustCompile(`\w+\s*:\s*([\w:<>]+)`)
+
+	// Rust function declarations: fn name
+	rustFnDeclRegex = regexp.MustCompile(`\bfn\s+(\w+)\b`)
+
+	// Route registrations scanned for per-route guards anywhere in the project
+	jsRouteCallRegex = regexp.MustCompile(`\.(?:get|post|put|patch|delete|all)\s*\(`)
+	goRouteCallRegex = regexp.MustCompile(`\.(?:GET|POST|PUT|PATCH|DELETE|Get|Post|Put|Patch|Delete|Handle|HandleFunc|With)\s*\(`)
+)
+
+// routeAuthGuard is middleware registered for a router receiver, valid while
+// the brace depth at which it was registered is still open
+type routeAuthGuard struct {
+	receiver  string
+	prefix    string
+	mechanism string
+	depth     int
+}
+
+// routeAuthTracker follows app.use / r.Use / r.Group registrations through a
+// single file so routes can be matched with the middleware chain guarding them
+type routeAuthTracker struct {
+	guards []routeAuthGuard
+	depth  int
+}
+
+// newRouteAuthTracker creates a tracker for one source file
+func newRouteAuthTracker() *routeAuthTracker {
+	return &routeAuthTracker{}
+}
+
+// observeJS records Express/Koa/Hono/Fastify use() registrations on a line
+func (t *routeAuthTracker) observeJS(line string) {
+	for _, idx := range jsUseRegex.FindAllStringSubmatchIndex(line, -1) {
+		receiver := line[idx[2]:idx[3]]
+		args, _ := splitCallArgs(line[idx[1]:])
+		prefix := ""
+		if len(args) > 0 && isQuoted(args[0]) {
+			prefix = strings.TrimSuffix(unquote(args[0]), "*")
+			args = args[1:]
+		}
+		if mechanism := firstAuthMiddleware(args); mechanism != "" {
+			t.guards = append(t.guards, routeAuthGuard{receiver: receiver, prefix: prefix, mechanism: mechanism, depth: t.depth})
+		}
+	}
+
+	// Fastify hooks: fastify.addHook('onRequest', fastify.authenticate)
+	for _, idx := range jsHookRegex.FindAllStringSubmatchIndex(line, -1) {
+		receiver := line[idx[2]:idx[3]]
+		args, _ := splitCallArgs(line[idx[1]:])
+		if mechanism := firstAuthMiddleware(args); mechanism != "" {
+			t.guards = append(t.guards, routeAuthGuard{receiver: receiver, mechanism: mechanism, depth: t.depth})
+		}
+	}
+}
+
+// observeGo records Use() and Group() registrations for Gin, Echo, Fiber,
+// Chi and Gorilla Mux routers on a line
+func (t *routeAuthTracker) observeGo(line string) {
+	if match := goGroupRegex.FindStringSubmatchIndex(line); match != nil {
+		group := line[match[2]:match[3]]
+		parent := line[match[4]:match[5]]
+		mechanism := t.lookup(parent, "")
+		if args, _ := splitCallArgs(line[match[1]:]); len(args) > 1 {
+			if mw := firstAuthMiddleware(args[1:]); mw != "" {
+				mechanism = mw
+			}
+		}
+		if mechanism != "" {
+			t.guards = append(t.guards, routeAuthGuard{receiver: group, mechanism: mechanism, depth: t.depth})
+		}
+	}
+
+	for _, idx := range goUseRegex.FindAllStringSubmatchIndex(line, -1) {
+		receiver := line[idx[2]:idx[3]]
+		args, _ := splitCallArgs(line[idx[1]:])
+		if mechanism := firstAuthMiddleware(args); mechanism != "" {
+			t.guards = append(t.guards, routeAuthGuard{receiver: receiver, mechanism: mechanism, depth: t.depth})
+		}
+	}
+}
+
+// observePython records FastAPI router-level dependencies declared on lines[lineIdx]
+func (t *routeAuthTracker) observePython(lines []string, lineIdx int) {
+	line := lines[lineIdx]
+	match := pyRouterRegex.FindStringSubmatchIndex(line)
+	if match == nil {
+		return
+	}
+	args := routeCallArgs(lines, lineIdx, match[1]-1)
+	if mechanism := pythonDependencyAuth(strings.Join(args, ",")); mechanism != "" {
+		t.guards = append(t.guards, routeAuthGuard{receiver: line[match[2]:match[3]], mechanism: mechanism, depth: t.depth})
+	}
+}
+
+// advance updates the brace depth after a line and drops guards whose scope closed
+func (t *routeAuthTracker) advance(line string) {
+	t.depth += strings.Count(line, "{") - strings.Count(line, "}")
+	if t.depth < 0 {
+		t.depth = 0
+	}
+	kept := t.guards[:0]
+	for _, g := range t.guards {
+		if g.depth <= t.depth {
+			kept = append(kept, g)
+		}
+	}
+	t.guards = kept
+}
+
+// lookup returns the mechanism guarding routes on receiver with the given path
+func (t *routeAuthTracker) lookup(receiver, path string) string {
+	for i := len(t.guards) - 1; i >= 0; i-- {
+		g := t.guards[i]
+		if g.receiver != receiver {
+			continue
+		}
+		if g.prefix != "" && !strings.HasPrefix(path, g.prefix) {
+			continue
+		}
+		return g.mechanism
+	}
+	return ""
+}
+
+// resolve combines per-route middleware with receiver-level guards
+func (t *routeAuthTracker) resolve(receiver, path string, middleware []string) string {
+	if mechanism := firstAuthMiddleware(middleware); mechanism != "" {
+		return mechanism
+	}
+	return t.lookup(receiver, path)
+}
+
+// splitCallArgs splits the argument list of a call whose opening parenthesis
+// has already been consumed. It returns the top-level arguments and whether
+// the closing parenthesis was found.
+func splitCallArgs(s string) ([]string, bool) {
+	var args []string
+	var current strings.Builder
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			current.WriteByte(c)
+			if c == '\\' && i+1 < len(s) {
+				i++
+				current.WriteByte(s[i])
+				continue
+			}
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				if arg := strings.TrimSpace(current.String()); arg != "" {
+					args = append(args, arg)
+				}
+				return args, true
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				if arg := strings.TrimSpace(current.String()); arg != "" {
+					args = append(args, arg)
+				}
+				current.Reset()
+				continue
+			}
+		}
+		current.WriteByte(c)
+	}
+
+	if arg := strings.TrimSpace(current.String()); arg != "" {
+		args = append(args, arg)
+	}
+	return args, false
+}
+
+// routeCallArgs returns the arguments of the route call whose method name ends
+// at col on lines[lineIdx], joining following lines for multi-line calls
+func routeCallArgs(lines []string, lineIdx, col int) []string {
+	line := lines[lineIdx]
+	open := strings.Index(line[col:], "(")
+	if open < 0 {
+		return nil
+	}
+	text := line[col+open+1:]
+	for i := lineIdx + 1; i < len(lines) && i <= lineIdx+8; i++ {
+		if _, closed := splitCallArgs(text); closed {
+			break
+		}
+		text += "\n" + lines[i]
+	}
+	args, _ := splitCallArgs(text)
+	return args
+}
+
+// routeMiddleware returns the middleware between the path and the final
+// handler: app.get('/x', requireAuth, validate, handler)
+func routeMiddleware(args []string) []string {
+	if len(args) <= 2 {
+		return nil
+	}
+	return args[1 : len(args)-1]
+}
+
+// echoMiddleware returns the route-level middleware Echo takes after the
+// handler: e.GET("/x", handler, middleware.JWT(secret))
+func echoMiddleware(args []string) []string {
+	if len(args) <= 2 {
+		return nil
+	}
+	return args[2:]
+}
+
+// leadingIdentifier returns the identifier a statement starts with
+func leadingIdentifier(line string) string {
+	line = strings.TrimSpace(line)
+	end := 0
+	for end < len(line) && (line[end] == '_' || (line[end] >= 'a' && line[end] <= 'z') ||
+		(line[end] >= 'A' && line[end] <= 'Z') || (line[end] >= '0' && line[end] <= '9')) {
+		end++
+	}
+	return line[:end]
+}
+
+// wrappedHandlerAuth returns the auth wrapper around a handler argument:
+// http.Handle("/admin", requireAuth(adminHandler)). Plain handler names are
+// never treated as guards.
+func wrappedHandlerAuth(args []string) string {
+	for _, arg := range args {
+		if !strings.Contains(arg, "(") {
+			continue
+		}
+		if isAuthExpression(arg) {
+			return calleeName(arg)
+		}
+	}
+	return ""
+}
+
+// firstAuthMiddleware returns the first middleware expression that names an
+// auth guard, expanding array literals like [requireAuth, validate]
+func firstAuthMiddleware(middleware []string) string {
+	for _, mw := range middleware {
+		mw = strings.TrimSpace(mw)
+		if strings.HasPrefix(mw, "[") {
+			inner, _ := splitCallArgs(strings.TrimPrefix(mw, "["))
+			if mechanism := firstAuthMiddleware(inner); mechanism != "" {
+				return mechanism
+			}
+			continue
+		}
+		if strings.HasPrefix(mw, "{") {
+			if hook := fastifyHookRegex.FindStringSubmatch(mw); hook != nil {
+				if mechanism := firstAuthMiddleware([]string{hook[1]}); mechanism != "" {
+					return mechanism
+				}
+			}
+			continue
+		}
+		if isAuthExpression(mw) {
+			return compactMechanism(mw)
+		}
+	}
+	return ""
+}
+
+// isAuthExpression reports whether a middleware expression names an auth guard.
+// Only the callee is inspected, so arguments and inline handlers are ignored.
+func isAuthExpression(expr string) bool {
+	callee := calleeName(expr)
+	if callee == "" {
+		return false
+	}
+	return authNameRegex.MatchString(callee) && !nonAuthNameRegex.MatchString(callee)
+}
+
+// calleeName returns the dotted identifier an expression starts with:
+// passport.authenticate('jwt') -> passport.authenticate
+func calleeName(expr string) string {
+	expr = strings.TrimSpace(expr)
+	expr = strings.TrimPrefix(expr, "@")
+	expr = strings.TrimPrefix(expr, "&")
+	end := 0
+	for end < len(expr) {
+		c := expr[end]
+		if c == '.' || c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			end++
+			continue
+		}
+		break
+	}
+	// Arrow functions and anonymous handlers are never middleware names
+	rest := strings.TrimSpace(expr[end:])
+	if strings.HasPrefix(rest, "=>") || expr[:end] == "function" || expr[:end] == "func" || expr[:end] == "async" {
+		return ""
+	}
+	return strings.Trim(expr[:end], ".:")
+}
+
+// compactMechanism shortens a middleware expression for display
+func compactMechanism(expr string) string {
+	expr = strings.Join(strings.Fields(expr), " ")
+	if len(expr) > 60 {
+		expr = expr[:57] + "..."
+	}
+	return expr
+}
+
+// decoratorBlock returns the contiguous decorator/annotation lines around
+// lineIdx, stopping at the first line that is neither a decorator nor blank
+// continuation of one
+func decoratorBlock(lines []string, lineIdx int) []string {
+	isDecorator := func(s string) bool {
+		s = strings.TrimSpace(s)
+		return strings.HasPrefix(s, "@") || strings.HasPrefix(s, "#[")
+	}
+
+	start := lineIdx
+	for start > 0 && isDecorator(lines[start-1]) {
+		start--
+	}
+	end := lineIdx
+	for end+1 < len(lines) && isDecorator(lines[end+1]) {
+		end++
+	}
+
+	var block []string
+	for i := start; i <= end; i++ {
+		block = append(block, strings.TrimSpace(lines[i]))
+	}
+	return block
+}
+
+// decoratorAuth resolves auth from a decorator block. skip matches route
+// decorators which are never treated as guards (e.g. @auth_bp.route).
+// It returns the mechanism and whether the block explicitly marks the route public.
+func decoratorAuth(block []string, skip *regexp.Regexp) (string, bool) {
+	for _, dec := range block {
+		if publicMarkerRegex.MatchString(dec) {
+			return "", true
+		}
+	}
+	for _, dec := range block {
+		if skip != nil && skip.MatchString(dec) {
+			continue
+		}
+		name := calleeName(strings.TrimPrefix(dec, "#["))
+		if name == "UseGuards" {
+			args, _ := splitCallArgs(dec[strings.Index(dec, "(")+1:])
+			if guard := firstAuthMiddleware(args); guard != "" {
+				return "@UseGuards(" + guard + ")", false
+			}
+			continue
+		}
+		if mechanism := pythonDependencyAuth(dec); mechanism != "" {
+			return mechanism, false
+		}
+		if name != "" && authNameRegex.MatchString(name) && !nonAuthNameRegex.MatchString(name) {
+			return compactMechanism(dec), false
+		}
+	}
+	return "", false
+}
+
+// classDecoratorBlock returns the annotations on the nearest class declared
+// before lineIdx (Spring controllers, NestJS controllers)
+func classDecoratorBlock(lines []string, lineIdx int) []string {
+	for i := lineIdx; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.Contains(trimmed, "class ") && !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "*") {
+			if i == 0 {
+				return nil
+			}
+			return decoratorBlock(lines, i-1)
+		}
+	}
+	return nil
+}
+
+// pythonDependencyAuth returns the first Depends()/Security() dependency that
+// names an auth callable
+func pythonDependencyAuth(text string) string {
+	for _, match := range pyDependsRegex.FindAllStringSubmatch(text, -1) {
+		if authNameRegex.MatchString(match[2]) && !nonAuthNameRegex.MatchString(match[2]) {
+			return match[1] + "(" + match[2] + ")"
+		}
+		// Security() is always an auth dependency
+		if match[1] == "Security" {
+			return match[1] + "(" + match[2] + ")"
+		}
+	}
+	return ""
+}
+
+// pythonSignature returns the text of the def statement following a
+// decorator block starting at lineIdx
+func pythonSignature(lines []string, lineIdx int) string {
+	var sig strings.Builder
+	inDef := false
+	for i := lineIdx; i < len(lines) && i <= lineIdx+25; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !inDef {
+			if strings.HasPrefix(trimmed, "def ") || strings.HasPrefix(trimmed, "async def ") {
+				inDef = true
+			} else {
+				continue
+			}
+		}
+		sig.WriteString(trimmed)
+		sig.WriteString(" ")
+		if strings.HasSuffix(trimmed, ":") {
+			break
+		}
+	}
+	return sig.String()
+}
+
+// rustSignatureAuth checks the handler signature after an attribute route for
+// auth extractors (BearerAuth, Claims, AuthenticatedUser, ...)
+func rustSignatureAuth(lines []string, lineIdx int) string {
+	var sig strings.Builder
+	for i := lineIdx; i < len(lines) && i <= lineIdx+15; i++ {
+		sig.WriteString(lines[i])
+		if strings.Contains(lines[i], "{") {
+			break
+		}
+	}
+	text := sig.String()
+	fnIdx := strings.Index(text, "fn ")
+	if fnIdx < 0 {
+		return ""
+	}
+	for _, match := range rustParamRegex.FindAllStringSubmatch(text[fnIdx:], -1) {
+		typ := match[1]
+		if authNameRegex.MatchString(typ) && !nonAuthNameRegex.MatchString(typ) {
+			return typ
+		}
+	}
+	return ""
+}
+
+// rustFunctionLine returns the line declaring fn name, or -1
+func rustFunctionLine(lines []string, name string) int {
+	if idx := strings.LastIndex(name, "::"); idx >= 0 {
+		name = name[idx+2:]
+	}
+	if name == "" {
+		return -1
+	}
+	for i, line := range lines {
+		for _, match := range rustFnDeclRegex.FindAllStringSubmatch(line, -1) {
+			if match[1] == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// rustStatementAuth looks for auth middleware applied with .wrap/.layer/
+// .route_layer in the builder statement containing lineIdx
+func rustStatementAuth(lines []string, lineIdx int) string {
+	start := lineIdx
+	for start > 0 && !strings.HasSuffix(strings.TrimSpace(lines[start-1]), ";") && lineIdx-start < 30 {
+		start--
+	}
+	for i := start; i < len(lines) && i-lineIdx < 30; i++ {
+		for _, idx := range rustLayerRegex.FindAllStringIndex(lines[i], -1) {
+			args := routeCallArgs(lines, i, idx[1]-1)
+			for _, arg := range args {
+				if authNameRegex.MatchString(arg) && !nonAuthNameRegex.MatchString(arg) {
+					return compactMechanism(arg)
+				}
+			}
+		}
+		if strings.HasSuffix(strings.TrimSpace(lines[i]), ";") {
+			break
+		}
+	}
+	return ""
+}
+
+// isQuoted reports whether an argument is a string literal
+func isQuoted(s string) bool {
+	return len(s) >= 2 && strings.ContainsRune(`"'`+"`", rune(s[0])) && s[len(s)-1] == s[0]
+}
+
+// unquote strips the quotes from a string literal argument
+func unquote(s string) string {
+	if isQuoted(s) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// springSecurityRule is one authorizeHttpRequests/authorizeRequests matcher
+// from a SecurityFilterChain, evaluated in declaration order like Spring does
+type springSecurityRule struct {
+	patterns []*regexp.Regexp // empty for anyRequest()
+	access   string           // permitAll, authenticated, hasRole('ADMIN'), ...
+}
+
+var (
+	springMatcherRegex    = regexp.MustCompile(`\.(?:requestMatchers|antMatchers|mvcMatchers)\s*\(([^)]*)\)\s*\.(\w+)(\([^)]*\))?`)
+	springAnyRequestRegex = regexp.MustCompile(`\.anyRequest\s*\(\s*\)\s*\.(\w+)(\([^)]*\))?`)
+	quotedStringRegex     = regexp.MustCompile(`["']([^"']+)["']`)
+)
+
+// detectSpringSecurityRules collects URL authorization rules from Spring
+// Security configuration classes
+func (d *EndpointDetector) detectSpringSecurityRules() []springSecurityRule {
+	var rules []springSecurityRule
+
+	for _, f := range d.files {
+		if f.IsDir || (f.Extension != ".java" && f.Extension != ".kt") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil || len(content) > 500000 {
+			continue
+		}
+		contentStr := string(content)
+		if !strings.Contains(contentStr, "SecurityFilterChain") && !strings.Contains(contentStr, "WebSecurityConfigurerAdapter") {
+			continue
+		}
+
+		type located struct {
+			pos  int
+			rule springSecurityRule
+		}
+		var found []located
+		for _, m := range springMatcherRegex.FindAllStringSubmatchIndex(contentStr, -1) {
+			var patterns []*regexp.Regexp
+			for _, q := range quotedStringRegex.FindAllStringSubmatch(contentStr[m[2]:m[3]], -1) {
+				patterns = append(patterns, antPathRegex(q[1]))
+			}
+			access := contentStr[m[4]:m[5]]
+			if m[6] >= 0 && contentStr[m[6]:m[7]] != "()" {
+				access += contentStr[m[6]:m[7]]
+			}
+			found = append(found, located{m[0], springSecurityRule{patterns: patterns, access: access}})
+		}
+		for _, m := range springAnyRequestRegex.FindAllStringSubmatchIndex(contentStr, -1) {
+			access := contentStr[m[2]:m[3]]
+			if m[4] >= 0 && contentStr[m[4]:m[5]] != "()" {
+				access += contentStr[m[4]:m[5]]
+			}
+			found = append(found, located{m[0], springSecurityRule{access: access}})
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i].pos < found[j].pos })
+		for _, l := range found {
+			rules = append(rules, l.rule)
+		}
+	}
+
+	return rules
+}
+
+// resolveSpringSecurity returns the access rule for a path and whether any rule matched
+func resolveSpringSecurity(rules []springSecurityRule, path string) (string, bool) {
+	for _, rule := range rules {
+		if len(rule.patterns) == 0 {
+			return rule.access, true
+		}
+		for _, pattern := range rule.patterns {
+			if pattern.MatchString(path) {
+				return rule.access, true
+			}
+		}
+	}
+	return "", false
+}
+
+// antPathRegex compiles Spring's Ant-style patterns (/api/**, /users/*) once
+// per security rule
+func antPathRegex(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for rest := pattern; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, "/**"):
+			expr.WriteString("(/.*)?")
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "**"):
+			expr.WriteString(".*")
+			rest = rest[2:]
+		case rest[0] == '*':
+			expr.WriteString("[^/]*")
+			rest = rest[1:]
+		default:
+			r, size := utf8.DecodeRuneInString(rest)
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			rest = rest[size:]
+		}
+	}
+	expr.WriteString("/?$")
+	return regexp.MustCompile(expr.String())
+}
+
+var (
+	nestGlobalGuardRegex = regexp.MustCompile(`useGlobalGuards\s*\(([^)]*)\)`)
+	nestAppGuardRegex    = regexp.MustCompile(`provide\s*:\s*APP_GUARD\s*,\s*useClass\s*:\s*(\w+)`)
+)
+
+// detectNestGlobalGuard returns an auth guard registered for every route via
+// app.useGlobalGuards() or an APP_GUARD provider
+func (d *EndpointDetector) detectNestGlobalGuard() string {
+	for _, f := range d.files {
+		if f.IsDir || (f.Extension != ".ts" && f.Extension != ".js") || strings.Contains(f.Path, "node_modules") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil || len(content) > 500000 {
+			continue
+		}
+		contentStr := string(content)
+		for _, m := range nestAppGuardRegex.FindAllStringSubmatch(contentStr, -1) {
+			if isAuthExpression(m[1]) {
+				return m[1]
+			}
+		}
+		for _, m := range nestGlobalGuardRegex.FindAllStringSubmatch(contentStr, -1) {
+			args, _ := splitCallArgs(m[1] + ")")
+			for _, arg := range args {
+				guard := strings.TrimSpace(strings.TrimPrefix(arg, "new "))
+				if isAuthExpression(guard) {
+					return calleeName(guard)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	// Routers created in a file: const router = express.Router(), r := gin.Default()
+	jsRouterCtorRegex = regexp.MustCompile(`(?:const|let|var)\s+(\w+)\s*=\s*(?:new\s+)?(?:express(?:\.Router)?|Router|[Ff]astify|Koa|KoaRouter|Hono)\s*\(`)
+	goRouterCtorRegex = regexp.MustCompile(`(\w+)\s*:?=\s*(?:gin\.(?:Default|New)|echo\.New|fiber\.New|chi\.NewRouter|mux\.NewRouter|http\.NewServeMux)\s*\(`)
+	pyAppCtorRegex    = regexp.MustCompile(`(?m)^(\w+)\s*=\s*(?:FastAPI|Flask)\s*\(`)
+
+	// Routers handed to other modules, which may mount them behind more middleware
+	jsExportRegex     = regexp.MustCompile(`(?:module\.exports|exports\.\w+)\s*=\s*(\w+)|export\s+default\s+(\w+)|export\s+(?:const|let|var)\s+(\w+)`)
+	jsExportListRegex = regexp.MustCompile(`(?:module\.exports\s*=|export)\s*\{([^}]*)\}`)
+)
+
+// localReceivers returns the routers a file creates itself and does not
+// export. Every guard such a router can have is registered in that file, so
+// the per-file tracker sees all of them. APIRouter and Blueprint objects exist
+// to be mounted elsewhere and are never local.
+func localReceivers(content, ext string) map[string]bool {
+	local := make(map[string]bool)
+	switch ext {
+	case ".go":
+		for _, m := range goRouterCtorRegex.FindAllStringSubmatch(content, -1) {
+			local[m[1]] = true
+		}
+		// Groups of a local router are local too: api := r.Group("/api")
+		groups := goGroupRegex.FindAllStringSubmatch(content, -1)
+		for changed := true; changed; {
+			changed = false
+			for _, m := range groups {
+				if local[m[2]] && !local[m[1]] {
+					local[m[1]] = true
+					changed = true
+				}
+			}
+		}
+	case ".py":
+		for _, m := range pyAppCtorRegex.FindAllStringSubmatch(content, -1) {
+			local[m[1]] = true
+		}
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx":
+		for _, m := range jsRouterCtorRegex.FindAllStringSubmatch(content, -1) {
+			local[m[1]] = true
+		}
+		for _, m := range jsExportRegex.FindAllStringSubmatch(content, -1) {
+			for _, name := range m[1:] {
+				delete(local, name)
+			}
+		}
+		for _, m := range jsExportListRegex.FindAllStringSubmatch(content, -1) {
+			for _, name := range strings.Split(m[1], ",") {
+				name = strings.TrimSpace(name)
+				if idx := strings.Index(name, ":"); idx >= 0 {
+					name = strings.TrimSpace(name[idx+1:])
+				}
+				if fields := strings.Fields(name); len(fields) > 0 {
+					delete(local, fields[0])
+				}
+			}
+		}
+	}
+	return local
+}
+
+// isPublic reports whether a route without a resolved guard can be reported
+// as unauthenticated. Explicitly public routes always are. When the route's
+// router may pick up guards in another file (an exported or mounted router,
+// a group passed in from main.go) and another file registers an auth guard,
+// the route is left unresolved instead.
+func (d *EndpointDetector) isPublic(auth, file string, explicit, inherited bool) bool {
+	if auth != "" {
+		return false
+	}
+	return explicit || !inherited || !d.hasAuthGuardOutside(file)
+}
+
+// hasAuthGuardOutside reports whether a global guard or any source file other
+// than file registers an auth guard, scanning the project once per detector
+func (d *EndpointDetector) hasAuthGuardOutside(file string) bool {
+	if d.guardFiles == nil {
+		d.guardFiles = d.scanProjectAuthGuards()
+	}
+	if d.globalGuard {
+		return true
+	}
+	for path := range d.guardFiles {
+		if path != file {
+			return true
+		}
+	}
+	return false
+}
+
+// scanProjectAuthGuards returns the source files that register middleware,
+// route-level guards or auth decorators, and records security configuration
+// that applies to every route
+func (d *EndpointDetector) scanProjectAuthGuards() map[string]bool {
+	guardFiles := make(map[string]bool)
+	if d.detectNestGlobalGuard() != "" {
+		d.globalGuard = true
+	}
+	for _, rule := range d.detectSpringSecurityRules() {
+		if rule.access != "permitAll" {
+			d.globalGuard = true
+		}
+	}
+
+	for _, f := range d.files {
+		if f.IsDir || shouldSkipForEndpoints(f.Path) {
+			continue
+		}
+		switch f.Extension {
+		case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".go", ".py", ".rs", ".java", ".kt":
+		default:
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil || len(content) > 500000 {
+			continue
+		}
+		lines := strings.Split(string(content), "\n")
+		if linesRegisterAuthGuard(f.Extension, lines) {
+			guardFiles[f.Path] = true
+		}
+	}
+	return guardFiles
+}
+
+// linesRegisterAuthGuard reports whether a source file registers any auth
+// guard for its language
+func linesRegisterAuthGuard(ext string, lines []string) bool {
+	tracker := newRouteAuthTracker()
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "@") || strings.HasPrefix(trimmed, "#[") {
+			if auth, _ := decoratorAuth([]string{trimmed}, pyRouteDecoratorRegex); auth != "" {
+				return true
+			}
+		}
+
+		switch ext {
+		case ".go":
+			tracker.observeGo(line)
+			for _, idx := range goRouteCallRegex.FindAllStringIndex(line, -1) {
+				args := routeCallArgs(lines, i, idx[0])
+				if len(args) > 1 && (firstAuthMiddleware(args[1:]) != "" || wrappedHandlerAuth(args[1:]) != "") {
+					return true
+				}
+				if strings.HasSuffix(line[:idx[1]], "With(") && firstAuthMiddleware(args) != "" {
+					return true
+				}
+			}
+		case ".py":
+			tracker.observePython(lines, i)
+			if pythonDependencyAuth(line) != "" {
+				return true
+			}
+		case ".rs":
+			if rustLayerRegex.MatchString(line) && rustStatementAuth(lines, i) != "" {
+				return true
+			}
+			if strings.Contains(line, "fn ") && rustSignatureAuth(lines, i) != "" {
+				return true
+			}
+		case ".java", ".kt":
+		default:
+			tracker.observeJS(line)
+			for _, idx := range jsRouteCallRegex.FindAllStringIndex(line, -1) {
+				if firstAuthMiddleware(routeMiddleware(routeCallArgs(lines, i, idx[0]))) != "" {
+					return true
+				}
+			}
+		}
+
+		if len(tracker.guards) > 0 {
+			return true
+		}
+		tracker.advance(line)
+	}
+	return false
+}
@@ -0,0 +1,381 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func findEndpoint(endpoints []types.Endpoint, method, path string) *types.Endpoint {
+	for i := range endpoints {
+		if endpoints[i].Method == method && endpoints[i].Path == path {
+			return &endpoints[i]
+		}
+	}
+	return nil
+}
+
+func TestEndpointAuth_ExpressMiddlewareChain(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+		"routes.js": `const router = express.Router();
+
+router.get('/authors', getAuthorProfile);
+router.get('/me', requireAuth, getMe);
+router.post('/posts', [passport.authenticate('jwt'), validate], createPost);
+router.use('/admin', isAdminRole);
+router.delete('/admin/users/:id', deleteUser);
+`,
+	})
+
+	endpoints := NewEndpointDetector(tmpDir, files).detectExpressEndpoints()
+
+	tests := []struct {
+		method, path, auth string
+		public             bool
+	}{
+		{"GET", "/authors", "", true},
+		{"GET", "/me", "requireAuth", false},
+		{"POST", "/posts", "passport.authenticate('jwt')", false},
+		{"DELETE", "/admin/users/:id", "isAdminRole", false},
+	}
+
+	for _, tt := range tests {
+		ep := findEndpoint(endpoints, tt.method, tt.path)
+		if ep == nil {
+			t.Errorf("expected %s %s endpoint", tt.method, tt.path)
+			continue
+		}
+		if ep.Auth != tt.auth || ep.Public != tt.public {
+			t.Errorf("%s %s: got auth=%q public=%v, expected auth=%q public=%v",
+				tt.method, tt.path, ep.Auth, ep.Public, tt.auth, tt.public)
+		}
+	}
+}
+
+func TestEndpointAuth_GinGroupsAndChiScopes(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"go.mod": "module test\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.0\n\tgithub.com/go-chi/chi/v5 v5.0.10\n)\n",
+		"gin_routes.go": `package routes
+
+import "github.com/gin-gonic/gin"
+
+func Setup(r *gin.Engine) {
+	r.GET("/health", Health)
+	api := r.Group("/api", AuthRequired())
+	admin := api.Group("/admin")
+	api.GET("/profile", Profile)
+	admin.DELETE("/users/:id", DeleteUser)
+}
+`,
+		"chi_routes.go": `package routes
+
+func Chi(r chi.Router) {
+	r.Get("/public", Public)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Authenticator)
+		r.Get("/private", Private)
+	})
+	r.With(RequireSession).Post("/logout", Logout)
+	r.Get("/after", After)
+}
+`,
+	})
+
+	d := NewEndpointDetector(tmpDir, files)
+	gin := d.detectGinEndpoints()
+	if ep := findEndpoint(gin, "GET", "/health"); ep == nil || ep.Auth != "" || ep.Public {
+		t.Errorf("expected /health unresolved, got %+v", ep)
+	}
+	if ep := findEndpoint(gin, "GET", "/profile"); ep == nil || ep.Auth != "AuthRequired()" {
+		t.Errorf("expected /profile guarded by AuthRequired(), got %+v", ep)
+	}
+	if ep := findEndpoint(gin, "DELETE", "/users/:id"); ep == nil || ep.Auth != "AuthRequired()" {
+		t.Errorf("expected nested group to inherit AuthRequired(), got %+v", ep)
+	}
+
+	chi := d.detectChiEndpoints()
+	if ep := findEndpoint(chi, "GET", "/private"); ep == nil || ep.Auth != "jwtauth.Authenticator" {
+		t.Errorf("expected /private guarded inside group, got %+v", ep)
+	}
+	if ep := findEndpoint(chi, "POST", "/logout"); ep == nil || ep.Auth != "RequireSession" {
+		t.Errorf("expected With() middleware on /logout, got %+v", ep)
+	}
+	for _, path := range []string{"/public", "/after"} {
+		if ep := findEndpoint(chi, "GET", path); ep == nil || ep.Auth != "" || ep.Public {
+			t.Errorf("expected %s unresolved outside the group, got %+v", path, ep)
+		}
+	}
+}
+
+func TestEndpointAuth_PythonDecoratorsAndDepends(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"requirements.txt": "fastapi\nflask\n",
+		"api.py": `from fastapi import APIRouter, Depends
+
+router = APIRouter()
+
+@router.get("/items")
+async def list_items(db: Session = Depends(get_db)):
+    pass
+
+@router.get("/me")
+async def me(
+    user: User = Depends(get_current_user),
+):
+    pass
+
+@router.post("/admin", dependencies=[Depends(verify_admin_token)])
+async def admin():
+    pass
+`,
+		"views.py": `from flask import Flask
+
+@app.route("/login", methods=["POST"])
+def login():
+    pass
+
+@app.route("/dashboard")
+@login_required
+def dashboard():
+    pass
+`,
+	})
+
+	d := NewEndpointDetector(tmpDir, files)
+	fastapi := d.detectFastAPIEndpoints()
+	if ep := findEndpoint(fastapi, "GET", "/items"); ep == nil || ep.Auth != "" || ep.Public {
+		t.Errorf("expected Depends(get_db) not to count as auth, got %+v", ep)
+	}
+	if ep := findEndpoint(fastapi, "GET", "/me"); ep == nil || ep.Auth != "Depends(get_current_user)" {
+		t.Errorf("expected signature dependency, got %+v", ep)
+	}
+	if ep := findEndpoint(fastapi, "POST", "/admin"); ep == nil || ep.Auth != "Depends(verify_admin_token)" {
+		t.Errorf("expected route dependencies, got %+v", ep)
+	}
+
+	flask := d.detectFlaskEndpoints()
+	if ep := findEndpoint(flask, "POST", "/login"); ep == nil || ep.Auth != "" || ep.Public {
+		t.Errorf("expected /login unresolved, got %+v", ep)
+	}
+	if ep := findEndpoint(flask, "GET", "/dashboard"); ep == nil || ep.Auth != "@login_required" {
+		t.Errorf("expected @login_required, got %+v", ep)
+	}
+}
+
+func TestEndpointAuth_SpringAndNestJS(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"pom.xml":      "<artifactId>spring-boot-starter-web</artifactId>",
+		"package.json": `{"dependencies": {"@nestjs/core": "^10.0.0"}}`,
+		"src/main/java/UserController.java": `@RestController
+@RequestMapping("/users")
+public class UserController {
+    @GetMapping("/me")
+    @PreAuthorize("isAuthenticated()")
+    public User me() {}
+
+    @PermitAll
+    @GetMapping("/count")
+    public int count() {}
+}
+`,
+		"src/main/java/SecurityConfig.java": `public class SecurityConfig {
+    SecurityFilterChain chain(HttpSecurity http) {
+        http.authorizeHttpRequests(a -> a
+            .requestMatchers("/public/**").permitAll()
+            .anyRequest().authenticated());
+    }
+}
+`,
+		"src/main/java/InfoController.java": `@RestController
+public class InfoController {
+    @GetMapping("/public/info")
+    public String info() {}
+
+    @GetMapping("/stats")
+    public String stats() {}
+}
+`,
+		"src/cats.controller.ts": `@Controller('cats')
+@UseGuards(JwtAuthGuard)
+export class CatsController {
+  @Get(':id')
+  findOne() {}
+
+  @Public()
+  @Get('featured')
+  featured() {}
+}
+`,
+	})
+
+	d := NewEndpointDetector(tmpDir, files)
+	spring := d.detectSpringEndpoints()
+	if ep := findEndpoint(spring, "GET", "/users/me"); ep == nil || ep.Auth != `@PreAuthorize("isAuthenticated()")` {
+		t.Errorf("expected @PreAuthorize on /users/me, got %+v", ep)
+	}
+	if ep := findEndpoint(spring, "GET", "/users/count"); ep == nil || !ep.Public {
+		t.Errorf("expected @PermitAll to mark /users/count public, got %+v", ep)
+	}
+	if ep := findEndpoint(spring, "GET", "/public/info"); ep == nil || !ep.Public {
+		t.Errorf("expected permitAll matcher for /public/info, got %+v", ep)
+	}
+	if ep := findEndpoint(spring, "GET", "/stats"); ep == nil || ep.Auth != "SecurityFilterChain: authenticated" {
+		t.Errorf("expected anyRequest().authenticated() for /stats, got %+v", ep)
+	}
+
+	nest := d.detectNestJSEndpoints()
+	if ep := findEndpoint(nest, "GET", "/cats/:id"); ep == nil || ep.Auth != "@UseGuards(JwtAuthGuard)" {
+		t.Errorf("expected controller guard on /cats/:id, got %+v", ep)
+	}
+	if ep := findEndpoint(nest, "GET", "/cats/featured"); ep == nil || !ep.Public {
+		t.Errorf("expected @Public() to override controller guard, got %+v", ep)
+	}
+}
+
+func TestEndpointAuth_GuardRegisteredInAnotherFile(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+		"app.js": `const app = express();
+app.use(authMiddleware);
+app.use('/api', require('./routes'));
+`,
+		"routes.js": `const router = express.Router();
+router.get('/orders', listOrders);
+module.exports = router;
+`,
+		"go.mod": "module test\n\nrequire github.com/gin-gonic/gin v1.9.0\n",
+		"main.go": `package main
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api", JWTMiddleware())
+	registerRoutes(api)
+}
+`,
+		"routes.go": `package main
+
+func registerRoutes(api *gin.RouterGroup) {
+	api.GET("/invoices", ListInvoices)
+}
+`,
+	})
+
+	d := NewEndpointDetector(tmpDir, files)
+	if ep := findEndpoint(d.detectExpressEndpoints(), "GET", "/orders"); ep == nil || ep.Public {
+		t.Errorf("expected app.use(authMiddleware) in app.js to keep /orders off the public list, got %+v", ep)
+	}
+	if ep := findEndpoint(d.detectGinEndpoints(), "GET", "/invoices"); ep == nil || ep.Public {
+		t.Errorf("expected group middleware in main.go to keep /invoices off the public list, got %+v", ep)
+	}
+}
+
+func TestEndpointAuth_MixedGuardsInOneFile(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+		"routes.js": `const express = require('express');
+const router = express.Router();
+
+router.get('/admin', requireAuth, adminPanel);
+router.get('/health', health);
+
+const app = express();
+app.use('/', router);
+app.listen(3000);
+`,
+		"billing.js": `const router = express.Router();
+router.use(requireSession);
+router.get('/invoices', listInvoices);
+`,
+	})
+
+	endpoints := NewEndpointDetector(tmpDir, files).detectExpressEndpoints()
+	if ep := findEndpoint(endpoints, "GET", "/admin"); ep == nil || ep.Auth != "requireAuth" {
+		t.Errorf("expected /admin guarded by requireAuth, got %+v", ep)
+	}
+	if ep := findEndpoint(endpoints, "GET", "/health"); ep == nil || ep.Auth != "" || !ep.Public {
+		t.Errorf("expected /health public on a router whose guards are all in routes.js, got %+v", ep)
+	}
+	if ep := findEndpoint(endpoints, "GET", "/invoices"); ep == nil || ep.Auth != "requireSession" {
+		t.Errorf("expected /invoices guarded by requireSession, got %+v", ep)
+	}
+}
+
+func TestLocalReceivers(t *testing.T) {
+	tests := []struct {
+		name, ext, content string
+		expected           map[string]bool
+	}{
+		{"express exported", ".js", "const router = express.Router();\nmodule.exports = router;\n", map[string]bool{}},
+		{"express export list", ".ts", "const api = Router();\nconst app = express();\nexport { api as default };\n", map[string]bool{"app": true}},
+		{"gin groups", ".go", "r := gin.Default()\napi := r.Group(\"/api\")\nv1 := api.Group(\"/v1\")\nother := param.Group(\"/x\")\n", map[string]bool{"r": true, "api": true, "v1": true}},
+		{"fastapi router", ".py", "app = FastAPI()\nrouter = APIRouter()\n", map[string]bool{"app": true}},
+	}
+	for _, tt := range tests {
+		if got := localReceivers(tt.content, tt.ext); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: localReceivers() = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestEndpointAuth_NoGuardsAnywhere(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+		"app.js": `const app = express();
+app.use(cors());
+app.use(rateLimit({ windowMs: 60000 }));
+app.get('/status', getStatus);
+app.post('/feedback', validate, postFeedback);
+`,
+	})
+
+	endpoints := NewEndpointDetector(tmpDir, files).detectExpressEndpoints()
+	for _, path := range []string{"/status", "/feedback"} {
+		var ep *types.Endpoint
+		for _, method := range []string{"GET", "POST"} {
+			if found := findEndpoint(endpoints, method, path); found != nil {
+				ep = found
+			}
+		}
+		if ep == nil || !ep.Public {
+			t.Errorf("expected %s public when no auth guard exists, got %+v", path, ep)
+		}
+	}
+}
+
+func TestSplitCallArgs(t *testing.T) {
+	args, closed := splitCallArgs(`'/x', [a, b(c, d)], { preHandler: e }, h) // tail`)
+	if !closed {
+		t.Fatal("expected call to be closed")
+	}
+	expected := []string{"'/x'", "[a, b(c, d)]", "{ preHandler: e }", "h"}
+	if len(args) != len(expected) {
+		t.Fatalf("expected %d args, got %d: %v", len(expected), len(args), args)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("arg %d: expected %q, got %q", i, expected[i], args[i])
+		}
+	}
+}
+
+func TestAntPathRegex(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		expected      bool
+	}{
+		{"/api/**", "/api/users/1", true},
+		{"/api/**", "/api", true},
+		{"/users/*", "/users/{id}", true},
+		{"/users/*", "/users/1/posts", false},
+		{"/health", "/healthz", false},
+		{"/café/*", "/café/menu", true},
+		{"/naïve/**", "/naïve/a/b", true},
+	}
+	for _, tt := range tests {
+		if got := antPathRegex(tt.pattern).MatchString(tt.path); got != tt.expected {
+			t.Errorf("antPathRegex(%q, %q) = %v, expected %v", tt.pattern, tt.path, got, tt.expected)
+		}
+	}
+}
@@ -14,6 +14,10 @@ import (
 type EndpointDetector struct {
 	rootPath string
 	files    []types.FileInfo
+
+	// Auth guards found by scanning the whole project, computed on first use
+	guardFiles  map[string]bool
+	globalGuard bool
 }
 
 // NewEndpointDetector creates a new endpoint detector
@@ -125,11 +129,8 @@ func (d *EndpointDetector) detectExpressEndpoints() []types.Endpoint {
 
 	// Patterns for Express routes
 	// app.get('/path', handler)
-	// router.get('/path', handler)
-	routeRegex := regexp.MustCompile(`(?:app|router)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
-
-	// Check for auth middleware
-	authMiddlewareRegex := regexp.MustCompile(`(?:authenticate|auth|protect|requireAuth|isAuthenticated|verifyToken|jwt)`)
+	// router.get('/path', requireAuth, handler)
+	routeRegex := regexp.MustCompile(`(app|router)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
 
 	for _, f := range d.files {
 		if f.IsDir {
@@ -152,30 +153,28 @@ func (d *EndpointDetector) detectExpressEndpoints() []types.Endpoint {
 
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					method := strings.ToUpper(match[1])
-					path := match[2]
-
-					// Check if line has auth middleware
-					auth := ""
-					if authMiddlewareRegex.MatchString(line) {
-						auth = "Required"
-					}
+			tracker.observeJS(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				receiver := line[loc[2]:loc[3]]
+				path := line[loc[6]:loc[7]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
 
-					endpoints = append(endpoints, types.Endpoint{
-						Method:  method,
-						Path:    path,
-						File:    f.Path,
-						Line:    lineNum + 1,
-						Auth:    auth,
-						Handler: extractHandlerName(line),
-					})
-				}
+				endpoints = append(endpoints, types.Endpoint{
+					Method:  strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:    path,
+					File:    f.Path,
+					Line:    lineNum + 1,
+					Auth:    auth,
+					Public:  d.isPublic(auth, f.Path, false, !local[receiver]),
+					Handler: extractHandlerName(line),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -190,7 +189,7 @@ func (d *EndpointDetector) detectFastifyEndpoints() []types.Endpoint {
 		return endpoints
 	}
 
-	routeRegex := regexp.MustCompile(`(?:fastify|app|server)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
+	routeRegex := regexp.MustCompile(`(fastify|app|server)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
 
 	for _, f := range d.files {
 		if f.IsDir || (f.Extension != ".js" && f.Extension != ".ts") {
@@ -208,19 +207,26 @@ func (d *EndpointDetector) detectFastifyEndpoints() []types.Endpoint {
 
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   match[2],
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
-				}
+			tracker.observeJS(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				receiver := line[loc[2]:loc[3]]
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -235,6 +241,16 @@ func (d *EndpointDetector) detectNextJSEndpoints() []types.Endpoint {
 		return endpoints
 	}
 
+	// A root middleware.ts can guard any route, so handlers without their own
+	// session check are only reported public when no middleware exists
+	hasMiddleware := false
+	for _, f := range d.files {
+		if f.Path == "middleware.ts" || f.Path == "middleware.js" ||
+			f.Path == "src/middleware.ts" || f.Path == "src/middleware.js" {
+			hasMiddleware = true
+		}
+	}
+
 	for _, f := range d.files {
 		if f.IsDir {
 			continue
@@ -243,10 +259,13 @@ func (d *EndpointDetector) detectNextJSEndpoints() []types.Endpoint {
 		// Pages Router: pages/api/**/*.ts
 		if strings.Contains(f.Path, "pages/api/") || strings.Contains(f.Path, "src/pages/api/") {
 			path := extractNextPagesAPIPath(f.Path)
+			auth := d.detectNextHandlerAuth(f.Path)
 			endpoints = append(endpoints, types.Endpoint{
 				Method: "ALL",
 				Path:   path,
 				File:   f.Path,
+				Auth:   auth,
+				Public: auth == "" && !hasMiddleware,
 			})
 		}
 
@@ -255,11 +274,14 @@ func (d *EndpointDetector) detectNextJSEndpoints() []types.Endpoint {
 			(strings.Contains(f.Path, "/app/") || strings.Contains(f.Path, "src/app/")) {
 			path := extractNextAppAPIPath(f.Path)
 			methods := d.detectNextAppRouterMethods(filepath.Join(d.rootPath, f.Path))
+			auth := d.detectNextHandlerAuth(f.Path)
 			for _, method := range methods {
 				endpoints = append(endpoints, types.Endpoint{
 					Method: method,
 					Path:   path,
 					File:   f.Path,
+					Auth:   auth,
+					Public: auth == "" && !hasMiddleware,
 				})
 			}
 		}
@@ -295,6 +317,19 @@ func extractNextAppAPIPath(filePath string) string {
 	return path
 }
 
+// detectNextHandlerAuth returns the session helper a Next.js route handler
+// calls (getServerSession, auth, currentUser, ...), if any
+func (d *EndpointDetector) detectNextHandlerAuth(relPath string) string {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil {
+		return ""
+	}
+	if match := nextAuthCallRegex.FindStringSubmatch(string(content)); match != nil {
+		return match[1] + "()"
+	}
+	return ""
+}
+
 func (d *EndpointDetector) detectNextAppRouterMethods(filePath string) []string {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -335,7 +370,7 @@ func (d *EndpointDetector) detectFastAPIEndpoints() []types.Endpoint {
 	}
 
 	// @app.get("/path") or @router.get("/path")
-	routeRegex := regexp.MustCompile(`@(?:app|router)\.(get|post|put|patch|delete)\s*\(\s*["']([^"']+)["']`)
+	routeRegex := regexp.MustCompile(`@(app|router)\.(get|post|put|patch|delete)\s*\(\s*["']([^"']+)["']`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".py" {
@@ -350,19 +385,38 @@ func (d *EndpointDetector) detectFastAPIEndpoints() []types.Endpoint {
 
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   match[2],
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
+			tracker.observePython(lines, lineNum)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				// Route-level dependencies=[Depends(...)], stacked decorators,
+				// then parameters like user: User = Depends(get_current_user)
+				receiver := line[loc[2]:loc[3]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				auth := pythonDependencyAuth(strings.Join(args, ","))
+				public := false
+				if auth == "" {
+					auth, public = decoratorAuth(decoratorBlock(lines, lineNum), pyRouteDecoratorRegex)
+				}
+				if auth == "" {
+					auth = pythonDependencyAuth(pythonSignature(lines, lineNum))
+				}
+				if auth == "" {
+					auth = tracker.lookup(receiver, "")
 				}
+
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   line[loc[6]:loc[7]],
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, public, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -378,7 +432,7 @@ func (d *EndpointDetector) detectFlaskEndpoints() []types.Endpoint {
 	}
 
 	// @app.route("/path", methods=["GET", "POST"])
-	routeRegex := regexp.MustCompile(`@(?:app|blueprint|bp)\.route\s*\(\s*["']([^"']+)["'](?:.*methods\s*=\s*\[([^\]]+)\])?`)
+	routeRegex := regexp.MustCompile(`@(app|blueprint|bp)\.route\s*\(\s*["']([^"']+)["'](?:.*methods\s*=\s*\[([^\]]+)\])?`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".py" {
@@ -393,17 +447,22 @@ func (d *EndpointDetector) detectFlaskEndpoints() []types.Endpoint {
 
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
 			matches := routeRegex.FindAllStringSubmatch(line, -1)
 			for _, match := range matches {
-				if len(match) >= 2 {
-					path := match[1]
+				if len(match) >= 3 {
+					receiver := match[1]
+					path := match[2]
 					methods := []string{"GET"} // Default
 
-					if len(match) >= 3 && match[2] != "" {
+					// @login_required, @jwt_required(), @auth.login_required, ...
+					auth, public := decoratorAuth(decoratorBlock(lines, lineNum), pyRouteDecoratorRegex)
+
+					if len(match) >= 4 && match[3] != "" {
 						// Parse methods from ["GET", "POST"]
-						methodStr := strings.ReplaceAll(match[2], "'", "")
+						methodStr := strings.ReplaceAll(match[3], "'", "")
 						methodStr = strings.ReplaceAll(methodStr, "\"", "")
 						methodStr = strings.ReplaceAll(methodStr, " ", "")
 						methods = strings.Split(methodStr, ",")
@@ -415,6 +474,8 @@ func (d *EndpointDetector) detectFlaskEndpoints() []types.Endpoint {
 							Path:   path,
 							File:   f.Path,
 							Line:   lineNum + 1,
+							Auth:   auth,
+							Public: d.isPublic(auth, f.Path, public, !local[receiver]),
 						})
 					}
 				}
@@ -436,6 +497,10 @@ func (d *EndpointDetector) detectDjangoEndpoints() []types.Endpoint {
 	// Look for urls.py files
 	urlPathRegex := regexp.MustCompile(`path\s*\(\s*["']([^"']+)["']`)
 
+	// Only views wrapped in urls.py (login_required(views.profile)) can be
+	// resolved here; unwrapped views may still be guarded in the view itself,
+	// so they are left unresolved rather than reported public.
+
 	for _, f := range d.files {
 		if f.IsDir || f.Name != "urls.py" {
 			continue
@@ -451,17 +516,20 @@ func (d *EndpointDetector) detectDjangoEndpoints() []types.Endpoint {
 		lines := strings.Split(contentStr, "\n")
 
 		for lineNum, line := range lines {
-			matches := urlPathRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 2 {
-					path := "/" + match[1]
-					endpoints = append(endpoints, types.Endpoint{
-						Method: "ALL",
-						Path:   path,
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
+			for _, loc := range urlPathRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := "/" + line[loc[2]:loc[3]]
+				args := routeCallArgs(lines, lineNum, loc[0])
+				var auth string
+				if len(args) > 1 {
+					auth = wrappedHandlerAuth(args[1:2])
 				}
+				endpoints = append(endpoints, types.Endpoint{
+					Method: "ALL",
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+				})
 			}
 		}
 	}
@@ -481,6 +549,10 @@ func (d *EndpointDetector) detectSpringEndpoints() []types.Endpoint {
 	mappingRegex := regexp.MustCompile(`@(Get|Post|Put|Patch|Delete)Mapping\s*\(\s*(?:value\s*=\s*)?["']?([^"'\)]+)["']?\s*\)`)
 	requestMappingRegex := regexp.MustCompile(`@RequestMapping\s*\(\s*(?:value\s*=\s*)?["']([^"']+)["']`)
 	classRequestMapping := regexp.MustCompile(`@RequestMapping\s*\(\s*["']([^"']+)["']\s*\)`)
+	mappingDecoratorRegex := regexp.MustCompile(`^@\w*Mapping\b`)
+
+	// URL rules from a SecurityFilterChain apply when no annotation guards the method
+	securityRules := d.detectSpringSecurityRules()
 
 	for _, f := range d.files {
 		if f.IsDir || (f.Extension != ".java" && f.Extension != ".kt") {
@@ -515,12 +587,14 @@ func (d *EndpointDetector) detectSpringEndpoints() []types.Endpoint {
 					if !strings.HasPrefix(path, "/") {
 						path = "/" + path
 					}
-					endpoints = append(endpoints, types.Endpoint{
+					ep := types.Endpoint{
 						Method: method,
 						Path:   path,
 						File:   f.Path,
 						Line:   lineNum + 1,
-					})
+					}
+					d.resolveSpringAuth(&ep, lines, lineNum, mappingDecoratorRegex, securityRules)
+					endpoints = append(endpoints, ep)
 				}
 			}
 
@@ -531,12 +605,14 @@ func (d *EndpointDetector) detectSpringEndpoints() []types.Endpoint {
 					if !strings.HasPrefix(path, "/") {
 						path = "/" + path
 					}
-					endpoints = append(endpoints, types.Endpoint{
+					ep := types.Endpoint{
 						Method: "ALL",
 						Path:   path,
 						File:   f.Path,
 						Line:   lineNum + 1,
-					})
+					}
+					d.resolveSpringAuth(&ep, lines, lineNum, mappingDecoratorRegex, securityRules)
+					endpoints = append(endpoints, ep)
 				}
 			}
 		}
@@ -545,6 +621,29 @@ func (d *EndpointDetector) detectSpringEndpoints() []types.Endpoint {
 	return endpoints
 }
 
+// resolveSpringAuth sets Auth/Public on a Spring endpoint from method-level
+// annotations, then controller annotations, then SecurityFilterChain rules
+func (d *EndpointDetector) resolveSpringAuth(ep *types.Endpoint, lines []string, lineIdx int, skip *regexp.Regexp, rules []springSecurityRule) {
+	auth, public := decoratorAuth(decoratorBlock(lines, lineIdx), skip)
+	if auth == "" && !public {
+		auth, public = decoratorAuth(classDecoratorBlock(lines, lineIdx), skip)
+	}
+	if auth == "" && !public && len(rules) > 0 {
+		access, matched := resolveSpringSecurity(rules, ep.Path)
+		if !matched {
+			// Configured security without a matching rule: leave unresolved
+			return
+		}
+		if access == "permitAll" {
+			public = true
+		} else {
+			auth = "SecurityFilterChain: " + access
+		}
+	}
+	ep.Auth = auth
+	ep.Public = d.isPublic(auth, ep.File, public, false)
+}
+
 // detectGinEndpoints detects Gin (Go) endpoints
 func (d *EndpointDetector) detectGinEndpoints() []types.Endpoint {
 	var endpoints []types.Endpoint
@@ -553,9 +652,9 @@ func (d *EndpointDetector) detectGinEndpoints() []types.Endpoint {
 		return endpoints
 	}
 
-	// r.GET("/path", handler) or group.GET("/path", handler)
+	// r.GET("/path", handler) or group.GET("/path", authMiddleware(), handler)
 	// Requires path to start with / to avoid false positives
-	routeRegex := regexp.MustCompile(`\w\.(GET|POST|PUT|PATCH|DELETE)\s*\(\s*["'](/[^"']+)["']`)
+	routeRegex := regexp.MustCompile(`(\w+)\.(GET|POST|PUT|PATCH|DELETE)\s*\(\s*["'](/[^"']+)["']`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".go" || shouldSkipForEndpoints(f.Path) {
@@ -575,22 +674,29 @@ func (d *EndpointDetector) detectGinEndpoints() []types.Endpoint {
 
 		lines := strings.Split(contentStr, "\n")
 
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
+
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					path := match[2]
-					if !isValidEndpointPath(path) {
-						continue
-					}
-					endpoints = append(endpoints, types.Endpoint{
-						Method: match[1],
-						Path:   path,
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
+			tracker.observeGo(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				if !isValidEndpointPath(path) {
+					continue
 				}
+				receiver := line[loc[2]:loc[3]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: line[loc[4]:loc[5]],
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -605,8 +711,9 @@ func (d *EndpointDetector) detectEchoEndpoints() []types.Endpoint {
 		return endpoints
 	}
 
+	// e.GET("/path", handler, middleware...) or g.GET(...) on a route group
 	// Require path to start with / to avoid false positives
-	routeRegex := regexp.MustCompile(`e\.(GET|POST|PUT|PATCH|DELETE)\s*\(\s*["'](/[^"']+)["']`)
+	routeRegex := regexp.MustCompile(`(\w+)\.(GET|POST|PUT|PATCH|DELETE)\s*\(\s*["'](/[^"']+)["']`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".go" || shouldSkipForEndpoints(f.Path) {
@@ -622,22 +729,29 @@ func (d *EndpointDetector) detectEchoEndpoints() []types.Endpoint {
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
 
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
+
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					path := match[2]
-					if !isValidEndpointPath(path) {
-						continue
-					}
-					endpoints = append(endpoints, types.Endpoint{
-						Method: match[1],
-						Path:   path,
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
+			tracker.observeGo(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				if !isValidEndpointPath(path) {
+					continue
 				}
+				receiver := line[loc[2]:loc[3]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				auth := tracker.resolve(receiver, path, echoMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: line[loc[4]:loc[5]],
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -657,7 +771,7 @@ func (d *EndpointDetector) detectFiberEndpoints() []types.Endpoint {
 
 	// app.Get("/path", handler) - requires identifier before dot
 	// Also require path to start with / to avoid false positives
-	routeRegex := regexp.MustCompile(`\w\.(Get|Post|Put|Patch|Delete)\s*\(\s*["'](/[^"']+)["']`)
+	routeRegex := regexp.MustCompile(`(\w+)\.(Get|Post|Put|Patch|Delete)\s*\(\s*["'](/[^"']+)["']`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".go" || shouldSkipForEndpoints(f.Path) {
@@ -673,22 +787,29 @@ func (d *EndpointDetector) detectFiberEndpoints() []types.Endpoint {
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
 
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
+
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					path := match[2]
-					if !isValidEndpointPath(path) {
-						continue
-					}
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   path,
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
+			tracker.observeGo(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				if !isValidEndpointPath(path) {
+					continue
 				}
+				receiver := line[loc[2]:loc[3]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -704,7 +825,8 @@ func (d *EndpointDetector) detectChiEndpoints() []types.Endpoint {
 	}
 
 	// Require path to start with / to avoid false positives like Header.Get("Content-Type")
-	routeRegex := regexp.MustCompile(`r\.(Get|Post|Put|Patch|Delete)\s*\(\s*["'](/[^"']+)["']`)
+	// r.With(mw).Get(...) leaves ")" as the receiver; the With() args are the middleware
+	routeRegex := regexp.MustCompile(`(r|\))\.(Get|Post|Put|Patch|Delete)\s*\(\s*["'](/[^"']+)["']`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".go" || shouldSkipForEndpoints(f.Path) {
@@ -720,22 +842,38 @@ func (d *EndpointDetector) detectChiEndpoints() []types.Endpoint {
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
 
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
+
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					path := match[2]
-					if !isValidEndpointPath(path) {
-						continue
+			tracker.observeGo(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				if !isValidEndpointPath(path) {
+					continue
+				}
+				receiver := line[loc[2]:loc[3]]
+				var middleware []string
+				if receiver == ")" {
+					receiver = leadingIdentifier(line)
+					if with := chiWithRegex.FindStringIndex(line[:loc[0]+1]); with != nil {
+						middleware, _ = splitCallArgs(line[with[1]:])
 					}
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   path,
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
 				}
+				auth := tracker.resolve(receiver, path, middleware)
+				if auth == "" {
+					auth = wrappedHandlerAuth(routeCallArgs(lines, lineNum, loc[5]))
+				}
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -825,6 +963,10 @@ func (d *EndpointDetector) detectNestJSEndpoints() []types.Endpoint {
 	decoratorRegex := regexp.MustCompile(`@(Get|Post|Put|Patch|Delete)\s*\(\s*['"]([^'"]+)['"]`)
 	// Controller decorator for base path
 	controllerRegex := regexp.MustCompile(`@Controller\s*\(\s*['"]([^'"]+)['"]`)
+	routeDecoratorRegex := regexp.MustCompile(`^@(?:Get|Post|Put|Patch|Delete|All|Controller)\b`)
+
+	// Guards registered with useGlobalGuards()/APP_GUARD apply unless @Public()
+	globalGuard := d.detectNestGlobalGuard()
 
 	for _, f := range d.files {
 		if f.IsDir || (f.Extension != ".ts" && f.Extension != ".js") {
@@ -872,11 +1014,22 @@ func (d *EndpointDetector) detectNestJSEndpoints() []types.Endpoint {
 						fullEndpointPath = "/"
 					}
 
+					// Method-level @UseGuards/@Public win over controller-level ones
+					auth, public := decoratorAuth(decoratorBlock(lines, lineNum), routeDecoratorRegex)
+					if auth == "" && !public {
+						auth, public = decoratorAuth(classDecoratorBlock(lines, lineNum), routeDecoratorRegex)
+					}
+					if auth == "" && !public {
+						auth = globalGuard
+					}
+
 					endpoints = append(endpoints, types.Endpoint{
 						Method: method,
 						Path:   fullEndpointPath,
 						File:   f.Path,
 						Line:   lineNum + 1,
+						Auth:   auth,
+						Public: d.isPublic(auth, f.Path, public, false),
 					})
 				}
 			}
@@ -895,7 +1048,7 @@ func (d *EndpointDetector) detectHonoEndpoints() []types.Endpoint {
 	}
 
 	// app.get('/path', handler)
-	routeRegex := regexp.MustCompile(`(?:app|hono)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
+	routeRegex := regexp.MustCompile(`(app|hono)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
 
 	for _, f := range d.files {
 		if f.IsDir || (f.Extension != ".ts" && f.Extension != ".js") {
@@ -917,19 +1070,26 @@ func (d *EndpointDetector) detectHonoEndpoints() []types.Endpoint {
 		}
 
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   match[2],
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
-				}
+			tracker.observeJS(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				receiver := line[loc[2]:loc[3]]
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -945,7 +1105,7 @@ func (d *EndpointDetector) detectKoaEndpoints() []types.Endpoint {
 	}
 
 	// router.get('/path', handler)
-	routeRegex := regexp.MustCompile(`router\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
+	routeRegex := regexp.MustCompile(`(router)\.(get|post|put|patch|delete)\s*\(\s*['"\x60]([^'"\x60]+)['"\x60]`)
 
 	for _, f := range d.files {
 		if f.IsDir || (f.Extension != ".ts" && f.Extension != ".js") {
@@ -963,19 +1123,26 @@ func (d *EndpointDetector) detectKoaEndpoints() []types.Endpoint {
 
 		contentStr := string(content)
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			matches := routeRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) >= 3 {
-					endpoints = append(endpoints, types.Endpoint{
-						Method: strings.ToUpper(match[1]),
-						Path:   match[2],
-						File:   f.Path,
-						Line:   lineNum + 1,
-					})
-				}
+			tracker.observeJS(line)
+			for _, loc := range routeRegex.FindAllStringSubmatchIndex(line, -1) {
+				path := line[loc[6]:loc[7]]
+				args := routeCallArgs(lines, lineNum, loc[5])
+				receiver := line[loc[2]:loc[3]]
+				auth := tracker.resolve(receiver, path, routeMiddleware(args))
+				endpoints = append(endpoints, types.Endpoint{
+					Method: strings.ToUpper(line[loc[4]:loc[5]]),
+					Path:   path,
+					File:   f.Path,
+					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+				})
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -991,7 +1158,8 @@ func (d *EndpointDetector) detectMuxEndpoints() []types.Endpoint {
 	}
 
 	// r.HandleFunc("/path", handler).Methods("GET")
-	handleFuncRegex := regexp.MustCompile(`\.HandleFunc\s*\(\s*["']([^"']+)["']`)
+	// api.Handle("/path", requireAuth(handler)) or api.Use(authMiddleware) on a subrouter
+	handleFuncRegex := regexp.MustCompile(`(\w+)\.(?:HandleFunc|Handle)\s*\(\s*["']([^"']+)["']`)
 	methodsRegex := regexp.MustCompile(`\.Methods\s*\(\s*["']([^"']+)["']`)
 
 	for _, f := range d.files {
@@ -1011,28 +1179,39 @@ func (d *EndpointDetector) detectMuxEndpoints() []types.Endpoint {
 		}
 
 		lines := strings.Split(contentStr, "\n")
+		tracker := newRouteAuthTracker()
+		local := localReceivers(contentStr, f.Extension)
 
 		for lineNum, line := range lines {
-			handleMatch := handleFuncRegex.FindStringSubmatch(line)
-			if len(handleMatch) >= 2 {
-				path := handleMatch[1]
-				if !isValidEndpointPath(path) {
-					continue
-				}
-				method := "ALL"
+			tracker.observeGo(line)
+			handleMatch := handleFuncRegex.FindStringSubmatchIndex(line)
+			if handleMatch != nil {
+				path := line[handleMatch[4]:handleMatch[5]]
+				if isValidEndpointPath(path) {
+					method := "ALL"
+
+					// Check if .Methods() is on the same line
+					if methodMatch := methodsRegex.FindStringSubmatch(line); len(methodMatch) >= 2 {
+						method = strings.ToUpper(methodMatch[1])
+					}
 
-				// Check if .Methods() is on the same line
-				if methodMatch := methodsRegex.FindStringSubmatch(line); len(methodMatch) >= 2 {
-					method = strings.ToUpper(methodMatch[1])
-				}
+					receiver := line[handleMatch[2]:handleMatch[3]]
+					auth := wrappedHandlerAuth(routeCallArgs(lines, lineNum, handleMatch[1]-1))
+					if auth == "" {
+						auth = tracker.lookup(receiver, path)
+					}
 
-				endpoints = append(endpoints, types.Endpoint{
-					Method: method,
-					Path:   path,
-					File:   f.Path,
-					Line:   lineNum + 1,
-				})
+					endpoints = append(endpoints, types.Endpoint{
+						Method: method,
+						Path:   path,
+						File:   f.Path,
+						Line:   lineNum + 1,
+						Auth:   auth,
+						Public: d.isPublic(auth, f.Path, false, !local[receiver]),
+					})
+				}
 			}
+			tracker.advance(line)
 		}
 	}
 
@@ -1082,30 +1261,36 @@ func (d *EndpointDetector) detectGoHTTPEndpoints() []types.Endpoint {
 
 		for lineNum, line := range lines {
 			// Check http.HandleFunc
-			if match := handleFuncRegex.FindStringSubmatch(line); len(match) >= 2 {
-				path := match[1]
+			if match := handleFuncRegex.FindStringSubmatchIndex(line); match != nil {
+				path := line[match[2]:match[3]]
 				if !isValidEndpointPath(path) {
 					continue
 				}
+				auth := wrappedHandlerAuth(routeCallArgs(lines, lineNum, match[0]))
 				endpoints = append(endpoints, types.Endpoint{
 					Method: "ALL",
 					Path:   path,
 					File:   f.Path,
 					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, true),
 				})
 			}
 
 			// Check http.Handle
-			if match := handleRegex.FindStringSubmatch(line); len(match) >= 2 {
-				path := match[1]
+			if match := handleRegex.FindStringSubmatchIndex(line); match != nil {
+				path := line[match[2]:match[3]]
 				if !isValidEndpointPath(path) {
 					continue
 				}
+				auth := wrappedHandlerAuth(routeCallArgs(lines, lineNum, match[0]))
 				endpoints = append(endpoints, types.Endpoint{
 					Method: "ALL",
 					Path:   path,
 					File:   f.Path,
 					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, true),
 				})
 			}
 		}
@@ -1142,23 +1327,33 @@ func (d *EndpointDetector) detectActixEndpoints() []types.Endpoint {
 		lines := strings.Split(contentStr, "\n")
 
 		for lineNum, line := range lines {
-			// Check attribute macros
+			// Check attribute macros: guards come from #[protect(...)]-style
+			// attributes or auth extractors in the handler signature
 			if match := attrRegex.FindStringSubmatch(line); len(match) >= 3 {
+				auth, public := decoratorAuth(decoratorBlock(lines, lineNum), attrRegex)
+				if auth == "" {
+					auth = rustSignatureAuth(lines, lineNum)
+				}
 				endpoints = append(endpoints, types.Endpoint{
 					Method: strings.ToUpper(match[1]),
 					Path:   match[2],
 					File:   f.Path,
 					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, public, true),
 				})
 			}
 
 			// Check .route() calls
 			if match := routeRegex.FindStringSubmatch(line); len(match) >= 3 {
+				auth := rustStatementAuth(lines, lineNum)
 				endpoints = append(endpoints, types.Endpoint{
 					Method: strings.ToUpper(match[2]),
 					Path:   match[1],
 					File:   f.Path,
 					Line:   lineNum + 1,
+					Auth:   auth,
+					Public: d.isPublic(auth, f.Path, false, true),
 				})
 			}
 		}
@@ -1176,7 +1371,7 @@ func (d *EndpointDetector) detectAxumEndpoints() []types.Endpoint {
 	}
 
 	// .route("/path", get(handler)) or .route("/path", post(handler))
-	routeRegex := regexp.MustCompile(`\.route\s*\(\s*["']([^"']+)["']\s*,\s*(get|post|put|patch|delete)\s*\(`)
+	routeRegex := regexp.MustCompile(`\.route\s*\(\s*["']([^"']+)["']\s*,\s*(get|post|put|patch|delete)\s*\(\s*([\w:]*)`)
 
 	for _, f := range d.files {
 		if f.IsDir || f.Extension != ".rs" {
@@ -1200,12 +1395,24 @@ func (d *EndpointDetector) detectAxumEndpoints() []types.Endpoint {
 			matches := routeRegex.FindAllStringSubmatch(line, -1)
 			for _, match := range matches {
 				if len(match) >= 3 {
-					endpoints = append(endpoints, types.Endpoint{
+					ep := types.Endpoint{
 						Method: strings.ToUpper(match[2]),
 						Path:   match[1],
 						File:   f.Path,
 						Line:   lineNum + 1,
-					})
+					}
+
+					// route_layer/layer auth in the router chain, then auth
+					// extractors on a handler defined in the same file
+					ep.Auth = rustStatementAuth(lines, lineNum)
+					if ep.Auth == "" {
+						// A handler defined elsewhere leaves the guard unknown
+						if handlerIdx := rustFunctionLine(lines, match[3]); handlerIdx >= 0 {
+							ep.Auth = rustSignatureAuth(lines, handlerIdx)
+							ep.Public = d.isPublic(ep.Auth, f.Path, false, true)
+						}
+					}
+					endpoints = append(endpoints, ep)
 				}
 			}
 		}
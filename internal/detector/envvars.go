@@ -0,0 +1,315 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// EnvVarDetector finds the environment variables a project reads and
+// cross-references them with env templates, docker-compose and Kubernetes
+// manifests
+type EnvVarDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewEnvVarDetector creates a new environment variable detector
+func NewEnvVarDetector(rootPath string, files []types.FileInfo) *EnvVarDetector {
+	return &EnvVarDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// envPattern matches a read of an environment variable. The regex captures
+// the variable in the "name" group; def, applied to the text following the
+// match (or to the whole line when wholeLine is set), captures a default value.
+type envPattern struct {
+	regex     *regexp.Regexp
+	def       *regexp.Regexp
+	wholeLine bool
+}
+
+var (
+	envCallDefault = regexp.MustCompile(`^\s*,\s*(?:default\s*=\s*)?("[^"]*"|'[^']*'|-?\d+(?:\.\d+)?|True|False|true|false)`)
+	envOrDefault   = regexp.MustCompile("^\\s*(?:\\|\\||\\?\\?)\\s*(\"[^\"]*\"|'[^']*'|`[^`$]*`|-?\\d+(?:\\.\\d+)?|true|false)")
+	envRustDefault = regexp.MustCompile(`^\s*\)\s*(?:\.ok\(\))?\s*\.unwrap_or(?:_else)?\(\s*(?:\|_\|\s*)?"([^"]*)"`)
+	envTagDefault  = regexp.MustCompile(`(?:envDefault|default):"([^"]*)"`)
+
+	envTemplateLineRegex = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=`)
+	composeEnvItemRegex  = regexp.MustCompile(`^\s*-\s*["']?([A-Za-z_][A-Za-z0-9_]*)\s*(?:=|["']?\s*$)`)
+	composeEnvKeyRegex   = regexp.MustCompile(`^\s*["']?([A-Za-z_][A-Za-z0-9_]*)["']?\s*:`)
+	k8sEnvNameRegex      = regexp.MustCompile(`^\s*-\s*name:\s*["']?([A-Za-z_][A-Za-z0-9_]*)`)
+	k8sDataKeyRegex      = regexp.MustCompile(`^\s+([A-Z][A-Z0-9_]*)\s*:`)
+	yamlBlockRegex       = regexp.MustCompile(`^(\s*)(-\s+)?(environment|env|data|stringData):\s*$`)
+)
+
+var envPatterns = map[string][]envPattern{
+	"go": {
+		{regexp.MustCompile(`\bos\.(?:Getenv|LookupEnv)\(\s*"(?P<name>\w+)"`), nil, false},
+		{regexp.MustCompile(`\bBindEnv\(\s*"[^"]*"\s*,\s*"(?P<name>\w+)"`), nil, false},
+		// Helpers such as getEnv("PORT", "8080") or envOr("PORT", "8080")
+		{regexp.MustCompile(`\b(?P<callee>\w*[Ee]nv\w*)\(\s*"(?P<name>[A-Z][A-Z0-9_]*)"`), envCallDefault, false},
+		// Struct tags read by envconfig and caarlos0/env
+		{regexp.MustCompile("`[^`]*\\b(?:env|envconfig):\"(?P<name>[A-Za-z_]\\w*)[^\"]*\"[^`]*`"), envTagDefault, true},
+	},
+	"js": {
+		{regexp.MustCompile(`\bprocess\.env\.(?P<name>[A-Za-z_]\w*)`), envOrDefault, false},
+		{regexp.MustCompile(`\bprocess\.env\[\s*['"](?P<name>\w+)['"]\s*\]`), envOrDefault, false},
+		{regexp.MustCompile(`\bimport\.meta\.env\.(?P<name>[A-Za-z_]\w*)`), envOrDefault, false},
+	},
+	"python": {
+		{regexp.MustCompile(`\bos\.environ\[\s*['"](?P<name>\w+)['"]\s*\]`), nil, false},
+		{regexp.MustCompile(`\bos\.(?:environ\.get|getenv)\(\s*['"](?P<name>\w+)['"]`), envCallDefault, false},
+		// django-environ: env("DEBUG", default=False), env.bool("DEBUG")
+		{regexp.MustCompile(`\benv(?:\.\w+)?\(\s*['"](?P<name>[A-Z][A-Z0-9_]*)['"]`), envCallDefault, false},
+	},
+	"rust": {
+		{regexp.MustCompile(`\benv::var(?:_os)?\(\s*"(?P<name>\w+)"`), envRustDefault, false},
+		{regexp.MustCompile(`\b(?:option_)?env!\(\s*"(?P<name>\w+)"`), nil, false},
+	},
+	"ruby": {
+		{regexp.MustCompile(`\bENV\[\s*['"](?P<name>\w+)['"]\s*\]`), envOrDefault, false},
+		{regexp.MustCompile(`\bENV\.fetch\(\s*['"](?P<name>\w+)['"]`), envCallDefault, false},
+	},
+	"java": {
+		{regexp.MustCompile(`\bSystem\.getenv\(\s*"(?P<name>\w+)"`), nil, false},
+	},
+}
+
+// envSystemVars are set by the OS or shell rather than configured for the project
+var envSystemVars = map[string]bool{
+	"HOME": true, "PATH": true, "USER": true, "USERPROFILE": true, "SHELL": true,
+	"TERM": true, "PWD": true, "TMPDIR": true, "TEMP": true, "TMP": true,
+	"LANG": true, "EDITOR": true, "VISUAL": true, "HOSTNAME": true, "APPDATA": true,
+}
+
+// envTemplateFiles document the variables a project expects
+var envTemplateFiles = map[string]bool{
+	".env.example": true, ".env.sample": true, ".env.template": true,
+	".env.dist": true, ".env.defaults": true, "env.example": true, "example.env": true,
+}
+
+func envLanguage(ext string) string {
+	switch ext {
+	case ".go":
+		return "go"
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs", ".vue", ".svelte":
+		return "js"
+	case ".py":
+		return "python"
+	case ".rs":
+		return "rust"
+	case ".rb":
+		return "ruby"
+	case ".java", ".kt":
+		return "java"
+	}
+	return ""
+}
+
+// Detect returns the environment variables read in source code or set in
+// env templates, docker-compose files and Kubernetes manifests, sorted by name
+func (d *EnvVarDetector) Detect() []types.EnvVar {
+	vars := make(map[string]*types.EnvVar)
+	get := func(name string) *types.EnvVar {
+		v, ok := vars[name]
+		if !ok {
+			v = &types.EnvVar{Name: name}
+			vars[name] = v
+		}
+		return v
+	}
+
+	for _, f := range d.files {
+		if f.IsDir || f.Size > 500000 {
+			continue
+		}
+		switch {
+		case envTemplateFiles[f.Name]:
+			for _, name := range d.templateVars(f.Path) {
+				v := get(name)
+				v.DefinedIn = appendUnique(v.DefinedIn, f.Path)
+				v.Documented = true
+			}
+		case isComposeFile(f.Name):
+			for _, name := range d.yamlEnvVars(f.Path, false) {
+				v := get(name)
+				v.DefinedIn = appendUnique(v.DefinedIn, f.Path)
+			}
+		case (f.Extension == ".yaml" || f.Extension == ".yml") && !strings.HasPrefix(f.Path, ".github/"):
+			for _, name := range d.yamlEnvVars(f.Path, true) {
+				v := get(name)
+				v.DefinedIn = appendUnique(v.DefinedIn, f.Path)
+			}
+		default:
+			lang := envLanguage(f.Extension)
+			if lang == "" || shouldSkipForEndpoints(f.Path) || isTestSourceFile(f.Path) {
+				continue
+			}
+			d.sourceReads(f.Path, lang, get)
+		}
+	}
+
+	if len(vars) == 0 {
+		return nil
+	}
+
+	// Variables mentioned in the README count as documented
+	readme := ""
+	for _, name := range []string{"README.md", "readme.md", "README"} {
+		if content, err := os.ReadFile(filepath.Join(d.rootPath, name)); err == nil {
+			readme = string(content)
+			break
+		}
+	}
+
+	result := make([]types.EnvVar, 0, len(vars))
+	for _, v := range vars {
+		if !v.Documented && readme != "" && regexp.MustCompile(`\b`+regexp.QuoteMeta(v.Name)+`\b`).MatchString(readme) {
+			v.Documented = true
+		}
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// sourceReads records every environment variable read in a source file
+func (d *EnvVarDetector) sourceReads(path, lang string, get func(string) *types.EnvVar) {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+	if err != nil {
+		return
+	}
+	text := string(content)
+	if !strings.Contains(text, "env") && !strings.Contains(text, "ENV") && !strings.Contains(text, "Env") {
+		return
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "*") {
+			continue
+		}
+		for _, p := range envPatterns[lang] {
+			nameIdx := p.regex.SubexpIndex("name")
+			calleeIdx := p.regex.SubexpIndex("callee")
+			for _, m := range p.regex.FindAllStringSubmatchIndex(line, -1) {
+				if calleeIdx >= 0 {
+					// os.Getenv is matched above; setters are not reads
+					callee := line[m[2*calleeIdx]:m[2*calleeIdx+1]]
+					lower := strings.ToLower(callee)
+					if callee == "Getenv" || callee == "LookupEnv" || strings.HasPrefix(lower, "set") || strings.HasPrefix(lower, "unset") {
+						continue
+					}
+				}
+				name := line[m[2*nameIdx]:m[2*nameIdx+1]]
+				if envSystemVars[name] {
+					continue
+				}
+
+				v := get(name)
+				v.UsedIn = appendUnique(v.UsedIn, fmt.Sprintf("%s:%d", path, i+1))
+				if v.Default != "" {
+					continue
+				}
+				if p.def == nil {
+					continue
+				}
+				rest := line[m[1]:]
+				if p.wholeLine {
+					rest = line
+				}
+				if dm := p.def.FindStringSubmatch(rest); dm != nil {
+					v.Default = unquote(dm[1])
+				}
+			}
+		}
+	}
+}
+
+// templateVars returns the variables listed in an env template
+func (d *EnvVarDetector) templateVars(path string) []string {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if m := envTemplateLineRegex.FindStringSubmatch(line); m != nil {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// yamlEnvVars returns the variables set in docker-compose environment blocks
+// or, for Kubernetes manifests (k8s=true), container env lists and
+// ConfigMap/Secret data keys
+func (d *EnvVarDetector) yamlEnvVars(path string, k8s bool) []string {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+	if err != nil {
+		return nil
+	}
+	text := string(content)
+	if k8s && (!strings.Contains(text, "apiVersion:") || !strings.Contains(text, "kind:")) {
+		return nil
+	}
+
+	var names []string
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		m := yamlBlockRegex.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		block := m[3]
+		if k8s == (block == "environment") {
+			continue
+		}
+		indent := len(m[1]) + len(m[2])
+		for i+1 < len(lines) {
+			next := lines[i+1]
+			if strings.TrimSpace(next) == "" || strings.HasPrefix(strings.TrimSpace(next), "#") {
+				i++
+				continue
+			}
+			// List items may sit at the same indentation as their key
+			nextIndent := len(next) - len(strings.TrimLeft(next, " "))
+			if nextIndent < indent || (nextIndent == indent && !strings.HasPrefix(strings.TrimSpace(next), "- ")) {
+				break
+			}
+			i++
+			var re *regexp.Regexp
+			switch block {
+			case "environment":
+				if sm := composeEnvItemRegex.FindStringSubmatch(next); sm != nil {
+					names = append(names, sm[1])
+					continue
+				}
+				re = composeEnvKeyRegex
+			case "env":
+				re = k8sEnvNameRegex
+			default:
+				re = k8sDataKeyRegex
+			}
+			if sm := re.FindStringSubmatch(next); sm != nil {
+				names = append(names, sm[1])
+			}
+		}
+	}
+	return names
+}
+
+func isComposeFile(name string) bool {
+	return (strings.HasPrefix(name, "docker-compose") || strings.HasPrefix(name, "compose")) &&
+		(strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml"))
+}
@@ -0,0 +1,149 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func findEnvVar(vars []types.EnvVar, name string) *types.EnvVar {
+	for i := range vars {
+		if vars[i].Name == name {
+			return &vars[i]
+		}
+	}
+	return nil
+}
+
+func TestEnvVarDetector_Detect(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"config/config.go": `package config
+
+import "os"
+
+type Config struct {
+	Port     int    ` + "`envconfig:\"PORT\" default:\"8080\"`" + `
+	LogLevel string ` + "`env:\"LOG_LEVEL,required\"`" + `
+}
+
+func Load() {
+	dsn := os.Getenv("DATABASE_URL")
+	region := getEnv("AWS_REGION", "us-east-1")
+	os.Setenv("IGNORED_SET", "1")
+	home := os.Getenv("HOME")
+}
+`,
+		"web/src/api.ts": `export const apiUrl = process.env.API_URL || 'http://localhost:3000';
+const key = import.meta.env.VITE_KEY;
+`,
+		"worker/settings.py": `import os
+
+REDIS_URL = os.environ.get("REDIS_URL", "redis://localhost:6379")
+SECRET = os.environ["SECRET_KEY"]
+`,
+		"src/main.rs": `fn main() {
+    let threads = std::env::var("THREADS").unwrap_or("4".to_string());
+}
+`,
+		"config/config_test.go": `package config
+
+func TestLoad(t *testing.T) { os.Getenv("TEST_ONLY") }
+`,
+		".env.example": `# Database
+DATABASE_URL=postgres://localhost/app
+export API_URL=
+UNUSED_VAR=1
+`,
+		"docker-compose.yml": `services:
+  api:
+    image: app
+    environment:
+      - DATABASE_URL=postgres://db/app
+      - REDIS_URL
+  worker:
+    environment:
+      THREADS: "8"
+`,
+		"deploy/k8s/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+      - name: api
+        env:
+        - name: SECRET_KEY
+          valueFrom:
+            secretKeyRef:
+              name: app
+        - name: PORT
+          value: "80"
+`,
+		"README.md": "Set `AWS_REGION` to choose the region.\n",
+	})
+
+	vars := NewEnvVarDetector(tmpDir, files).Detect()
+
+	tests := []struct {
+		name       string
+		def        string
+		documented bool
+		used       bool
+	}{
+		{"DATABASE_URL", "", true, true},
+		{"AWS_REGION", "us-east-1", true, true},
+		{"PORT", "8080", false, true},
+		{"LOG_LEVEL", "", false, true},
+		{"API_URL", "http://localhost:3000", true, true},
+		{"VITE_KEY", "", false, true},
+		{"REDIS_URL", "redis://localhost:6379", false, true},
+		{"SECRET_KEY", "", false, true},
+		{"THREADS", "4", false, true},
+		{"UNUSED_VAR", "", true, false},
+	}
+	for _, tt := range tests {
+		v := findEnvVar(vars, tt.name)
+		if v == nil {
+			t.Errorf("expected %s to be detected", tt.name)
+			continue
+		}
+		if v.Default != tt.def {
+			t.Errorf("%s: expected default %q, got %q", tt.name, tt.def, v.Default)
+		}
+		if v.Documented != tt.documented {
+			t.Errorf("%s: expected documented=%v", tt.name, tt.documented)
+		}
+		if (len(v.UsedIn) > 0) != tt.used {
+			t.Errorf("%s: expected used=%v, got %v", tt.name, tt.used, v.UsedIn)
+		}
+	}
+
+	if v := findEnvVar(vars, "DATABASE_URL"); v != nil && len(v.DefinedIn) != 2 {
+		t.Errorf("expected DATABASE_URL in .env.example and docker-compose.yml, got %v", v.DefinedIn)
+	}
+	if v := findEnvVar(vars, "SECRET_KEY"); v != nil && (len(v.DefinedIn) != 1 || v.DefinedIn[0] != "deploy/k8s/deployment.yaml") {
+		t.Errorf("expected SECRET_KEY set in the Kubernetes manifest, got %v", v.DefinedIn)
+	}
+	if v := findEnvVar(vars, "THREADS"); v != nil && len(v.DefinedIn) != 1 {
+		t.Errorf("expected THREADS from compose map syntax, got %v", v.DefinedIn)
+	}
+	if v := findEnvVar(vars, "DATABASE_URL"); v != nil && (len(v.UsedIn) != 1 || v.UsedIn[0] != "config/config.go:11") {
+		t.Errorf("expected DATABASE_URL used at config/config.go:11, got %v", v.UsedIn)
+	}
+
+	for _, name := range []string{"HOME", "IGNORED_SET", "TEST_ONLY", "api", "app"} {
+		if findEnvVar(vars, name) != nil {
+			t.Errorf("unexpected variable %s", name)
+		}
+	}
+}
+
+func TestEnvVarDetector_NoVars(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+
+	if vars := NewEnvVarDetector(tmpDir, files).Detect(); vars != nil {
+		t.Errorf("expected no variables, got %+v", vars)
+	}
+}
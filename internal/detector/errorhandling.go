@@ -0,0 +1,867 @@
+package detector
+
+import (
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Error practice kinds
+const (
+	ErrorPracticeWrapping   = "wrapping"
+	ErrorPracticeSentinel   = "sentinel"
+	ErrorPracticeCustomType = "custom-type"
+	ErrorPracticePanic      = "panic"
+	ErrorPracticeHierarchy  = "hierarchy"
+	ErrorPracticeChaining   = "chaining"
+	ErrorPracticeResult     = "result"
+	ErrorPracticeThrow      = "throw"
+)
+
+const (
+	// minErrorFindings is the fewest occurrences an error practice needs
+	minErrorFindings = 3
+	// maxLoggerFields caps the field keys listed for a logger
+	maxLoggerFields = 5
+)
+
+// goLoggerImports maps Go import paths to the logger they provide
+var goLoggerImports = map[string]string{
+	"log/slog":                   "slog",
+	"golang.org/x/exp/slog":      "slog",
+	"go.uber.org/zap":            "zap",
+	"github.com/rs/zerolog":      "zerolog",
+	"github.com/rs/zerolog/log":  "zerolog",
+	"github.com/sirupsen/logrus": "logrus",
+}
+
+// goFieldConstructors are calls whose first argument is a log field key
+var goFieldConstructors = map[string]map[string]bool{
+	"slog": {"String": true, "Int": true, "Int64": true, "Uint64": true, "Float64": true, "Bool": true,
+		"Time": true, "Duration": true, "Any": true, "Group": true},
+	"zap": {"String": true, "Int": true, "Int64": true, "Uint": true, "Float64": true, "Bool": true,
+		"Time": true, "Duration": true, "Any": true, "Strings": true, "Stringer": true, "NamedError": true},
+	"zerolog": {"Str": true, "Int": true, "Int64": true, "Uint": true, "Float64": true, "Bool": true,
+		"Time": true, "Dur": true, "Interface": true, "Any": true, "Strs": true, "Stringer": true},
+	"logrus": {"WithField": true},
+}
+
+// slogLevels are slog calls followed by alternating keys and values
+var slogLevels = map[string]int{
+	"Debug": 1, "Info": 1, "Warn": 1, "Error": 1,
+	"DebugContext": 2, "InfoContext": 2, "WarnContext": 2, "ErrorContext": 2,
+	"With": 0,
+}
+
+// loggerInstructions describes how to log through each library
+var loggerInstructions = map[string]string{
+	"slog":      "Log through log/slog with key-value pairs: `slog.Info(\"msg\", \"key\", value)`",
+	"zap":       "Log through zap with typed fields: `logger.Info(\"msg\", zap.String(\"key\", value))`",
+	"zerolog":   "Log through zerolog's chained events: `log.Info().Str(\"key\", value).Msg(\"msg\")`",
+	"logrus":    "Log through logrus with fields: `log.WithField(\"key\", value).Info(\"msg\")`",
+	"structlog": "Log through structlog with an event name and keyword fields: `log.info(\"event\", key=value)`",
+	"loguru":    "Log through loguru's shared `logger` rather than print or the logging module",
+	"logging":   "Log through the logging module with a per-module `logger = logging.getLogger(__name__)`",
+	"pino":      "Log through pino with the fields object first: `logger.info({ key: value }, \"msg\")`",
+	"bunyan":    "Log through bunyan with the fields object first: `log.info({ key: value }, \"msg\")`",
+	"winston":   "Log through winston with metadata after the message: `logger.info(\"msg\", { key: value })`",
+}
+
+var (
+	pyClassBasesRegex  = regexp.MustCompile(`^\s*class\s+(\w+)\s*\(([^)]*)\)\s*:`)
+	pyExceptRegex      = regexp.MustCompile(`^(\s*)except\b`)
+	pyRaiseRegex       = regexp.MustCompile(`^(\s*)raise\s+\w`)
+	pyRaiseFromRegex   = regexp.MustCompile(`^\s*raise\s+.+\sfrom\s+\w+`)
+	pyLoggerImports    = regexp.MustCompile(`^\s*(?:import\s+(structlog|logging)\b|from\s+(loguru|structlog)\s+import\b)`)
+	pyStructlogCall    = regexp.MustCompile(`\.(?:debug|info|warning|warn|error|exception|critical|msg|bind)\((.*)`)
+	pyKeywordArgRegex  = regexp.MustCompile(`\b(\w+)=`)
+	pyExtraRegex       = regexp.MustCompile(`extra=\{([^}]*)\}`)
+	pyLogFormatRegex   = regexp.MustCompile(`\b(?:logger|log|logging)\.(?:debug|info|warning|error|exception|critical)\(\s*(f?)["']([^"']*)`)
+	jsErrorClassRegex  = regexp.MustCompile(`\bclass\s+(\w+)\s+extends\s+(\w*Error)\b`)
+	jsThrowRegex       = regexp.MustCompile(`\bthrow\s+new\s+(\w+)`)
+	jsResultRegex      = regexp.MustCompile(`\bResult<|\breturn\s+(?:ok|err|Ok|Err)\(`)
+	jsResultLibRegex   = regexp.MustCompile(`from\s+['"](neverthrow|ts-results|oxide\.ts|true-myth)['"]`)
+	jsLoggerImport     = regexp.MustCompile(`(?:from\s+|require\(\s*)['"](pino|winston|bunyan)['"]`)
+	jsFieldsFirstCall  = regexp.MustCompile(`\.(?:trace|debug|info|warn|error|fatal)\(\s*\{([^}]*)\}`)
+	jsFieldsAfterCall  = regexp.MustCompile(`\.(?:debug|verbose|info|warn|error|log)\(\s*['"` + "`" + `][^'"` + "`" + `]*['"` + "`" + `]\s*,\s*\{([^}]*)\}`)
+	objectKeyRegex     = regexp.MustCompile(`^['"]?([A-Za-z_$][\w.$-]*)['"]?$`)
+	quotedDictKeyRegex = regexp.MustCompile(`['"]([\w.-]+)['"]\s*:`)
+)
+
+// ErrorHandlingDetector determines how each language in the codebase reports
+// errors and which logger it writes through
+type ErrorHandlingDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewErrorHandlingDetector creates a new error handling detector
+func NewErrorHandlingDetector(rootPath string, files []types.FileInfo) *ErrorHandlingDetector {
+	return &ErrorHandlingDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// errorFinding tallies one habit, the files it appears in and a few examples
+type errorFinding struct {
+	count    int
+	files    map[string]bool
+	examples []string
+}
+
+func (f *errorFinding) add(file, example string) {
+	if f.files == nil {
+		f.files = make(map[string]bool)
+	}
+	f.count++
+	f.files[file] = true
+	if example != "" && len(f.examples) < maxEvidenceSamples && !sliceContainsString(f.examples, example) {
+		f.examples = append(f.examples, example)
+	}
+}
+
+// loggerFindings tracks, per library, the files importing it and the field
+// keys passed to it, and how logging module messages are formatted
+type loggerFindings struct {
+	files  map[string]map[string]bool
+	fields map[string]map[string]int
+
+	lazyMessages, fstringMessages int
+}
+
+func (l *loggerFindings) use(library, file string) {
+	if l.files == nil {
+		l.files = make(map[string]map[string]bool)
+		l.fields = make(map[string]map[string]int)
+	}
+	if l.files[library] == nil {
+		l.files[library] = make(map[string]bool)
+		l.fields[library] = make(map[string]int)
+	}
+	l.files[library][file] = true
+}
+
+func (l *loggerFindings) field(library, key string) {
+	if l.fields[library] != nil && key != "" {
+		l.fields[library][key]++
+	}
+}
+
+// Detect samples the source and returns its error handling practices and
+// loggers, or nil when none stand out
+func (d *ErrorHandlingDetector) Detect() *types.ErrorHandling {
+	samples := sampleSourceFiles(d.rootPath, d.files)
+	info := &types.ErrorHandling{}
+
+	for _, lang := range []string{"go", "py", "js"} {
+		var sources []sampledFile
+		for _, f := range samples[lang] {
+			if !isTestSourceFile(f.path) {
+				sources = append(sources, f)
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		var practices []types.ErrorPractice
+		var loggers loggerFindings
+		switch lang {
+		case "go":
+			practices = goErrorPractices(sources, &loggers)
+		case "py":
+			practices = pythonErrorPractices(sources, &loggers)
+		case "js":
+			practices = jsErrorPractices(sources, &loggers)
+		}
+		info.Practices = append(info.Practices, practices...)
+		if logger := dominantLogger(styleLanguageNames[lang], &loggers); logger != nil {
+			info.Loggers = append(info.Loggers, *logger)
+		}
+	}
+
+	if len(info.Practices) == 0 && len(info.Loggers) == 0 {
+		return nil
+	}
+	return info
+}
+
+// practice builds an error practice from a finding. total counts the
+// occurrences examined, including competing ones, or is 0 when nothing competes
+func practice(language, kind, instruction string, f *errorFinding, total int) types.ErrorPractice {
+	return types.ErrorPractice{
+		Language:    language,
+		Kind:        kind,
+		Instruction: instruction,
+		Examples:    f.examples,
+		Files:       len(f.files),
+		Confidence:  evidenceConfidence(f.count, total, len(f.files)),
+		Evidence:    &types.Evidence{Matches: f.count, Total: total, Files: len(f.files), Samples: f.samples()},
+	}
+}
+
+// samples returns the path:line examples of a finding, or the first files
+// it was seen in when its examples are names
+func (f *errorFinding) samples() []string {
+	var samples []string
+	for _, example := range f.examples {
+		if strings.Contains(example, ":") {
+			samples = append(samples, example)
+		}
+	}
+	if len(samples) > 0 {
+		return samples
+	}
+	return firstFiles(f.files)
+}
+
+// firstFiles returns up to maxEvidenceSamples of the files, sorted
+func firstFiles(files map[string]bool) []string {
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+	if len(paths) > maxEvidenceSamples {
+		paths = paths[:maxEvidenceSamples]
+	}
+	return paths
+}
+
+// goErrorPractices parses Go sources for error wrapping, sentinel errors,
+// custom error types and panics, and records logger field keys
+func goErrorPractices(files []sampledFile, loggers *loggerFindings) []types.ErrorPractice {
+	var wrapped, pkgWrapped, stringified, sentinels, customTypes, errorsIs, equality errorFinding
+	var setupPanics, otherPanics errorFinding
+
+	for _, f := range files {
+		fset := token.NewFileSet()
+		node, err := goparser.ParseFile(fset, f.path, strings.Join(f.lines, "\n"), 0)
+		if err != nil {
+			continue
+		}
+		loc := func(pos token.Pos) string {
+			return fmt.Sprintf("%s:%d", f.path, fset.Position(pos).Line)
+		}
+
+		library, pkgErrors := "", false
+		for _, imp := range node.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if lib, ok := goLoggerImports[path]; ok {
+				library = lib
+				loggers.use(lib, f.path)
+			}
+			if path == "github.com/pkg/errors" {
+				pkgErrors = true
+			}
+		}
+
+		for _, decl := range node.Decls {
+			switch decl := decl.(type) {
+			case *goast.GenDecl:
+				if decl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					vs, ok := spec.(*goast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, name := range vs.Names {
+						if i < len(vs.Values) && isGoSentinelName(name.Name) && isGoErrorConstructor(vs.Values[i]) {
+							sentinels.add(f.path, name.Name)
+						}
+					}
+				}
+
+			case *goast.FuncDecl:
+				if typeName := goErrorMethodType(decl); typeName != "" {
+					customTypes.add(f.path, typeName)
+				}
+				if decl.Body == nil {
+					continue
+				}
+				funcName := decl.Name.Name
+				goast.Inspect(decl.Body, func(n goast.Node) bool {
+					switch x := n.(type) {
+					case *goast.CallExpr:
+						if ident, ok := x.Fun.(*goast.Ident); ok && ident.Name == "panic" {
+							if strings.HasPrefix(funcName, "Must") || strings.HasPrefix(funcName, "must") ||
+								funcName == "init" || funcName == "main" {
+								setupPanics.add(f.path, funcName)
+							} else {
+								otherPanics.add(f.path, loc(x.Pos()))
+							}
+							return true
+						}
+						sel, ok := x.Fun.(*goast.SelectorExpr)
+						if !ok {
+							return true
+						}
+						pkg := ""
+						if ident, ok := sel.X.(*goast.Ident); ok {
+							pkg = ident.Name
+						}
+						switch {
+						case pkg == "fmt" && sel.Sel.Name == "Errorf" && len(x.Args) > 0:
+							format := goStringLiteral(x.Args[0])
+							if strings.Contains(format, "%w") {
+								wrapped.add(f.path, loc(x.Pos()))
+							} else if goMentionsErr(x.Args[1:]) {
+								stringified.add(f.path, loc(x.Pos()))
+							}
+						case pkg == "errors" && pkgErrors && (sel.Sel.Name == "Wrap" || sel.Sel.Name == "Wrapf"):
+							pkgWrapped.add(f.path, loc(x.Pos()))
+						case pkg == "errors" && (sel.Sel.Name == "Is" || sel.Sel.Name == "As"):
+							errorsIs.add(f.path, "")
+						}
+						goLogFields(library, pkg, sel.Sel.Name, x.Args, loggers)
+					case *goast.BinaryExpr:
+						if (x.Op == token.EQL || x.Op == token.NEQ) && (goIsSentinelRef(x.X) || goIsSentinelRef(x.Y)) {
+							equality.add(f.path, "")
+						}
+					case *goast.CompositeLit:
+						if sel, ok := x.Type.(*goast.SelectorExpr); ok && library == "logrus" && sel.Sel.Name == "Fields" {
+							for _, elt := range x.Elts {
+								if kv, ok := elt.(*goast.KeyValueExpr); ok {
+									loggers.field("logrus", goStringLiteral(kv.Key))
+								}
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+
+	const lang = "Go"
+	var practices []types.ErrorPractice
+
+	total := wrapped.count + pkgWrapped.count + stringified.count
+	switch {
+	case total >= minErrorFindings && wrapped.count*10 >= total*7:
+		practices = append(practices, practice(lang, ErrorPracticeWrapping,
+			"Wrap returned errors with context using `fmt.Errorf(\"doing x: %w\", err)`, never `%v`, so callers can match them with errors.Is/As", &wrapped, total))
+	case total >= minErrorFindings && pkgWrapped.count*10 >= total*7:
+		practices = append(practices, practice(lang, ErrorPracticeWrapping,
+			"Wrap returned errors with `errors.Wrap(err, \"doing x\")` from github.com/pkg/errors", &pkgWrapped, total))
+	}
+
+	if sentinels.count >= 2 {
+		instruction := "Declare expected failures as package-level sentinel errors (`var ErrNotFound = errors.New(\"...\")`) and check them with `errors.Is`"
+		if equality.count > errorsIs.count {
+			instruction = "Declare expected failures as package-level sentinel errors (`var ErrNotFound = errors.New(\"...\")`); callers compare them directly with `==`"
+		}
+		practices = append(practices, practice(lang, ErrorPracticeSentinel, instruction, &sentinels, 0))
+	}
+
+	if customTypes.count >= 2 {
+		practices = append(practices, practice(lang, ErrorPracticeCustomType,
+			"Return a custom error type implementing `Error() string` when callers need details, and unwrap it with `errors.As`", &customTypes, 0))
+	}
+
+	switch {
+	case otherPanics.count > 0:
+	case setupPanics.count > 0:
+		practices = append(practices, practice(lang, ErrorPracticePanic,
+			"Panic only in `Must*` helpers and program setup (init/main); return errors everywhere else", &setupPanics, 0))
+	case len(files) >= 5:
+		practices = append(practices, types.ErrorPractice{
+			Language:    lang,
+			Kind:        ErrorPracticePanic,
+			Instruction: "Return errors instead of panicking; no non-test code panics",
+			Files:       len(files),
+			Confidence:  presenceConfidence(len(files)),
+			Evidence:    &types.Evidence{Files: len(files)},
+		})
+	}
+
+	return practices
+}
+
+// goLogFields records the field keys passed to a logging call
+func goLogFields(library, pkg, method string, args []goast.Expr, loggers *loggerFindings) {
+	if library == "" {
+		return
+	}
+	if goFieldConstructors[library][method] && len(args) > 0 {
+		// zap.String("key", v), slog.Int("key", v), event.Str("key", v), WithField("key", v)
+		if library == "zerolog" || library == "logrus" || pkg == library {
+			loggers.field(library, goStringLiteral(args[0]))
+		}
+		return
+	}
+	if library != "slog" {
+		return
+	}
+	start, ok := slogLevels[method]
+	if !ok {
+		return
+	}
+	if method != "With" && len(args) > start-1 && goStringLiteral(args[start-1]) == "" {
+		return
+	}
+	for i := start; i < len(args); i += 2 {
+		loggers.field(library, goStringLiteral(args[i]))
+	}
+}
+
+// goStringLiteral returns the value of a string literal expression, or ""
+func goStringLiteral(expr goast.Expr) string {
+	lit, ok := expr.(*goast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// goMentionsErr reports whether any argument is an err variable
+func goMentionsErr(args []goast.Expr) bool {
+	for _, arg := range args {
+		if ident, ok := arg.(*goast.Ident); ok && (ident.Name == "err" || strings.HasSuffix(ident.Name, "Err")) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGoSentinelName(name string) bool {
+	return len(name) > 3 && (strings.HasPrefix(name, "Err") || strings.HasPrefix(name, "err")) && name[3] >= 'A' && name[3] <= 'Z'
+}
+
+// isGoErrorConstructor matches errors.New(...) and fmt.Errorf(...)
+func isGoErrorConstructor(expr goast.Expr) bool {
+	call, ok := expr.(*goast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*goast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*goast.Ident)
+	return ok && (pkg.Name == "errors" && sel.Sel.Name == "New" || pkg.Name == "fmt" && sel.Sel.Name == "Errorf")
+}
+
+// goIsSentinelRef matches ErrX and pkg.ErrX
+func goIsSentinelRef(expr goast.Expr) bool {
+	switch e := expr.(type) {
+	case *goast.Ident:
+		return isGoSentinelName(e.Name)
+	case *goast.SelectorExpr:
+		return isGoSentinelName(e.Sel.Name)
+	}
+	return false
+}
+
+// goErrorMethodType returns the receiver type of an `Error() string` method
+func goErrorMethodType(decl *goast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 || decl.Name.Name != "Error" {
+		return ""
+	}
+	if len(decl.Type.Params.List) != 0 || decl.Type.Results == nil || len(decl.Type.Results.List) != 1 {
+		return ""
+	}
+	if result, ok := decl.Type.Results.List[0].Type.(*goast.Ident); !ok || result.Name != "string" {
+		return ""
+	}
+	recv := decl.Recv.List[0].Type
+	if star, ok := recv.(*goast.StarExpr); ok {
+		recv = star.X
+	}
+	if ident, ok := recv.(*goast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// pythonErrorPractices looks for a project exception hierarchy and exception
+// chaining, and records logger field keys
+func pythonErrorPractices(files []sampledFile, loggers *loggerFindings) []types.ErrorPractice {
+	bases := make(map[string][]string)
+	definedIn := make(map[string]string)
+	var chained, unchained errorFinding
+
+	for _, f := range files {
+		library := ""
+		exceptIndent := -1
+		for n, line := range f.lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+			if m := pyLoggerImports.FindStringSubmatch(line); m != nil {
+				lib := m[1] + m[2]
+				// structlog wins over the logging module it wraps
+				if library != "structlog" {
+					library = lib
+				}
+				loggers.use(lib, f.path)
+			}
+			if m := pyClassBasesRegex.FindStringSubmatch(line); m != nil {
+				for _, base := range strings.Split(m[2], ",") {
+					base = strings.TrimSpace(base)
+					if i := strings.LastIndex(base, "."); i >= 0 {
+						base = base[i+1:]
+					}
+					if base != "" && !strings.Contains(base, "=") {
+						bases[m[1]] = append(bases[m[1]], base)
+					}
+				}
+				definedIn[m[1]] = f.path
+			}
+
+			if m := pyExceptRegex.FindStringSubmatch(line); m != nil {
+				exceptIndent = len(m[1])
+			} else if exceptIndent >= 0 && indent <= exceptIndent {
+				exceptIndent = -1
+			}
+			if m := pyRaiseRegex.FindStringSubmatch(line); m != nil && exceptIndent >= 0 && len(m[1]) > exceptIndent {
+				sample := fmt.Sprintf("%s:%d", f.path, n+1)
+				if pyRaiseFromRegex.MatchString(line) {
+					chained.add(f.path, sample)
+				} else {
+					unchained.add(f.path, sample)
+				}
+			}
+
+			switch library {
+			case "structlog":
+				if m := pyStructlogCall.FindStringSubmatch(line); m != nil {
+					for _, kw := range pyKeywordArgRegex.FindAllStringSubmatch(m[1], -1) {
+						loggers.field(library, kw[1])
+					}
+				}
+			case "logging", "loguru":
+				if m := pyLogFormatRegex.FindStringSubmatch(line); m != nil && library == "logging" {
+					if m[1] == "f" {
+						loggers.fstringMessages++
+					} else if strings.Contains(m[2], "%") {
+						loggers.lazyMessages++
+					}
+				}
+				if m := pyExtraRegex.FindStringSubmatch(line); m != nil {
+					for _, key := range quotedDictKeyRegex.FindAllStringSubmatch(m[1], -1) {
+						loggers.field(library, key[1])
+					}
+				}
+			}
+		}
+	}
+
+	const lang = "Python"
+	var practices []types.ErrorPractice
+
+	if root, members := pythonExceptionRoot(bases); root != "" {
+		hierarchy := &errorFinding{}
+		hierarchy.add(definedIn[root], root)
+		for _, member := range members {
+			hierarchy.add(definedIn[member], member)
+		}
+		practices = append(practices, practice(lang, ErrorPracticeHierarchy,
+			fmt.Sprintf("Raise exceptions from the project's `%s` hierarchy (defined in `%s`) instead of bare `Exception` or built-in errors", root, definedIn[root]),
+			hierarchy, 0))
+	}
+
+	if total := chained.count + unchained.count; chained.count >= minErrorFindings && chained.count*10 >= total*7 {
+		practices = append(practices, practice(lang, ErrorPracticeChaining,
+			"Chain exceptions raised while handling another with `raise NewError(...) from err`", &chained, total))
+	}
+
+	return practices
+}
+
+// pythonExceptionRoot finds the project-defined exception class most other
+// project exceptions derive from, with those descendants
+func pythonExceptionRoot(bases map[string][]string) (string, []string) {
+	isException := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for class, parents := range bases {
+			if isException[class] {
+				continue
+			}
+			for _, parent := range parents {
+				if isException[parent] || strings.HasSuffix(parent, "Error") || strings.HasSuffix(parent, "Exception") {
+					isException[class] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	classes := make([]string, 0, len(isException))
+	for class := range isException {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	best, bestMembers := "", []string(nil)
+	for _, class := range classes {
+		// A root derives only from classes defined outside the project
+		root := true
+		for _, parent := range bases[class] {
+			if isException[parent] {
+				root = false
+			}
+		}
+		if !root {
+			continue
+		}
+		var members []string
+		for _, other := range classes {
+			if other != class && pythonDerivesFrom(other, class, bases) {
+				members = append(members, other)
+			}
+		}
+		if len(members) > len(bestMembers) {
+			best, bestMembers = class, members
+		}
+	}
+	if len(bestMembers) < 2 {
+		return "", nil
+	}
+	return best, bestMembers
+}
+
+func pythonDerivesFrom(class, ancestor string, bases map[string][]string) bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), bases[class]...)
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		if parent == ancestor {
+			return true
+		}
+		if !seen[parent] {
+			seen[parent] = true
+			queue = append(queue, bases[parent]...)
+		}
+	}
+	return false
+}
+
+// jsErrorPractices compares Result-returning code with thrown Error
+// subclasses, and records logger field keys
+func jsErrorPractices(files []sampledFile, loggers *loggerFindings) []types.ErrorPractice {
+	var results, throws, errorClasses errorFinding
+	resultLib := ""
+
+	for _, f := range files {
+		library := ""
+		for n, line := range f.lines {
+			if m := jsLoggerImport.FindStringSubmatch(line); m != nil {
+				library = m[1]
+				loggers.use(library, f.path)
+			}
+			if m := jsResultLibRegex.FindStringSubmatch(line); m != nil {
+				resultLib = m[1]
+				results.add(f.path, fmt.Sprintf("%s:%d", f.path, n+1))
+			} else if jsResultRegex.MatchString(line) {
+				results.add(f.path, fmt.Sprintf("%s:%d", f.path, n+1))
+			}
+			if m := jsErrorClassRegex.FindStringSubmatch(line); m != nil {
+				errorClasses.add(f.path, m[1])
+			}
+			if jsThrowRegex.MatchString(line) {
+				throws.add(f.path, fmt.Sprintf("%s:%d", f.path, n+1))
+			}
+
+			var body string
+			switch library {
+			case "pino", "bunyan":
+				if m := jsFieldsFirstCall.FindStringSubmatch(line); m != nil {
+					body = m[1]
+				}
+			case "winston":
+				if m := jsFieldsAfterCall.FindStringSubmatch(line); m != nil {
+					body = m[1]
+				}
+			}
+			for _, key := range jsObjectKeys(body) {
+				loggers.field(library, key)
+			}
+		}
+	}
+
+	lang := styleLanguageNames["js"]
+	var practices []types.ErrorPractice
+
+	switch {
+	case len(results.files) >= 2 && len(results.files) >= len(throws.files):
+		instruction := "Return `Result` values for expected failures instead of throwing"
+		if resultLib != "" {
+			instruction += fmt.Sprintf(" (`ok()`/`err()` from %s)", resultLib)
+		}
+		practices = append(practices, practice(lang, ErrorPracticeResult, instruction, &results, results.count+throws.count))
+	case throws.count >= minErrorFindings && errorClasses.count >= 2:
+		practices = append(practices, practice(lang, ErrorPracticeThrow,
+			"Throw the project's Error subclasses rather than plain `Error` or strings, so callers can branch on `instanceof`", &errorClasses, 0))
+	}
+
+	return practices
+}
+
+// jsObjectKeys lists the keys of a one-line object literal body
+func jsObjectKeys(body string) []string {
+	var keys []string
+	for _, part := range strings.Split(body, ",") {
+		key := strings.TrimSpace(part)
+		if i := strings.Index(key, ":"); i >= 0 {
+			key = strings.TrimSpace(key[:i])
+		}
+		if m := objectKeyRegex.FindStringSubmatch(key); m != nil && !strings.HasPrefix(key, "...") {
+			keys = append(keys, m[1])
+		}
+	}
+	return keys
+}
+
+// dominantLogger picks the logger imported by the most files and describes
+// how its fields are named
+func dominantLogger(language string, loggers *loggerFindings) *types.LoggerUsage {
+	best, bestFiles, importing := "", 0, 0
+	for library, files := range loggers.files {
+		n := len(files)
+		importing += n
+		if n > bestFiles || n == bestFiles && library < best {
+			best, bestFiles = library, n
+		}
+	}
+	if best == "" || bestFiles < 2 {
+		return nil
+	}
+
+	usage := &types.LoggerUsage{
+		Language:    language,
+		Library:     best,
+		Instruction: loggerInstructions[best],
+		Files:       bestFiles,
+		Confidence:  evidenceConfidence(bestFiles, importing, bestFiles),
+		Evidence: &types.Evidence{
+			Matches: bestFiles,
+			Total:   importing,
+			Files:   bestFiles,
+			Samples: firstFiles(loggers.files[best]),
+		},
+	}
+
+	fields := loggers.fields[best]
+	styles := make(map[string]int)
+	total := 0
+	for key, count := range fields {
+		if style := fieldKeyStyle(key); style != "" {
+			styles[style] += count
+			total += count
+		}
+	}
+	for style := range styles {
+		if total >= minErrorFindings && styles[style]*10 >= total*7 {
+			usage.FieldStyle = style
+			usage.Instruction += ", naming fields in " + style
+		}
+	}
+	if formatted := loggers.lazyMessages + loggers.fstringMessages; best == "logging" &&
+		loggers.lazyMessages >= minErrorFindings && loggers.lazyMessages*10 >= formatted*7 {
+		usage.Instruction += ", passing message arguments lazily: `logger.info(\"loaded %s\", name)`"
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if fields[keys[i]] != fields[keys[j]] {
+			return fields[keys[i]] > fields[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxLoggerFields {
+		keys = keys[:maxLoggerFields]
+	}
+	usage.Fields = keys
+
+	return usage
+}
+
+// fieldKeyStyle classifies a log field key, or returns "" for single words
+func fieldKeyStyle(key string) string {
+	lower := strings.ToLower(key)
+	switch {
+	case strings.Contains(key, "."):
+		return "dot.case"
+	case strings.Contains(key, "_") && key == lower:
+		return "snake_case"
+	case strings.Contains(key, "-"):
+		return "kebab-case"
+	case key != lower && key[0] >= 'a' && key[0] <= 'z':
+		return "camelCase"
+	}
+	return ""
+}
+
+// ErrorPracticesFor returns the practices detected for one language
+func ErrorPracticesFor(info *types.ErrorHandling, language string) []types.ErrorPractice {
+	if info == nil {
+		return nil
+	}
+	var practices []types.ErrorPractice
+	for _, p := range info.Practices {
+		if p.Language == language {
+			practices = append(practices, p)
+		}
+	}
+	return practices
+}
+
+// ErrorHandlingAbove returns a copy of the error handling findings without
+// the practices and loggers scored below the threshold
+func ErrorHandlingAbove(info *types.ErrorHandling, threshold float64) *types.ErrorHandling {
+	if info == nil || threshold <= 0 {
+		return info
+	}
+	kept := &types.ErrorHandling{}
+	for _, p := range info.Practices {
+		if AboveConfidence(p.Confidence, threshold) {
+			kept.Practices = append(kept.Practices, p)
+		}
+	}
+	for _, l := range info.Loggers {
+		if AboveConfidence(l.Confidence, threshold) {
+			kept.Loggers = append(kept.Loggers, l)
+		}
+	}
+	if len(kept.Practices) == 0 && len(kept.Loggers) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// LoggerFor returns the logger detected for one language, or nil
+func LoggerFor(info *types.ErrorHandling, language string) *types.LoggerUsage {
+	if info == nil {
+		return nil
+	}
+	for i := range info.Loggers {
+		if info.Loggers[i].Language == language {
+			return &info.Loggers[i]
+		}
+	}
+	return nil
+}
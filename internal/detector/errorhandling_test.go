@@ -0,0 +1,249 @@
+package detector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func practiceKinds(practices []types.ErrorPractice) map[string]types.ErrorPractice {
+	kinds := make(map[string]types.ErrorPractice)
+	for _, p := range practices {
+		kinds[p.Kind] = p
+	}
+	return kinds
+}
+
+func TestErrorHandlingDetector_Go(t *testing.T) {
+	store := `package store
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type ValidationError struct{ Field string }
+
+func (e *ValidationError) Error() string { return e.Field }
+
+func Load(id string) error {
+	if err := read(id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return err
+		}
+		slog.Error("load failed", "user_id", id, "request_id", id)
+		return fmt.Errorf("load %s: %w", id, err)
+	}
+	return nil
+}
+`
+	other := `package store
+
+import "errors"
+
+var ErrConflict = errors.New("conflict")
+
+type QuotaError struct{}
+
+func (QuotaError) Error() string { return "quota" }
+
+func MustOpen(path string) *DB {
+	db, err := open(path)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+`
+	files := map[string]string{}
+	repeatFixture(files, "internal/store/load%d.go", 3, store)
+	files["internal/store/db.go"] = other
+	files["internal/store/load_test.go"] = "package store\n\nfunc helper() { panic(\"boom\") }\n"
+
+	tmpDir, infos := writeProjectFixture(t, files)
+	info := NewErrorHandlingDetector(tmpDir, infos).Detect()
+	if info == nil {
+		t.Fatal("expected error handling info")
+	}
+
+	kinds := practiceKinds(ErrorPracticesFor(info, "Go"))
+	for _, kind := range []string{ErrorPracticeWrapping, ErrorPracticeSentinel, ErrorPracticeCustomType, ErrorPracticePanic} {
+		if _, ok := kinds[kind]; !ok {
+			t.Errorf("missing %s practice, got %+v", kind, info.Practices)
+		}
+	}
+	if got := kinds[ErrorPracticeSentinel].Examples; !reflect.DeepEqual(got, []string{"ErrConflict", "ErrNotFound"}) {
+		t.Errorf("sentinel examples = %v", got)
+	}
+	if got := kinds[ErrorPracticeCustomType].Examples; !reflect.DeepEqual(got, []string{"QuotaError", "ValidationError"}) {
+		t.Errorf("custom type examples = %v", got)
+	}
+	if got := kinds[ErrorPracticePanic].Examples; !reflect.DeepEqual(got, []string{"MustOpen"}) {
+		t.Errorf("panic examples = %v", got)
+	}
+	if got := kinds[ErrorPracticeWrapping].Examples[0]; got != "internal/store/load0.go:21" {
+		t.Errorf("wrapping sample = %q", got)
+	}
+	for kind, p := range kinds {
+		if p.Confidence <= 0 || p.Confidence > 1 || p.Evidence == nil || len(p.Evidence.Samples) == 0 {
+			t.Errorf("%s practice lacks a score or evidence: %+v", kind, p)
+		}
+	}
+	if e := kinds[ErrorPracticeWrapping].Evidence; e.Samples[0] != "internal/store/load0.go:21" || e.Total < e.Matches {
+		t.Errorf("wrapping evidence = %+v", e)
+	}
+	if e := kinds[ErrorPracticeSentinel].Evidence; e.Samples[0] != "internal/store/db.go" {
+		t.Errorf("sentinel evidence should sample files, got %+v", e)
+	}
+
+	logger := LoggerFor(info, "Go")
+	if logger == nil {
+		t.Fatal("expected a Go logger")
+	}
+	if logger.Library != "slog" || logger.FieldStyle != "snake_case" {
+		t.Errorf("logger = %+v, want slog with snake_case fields", logger)
+	}
+	if logger.Confidence <= 0 || logger.Evidence == nil || logger.Evidence.Files != logger.Files {
+		t.Errorf("logger lacks a score or evidence: %+v", logger)
+	}
+	if !reflect.DeepEqual(logger.Fields, []string{"request_id", "user_id"}) {
+		t.Errorf("fields = %v", logger.Fields)
+	}
+}
+
+func TestErrorHandlingDetector_Python(t *testing.T) {
+	errorsPy := `class AppError(Exception):
+    pass
+
+
+class NotFoundError(AppError):
+    pass
+
+
+class AuthError(AppError):
+    pass
+`
+	service := `import structlog
+
+log = structlog.get_logger()
+
+
+def fetch(user_id):
+    try:
+        return client.get(user_id)
+    except KeyError as err:
+        log.error("fetch_failed", user_id=user_id, request_id=rid)
+        raise NotFoundError(user_id) from err
+`
+	files := map[string]string{"app/errors.py": errorsPy}
+	repeatFixture(files, "app/service%d.py", 3, service)
+
+	tmpDir, infos := writeProjectFixture(t, files)
+	info := NewErrorHandlingDetector(tmpDir, infos).Detect()
+
+	kinds := practiceKinds(ErrorPracticesFor(info, "Python"))
+	hierarchy, ok := kinds[ErrorPracticeHierarchy]
+	if !ok {
+		t.Fatalf("missing hierarchy practice, got %+v", info)
+	}
+	if !reflect.DeepEqual(hierarchy.Examples, []string{"AppError", "AuthError", "NotFoundError"}) {
+		t.Errorf("hierarchy examples = %v", hierarchy.Examples)
+	}
+	if _, ok := kinds[ErrorPracticeChaining]; !ok {
+		t.Errorf("missing chaining practice")
+	}
+
+	logger := LoggerFor(info, "Python")
+	if logger == nil || logger.Library != "structlog" || logger.FieldStyle != "snake_case" {
+		t.Errorf("logger = %+v, want structlog with snake_case fields", logger)
+	}
+}
+
+func TestErrorHandlingDetector_PythonLazyLogging(t *testing.T) {
+	files := map[string]string{}
+	repeatFixture(files, "app/job%d.py", 3, `import logging
+
+logger = logging.getLogger(__name__)
+
+
+def run(name):
+    logger.info("loaded %s", name)
+`)
+
+	tmpDir, infos := writeProjectFixture(t, files)
+	logger := LoggerFor(NewErrorHandlingDetector(tmpDir, infos).Detect(), "Python")
+	if logger == nil || logger.Library != "logging" || !strings.Contains(logger.Instruction, "passing message arguments lazily") {
+		t.Errorf("logger = %+v, want the logging module with lazy arguments", logger)
+	}
+}
+
+func TestErrorHandlingDetector_JavaScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		kind    string
+		library string
+		style   string
+	}{
+		{
+			name: "throwing error subclasses",
+			source: `import pino from 'pino'
+
+export class NotFoundError extends Error {}
+export class AuthError extends Error {}
+
+export function load(id) {
+  logger.info({ userId: id, requestId: id }, 'loading')
+  if (!id) throw new NotFoundError(id)
+}
+`,
+			kind:    ErrorPracticeThrow,
+			library: "pino",
+			style:   "camelCase",
+		},
+		{
+			name: "result values",
+			source: `import { ok, err, Result } from 'neverthrow'
+import winston from 'winston'
+
+export function parse(input: string): Result<number, string> {
+  logger.info('parsing', { input_length: input.length, source_id: 1 })
+  return input ? ok(1) : err('empty')
+}
+`,
+			kind:    ErrorPracticeResult,
+			library: "winston",
+			style:   "snake_case",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{}
+			repeatFixture(files, "src/mod%d.ts", 3, tt.source)
+			tmpDir, infos := writeProjectFixture(t, files)
+			info := NewErrorHandlingDetector(tmpDir, infos).Detect()
+
+			kinds := practiceKinds(ErrorPracticesFor(info, "JavaScript/TypeScript"))
+			if _, ok := kinds[tt.kind]; !ok || len(kinds) != 1 {
+				t.Errorf("practices = %+v, want only %s", kinds, tt.kind)
+			}
+			logger := LoggerFor(info, "JavaScript/TypeScript")
+			if logger == nil || logger.Library != tt.library || logger.FieldStyle != tt.style {
+				t.Errorf("logger = %+v, want %s with %s fields", logger, tt.library, tt.style)
+			}
+		})
+	}
+}
+
+func TestErrorHandlingDetector_Empty(t *testing.T) {
+	tmpDir, infos := writeProjectFixture(t, map[string]string{"README.md": "# app\n"})
+	if info := NewErrorHandlingDetector(tmpDir, infos).Detect(); info != nil {
+		t.Errorf("expected nil, got %+v", info)
+	}
+}
@@ -0,0 +1,522 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Event roles
+const (
+	EventRoleProducer = "producer"
+	EventRoleConsumer = "consumer"
+)
+
+// EventDetector detects message queue and event bus producers and consumers
+// (Kafka, NATS, RabbitMQ, SQS, NestJS microservices)
+type EventDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewEventDetector creates a new event detector
+func NewEventDetector(rootPath string, files []types.FileInfo) *EventDetector {
+	return &EventDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// eventArg locates a value in a client call's arguments. pos is 1-based, with
+// -1 meaning the last argument and 0 meaning positional lookup is not used.
+// key is checked first and matches keyword arguments (routing_key="x"),
+// object properties ({ topic: 'x' }), struct fields (Topic: "x") and
+// annotation attributes (topics = "x"); alternatives are separated by |.
+type eventArg struct {
+	pos int
+	key string
+}
+
+// eventPattern describes one client call that produces or consumes messages.
+// regex must end at the opening ( or { of the call or literal.
+type eventPattern struct {
+	role       string
+	regex      *regexp.Regexp
+	topic      eventArg
+	exchange   eventArg // RabbitMQ exchange, shown as exchange/routing-key
+	handler    eventArg
+	annotation bool // handler is the method declared after the annotation
+}
+
+// eventBroker groups the client patterns of one broker per language. A file
+// is only scanned for a broker's patterns when it references the broker's
+// client library, which keeps generic names like publish() and send() from
+// matching unrelated code.
+type eventBroker struct {
+	name     string
+	marker   *regexp.Regexp
+	patterns map[string][]eventPattern // keyed by language
+}
+
+func eventPatterns(role string, topic eventArg, regexes ...string) []eventPattern {
+	patterns := make([]eventPattern, 0, len(regexes))
+	for _, r := range regexes {
+		patterns = append(patterns, eventPattern{role: role, regex: regexp.MustCompile(r), topic: topic})
+	}
+	return patterns
+}
+
+func eventPatternWith(role, regex string, topic, handler eventArg) eventPattern {
+	return eventPattern{role: role, regex: regexp.MustCompile(regex), topic: topic, handler: handler}
+}
+
+func eventAnnotation(role, regex string, topic eventArg) eventPattern {
+	return eventPattern{role: role, regex: regexp.MustCompile(regex), topic: topic, annotation: true}
+}
+
+func rabbitPublish(regex string, exchange, key eventArg) eventPattern {
+	return eventPattern{role: EventRoleProducer, regex: regexp.MustCompile(regex), topic: key, exchange: exchange}
+}
+
+var eventBrokers = []eventBroker{
+	{
+		name:   "Kafka",
+		marker: regexp.MustCompile(`(?i)kafka|sarama`),
+		patterns: map[string][]eventPattern{
+			"go": append(append(append(
+				eventPatterns(EventRoleProducer, eventArg{key: "Topic"},
+					`kafka\.(?:Writer|WriterConfig)\s*\{`,
+					`sarama\.ProducerMessage\s*\{`,
+					`kafka\.TopicPartition\s*\{`),
+				eventPatterns(EventRoleConsumer, eventArg{key: "Topic"},
+					`kafka\.ReaderConfig\s*\{`)...),
+				eventPatterns(EventRoleConsumer, eventArg{pos: 1},
+					`\.ConsumePartition\s*\(`,
+					`\.SubscribeTopics\s*\(`)...),
+				eventPatternWith(EventRoleConsumer, `\.Consume\s*\(`, eventArg{pos: 2}, eventArg{pos: 3})),
+			"python": append(
+				eventPatterns(EventRoleProducer, eventArg{pos: 1, key: "topic"},
+					`\.(?:send|send_and_wait|produce)\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{pos: 1, key: "topics"},
+					`\b(?:AIO)?KafkaConsumer\s*\(`,
+					`\.subscribe\s*\(`)...),
+			"js": append(
+				eventPatterns(EventRoleProducer, eventArg{key: "topic"},
+					`\.send\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{key: "topic|topics"},
+					`\.subscribe\s*\(`)...),
+			"java": append(append(
+				eventPatterns(EventRoleProducer, eventArg{pos: 1},
+					`\w*[Tt]emplate\.send\s*\(`,
+					`new\s+ProducerRecord\s*(?:<[^>]*>)?\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{pos: 1},
+					`\.subscribe\s*\(`)...),
+				eventAnnotation(EventRoleConsumer, `@KafkaListener\s*\(`, eventArg{pos: 1, key: "topics"})),
+		},
+	},
+	{
+		name:   "NATS",
+		marker: regexp.MustCompile(`nats-io/|io\.nats|\bimport nats\b|\bfrom nats\b|['"]nats['"]`),
+		patterns: map[string][]eventPattern{
+			"go": append(
+				eventPatterns(EventRoleProducer, eventArg{pos: 1},
+					`\.(?:Publish|PublishMsg|Request)\s*\(`),
+				eventPatternWith(EventRoleConsumer, `\.(?:Subscribe|QueueSubscribe|ChanSubscribe|PullSubscribe|SubscribeSync)\s*\(`,
+					eventArg{pos: 1}, eventArg{pos: -1})),
+			"python": {
+				eventPatternWith(EventRoleProducer, `\.(?:publish|request)\s*\(`, eventArg{pos: 1, key: "subject"}, eventArg{}),
+				eventPatternWith(EventRoleConsumer, `\.subscribe\s*\(`, eventArg{pos: 1, key: "subject"}, eventArg{key: "cb"}),
+			},
+			"js": append(
+				eventPatterns(EventRoleProducer, eventArg{pos: 1},
+					`\.(?:publish|request)\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{pos: 1},
+					`\.subscribe\s*\(`)...),
+			"java": append(
+				eventPatterns(EventRoleProducer, eventArg{pos: 1},
+					`\.publish\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{pos: 1},
+					`\.subscribe\s*\(`)...),
+		},
+	},
+	{
+		name:   "RabbitMQ",
+		marker: regexp.MustCompile(`(?i)amqp|pika|rabbit`),
+		patterns: map[string][]eventPattern{
+			"go": {
+				rabbitPublish(`\.Publish\s*\(`, eventArg{pos: 1}, eventArg{pos: 2}),
+				rabbitPublish(`\.PublishWithContext\s*\(`, eventArg{pos: 2}, eventArg{pos: 3}),
+				eventPatternWith(EventRoleConsumer, `\.Consume\s*\(`, eventArg{pos: 1}, eventArg{}),
+				eventPatternWith(EventRoleConsumer, `\.ConsumeWithContext\s*\(`, eventArg{pos: 2}, eventArg{}),
+			},
+			"python": {
+				rabbitPublish(`\.basic_publish\s*\(`, eventArg{pos: 1, key: "exchange"}, eventArg{pos: 2, key: "routing_key"}),
+				rabbitPublish(`\.publish\s*\(`, eventArg{}, eventArg{key: "routing_key"}),
+				eventPatternWith(EventRoleConsumer, `\.basic_consume\s*\(`, eventArg{pos: 1, key: "queue"}, eventArg{pos: 2, key: "on_message_callback"}),
+			},
+			"js": {
+				eventPatternWith(EventRoleProducer, `\.sendToQueue\s*\(`, eventArg{pos: 1}, eventArg{}),
+				rabbitPublish(`\.publish\s*\(`, eventArg{pos: 1}, eventArg{pos: 2}),
+				eventPatternWith(EventRoleConsumer, `\.consume\s*\(`, eventArg{pos: 1}, eventArg{pos: 2}),
+			},
+			"java": {
+				eventPatternWith(EventRoleProducer, `\.convertAndSend\s*\(`, eventArg{pos: 1}, eventArg{}),
+				rabbitPublish(`\.basicPublish\s*\(`, eventArg{pos: 1}, eventArg{pos: 2}),
+				eventPatternWith(EventRoleConsumer, `\.basicConsume\s*\(`, eventArg{pos: 1}, eventArg{}),
+				eventAnnotation(EventRoleConsumer, `@RabbitListener\s*\(`, eventArg{pos: 1, key: "queues"}),
+			},
+		},
+	},
+	{
+		name:   "SQS",
+		marker: regexp.MustCompile(`(?i)sqs`),
+		patterns: map[string][]eventPattern{
+			"go": append(
+				eventPatterns(EventRoleProducer, eventArg{key: "QueueUrl"},
+					`sqs\.SendMessage(?:Batch)?Input\s*\{`),
+				eventPatterns(EventRoleConsumer, eventArg{key: "QueueUrl"},
+					`sqs\.ReceiveMessageInput\s*\{`)...),
+			"python": append(
+				eventPatterns(EventRoleProducer, eventArg{key: "QueueUrl"},
+					`\.send_message(?:_batch)?\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{key: "QueueUrl"},
+					`\.receive_message\s*\(`)...),
+			"js": append(
+				eventPatterns(EventRoleProducer, eventArg{key: "QueueUrl"},
+					`new\s+SendMessage(?:Batch)?Command\s*\(`,
+					`\.sendMessage(?:Batch)?\s*\(`),
+				eventPatterns(EventRoleConsumer, eventArg{key: "QueueUrl"},
+					`new\s+ReceiveMessageCommand\s*\(`,
+					`\.receiveMessage\s*\(`)...),
+			"java": {
+				eventAnnotation(EventRoleConsumer, `@SqsListener\s*\(`, eventArg{pos: 1, key: "value"}),
+			},
+		},
+	},
+	{
+		name:   "NestJS microservices",
+		marker: regexp.MustCompile(`@nestjs/microservices`),
+		patterns: map[string][]eventPattern{
+			"js": {
+				eventAnnotation(EventRoleConsumer, `@(?:EventPattern|MessagePattern)\s*\(`, eventArg{pos: 1}),
+				eventPatternWith(EventRoleProducer, `\.(?:emit|send)\s*\(`, eventArg{pos: 1}, eventArg{}),
+			},
+		},
+	},
+}
+
+var (
+	// eventConstRegex finds string constants a topic argument may refer to:
+	// const OrdersTopic = "orders", ORDERS_TOPIC = 'orders', String TOPIC = "orders"
+	eventConstRegex = regexp.MustCompile(`(\w+)\s*(?::\s*\w+\s*)?(?::=|=)\s*["'\x60]([^"'\x60\n]+)["'\x60]`)
+
+	// eventTopicNameRegex accepts unresolved identifiers that read like topic names
+	eventTopicNameRegex = regexp.MustCompile(`(?i:topic|subject|queue|channel|stream|exchange|event)|^[A-Z][A-Z0-9_]*$`)
+
+	// eventMethodRegex finds the method declared after a listener annotation
+	eventMethodRegex = regexp.MustCompile(`(\w+)\s*\(`)
+
+	eventIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+)
+
+// Detect finds all message producers and consumers in the codebase
+func (d *EventDetector) Detect() []types.Event {
+	var events []types.Event
+	seen := make(map[string]bool)
+
+	for _, f := range d.files {
+		if f.IsDir || shouldSkipForEndpoints(f.Path) {
+			continue
+		}
+		lang := eventLanguage(f.Extension)
+		if lang == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil || len(content) > 500000 {
+			continue
+		}
+		text := string(content)
+
+		var brokers []eventBroker
+		for _, b := range eventBrokers {
+			if len(b.patterns[lang]) > 0 && b.marker.MatchString(text) {
+				brokers = append(brokers, b)
+			}
+		}
+		if len(brokers) == 0 {
+			continue
+		}
+
+		constants := eventConstants(text)
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "*") {
+				continue
+			}
+			for _, b := range brokers {
+				for _, p := range b.patterns[lang] {
+					for _, loc := range p.regex.FindAllStringIndex(line, -1) {
+						for _, ev := range d.matchEvent(b.name, p, lines, i, loc[1], constants) {
+							ev.File = f.Path
+							ev.Line = i + 1
+							key := fmt.Sprintf("%s|%s|%s|%s|%d", ev.Broker, ev.Role, ev.Topic, ev.File, ev.Line)
+							if seen[key] {
+								continue
+							}
+							seen[key] = true
+							events = append(events, ev)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Broker != events[j].Broker {
+			return events[i].Broker < events[j].Broker
+		}
+		if events[i].Topic != events[j].Topic {
+			return events[i].Topic < events[j].Topic
+		}
+		if events[i].Role != events[j].Role {
+			return events[i].Role > events[j].Role // producers before consumers
+		}
+		if events[i].File != events[j].File {
+			return events[i].File < events[j].File
+		}
+		return events[i].Line < events[j].Line
+	})
+
+	return events
+}
+
+// matchEvent builds the events for one pattern match whose opening delimiter
+// ends at col on lines[lineIdx]. A call listing several topics yields one
+// event per topic.
+func (d *EventDetector) matchEvent(broker string, p eventPattern, lines []string, lineIdx, col int, constants map[string]string) []types.Event {
+	args, endLine := callArgsFrom(lines, lineIdx, col)
+	if len(args) == 0 {
+		return nil
+	}
+
+	exchange := ""
+	if ex := eventTopics(eventArgValue(args, p.exchange), constants); len(ex) > 0 {
+		exchange = ex[0]
+	}
+
+	topics := eventTopics(eventArgValue(args, p.topic), constants)
+	if len(topics) == 0 {
+		// Fanout exchanges are published to with an empty routing key
+		if exchange == "" {
+			return nil
+		}
+		topics, exchange = []string{exchange}, ""
+	}
+
+	handler := ""
+	if p.annotation {
+		handler = annotatedMethodName(lines, endLine)
+	} else if h := eventArgValue(args, p.handler); h != "" {
+		if name := calleeName(h); name != "" && eventIdentifierRegex.MatchString(h) {
+			handler = name
+		}
+	}
+
+	events := make([]types.Event, 0, len(topics))
+	for _, topic := range topics {
+		if exchange != "" {
+			topic = exchange + "/" + topic
+		}
+		events = append(events, types.Event{
+			Broker:  broker,
+			Role:    p.role,
+			Topic:   topic,
+			Handler: handler,
+		})
+	}
+	return events
+}
+
+// callArgsFrom splits the arguments of a call or literal whose opening
+// delimiter ends at pos on lines[lineIdx], joining following lines for
+// multi-line calls. It also returns the line the arguments end on.
+func callArgsFrom(lines []string, lineIdx, pos int) ([]string, int) {
+	text := lines[lineIdx][pos:]
+	end := lineIdx
+	for i := lineIdx + 1; i < len(lines) && i <= lineIdx+8; i++ {
+		if _, closed := splitCallArgs(text); closed {
+			break
+		}
+		text += "\n" + lines[i]
+		end = i
+	}
+	args, _ := splitCallArgs(text)
+	return args, end
+}
+
+// eventArgValue returns the raw argument text selected by ref
+func eventArgValue(args []string, ref eventArg) string {
+	if ref.key != "" {
+		for _, key := range strings.Split(ref.key, "|") {
+			if v, ok := keyedArgValue(args, key); ok {
+				return v
+			}
+		}
+	}
+	switch {
+	case ref.pos > 0 && ref.pos <= len(args):
+		if _, _, keyed := splitKeyedArg(args[ref.pos-1]); !keyed {
+			return args[ref.pos-1]
+		}
+	case ref.pos == -1 && len(args) > 0:
+		return args[len(args)-1]
+	}
+	return ""
+}
+
+// keyedArgValue finds key among keyword arguments, annotation attributes,
+// struct fields and the properties of object literal arguments
+func keyedArgValue(args []string, key string) (string, bool) {
+	for _, arg := range args {
+		if k, v, ok := splitKeyedArg(arg); ok && k == key {
+			return v, true
+		}
+		if strings.HasPrefix(arg, "{") {
+			fields, _ := splitCallArgs(arg[1:])
+			if v, ok := keyedArgValue(fields, key); ok {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitKeyedArg splits key=value, key = value and key: value arguments
+func splitKeyedArg(arg string) (string, string, bool) {
+	end := 0
+	for end < len(arg) && (arg[end] == '_' || (arg[end] >= 'a' && arg[end] <= 'z') || (arg[end] >= 'A' && arg[end] <= 'Z') || (arg[end] >= '0' && arg[end] <= '9')) {
+		end++
+	}
+	if end == 0 {
+		return "", "", false
+	}
+	rest := strings.TrimSpace(arg[end:])
+	if strings.HasPrefix(rest, "==") || strings.HasPrefix(rest, "=>") || strings.HasPrefix(rest, "::") || strings.HasPrefix(rest, ":=") {
+		return "", "", false
+	}
+	if strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":") {
+		return arg[:end], strings.TrimSpace(rest[1:]), true
+	}
+	return "", "", false
+}
+
+// eventTopics turns an argument into topic names. Lists ([]string{...},
+// ["a", "b"], Arrays.asList(...), {"a", "b"}) yield every element; constants
+// defined in the same file are resolved and SQS queue URLs are reduced to the
+// queue name.
+func eventTopics(value string, constants map[string]string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	// Unwrap helpers around a single value: aws.String("x"), &topic, *topic
+	value = strings.TrimLeft(value, "&*")
+	for _, prefix := range []string{"aws.String(", "String(", "str("} {
+		if strings.HasPrefix(value, prefix) && strings.HasSuffix(value, ")") {
+			value = strings.TrimSpace(value[len(prefix) : len(value)-1])
+		}
+	}
+
+	// List literals
+	if i := strings.IndexAny(value, "[{("); i >= 0 && !isQuoted(value) {
+		if strings.HasPrefix(value, "[]string{") {
+			i = len("[]string")
+		}
+		head := value[:i]
+		if head == "" || head == "[]string" || head == "List.of" || head == "Arrays.asList" ||
+			head == "Collections.singletonList" || head == "Set.of" || head == "new String[]" {
+			elements, _ := splitCallArgs(value[i+1:])
+			var topics []string
+			for _, el := range elements {
+				topics = append(topics, eventTopics(el, constants)...)
+			}
+			return topics
+		}
+		return nil
+	}
+
+	var topic string
+	switch {
+	case isQuoted(value):
+		topic = unquote(value)
+	case eventIdentifierRegex.MatchString(value):
+		name := value[strings.LastIndex(value, ".")+1:]
+		if resolved, ok := constants[name]; ok {
+			topic = resolved
+		} else if eventTopicNameRegex.MatchString(name) {
+			topic = value
+		}
+	}
+
+	topic = strings.TrimSpace(topic)
+	if strings.HasPrefix(topic, "https://sqs.") || strings.HasPrefix(topic, "http://") {
+		topic = path.Base(topic)
+	}
+	if topic == "" || strings.ContainsAny(topic, " \n") {
+		return nil
+	}
+	return []string{topic}
+}
+
+// eventConstants collects string constants defined in a source file
+func eventConstants(text string) map[string]string {
+	constants := make(map[string]string)
+	for _, m := range eventConstRegex.FindAllStringSubmatch(text, -1) {
+		if _, exists := constants[m[1]]; !exists {
+			constants[m[1]] = m[2]
+		}
+	}
+	return constants
+}
+
+// annotatedMethodName returns the method declared after an annotation whose
+// arguments end on lines[endLine], skipping further annotations
+func annotatedMethodName(lines []string, endLine int) string {
+	for i := endLine + 1; i < len(lines) && i <= endLine+10; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "@") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		if m := eventMethodRegex.FindStringSubmatch(trimmed); m != nil {
+			return m[1]
+		}
+		return ""
+	}
+	return ""
+}
+
+// eventLanguage maps a file extension to the client pattern set it uses
+func eventLanguage(ext string) string {
+	switch ext {
+	case ".go":
+		return "go"
+	case ".py":
+		return "python"
+	case ".js", ".ts", ".mjs", ".cjs":
+		return "js"
+	case ".java", ".kt":
+		return "java"
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func findEvent(events []types.Event, broker, role, topic string) *types.Event {
+	for i := range events {
+		if events[i].Broker == broker && events[i].Role == role && events[i].Topic == topic {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+func TestEventDetector_Detect(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"orders/publisher.go": `package orders
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+const ordersTopic = "orders.created"
+
+func Publish(nc *nats.Conn) {
+	w := &kafka.Writer{
+		Addr:  kafka.TCP("localhost:9092"),
+		Topic: ordersTopic,
+	}
+	nc.Publish("billing.charge", data)
+	nc.QueueSubscribe("billing.result", "workers", handleResult)
+}
+`,
+		"worker/consumer.py": `import pika
+from kafka import KafkaConsumer
+
+consumer = KafkaConsumer("orders.created", bootstrap_servers="kafka:9092")
+channel.basic_publish(exchange="", routing_key="emails", body=payload)
+channel.basic_consume(queue="emails", on_message_callback=send_email)
+sock.send(data)
+`,
+		"src/events.ts": `import { Kafka } from 'kafkajs';
+import amqp from 'amqplib';
+
+await producer.send({ topic: 'audit-log', messages });
+await consumer.subscribe({ topics: ['orders.created', 'orders.cancelled'] });
+ch.publish('notifications', '', Buffer.from(msg));
+`,
+		"src/main/java/Listener.java": `import org.springframework.kafka.annotation.KafkaListener;
+
+public class Listener {
+    private static final String TOPIC = "payments";
+
+    @KafkaListener(topics = "orders.created", groupId = "billing")
+    public void onOrder(String message) {
+        kafkaTemplate.send(TOPIC, message);
+    }
+}
+`,
+	})
+
+	events := NewEventDetector(tmpDir, files).Detect()
+
+	tests := []struct {
+		broker, role, topic, handler string
+	}{
+		{"Kafka", EventRoleProducer, "orders.created", ""},
+		{"NATS", EventRoleProducer, "billing.charge", ""},
+		{"NATS", EventRoleConsumer, "billing.result", "handleResult"},
+		{"Kafka", EventRoleConsumer, "orders.created", ""},
+		{"RabbitMQ", EventRoleProducer, "emails", ""},
+		{"RabbitMQ", EventRoleConsumer, "emails", "send_email"},
+		{"Kafka", EventRoleProducer, "audit-log", ""},
+		{"Kafka", EventRoleConsumer, "orders.cancelled", ""},
+		{"RabbitMQ", EventRoleProducer, "notifications", ""},
+		{"Kafka", EventRoleProducer, "payments", ""},
+	}
+
+	for _, tt := range tests {
+		ev := findEvent(events, tt.broker, tt.role, tt.topic)
+		if ev == nil {
+			t.Errorf("expected %s %s for %q", tt.broker, tt.role, tt.topic)
+			continue
+		}
+		if ev.Handler != tt.handler {
+			t.Errorf("%s %s %q: expected handler %q, got %q", tt.broker, tt.role, tt.topic, tt.handler, ev.Handler)
+		}
+	}
+
+	// The Spring listener resolves to the annotated method
+	found := false
+	for _, ev := range events {
+		if ev.File == "src/main/java/Listener.java" && ev.Role == EventRoleConsumer && ev.Topic == "orders.created" {
+			found = true
+			if ev.Handler != "onOrder" || ev.Line != 6 {
+				t.Errorf("expected @KafkaListener handler onOrder on line 6, got %+v", ev)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected @KafkaListener consumer")
+	}
+
+	// Unrelated send() calls are not reported
+	for _, ev := range events {
+		if ev.Topic == "data" {
+			t.Errorf("unexpected event for sock.send(data): %+v", ev)
+		}
+	}
+}
+
+func TestEventDetector_IgnoresFilesWithoutClients(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"src/emitter.ts": `emitter.publish('orders', payload);
+socket.send({ topic: 'x' });
+`,
+	})
+
+	if events := NewEventDetector(tmpDir, files).Detect(); len(events) != 0 {
+		t.Errorf("expected no events without a broker client, got %+v", events)
+	}
+}
+
+func TestEventTopics(t *testing.T) {
+	constants := map[string]string{"ORDERS": "orders"}
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{`"orders"`, []string{"orders"}},
+		{`[]string{"a", "b"}`, []string{"a", "b"}},
+		{`Arrays.asList("a", ORDERS)`, []string{"a", "orders"}},
+		{`aws.String("https://sqs.us-east-1.amazonaws.com/123/jobs")`, []string{"jobs"}},
+		{`cfg.OrdersTopic`, []string{"cfg.OrdersTopic"}},
+		{`payload`, nil},
+	}
+	for _, tt := range tests {
+		got := eventTopics(tt.value, constants)
+		if len(got) != len(tt.expected) {
+			t.Errorf("eventTopics(%q) = %v, expected %v", tt.value, got, tt.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("eventTopics(%q) = %v, expected %v", tt.value, got, tt.expected)
+			}
+		}
+	}
+}
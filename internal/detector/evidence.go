@@ -0,0 +1,187 @@
+package detector
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+const (
+	// maxEvidenceSamples caps the path:line samples kept per finding
+	maxEvidenceSamples = 3
+	// minScoredConfidence is the lowest score a finding gets, so a scored
+	// finding is never mistaken for an unscored one (0)
+	minScoredConfidence = 0.01
+)
+
+// manifestSources are files a pattern can be declared in rather than observed in code
+var manifestSources = map[string]bool{
+	"package.json": true, "go.mod": true, "Cargo.toml": true, "pyproject.toml": true,
+	"requirements.txt": true, "pom.xml": true, "build.gradle": true, "build.gradle.kts": true,
+}
+
+// presenceConfidence scores a finding with no competing alternative by how
+// widely it was seen: a single file scores under 0.5, twenty or more score 1
+func presenceConfidence(files int) float64 {
+	if files <= 0 {
+		return minScoredConfidence
+	}
+	confidence := 0.3 + 0.7*math.Log2(1+float64(files))/math.Log2(21)
+	return math.Min(1, math.Round(confidence*100)/100)
+}
+
+// evidenceConfidence scores a finding seen in files, where matches of the
+// total occurrences examined agree with it
+func evidenceConfidence(matches, total, files int) float64 {
+	if total <= 0 || matches <= 0 {
+		return presenceConfidence(files)
+	}
+	ratio := math.Min(1, float64(matches)/float64(total))
+	return math.Max(minScoredConfidence, math.Round(ratio*presenceConfidence(files)*100)/100)
+}
+
+// countedConvention attaches count evidence to a convention detected by
+// tallying occurrences. total may be 0 when nothing competes with it
+func countedConvention(conv types.Convention, matches, total, files int) types.Convention {
+	conv.Confidence = evidenceConfidence(matches, total, files)
+	conv.Evidence = &types.Evidence{Matches: matches, Total: total, Files: files}
+	return conv
+}
+
+// configConvention marks a convention read directly from a config file
+func configConvention(conv types.Convention, source string) types.Convention {
+	conv.Confidence = 1
+	conv.Evidence = &types.Evidence{Source: source}
+	return conv
+}
+
+// scorePatterns fills in confidence and path:line samples for patterns whose
+// detector only recorded the files they were found in
+func scorePatterns(rootPath string, patterns []types.PatternInfo) {
+	for i := range patterns {
+		p := &patterns[i]
+		if p.Confidence > 0 {
+			continue
+		}
+		evidence := &types.Evidence{Files: p.FileCount}
+		for _, example := range p.Examples {
+			if manifestSources[filepath.Base(example)] {
+				evidence.Source = example
+				continue
+			}
+			if len(evidence.Samples) < maxEvidenceSamples {
+				evidence.Samples = append(evidence.Samples, locateSample(rootPath, example, p.Name))
+			}
+		}
+		p.Evidence = evidence
+		if evidence.Source != "" {
+			p.Confidence = 1
+		} else {
+			p.Confidence = presenceConfidence(p.FileCount)
+		}
+	}
+}
+
+// scoreCodePatterns scores every category of detected code patterns
+func scoreCodePatterns(rootPath string, patterns *types.CodePatterns) {
+	if patterns == nil {
+		return
+	}
+	for _, group := range [][]types.PatternInfo{
+		patterns.StateManagement, patterns.DataFetching, patterns.Routing, patterns.Forms,
+		patterns.Testing, patterns.Styling, patterns.Authentication, patterns.APIPatterns,
+		patterns.DatabaseORM, patterns.Utilities, patterns.GoPatterns, patterns.RustPatterns,
+		patterns.PythonPatterns, patterns.MLPatterns,
+	} {
+		scorePatterns(rootPath, group)
+	}
+}
+
+// locateSample returns path:line for the first line of the file containing
+// needle, or just the path when the file can't be read or doesn't contain it
+func locateSample(rootPath, path, needle string) string {
+	if needle == "" {
+		return path
+	}
+	f, err := os.Open(filepath.Join(rootPath, path))
+	if err != nil {
+		return path
+	}
+	defer f.Close()
+	if fi, err := f.Stat(); err != nil || fi.IsDir() || fi.Size() > maxStyleSampleSize {
+		return path
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.Contains(scanner.Text(), needle) {
+			return fmt.Sprintf("%s:%d", path, line)
+		}
+	}
+	return path
+}
+
+// AboveConfidence reports whether a finding with the given confidence is kept
+// at threshold. Unscored findings (0) and a threshold of 0 keep everything;
+// scored findings are never below minScoredConfidence.
+func AboveConfidence(confidence, threshold float64) bool {
+	return threshold <= 0 || confidence == 0 || confidence >= threshold
+}
+
+// ConventionsAbove drops scored conventions below the threshold. Conventions
+// without a score, such as custom ones from the config, are always kept
+func ConventionsAbove(conventions []types.Convention, threshold float64) []types.Convention {
+	if threshold <= 0 {
+		return conventions
+	}
+	var kept []types.Convention
+	for _, conv := range conventions {
+		if AboveConfidence(conv.Confidence, threshold) {
+			kept = append(kept, conv)
+		}
+	}
+	return kept
+}
+
+// PatternsAbove drops scored patterns below the threshold
+func PatternsAbove(patterns []types.PatternInfo, threshold float64) []types.PatternInfo {
+	if threshold <= 0 {
+		return patterns
+	}
+	var kept []types.PatternInfo
+	for _, p := range patterns {
+		if AboveConfidence(p.Confidence, threshold) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// CodePatternsAbove returns a copy of the code patterns without those below the threshold
+func CodePatternsAbove(patterns *types.CodePatterns, threshold float64) *types.CodePatterns {
+	if patterns == nil || threshold <= 0 {
+		return patterns
+	}
+	return &types.CodePatterns{
+		StateManagement: PatternsAbove(patterns.StateManagement, threshold),
+		DataFetching:    PatternsAbove(patterns.DataFetching, threshold),
+		Routing:         PatternsAbove(patterns.Routing, threshold),
+		Forms:           PatternsAbove(patterns.Forms, threshold),
+		Testing:         PatternsAbove(patterns.Testing, threshold),
+		Styling:         PatternsAbove(patterns.Styling, threshold),
+		Authentication:  PatternsAbove(patterns.Authentication, threshold),
+		APIPatterns:     PatternsAbove(patterns.APIPatterns, threshold),
+		DatabaseORM:     PatternsAbove(patterns.DatabaseORM, threshold),
+		Utilities:       PatternsAbove(patterns.Utilities, threshold),
+		GoPatterns:      PatternsAbove(patterns.GoPatterns, threshold),
+		RustPatterns:    PatternsAbove(patterns.RustPatterns, threshold),
+		PythonPatterns:  PatternsAbove(patterns.PythonPatterns, threshold),
+		MLPatterns:      PatternsAbove(patterns.MLPatterns, threshold),
+	}
+}
@@ -0,0 +1,117 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestEvidenceConfidence(t *testing.T) {
+	tests := []struct {
+		matches, total, files int
+		want                  float64
+	}{
+		{0, 0, 0, 0.01}, // scored findings never look unscored
+		{1, 1000, 1, 0.01},
+		{1, 0, 1, 0.46},
+		{3, 0, 3, 0.62},
+		{10, 0, 10, 0.85},
+		{400, 0, 400, 1},
+		{9, 10, 20, 0.9},
+		{3, 4, 20, 0.75},
+	}
+	for _, tt := range tests {
+		if got := evidenceConfidence(tt.matches, tt.total, tt.files); got != tt.want {
+			t.Errorf("evidenceConfidence(%d, %d, %d) = %v, want %v", tt.matches, tt.total, tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestScorePatterns(t *testing.T) {
+	tmpDir, _ := writeProjectFixture(t, map[string]string{
+		"src/store.ts": "import { create } from 'zustand'\n\nexport const useStore = create(() => ({}))\n",
+		"src/app.ts":   "const x = 1\n",
+	})
+
+	patterns := []types.PatternInfo{
+		{Name: "useStore", FileCount: 2, Examples: []string{"src/store.ts", "src/app.ts"}},
+		{Name: "serde", FileCount: 1, Examples: []string{"Cargo.toml"}},
+		{Name: "preset", FileCount: 1, Confidence: 0.3},
+	}
+	scorePatterns(tmpDir, patterns)
+
+	if got, want := patterns[0].Evidence.Samples, []string{"src/store.ts:3", "src/app.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("samples = %v, want %v", got, want)
+	}
+	if patterns[0].Confidence != 0.55 {
+		t.Errorf("confidence = %v, want 0.55", patterns[0].Confidence)
+	}
+	if patterns[1].Confidence != 1 || patterns[1].Evidence.Source != "Cargo.toml" {
+		t.Errorf("manifest pattern = %v %+v, want confidence 1 from Cargo.toml", patterns[1].Confidence, patterns[1].Evidence)
+	}
+	if patterns[2].Confidence != 0.3 || patterns[2].Evidence != nil {
+		t.Errorf("pre-scored pattern was rescored: %+v", patterns[2])
+	}
+}
+
+func TestConventionsAbove(t *testing.T) {
+	conventions := []types.Convention{
+		{Description: "strong", Confidence: 0.9},
+		{Description: "weak", Confidence: 0.4},
+		{Description: "custom"},
+	}
+
+	var got []string
+	for _, conv := range ConventionsAbove(conventions, 0.5) {
+		got = append(got, conv.Description)
+	}
+	if want := []string{"strong", "custom"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ConventionsAbove() = %v, want %v", got, want)
+	}
+	if len(ConventionsAbove(conventions, 0)) != 3 {
+		t.Error("a zero threshold should keep everything")
+	}
+}
+
+func TestErrorHandlingAbove(t *testing.T) {
+	info := &types.ErrorHandling{
+		Practices: []types.ErrorPractice{
+			{Kind: ErrorPracticeWrapping, Confidence: 0.9},
+			{Kind: ErrorPracticeSentinel, Confidence: 0.4},
+		},
+		Loggers: []types.LoggerUsage{{Library: "zap", Confidence: 0.3}},
+	}
+
+	kept := ErrorHandlingAbove(info, 0.5)
+	if kept == nil || len(kept.Practices) != 1 || kept.Practices[0].Kind != ErrorPracticeWrapping || len(kept.Loggers) != 0 {
+		t.Errorf("ErrorHandlingAbove() = %+v, want only the wrapping practice", kept)
+	}
+	if len(info.Practices) != 2 || len(info.Loggers) != 1 {
+		t.Error("ErrorHandlingAbove should not modify its input")
+	}
+	if ErrorHandlingAbove(info, 0.95) != nil {
+		t.Error("expected nil when every finding is below the threshold")
+	}
+	if ErrorHandlingAbove(info, 0) != info {
+		t.Error("a zero threshold should keep everything")
+	}
+}
+
+func TestAboveConfidence(t *testing.T) {
+	tests := []struct {
+		confidence, threshold float64
+		expected              bool
+	}{
+		{0.9, 0.5, true},
+		{0.4, 0.5, false},
+		{0, 0.5, true}, // unscored, e.g. custom config conventions
+		{evidenceConfidence(1, 1000, 1), 0.5, false},
+		{0.1, 0, true},
+	}
+	for _, tt := range tests {
+		if got := AboveConfidence(tt.confidence, tt.threshold); got != tt.expected {
+			t.Errorf("AboveConfidence(%v, %v) = %v, expected %v", tt.confidence, tt.threshold, got, tt.expected)
+		}
+	}
+}
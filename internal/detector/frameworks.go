@@ -68,6 +68,7 @@ func (d *FrameworkDetector) detectReactPatterns() []types.Convention {
 	classComponents := 0
 	hooksUsage := make(map[string]int)
 	stateManagement := ""
+	stateFiles := 0
 
 	// Regex patterns
 	functionalRegex := regexp.MustCompile(`(?:export\s+)?(?:const|function)\s+\w+\s*[=:]?\s*(?:\([^)]*\)|[^=])*\s*(?:=>|{)\s*(?:[^}]*)?(?:return\s+)?[(<]`)
@@ -132,17 +133,25 @@ func (d *FrameworkDetector) detectReactPatterns() []types.Convention {
 		}
 
 		// Check state management
+		usesStore := false
 		if reduxRegex.MatchString(contentStr) {
 			stateManagement = "Redux"
+			usesStore = true
 		}
 		if zustandRegex.MatchString(contentStr) {
 			stateManagement = "Zustand"
+			usesStore = true
 		}
 		if jotaiRegex.MatchString(contentStr) {
 			stateManagement = "Jotai"
+			usesStore = true
 		}
 		if recoilRegex.MatchString(contentStr) {
 			stateManagement = "Recoil"
+			usesStore = true
+		}
+		if usesStore {
+			stateFiles++
 		}
 
 		sampledFiles++
@@ -150,55 +159,55 @@ func (d *FrameworkDetector) detectReactPatterns() []types.Convention {
 
 	// Report findings
 	if functionalComponents > classComponents && functionalComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "Functional components with hooks (modern React pattern)",
 			Example:     "const Component = () => { return <div>...</div> }",
-		})
+		}, functionalComponents, functionalComponents+classComponents, functionalComponents))
 	} else if classComponents > functionalComponents && classComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "Class components (legacy React pattern)",
 			Example:     "class Component extends React.Component { render() { ... } }",
-		})
+		}, classComponents, functionalComponents+classComponents, classComponents))
 	}
 
 	// Report hooks usage
 	if hooksUsage["useState"] >= 5 && hooksUsage["useEffect"] >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "Standard React hooks for state and effects",
-		})
+		}, hooksUsage["useState"], 0, hooksUsage["useState"]))
 	}
 
 	if hooksUsage["useContext"] >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "React Context for shared state",
-		})
+		}, hooksUsage["useContext"], 0, hooksUsage["useContext"]))
 	}
 
 	// Report data fetching
 	if hooksUsage["react-query"] >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "TanStack Query (React Query) for server state management",
 			Example:     "const { data, isLoading } = useQuery({ queryKey: ['key'], queryFn })",
-		})
+		}, hooksUsage["react-query"], hooksUsage["react-query"]+hooksUsage["swr"], hooksUsage["react-query"]))
 	} else if hooksUsage["swr"] >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: "SWR for data fetching with caching",
 			Example:     "const { data, error } = useSWR('/api/data', fetcher)",
-		})
+		}, hooksUsage["swr"], hooksUsage["react-query"]+hooksUsage["swr"], hooksUsage["swr"]))
 	}
 
 	// Report state management
 	if stateManagement != "" {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "react",
 			Description: stateManagement + " for global state management",
-		})
+		}, stateFiles, 0, stateFiles))
 	}
 
 	return conventions
@@ -265,35 +274,35 @@ func (d *FrameworkDetector) detectVuePatterns() []types.Convention {
 
 	// Report findings
 	if scriptSetup >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "vue",
 			Description: "Vue 3 <script setup> syntax (recommended)",
 			Example:     "<script setup>\nconst count = ref(0)\n</script>",
-		})
+		}, scriptSetup, 0, scriptSetup))
 	} else if compositionAPI > optionsAPI && compositionAPI >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "vue",
 			Description: "Vue Composition API",
 			Example:     "setup() { const count = ref(0); return { count } }",
-		})
+		}, compositionAPI, compositionAPI+optionsAPI, compositionAPI))
 	} else if optionsAPI >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "vue",
 			Description: "Vue Options API",
 			Example:     "export default { data() { return { count: 0 } } }",
-		})
+		}, optionsAPI, compositionAPI+optionsAPI, optionsAPI))
 	}
 
 	if pinia >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "vue",
 			Description: "Pinia for state management (Vue 3 recommended)",
-		})
+		}, pinia, pinia+vuex, pinia))
 	} else if vuex >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "vue",
 			Description: "Vuex for state management",
-		})
+		}, vuex, pinia+vuex, vuex))
 	}
 
 	return conventions
@@ -364,38 +373,38 @@ func (d *FrameworkDetector) detectAngularPatterns() []types.Convention {
 
 	// Report findings
 	if standaloneComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "angular",
 			Description: "Standalone components (Angular 14+ pattern)",
 			Example:     "@Component({ standalone: true, imports: [...] })",
-		})
+		}, standaloneComponents, standaloneComponents+moduleComponents, standaloneComponents))
 	} else if moduleComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "angular",
 			Description: "NgModule-based architecture",
-		})
+		}, moduleComponents, standaloneComponents+moduleComponents, moduleComponents))
 	}
 
 	if signals >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "angular",
 			Description: "Angular Signals for reactive state (Angular 16+)",
 			Example:     "count = signal(0); doubled = computed(() => count() * 2)",
-		})
+		}, signals, 0, signals))
 	}
 
 	if rxjs >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "angular",
 			Description: "RxJS for reactive programming",
-		})
+		}, rxjs, 0, rxjs))
 	}
 
 	if ngrx >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "angular",
 			Description: "NgRx for state management",
-		})
+		}, ngrx, 0, ngrx))
 	}
 
 	return conventions
@@ -412,6 +421,8 @@ func (d *FrameworkDetector) detectNextJSPatterns() []types.Convention {
 	// Check for app router vs pages router
 	hasAppRouter := false
 	hasPagesRouter := false
+	appRouterFiles := 0
+	pagesRouterFiles := 0
 	serverComponents := 0
 	clientComponents := 0
 	serverActions := 0
@@ -421,9 +432,11 @@ func (d *FrameworkDetector) detectNextJSPatterns() []types.Convention {
 		// Check directory structure
 		if strings.HasPrefix(f.Path, "app/") || strings.HasPrefix(f.Path, "src/app/") {
 			hasAppRouter = true
+			appRouterFiles++
 		}
 		if strings.HasPrefix(f.Path, "pages/") || strings.HasPrefix(f.Path, "src/pages/") {
 			hasPagesRouter = true
+			pagesRouterFiles++
 		}
 
 		// Check for API routes
@@ -475,52 +488,52 @@ func (d *FrameworkDetector) detectNextJSPatterns() []types.Convention {
 
 	// Report findings
 	if hasAppRouter && !hasPagesRouter {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "Next.js App Router (recommended for new projects)",
 			Example:     "app/page.tsx, app/layout.tsx, app/api/route.ts",
-		})
+		}, appRouterFiles, 0, appRouterFiles))
 	} else if hasPagesRouter && !hasAppRouter {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "Next.js Pages Router",
 			Example:     "pages/index.tsx, pages/api/hello.ts",
-		})
+		}, pagesRouterFiles, 0, pagesRouterFiles))
 	} else if hasAppRouter && hasPagesRouter {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "Next.js hybrid routing (App Router + Pages Router)",
-		})
+		}, appRouterFiles+pagesRouterFiles, 0, appRouterFiles+pagesRouterFiles))
 	}
 
 	if serverComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "React Server Components (default in App Router)",
-		})
+		}, serverComponents, serverComponents+clientComponents, serverComponents))
 	}
 
 	if clientComponents >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "'use client' directive for client components",
 			Example:     "'use client'\\nexport default function Button() { ... }",
-		})
+		}, clientComponents, 0, clientComponents))
 	}
 
 	if serverActions >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "Server Actions for mutations",
 			Example:     "'use server'\\nasync function createItem(formData) { ... }",
-		})
+		}, serverActions, 0, serverActions))
 	}
 
 	if apiRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nextjs",
 			Description: "API routes for backend endpoints",
-		})
+		}, apiRoutes, 0, apiRoutes))
 	}
 
 	return conventions
@@ -592,39 +605,39 @@ func (d *FrameworkDetector) detectSpringBootPatterns() []types.Convention {
 
 	// Report findings
 	if restControllers >= 2 && services >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "spring",
 			Description: "Layered architecture: Controller → Service → Repository",
-		})
+		}, restControllers+services, 0, restControllers+services))
 	}
 
 	if restControllers >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "spring",
 			Description: "@RestController with @RequestMapping for REST APIs",
 			Example:     "@RestController\\n@RequestMapping(\"/api/users\")",
-		})
+		}, restControllers, 0, restControllers))
 	}
 
 	if repositories >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "spring",
 			Description: "Spring Data JPA repositories for data access",
-		})
+		}, repositories, 0, repositories))
 	}
 
 	if lombokUsage >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "spring",
 			Description: "Lombok for reducing boilerplate (@Data, @Builder, etc.)",
-		})
+		}, lombokUsage, 0, lombokUsage))
 	}
 
 	if webflux >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "spring",
 			Description: "Spring WebFlux for reactive programming (Mono/Flux)",
-		})
+		}, webflux, 0, webflux))
 	}
 
 	return conventions
@@ -686,34 +699,34 @@ func (d *FrameworkDetector) detectNodeBackendPatterns() []types.Convention {
 
 	// Report findings
 	if hasNest && controllerCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "nestjs",
 			Description: "NestJS with decorators (@Controller, @Injectable)",
 			Example:     "@Controller('users')\\nexport class UsersController { ... }",
-		})
+		}, controllerCount, 0, controllerCount))
 	}
 
 	if hasExpress && routerCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "express",
 			Description: "Express.js route handlers",
 			Example:     "app.get('/api/users', (req, res) => { ... })",
-		})
+		}, routerCount, 0, routerCount))
 	}
 
 	if hasFastify && routerCount >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "fastify",
 			Description: "Fastify route handlers",
 			Example:     "fastify.get('/api/users', async (request, reply) => { ... })",
-		})
+		}, routerCount, 0, routerCount))
 	}
 
 	if middlewareCount >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "node-backend",
 			Description: "Middleware pattern for request processing",
-		})
+		}, middlewareCount, 0, middlewareCount))
 	}
 
 	return conventions
@@ -779,34 +792,34 @@ func (d *FrameworkDetector) detectPythonBackendPatterns() []types.Convention {
 
 	// Report findings
 	if hasFastAPI && fastapiRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "fastapi",
 			Description: "FastAPI route decorators with type hints",
 			Example:     "@app.get('/users/{user_id}')\\nasync def get_user(user_id: int): ...",
-		})
+		}, fastapiRoutes, 0, fastapiRoutes))
 	}
 
 	if hasDjango && djangoViews >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "django",
 			Description: "Django class-based views / DRF ViewSets",
-		})
+		}, djangoViews, 0, djangoViews))
 	}
 
 	if hasFlask && flaskRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "flask",
 			Description: "Flask route decorators",
 			Example:     "@app.route('/users', methods=['GET'])",
-		})
+		}, flaskRoutes, 0, flaskRoutes))
 	}
 
 	if pydanticModels >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "python",
 			Description: "Pydantic models for data validation",
 			Example:     "class User(BaseModel):\\n    name: str\\n    email: EmailStr",
-		})
+		}, pydanticModels, 0, pydanticModels))
 	}
 
 	return conventions
@@ -873,35 +886,35 @@ func (d *FrameworkDetector) detectGoWebPatterns() []types.Convention {
 
 	// Report findings
 	if hasGin && ginRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "gin",
 			Description: "Gin HTTP handlers with gin.Context",
 			Example:     "r.GET(\"/users/:id\", func(c *gin.Context) { ... })",
-		})
+		}, ginRoutes, 0, ginRoutes))
 	}
 
 	if hasEcho && echoRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "echo",
 			Description: "Echo HTTP handlers",
 			Example:     "e.GET(\"/users/:id\", getUser)",
-		})
+		}, echoRoutes, 0, echoRoutes))
 	}
 
 	if hasFiber && fiberRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "fiber",
 			Description: "Fiber HTTP handlers (Express-like)",
 			Example:     "app.Get(\"/users/:id\", func(c *fiber.Ctx) error { ... })",
-		})
+		}, fiberRoutes, 0, fiberRoutes))
 	}
 
 	if hasChi && chiRoutes >= 2 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "chi",
 			Description: "Chi router with middleware support",
 			Example:     "r.Get(\"/users/{id}\", getUser)",
-		})
+		}, chiRoutes, 0, chiRoutes))
 	}
 
 	return conventions
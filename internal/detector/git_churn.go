@@ -0,0 +1,184 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DefaultChurnWindowDays is the history window used when none is configured
+const DefaultChurnWindowDays = 90
+
+// Churn analysis limits
+const (
+	churnMaxCommits   = 1000
+	churnRecentDays   = 30
+	churnMaxHotspots  = 10
+	churnMaxCoChanges = 10
+	churnBulkFiles    = 30 // Larger commits are bulk edits and don't imply coupling
+	churnMinTogether  = 3
+	churnMinCoupling  = 0.5
+)
+
+// DetectChurn counts how often each file changed in the windowDays before
+// HEAD and which files are usually committed together. The window is
+// anchored at the HEAD commit so inactive repositories still report their
+// last period of activity. Files deleted since are ignored.
+func (d *GitDetectorGoGit) DetectChurn(windowDays int) *types.ChurnInfo {
+	if windowDays <= 0 {
+		windowDays = DefaultChurnWindowDays
+	}
+	if !d.openRepo() {
+		return nil
+	}
+
+	ref, err := d.repo.Head()
+	if err != nil {
+		return nil
+	}
+	head, err := d.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil
+	}
+
+	current := make(map[string]bool)
+	walker := object.NewTreeWalker(headTree, true, nil)
+	for {
+		name, entry, err := walker.Next()
+		if err != nil {
+			break
+		}
+		if entry.Mode.IsFile() {
+			current[name] = true
+		}
+	}
+	walker.Close()
+
+	since := head.Committer.When.AddDate(0, 0, -windowDays)
+	recentSince := head.Committer.When.AddDate(0, 0, -churnRecentDays)
+
+	commitIter, err := d.repo.Log(&git.LogOptions{From: ref.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil
+	}
+	defer commitIter.Close()
+
+	changes := make(map[string]int)
+	recent := make(map[string]int)
+	together := make(map[[2]string]int)
+	count := 0
+
+	_ = commitIter.ForEach(func(c *object.Commit) error {
+		if count >= churnMaxCommits || c.Committer.When.Before(since) {
+			return fmt.Errorf("window reached")
+		}
+		if c.NumParents() > 1 {
+			return nil
+		}
+		count++
+
+		var files []string
+		for _, name := range changedFiles(c) {
+			if current[name] {
+				files = append(files, name)
+			}
+		}
+		sort.Strings(files)
+
+		for _, name := range files {
+			changes[name]++
+			if !c.Committer.When.Before(recentSince) {
+				recent[name]++
+			}
+		}
+
+		if len(files) > churnBulkFiles {
+			return nil
+		}
+		for i := 0; i < len(files); i++ {
+			for j := i + 1; j < len(files); j++ {
+				together[[2]string{files[i], files[j]}]++
+			}
+		}
+		return nil
+	})
+
+	if count == 0 {
+		return nil
+	}
+
+	return &types.ChurnInfo{
+		WindowDays:      windowDays,
+		CommitsAnalyzed: count,
+		Hotspots:        churnHotspots(changes, recent),
+		CoChanges:       churnCoChanges(changes, together),
+	}
+}
+
+// churnHotspots returns the most frequently changed files. A file changed
+// once is not a hotspot.
+func churnHotspots(changes, recent map[string]int) []types.FileChurn {
+	var hotspots []types.FileChurn
+	for path, n := range changes {
+		if n < 2 {
+			continue
+		}
+		hotspots = append(hotspots, types.FileChurn{Path: path, Changes: n, Recent: recent[path]})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Changes != hotspots[j].Changes {
+			return hotspots[i].Changes > hotspots[j].Changes
+		}
+		return hotspots[i].Path < hotspots[j].Path
+	})
+	if len(hotspots) > churnMaxHotspots {
+		hotspots = hotspots[:churnMaxHotspots]
+	}
+	return hotspots
+}
+
+// churnCoChanges returns file pairs committed together often enough, relative
+// to the less frequently changed file, to count as coupled
+func churnCoChanges(changes map[string]int, together map[[2]string]int) []types.CoChange {
+	var coChanges []types.CoChange
+	for pair, n := range together {
+		if n < churnMinTogether {
+			continue
+		}
+		coupling := float64(n) / float64(min(changes[pair[0]], changes[pair[1]]))
+		if coupling < churnMinCoupling {
+			continue
+		}
+		coChanges = append(coChanges, types.CoChange{
+			Files:    []string{pair[0], pair[1]},
+			Together: n,
+			Coupling: coupling,
+		})
+	}
+
+	sort.Slice(coChanges, func(i, j int) bool {
+		a, b := coChanges[i], coChanges[j]
+		if a.Together != b.Together {
+			return a.Together > b.Together
+		}
+		if a.Coupling != b.Coupling {
+			return a.Coupling > b.Coupling
+		}
+		if a.Files[0] != b.Files[0] {
+			return a.Files[0] < b.Files[0]
+		}
+		return a.Files[1] < b.Files[1]
+	})
+	if len(coChanges) > churnMaxCoChanges {
+		coChanges = coChanges[:churnMaxCoChanges]
+	}
+	return coChanges
+}
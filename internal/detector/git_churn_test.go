@@ -0,0 +1,89 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGitDetectorGoGit_DetectChurn(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := runGitCommand(tmpDir, "init"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	commit := func(files ...string) {
+		t.Helper()
+		for _, file := range files {
+			path := filepath.Join(tmpDir, file)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = f.WriteString("x\n")
+			_ = f.Close()
+		}
+		if err := runGitCommand(tmpDir, "add", "-A"); err != nil {
+			t.Fatal(err)
+		}
+		if err := runGitCommand(tmpDir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", "update"); err != nil {
+			t.Fatalf("commit failed: %v", err)
+		}
+	}
+
+	commit("api/handler.go", "api/handler_test.go")
+	commit("api/handler.go", "api/handler_test.go", "docs/api.md")
+	commit("api/handler.go", "api/handler_test.go")
+	commit("api/handler.go")
+	commit("docs/api.md")
+	commit("old.go")
+	if err := runGitCommand(tmpDir, "rm", "-q", "old.go"); err != nil {
+		t.Fatal(err)
+	}
+	commit()
+
+	churn := NewGitDetectorGoGit(tmpDir).DetectChurn(0)
+	if churn == nil {
+		t.Fatal("expected churn info")
+	}
+	if churn.WindowDays != DefaultChurnWindowDays || churn.CommitsAnalyzed != 7 {
+		t.Errorf("expected default window over 7 commits, got %d days over %d", churn.WindowDays, churn.CommitsAnalyzed)
+	}
+
+	var hotspots []string
+	for _, h := range churn.Hotspots {
+		hotspots = append(hotspots, h.Path)
+	}
+	if want := []string{"api/handler.go", "api/handler_test.go", "docs/api.md"}; !reflect.DeepEqual(hotspots, want) {
+		t.Errorf("expected hotspots %v, got %v", want, hotspots)
+	}
+	if churn.Hotspots[0].Changes != 4 || churn.Hotspots[0].Recent != 4 {
+		t.Errorf("expected 4 changes to handler.go, got %+v", churn.Hotspots[0])
+	}
+
+	if len(churn.CoChanges) != 1 {
+		t.Fatalf("expected one co-change pair, got %+v", churn.CoChanges)
+	}
+	pair := churn.CoChanges[0]
+	if !reflect.DeepEqual(pair.Files, []string{"api/handler.go", "api/handler_test.go"}) || pair.Together != 3 || pair.Coupling != 1 {
+		t.Errorf("expected handler.go <-> handler_test.go coupled, got %+v", pair)
+	}
+}
+
+func TestChurnCoChanges_Thresholds(t *testing.T) {
+	changes := map[string]int{"a.go": 10, "b.go": 3, "c.go": 8, "d.go": 2}
+	together := map[[2]string]int{
+		{"a.go", "b.go"}: 3, // b always changes with a
+		{"a.go", "c.go"}: 3, // 3/8 is below the coupling threshold
+		{"c.go", "d.go"}: 2, // too few commits
+	}
+
+	got := churnCoChanges(changes, together)
+	if len(got) != 1 || got[0].Files[1] != "b.go" {
+		t.Errorf("expected only a.go <-> b.go, got %+v", got)
+	}
+}
@@ -35,8 +35,16 @@ func (d *GitDetectorGoGit) Detect() *types.GitConventions {
 	}
 	d.repo = repo
 
-	// Detect commit conventions
-	conventions.CommitConvention = d.detectCommitConvention()
+	// Detect commit conventions (last 100 commits)
+	history := d.recentHistory(100)
+	conventions.CommitConvention = d.detectCommitConvention(history)
+
+	// Ticket references, DCO sign-off and commitlint/commitizen config
+	messages := commitMessages(history)
+	conventions.Tickets = detectTicketReferences(messages)
+	conventions.SignOff = d.detectSignOff(messages)
+	conventions.CommitLint = detectCommitLint(d.rootPath)
+	conventions.CommitConvention = applyCommitLint(conventions.CommitConvention, conventions.CommitLint)
 
 	// Detect branch naming conventions
 	conventions.BranchConvention = d.detectBranchConvention()
@@ -47,9 +55,51 @@ func (d *GitDetectorGoGit) Detect() *types.GitConventions {
 	// Get recent commits
 	conventions.RecentCommits = d.getRecentCommits(10)
 
+	// Hotspots and co-change coupling
+	conventions.Churn = d.DetectChurn(DefaultChurnWindowDays)
+
 	return conventions
 }
 
+// openRepo opens the repository if Detect hasn't already
+func (d *GitDetectorGoGit) openRepo() bool {
+	if d.repo != nil {
+		return true
+	}
+	repo, err := git.PlainOpen(d.rootPath)
+	if err != nil {
+		return false
+	}
+	d.repo = repo
+	return true
+}
+
+// changedFiles returns the paths a commit changed relative to its first parent
+func changedFiles(c *object.Commit) []string {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil
+	}
+	var parentTree *object.Tree
+	if parent, err := c.Parent(0); err == nil {
+		parentTree, _ = parent.Tree()
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files = append(files, name)
+	}
+	return files
+}
+
 // detectRepository extracts git repository information
 func (d *GitDetectorGoGit) detectRepository() *types.GitRepository {
 	if d.repo == nil {
@@ -170,46 +220,84 @@ func (d *GitDetectorGoGit) getRecentCommits(limit int) []types.GitCommit {
 	return commits
 }
 
-// detectCommitConvention analyzes commit history for patterns
-func (d *GitDetectorGoGit) detectCommitConvention() *types.CommitConvention {
-	if d.repo == nil {
+// DirectoryContributors walks up to maxCommits non-merge commits from HEAD and
+// returns the topN authors by commit count for each directory. dirFor maps a
+// changed file path to the directory it is credited to; "" skips the file.
+func (d *GitDetectorGoGit) DirectoryContributors(maxCommits, topN int, dirFor func(string) string) map[string][]string {
+	if !d.openRepo() {
 		return nil
 	}
 
-	// Get HEAD reference
 	ref, err := d.repo.Head()
 	if err != nil {
 		return nil
 	}
 
-	// Get commit history (last 100 commits)
 	commitIter, err := d.repo.Log(&git.LogOptions{From: ref.Hash()})
 	if err != nil {
 		return nil
 	}
 	defer commitIter.Close()
 
-	var commits []string
+	counts := make(map[string]map[string]int)
 	count := 0
-	maxCommits := 100
 
 	_ = commitIter.ForEach(func(c *object.Commit) error {
 		if count >= maxCommits {
 			return fmt.Errorf("limit reached")
 		}
-		// Get first line of commit message
-		message := strings.Split(c.Message, "\n")[0]
-		commits = append(commits, strings.TrimSpace(message))
+		if c.NumParents() > 1 {
+			return nil
+		}
 		count++
+
+		// Credit each directory once per commit
+		touched := make(map[string]bool)
+		for _, name := range changedFiles(c) {
+			if dir := dirFor(name); dir != "" {
+				touched[dir] = true
+			}
+		}
+		for dir := range touched {
+			if counts[dir] == nil {
+				counts[dir] = make(map[string]int)
+			}
+			counts[dir][c.Author.Name]++
+		}
 		return nil
 	})
 
-	if len(commits) == 0 {
+	contributors := make(map[string][]string, len(counts))
+	for dir, authors := range counts {
+		contributors[dir] = getTopKeysFromMap(authors, topN)
+	}
+	return contributors
+}
+
+// detectCommitConvention analyzes commit history for patterns
+func (d *GitDetectorGoGit) detectCommitConvention(history []*object.Commit) *types.CommitConvention {
+	if len(history) == 0 {
 		return nil
 	}
 
+	// First line of each commit message
+	commits := make([]string, 0, len(history))
+	for _, c := range history {
+		commits = append(commits, strings.TrimSpace(strings.Split(c.Message, "\n")[0]))
+	}
+
 	// Analyze patterns (reuse existing pattern detection logic)
-	return analyzeCommitPatterns(commits)
+	convention := analyzeCommitPatterns(commits)
+	if convention == nil {
+		return nil
+	}
+
+	if convention.Style == "conventional" || convention.Style == "angular" {
+		convention.ScopeDirs = d.mapScopesToDirs(history, convention.Scopes)
+		convention.Breaking = detectBreakingMarkers(commitMessages(history))
+	}
+
+	return convention
 }
 
 // detectBranchConvention analyzes branch names for patterns
@@ -250,52 +338,44 @@ func (d *GitDetectorGoGit) detectBranchConvention() *types.BranchConvention {
 // analyzeCommitPatterns extracts commit message conventions
 // This is extracted from the original implementation for reuse
 func analyzeCommitPatterns(commits []string) *types.CommitConvention {
-	// Pattern matching logic (from original git.go)
-	conventionalRegex := `^(feat|fix|docs|style|refactor|test|chore|perf|ci|build|revert)(\(([^)]+)\))?:\s*(.+)`
-	gitmojiRegex := `^:[a-z_]+:\s*.+`
-	jiraRegex := `^[A-Z]+-\d+\s*.+`
-	angularRegex := `^(feat|fix|docs|style|refactor|test|chore)\(.+\):\s*.+`
-
 	counts := make(map[string]int)
 	typeCount := make(map[string]int)
 	scopeCount := make(map[string]int)
 
 	for _, commit := range commits {
 		// Check patterns
-		if matched, _ := matchPattern(commit, conventionalRegex); matched {
+		if m := conventionalCommitRegex.FindStringSubmatch(commit); m != nil {
 			counts["conventional"]++
-			// Extract type and scope
-			if parts := extractConventionalParts(commit); len(parts) >= 2 {
-				typeCount[parts[1]]++
-				if len(parts) >= 4 && parts[3] != "" {
-					scopeCount[parts[3]]++
-				}
+			typeCount[m[1]]++
+			if m[3] != "" {
+				scopeCount[m[3]]++
 			}
 		}
-		if matched, _ := matchPattern(commit, gitmojiRegex); matched {
+		if gitmojiCommitRegex.MatchString(commit) {
 			counts["gitmoji"]++
 		}
-		if matched, _ := matchPattern(commit, jiraRegex); matched {
+		if jiraCommitRegex.MatchString(commit) {
 			counts["jira"]++
 		}
-		if matched, _ := matchPattern(commit, angularRegex); matched {
+		if angularCommitRegex.MatchString(commit) {
 			counts["angular"]++
 		}
 	}
 
-	// Determine dominant convention
+	// Determine dominant convention; earlier styles win ties since
+	// conventional commits also match the angular pattern
 	var bestPattern string
 	var bestCount int
-	for name, count := range counts {
-		if count > bestCount {
-			bestCount = count
+	for _, name := range []string{"conventional", "angular", "gitmoji", "jira"} {
+		if counts[name] > bestCount {
+			bestCount = counts[name]
 			bestPattern = name
 		}
 	}
 
 	// Need at least 30% of commits to follow a pattern
 	threshold := len(commits) * 30 / 100
-	if bestCount < threshold {
+	if bestCount == 0 || bestCount < threshold {
 		return nil
 	}
 
@@ -397,29 +477,6 @@ func matchPattern(text, pattern string) (bool, string) {
 	return false, ""
 }
 
-func extractConventionalParts(commit string) []string {
-	// Extract type(scope): message
-	parts := strings.SplitN(commit, ":", 2)
-	if len(parts) != 2 {
-		return nil
-	}
-
-	typeScope := parts[0]
-	result := []string{"", "", "", ""}
-
-	// Check for scope
-	if strings.Contains(typeScope, "(") && strings.Contains(typeScope, ")") {
-		startScope := strings.Index(typeScope, "(")
-		endScope := strings.Index(typeScope, ")")
-		result[1] = typeScope[:startScope]             // type
-		result[3] = typeScope[startScope+1 : endScope] // scope
-	} else {
-		result[1] = typeScope // type only
-	}
-
-	return result
-}
-
 func normalizeBranchPrefix(prefix string) string {
 	switch prefix {
 	case "feature":
@@ -443,7 +500,10 @@ func getTopKeysFromMap(m map[string]int, n int) []string {
 	}
 
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Value > sorted[j].Value
+		if sorted[i].Value != sorted[j].Value {
+			return sorted[i].Value > sorted[j].Value
+		}
+		return sorted[i].Key < sorted[j].Key
 	})
 
 	var result []string
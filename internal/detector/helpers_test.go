@@ -0,0 +1,32 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// writeProjectFixture writes files into a temp dir and returns the dir and FileInfo list
+func writeProjectFixture(t *testing.T, files map[string]string) (string, []types.FileInfo) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	var infos []types.FileInfo
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		infos = append(infos, types.FileInfo{
+			Path:      name,
+			Name:      filepath.Base(name),
+			Extension: filepath.Ext(name),
+		})
+	}
+	return tmpDir, infos
+}
@@ -0,0 +1,645 @@
+package detector
+
+import (
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+	jsast "github.com/dop251/goja/ast"
+	jsfile "github.com/dop251/goja/file"
+	jsparser "github.com/dop251/goja/parser"
+	jstoken "github.com/dop251/goja/token"
+	pyast "github.com/go-python/gpython/ast"
+	pyparser "github.com/go-python/gpython/parser"
+	"github.com/go-python/gpython/py"
+)
+
+// Identifier kinds, each tallied as its own naming rule
+const (
+	identType      = "type"
+	identInterface = "interface"
+	identFunc      = "func"
+	identConst     = "const"
+	identTest      = "test"
+	identReceiver  = "receiver"
+	identBool      = "bool"
+	identAcronym   = "acronym"
+)
+
+// identifierRules is the order naming conventions are reported in
+var identifierRules = []string{
+	identType, identInterface, identFunc, identConst, identReceiver, identAcronym, identBool, identTest,
+}
+
+// namingDescriptions maps lang:rule:variant to the convention it states.
+// Variants without an entry still count against the dominant one
+var namingDescriptions = map[string]string{
+	"go:interface:er":     "Name Go interfaces after their behaviour with an -er/-or suffix",
+	"go:const:mixed":      "Name Go constants in MixedCaps, not ALL_CAPS",
+	"go:const:caps":       "Name Go constants in ALL_CAPS",
+	"go:receiver:short":   "Use one- or two-letter receiver names derived from the type",
+	"go:receiver:self":    "Name method receivers `self` or `this`",
+	"go:acronym:upper":    "Keep initialisms uniformly cased in Go identifiers (`HTTPClient`, `userID`), not `HttpClient`",
+	"go:acronym:mixed":    "Capitalize only the first letter of initialisms in Go identifiers (`HttpClient`, `UserId`)",
+	"go:bool:prefixed":    "Prefix boolean fields and variables with is/has/can/should",
+	"go:test:underscore":  "Name Go tests `TestSubject_Scenario`, separating the function under test from the case with an underscore",
+	"go:test:plain":       "Name Go tests `TestSubjectScenario` in MixedCaps without underscores",
+	"py:type:pascal":      "Name Python classes in PascalCase",
+	"py:func:snake":       "Name Python functions and methods in snake_case",
+	"py:func:camel":       "Name Python functions and methods in camelCase",
+	"py:const:caps":       "Name module-level Python constants in UPPER_SNAKE_CASE",
+	"py:acronym:upper":    "Keep acronyms uppercase in Python class names (`HTTPClient`), not `HttpClient`",
+	"py:acronym:mixed":    "Capitalize only the first letter of acronyms in Python class names (`HttpClient`)",
+	"py:bool:prefixed":    "Prefix boolean functions and flags with is_/has_/can_/should_",
+	"py:test:function":    "Write Python tests as module-level `test_*` functions rather than classes",
+	"py:test:class":       "Group Python tests into `Test*` classes with `test_*` methods",
+	"js:type:pascal":      "Name JavaScript/TypeScript classes in PascalCase",
+	"js:interface:plain":  "Name TypeScript interfaces without an `I` prefix",
+	"js:interface:prefix": "Prefix TypeScript interfaces with `I`",
+	"js:func:camel":       "Name JavaScript/TypeScript functions in camelCase",
+	"js:func:snake":       "Name JavaScript/TypeScript functions in snake_case",
+	"js:const:caps":       "Name top-level JavaScript/TypeScript constants in UPPER_SNAKE_CASE",
+	"js:const:mixed":      "Name top-level JavaScript/TypeScript constants in camelCase like other variables",
+	"js:acronym:upper":    "Keep acronyms uppercase in JavaScript/TypeScript identifiers (`parseURL`, `userID`), not `parseUrl`",
+	"js:acronym:mixed":    "Capitalize only the first letter of acronyms in JavaScript/TypeScript identifiers (`parseUrl`, `userId`)",
+	"js:bool:prefixed":    "Prefix boolean variables with is/has/can/should",
+	"js:test:should":      "Phrase test names as `it(\"should ...\")`",
+	"js:test:plain":       "Phrase test names as plain statements of behaviour, without \"should\"",
+}
+
+// commonAcronyms are initialisms whose casing style is worth reporting
+var commonAcronyms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "CSV": true, "DB": true, "DNS": true, "GPU": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IO": true, "IP": true, "JSON": true,
+	"JWT": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "UI": true, "URI": true,
+	"URL": true, "UUID": true, "XML": true, "YAML": true,
+}
+
+// boolPrefixes are the leading words that mark a name as a boolean
+var boolPrefixes = map[string]bool{
+	"is": true, "has": true, "can": true, "should": true, "was": true, "will": true,
+	"did": true, "needs": true, "allow": true, "allows": true,
+}
+
+var (
+	tsInterfaceRegex = regexp.MustCompile(`^\s*(?:export\s+)?interface\s+([A-Za-z_$][\w$]*)`)
+	jsTestNameRegex  = regexp.MustCompile(`\b(?:it|test)\(\s*['"` + "`" + `]([^'"` + "`" + `]+)`)
+
+	// Declaration fallbacks for sources the AST parsers reject, such as
+	// TypeScript and modern Python syntax
+	jsFunctionDeclRegex = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)`)
+	jsClassDeclRegex    = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	jsConstDeclRegex    = regexp.MustCompile(`^(?:export\s+)?const\s+([A-Za-z_$][\w$]*)\s*(?::\s*[\w<>\[\]]+\s*)?=\s*(.*)`)
+	jsVarDeclRegex      = regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::\s*boolean\s*)?=\s*(true|false)\b`)
+	jsLiteralRegex      = regexp.MustCompile(`^(?:-?\d[\d_.]*|['"` + "`" + `][^'"` + "`" + `$]*['"` + "`" + `])\s*;?\s*$`)
+	pyDefLineRegex      = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s+(\w+)\s*\(.*?(->\s*bool)?\s*:?\s*$`)
+	pyClassRegex        = regexp.MustCompile(`^(\s*)class\s+(\w+)`)
+	pyAssignRegex       = regexp.MustCompile(`^(\w+)\s*(?::\s*\w+\s*)?=\s*(.+)$`)
+	pyLiteralRegex      = regexp.MustCompile(`^(?:-?\d[\d_.]*|[rbf]?['"][^'"]*['"])\s*$`)
+)
+
+// identifier is a declared name found in a sampled source file
+type identifier struct {
+	name string
+	kind string
+	file string
+	line int
+	// scoped marks Python test functions defined inside a class
+	scoped bool
+}
+
+// identifierVotes tallies naming variants along with the names behind them
+type identifierVotes struct {
+	styleVotes
+	examples map[string][]string
+}
+
+func (v *identifierVotes) add(variant string, id identifier) {
+	if v.examples == nil {
+		v.examples = make(map[string][]string)
+	}
+	v.styleVotes.add(variant, id.file, id.line)
+	if len(v.examples[variant]) < maxEvidenceSamples && !sliceContainsString(v.examples[variant], id.name) {
+		v.examples[variant] = append(v.examples[variant], id.name)
+	}
+}
+
+// detectIdentifierNaming infers how types, functions, constants, tests,
+// receivers and booleans are named from the declarations in sampled source
+func (d *ConventionDetector) detectIdentifierNaming() []types.Convention {
+	var conventions []types.Convention
+
+	samples := d.sampleStyleFiles()
+	for _, lang := range []string{"go", "py", "js"} {
+		files := samples[lang]
+		if len(files) < minInferenceFiles {
+			continue
+		}
+		var ids []identifier
+		for _, f := range files {
+			switch lang {
+			case "go":
+				ids = append(ids, goIdentifiers(f)...)
+			case "py":
+				ids = append(ids, pythonIdentifiers(f)...)
+			case "js":
+				ids = append(ids, jsIdentifiers(f)...)
+			}
+		}
+		conventions = append(conventions, namingConventions(lang, ids)...)
+	}
+
+	return conventions
+}
+
+// namingConventions votes on each identifier and reports the rules the
+// codebase follows consistently
+func namingConventions(lang string, ids []identifier) []types.Convention {
+	votes := make(map[string]*identifierVotes)
+	vote := func(rule, variant string, id identifier) {
+		if variant == "" {
+			return
+		}
+		if votes[rule] == nil {
+			votes[rule] = &identifierVotes{}
+		}
+		votes[rule].add(variant, id)
+	}
+
+	for _, id := range ids {
+		vote(id.kind, identifierVariant(lang, id), id)
+		if id.kind != identTest && id.kind != identReceiver && (lang != "py" || id.kind == identType) {
+			vote(identAcronym, acronymVariant(id.name), id)
+		}
+	}
+
+	var conventions []types.Convention
+	for _, rule := range identifierRules {
+		v := votes[rule]
+		if v == nil {
+			continue
+		}
+		variant, agree, total := v.dominant()
+		description := namingDescriptions[lang+":"+rule+":"+variant]
+		if description == "" {
+			continue
+		}
+		conv, ok := inferredConvention("naming", description, agree, total, len(v.files), v.samples[variant])
+		if !ok {
+			continue
+		}
+		conv.Example = strings.Join(v.examples[variant], ", ")
+		conventions = append(conventions, conv)
+	}
+	return conventions
+}
+
+// identifierVariant classifies a name under its kind's rule, or returns ""
+// when the name says nothing about the rule
+func identifierVariant(lang string, id identifier) string {
+	name := id.name
+	switch id.kind {
+	case identType:
+		if isPascalCase(name) {
+			return "pascal"
+		}
+		return "other"
+
+	case identInterface:
+		if lang == "go" {
+			if strings.HasSuffix(name, "er") || strings.HasSuffix(name, "or") {
+				return "er"
+			}
+			return "other"
+		}
+		if len(name) > 2 && name[0] == 'I' && unicode.IsUpper(rune(name[1])) && unicode.IsLower(rune(name[2])) {
+			return "prefix"
+		}
+		return "plain"
+
+	case identFunc:
+		if lang == "py" {
+			// Dunder methods say nothing about casing; private helpers do
+			if strings.HasSuffix(name, "__") {
+				return ""
+			}
+			name = strings.TrimLeft(name, "_")
+		}
+		if lang == "js" && isPascalCase(name) {
+			// PascalCase functions are components or constructors
+			return ""
+		}
+		switch detectNamingPattern(name) {
+		case SnakeCase:
+			return "snake"
+		case CamelCase:
+			return "camel"
+		}
+		return ""
+
+	case identConst:
+		switch {
+		case isUpperSnake(name):
+			return "caps"
+		case lang == "py":
+			return "lower"
+		}
+		return "mixed"
+
+	case identReceiver:
+		switch {
+		case name == "_":
+			return ""
+		case name == "self" || name == "this":
+			return "self"
+		case len(name) <= 2:
+			return "short"
+		}
+		return "long"
+
+	case identBool:
+		if boolPrefixes[strings.ToLower(identifierWords(strings.TrimLeft(name, "_"))[0])] {
+			return "prefixed"
+		}
+		return "bare"
+
+	case identTest:
+		switch lang {
+		case "go":
+			if strings.Contains(strings.TrimPrefix(name, "Test"), "_") {
+				return "underscore"
+			}
+			return "plain"
+		case "py":
+			if id.scoped {
+				return "class"
+			}
+			return "function"
+		case "js":
+			if strings.HasPrefix(strings.ToLower(name), "should ") {
+				return "should"
+			}
+			return "plain"
+		}
+	}
+	return ""
+}
+
+// acronymVariant reports whether known initialisms in a mixed-case name are
+// fully uppercased or title-cased, or "" when it contains none
+func acronymVariant(name string) string {
+	if strings.Contains(name, "_") || name == strings.ToUpper(name) || name == strings.ToLower(name) {
+		return ""
+	}
+	for i, word := range identifierWords(name) {
+		upper := strings.ToUpper(word)
+		if !commonAcronyms[upper] {
+			continue
+		}
+		switch {
+		case word == upper:
+			return "upper"
+		case i > 0 && word == upper[:1]+strings.ToLower(upper[1:]):
+			// A leading word is lowercased in either style
+			return "mixed"
+		case i == 0 && unicode.IsUpper(rune(word[0])) && word[1:] == strings.ToLower(word[1:]):
+			return "mixed"
+		}
+	}
+	return ""
+}
+
+// identifierWords splits a camel, Pascal or snake case name into words,
+// keeping runs of capitals such as HTTP together
+func identifierWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) {
+			words = append(words, string(runes[start:i]))
+			break
+		}
+		prev, cur := runes[i-1], runes[i]
+		boundary := cur == '_' ||
+			unicode.IsUpper(cur) && !unicode.IsUpper(prev) ||
+			unicode.IsUpper(prev) && unicode.IsLower(cur) && i-1 > start
+		if !boundary {
+			continue
+		}
+		if unicode.IsUpper(prev) && unicode.IsLower(cur) {
+			// The last capital of a run begins the next word: HTTPClient
+			words = append(words, string(runes[start:i-1]))
+			start = i - 1
+			continue
+		}
+		if start < i && runes[start] != '_' {
+			words = append(words, string(runes[start:i]))
+		}
+		start = i
+		if cur == '_' {
+			start = i + 1
+		}
+	}
+	if len(words) == 0 {
+		return []string{name}
+	}
+	return words
+}
+
+func isPascalCase(name string) bool {
+	return name != "" && unicode.IsUpper(rune(name[0])) && !strings.Contains(name, "_") && name != strings.ToUpper(name)
+}
+
+func isUpperSnake(name string) bool {
+	return len(name) > 1 && name == strings.ToUpper(name) && strings.ToLower(name) != name
+}
+
+// goIdentifiers collects declared names from a Go file
+func goIdentifiers(f sampledFile) []identifier {
+	fset := token.NewFileSet()
+	node, err := goparser.ParseFile(fset, f.path, strings.Join(f.lines, "\n"), 0)
+	if err != nil {
+		return nil
+	}
+
+	var ids []identifier
+	add := func(name, kind string, pos token.Pos) {
+		if name == "" || name == "_" {
+			return
+		}
+		ids = append(ids, identifier{name: name, kind: kind, file: f.path, line: fset.Position(pos).Line})
+	}
+	isTestFile := strings.HasSuffix(f.path, "_test.go")
+
+	for _, decl := range node.Decls {
+		switch decl := decl.(type) {
+		case *goast.FuncDecl:
+			if decl.Recv != nil {
+				for _, field := range decl.Recv.List {
+					for _, name := range field.Names {
+						add(name.Name, identReceiver, name.Pos())
+					}
+				}
+			}
+			switch {
+			case isTestFile && decl.Recv == nil && strings.HasPrefix(decl.Name.Name, "Test") && decl.Name.Name != "TestMain":
+				add(decl.Name.Name, identTest, decl.Name.Pos())
+			case !isTestFile:
+				add(decl.Name.Name, identFunc, decl.Name.Pos())
+			}
+
+		case *goast.GenDecl:
+			if isTestFile {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *goast.TypeSpec:
+					kind := identType
+					if _, ok := spec.Type.(*goast.InterfaceType); ok {
+						kind = identInterface
+					}
+					add(spec.Name.Name, kind, spec.Name.Pos())
+					if st, ok := spec.Type.(*goast.StructType); ok {
+						for _, field := range st.Fields.List {
+							if ident, ok := field.Type.(*goast.Ident); ok && ident.Name == "bool" {
+								for _, name := range field.Names {
+									add(name.Name, identBool, name.Pos())
+								}
+							}
+						}
+					}
+				case *goast.ValueSpec:
+					kind := ""
+					if decl.Tok == token.CONST {
+						kind = identConst
+					} else if ident, ok := spec.Type.(*goast.Ident); ok && ident.Name == "bool" {
+						kind = identBool
+					}
+					if kind == "" {
+						continue
+					}
+					for _, name := range spec.Names {
+						add(name.Name, kind, name.Pos())
+					}
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// pythonIdentifiers collects declared names from a Python file, falling back
+// to declaration patterns when the parser rejects the syntax
+func pythonIdentifiers(f sampledFile) []identifier {
+	source := strings.Join(f.lines, "\n")
+	mod, err := pyparser.Parse(strings.NewReader(source), f.path, py.ExecMode)
+	module, ok := mod.(*pyast.Module)
+	if err != nil || !ok {
+		return pythonDeclarations(f)
+	}
+
+	var ids []identifier
+	isTestFile := isPythonTestFile(f.path)
+	var visit func(stmts []pyast.Stmt, inClass, topLevel bool)
+	visit = func(stmts []pyast.Stmt, inClass, topLevel bool) {
+		for _, stmt := range stmts {
+			switch s := stmt.(type) {
+			case *pyast.FunctionDef:
+				name := string(s.Name)
+				id := identifier{name: name, kind: identFunc, file: f.path, line: s.Lineno, scoped: inClass}
+				if isTestFile && strings.HasPrefix(name, "test") {
+					id.kind = identTest
+				} else if isPyBool(s.Returns) {
+					id.kind = identBool
+				}
+				ids = append(ids, id)
+				visit(s.Body, false, false)
+			case *pyast.ClassDef:
+				if !isTestFile {
+					ids = append(ids, identifier{name: string(s.Name), kind: identType, file: f.path, line: s.Lineno})
+				}
+				visit(s.Body, true, false)
+			case *pyast.Assign:
+				for _, target := range s.Targets {
+					var name string
+					switch t := target.(type) {
+					case *pyast.Name:
+						name = string(t.Id)
+					case *pyast.Attribute:
+						name = string(t.Attr)
+					}
+					if name == "" {
+						continue
+					}
+					switch v := s.Value.(type) {
+					case *pyast.NameConstant:
+						if v.Value == py.True || v.Value == py.False {
+							ids = append(ids, identifier{name: name, kind: identBool, file: f.path, line: s.Lineno})
+						}
+					case *pyast.Num, *pyast.Str:
+						if _, ok := target.(*pyast.Name); ok && topLevel {
+							ids = append(ids, identifier{name: name, kind: identConst, file: f.path, line: s.Lineno})
+						}
+					}
+				}
+			case *pyast.If:
+				visit(s.Body, inClass, topLevel)
+				visit(s.Orelse, inClass, topLevel)
+			case *pyast.Try:
+				visit(s.Body, inClass, topLevel)
+			}
+		}
+	}
+	visit(module.Body, false, true)
+	return ids
+}
+
+// pythonDeclarations is the line-based fallback for pythonIdentifiers
+func pythonDeclarations(f sampledFile) []identifier {
+	var ids []identifier
+	isTestFile := isPythonTestFile(f.path)
+	classIndent := -1
+	for n, line := range f.lines {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if strings.TrimSpace(line) != "" && indent <= classIndent {
+			classIndent = -1
+		}
+		if m := pyClassRegex.FindStringSubmatch(line); m != nil {
+			classIndent = len(m[1])
+			if !isTestFile {
+				ids = append(ids, identifier{name: m[2], kind: identType, file: f.path, line: n + 1})
+			}
+			continue
+		}
+		if m := pyDefLineRegex.FindStringSubmatch(line); m != nil {
+			id := identifier{name: m[2], kind: identFunc, file: f.path, line: n + 1, scoped: classIndent >= 0 && len(m[1]) > classIndent}
+			if isTestFile && strings.HasPrefix(id.name, "test") {
+				id.kind = identTest
+			} else if m[3] != "" {
+				id.kind = identBool
+			}
+			ids = append(ids, id)
+			continue
+		}
+		if m := pyAssignRegex.FindStringSubmatch(line); m != nil {
+			value := strings.TrimSpace(m[2])
+			switch {
+			case value == "True" || value == "False":
+				ids = append(ids, identifier{name: m[1], kind: identBool, file: f.path, line: n + 1})
+			case pyLiteralRegex.MatchString(value):
+				ids = append(ids, identifier{name: m[1], kind: identConst, file: f.path, line: n + 1})
+			}
+		}
+	}
+	return ids
+}
+
+func isPythonTestFile(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py")
+}
+
+func isPyBool(expr pyast.Expr) bool {
+	name, ok := expr.(*pyast.Name)
+	return ok && name.Id == "bool"
+}
+
+// jsIdentifiers collects declared names from a JavaScript or TypeScript
+// file. goja can't parse TypeScript or ES modules, so those fall back to
+// declaration patterns; interfaces and test names are always matched by line
+func jsIdentifiers(f sampledFile) []identifier {
+	var ids []identifier
+	for n, line := range f.lines {
+		if m := tsInterfaceRegex.FindStringSubmatch(line); m != nil {
+			ids = append(ids, identifier{name: m[1], kind: identInterface, file: f.path, line: n + 1})
+		}
+		if m := jsTestNameRegex.FindStringSubmatch(line); m != nil {
+			ids = append(ids, identifier{name: m[1], kind: identTest, file: f.path, line: n + 1})
+		}
+	}
+
+	if f.ext == ".ts" || f.ext == ".tsx" {
+		return append(ids, jsDeclarations(f)...)
+	}
+	program, err := jsparser.ParseFile(nil, f.path, strings.Join(f.lines, "\n"), 0)
+	if err != nil {
+		return append(ids, jsDeclarations(f)...)
+	}
+
+	line := func(idx jsfile.Idx) int {
+		return program.File.Position(int(idx) - program.File.Base()).Line
+	}
+	addBindings := func(bindings []*jsast.Binding, topLevelConst bool) {
+		for _, b := range bindings {
+			ident, ok := b.Target.(*jsast.Identifier)
+			if !ok {
+				continue
+			}
+			id := identifier{name: string(ident.Name), file: f.path, line: line(ident.Idx)}
+			switch b.Initializer.(type) {
+			case *jsast.BooleanLiteral:
+				id.kind = identBool
+			case *jsast.NumberLiteral, *jsast.StringLiteral:
+				if !topLevelConst {
+					continue
+				}
+				id.kind = identConst
+			case *jsast.FunctionLiteral, *jsast.ArrowFunctionLiteral:
+				id.kind = identFunc
+			default:
+				continue
+			}
+			ids = append(ids, id)
+		}
+	}
+	for _, stmt := range program.Body {
+		switch s := stmt.(type) {
+		case *jsast.FunctionDeclaration:
+			if s.Function != nil && s.Function.Name != nil {
+				ids = append(ids, identifier{name: string(s.Function.Name.Name), kind: identFunc, file: f.path, line: line(s.Function.Name.Idx)})
+			}
+		case *jsast.ClassDeclaration:
+			if s.Class != nil && s.Class.Name != nil {
+				ids = append(ids, identifier{name: string(s.Class.Name.Name), kind: identType, file: f.path, line: line(s.Class.Name.Idx)})
+			}
+		case *jsast.LexicalDeclaration:
+			addBindings(s.List, s.Token == jstoken.CONST)
+		case *jsast.VariableStatement:
+			addBindings(s.List, false)
+		}
+	}
+	return ids
+}
+
+// jsDeclarations is the line-based fallback for jsIdentifiers
+func jsDeclarations(f sampledFile) []identifier {
+	var ids []identifier
+	for n, line := range f.lines {
+		switch {
+		case jsFunctionDeclRegex.MatchString(line):
+			ids = append(ids, identifier{name: jsFunctionDeclRegex.FindStringSubmatch(line)[1], kind: identFunc, file: f.path, line: n + 1})
+		case jsClassDeclRegex.MatchString(line):
+			ids = append(ids, identifier{name: jsClassDeclRegex.FindStringSubmatch(line)[1], kind: identType, file: f.path, line: n + 1})
+		case jsVarDeclRegex.MatchString(line):
+			ids = append(ids, identifier{name: jsVarDeclRegex.FindStringSubmatch(line)[1], kind: identBool, file: f.path, line: n + 1})
+		case jsConstDeclRegex.MatchString(line):
+			m := jsConstDeclRegex.FindStringSubmatch(line)
+			value := strings.TrimSpace(m[2])
+			switch {
+			case jsLiteralRegex.MatchString(value):
+				ids = append(ids, identifier{name: m[1], kind: identConst, file: f.path, line: n + 1})
+			case strings.HasPrefix(value, "(") && strings.Contains(value, "=>"),
+				strings.HasPrefix(value, "async ") && strings.Contains(value, "=>"):
+				ids = append(ids, identifier{name: m[1], kind: identFunc, file: f.path, line: n + 1})
+			}
+		}
+	}
+	return ids
+}
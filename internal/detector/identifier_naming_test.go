@@ -0,0 +1,189 @@
+package detector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIdentifierWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"HTTPClient", []string{"HTTP", "Client"}},
+		{"userID", []string{"user", "ID"}},
+		{"parseUrl", []string{"parse", "Url"}},
+		{"is_valid", []string{"is", "valid"}},
+		{"IsDir", []string{"Is", "Dir"}},
+		{"load", []string{"load"}},
+	}
+	for _, tt := range tests {
+		if got := identifierWords(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("identifierWords(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAcronymVariant(t *testing.T) {
+	tests := map[string]string{
+		"HTTPClient": "upper",
+		"userID":     "upper",
+		"HttpClient": "mixed",
+		"parseUrl":   "mixed",
+		"httpClient": "",
+		"MAX_ID":     "",
+		"loadConfig": "",
+	}
+	for name, want := range tests {
+		if got := acronymVariant(name); got != want {
+			t.Errorf("acronymVariant(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// namingFindings runs identifier naming over the fixture, keyed by
+// description without the evidence suffix, with each finding's examples
+func namingFindings(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+	tmpDir, infos := writeProjectFixture(t, files)
+	got := make(map[string]string)
+	for _, conv := range NewConventionDetector(tmpDir, infos).detectIdentifierNaming() {
+		if conv.Category != "naming" {
+			t.Errorf("category = %q, want naming", conv.Category)
+		}
+		desc := conv.Description
+		if i := strings.Index(desc, " (inferred from "); i >= 0 {
+			desc = desc[:i]
+		}
+		got[desc] = conv.Example
+	}
+	return got
+}
+
+func TestDetectIdentifierNaming(t *testing.T) {
+	goSource := `package store
+
+const maxRetries = 3
+
+type Reader interface {
+	Read() error
+}
+
+type HTTPClient struct {
+	isReady bool
+	hasAuth bool
+}
+
+func (c *HTTPClient) FetchURL(userID string) error {
+	return nil
+}
+`
+	goTest := `package store
+
+import "testing"
+
+func TestHTTPClient_FetchURL(t *testing.T) {}
+
+func TestHTTPClient_Retry(t *testing.T) {}
+`
+	pySource := `MAX_RETRIES = 3
+DEFAULT_NAME = "app"
+
+
+class JSONParser:
+    def parse_line(self, line):
+        self.is_done = False
+        return line
+
+    def is_empty(self) -> bool:
+        return True
+
+
+def load_config(path):
+    return path
+`
+	pyTest := `def test_load_config():
+    assert True
+
+
+def test_parse_line():
+    assert True
+`
+	tsSource := `export const MAX_ITEMS = 50
+
+export interface UserService {
+  fetchUser(id: string): Promise<User>
+}
+
+export function parseURL(input: string): URL {
+  const isValid = true
+  return new URL(input)
+}
+
+export class APIClient {}
+`
+	jsTest := `describe('parseURL', () => {
+  it('returns a URL', () => {})
+  it('rejects empty input', () => {})
+})
+`
+
+	files := map[string]string{"go.mod": "module example.com/app\n\ngo 1.22\n"}
+	repeatFixture(files, "internal/store/client%d.go", 6, goSource)
+	repeatFixture(files, "internal/store/client%d_test.go", 6, goTest)
+	repeatFixture(files, "app/parser%d.py", 6, pySource)
+	repeatFixture(files, "tests/test_parser%d.py", 6, pyTest)
+	repeatFixture(files, "src/url%d.ts", 6, tsSource)
+	repeatFixture(files, "src/url%d.test.js", 6, jsTest)
+
+	got := namingFindings(t, files)
+	want := map[string]string{
+		"Name Go interfaces after their behaviour with an -er/-or suffix":                                           "Reader",
+		"Name Go constants in MixedCaps, not ALL_CAPS":                                                              "maxRetries",
+		"Use one- or two-letter receiver names derived from the type":                                               "c",
+		"Keep initialisms uniformly cased in Go identifiers (`HTTPClient`, `userID`), not `HttpClient`":             "HTTPClient, FetchURL",
+		"Prefix boolean fields and variables with is/has/can/should":                                                "isReady, hasAuth",
+		"Name Go tests `TestSubject_Scenario`, separating the function under test from the case with an underscore": "TestHTTPClient_FetchURL, TestHTTPClient_Retry",
+		"Name Python classes in PascalCase":                                                                         "JSONParser",
+		"Name Python functions and methods in snake_case":                                                           "parse_line, load_config",
+		"Name module-level Python constants in UPPER_SNAKE_CASE":                                                    "MAX_RETRIES, DEFAULT_NAME",
+		"Keep acronyms uppercase in Python class names (`HTTPClient`), not `HttpClient`":                            "JSONParser",
+		"Prefix boolean functions and flags with is_/has_/can_/should_":                                             "is_done, is_empty",
+		"Write Python tests as module-level `test_*` functions rather than classes":                                 "test_load_config, test_parse_line",
+		"Name TypeScript interfaces without an `I` prefix":                                                          "UserService",
+		"Name JavaScript/TypeScript classes in PascalCase":                                                          "APIClient",
+		"Name JavaScript/TypeScript functions in camelCase":                                                         "parseURL",
+		"Prefix boolean variables with is/has/can/should":                                                           "isValid",
+		"Name top-level JavaScript/TypeScript constants in UPPER_SNAKE_CASE":                                        "MAX_ITEMS",
+		"Keep acronyms uppercase in JavaScript/TypeScript identifiers (`parseURL`, `userID`), not `parseUrl`":       "parseURL, APIClient",
+		"Phrase test names as plain statements of behaviour, without \"should\"":                                    "returns a URL, rejects empty input",
+	}
+	for desc, example := range want {
+		gotExample, ok := got[desc]
+		if !ok {
+			t.Errorf("missing %q", desc)
+			continue
+		}
+		if gotExample != example {
+			t.Errorf("%q example = %q, want %q", desc, gotExample, example)
+		}
+	}
+	for desc := range got {
+		if _, ok := want[desc]; !ok {
+			t.Errorf("unexpected %q", desc)
+		}
+	}
+}
+
+func TestDetectIdentifierNaming_Mixed(t *testing.T) {
+	files := map[string]string{}
+	repeatFixture(files, "src/upper%d.js", 4, "function parseURL(x) { return x }\n")
+	repeatFixture(files, "src/mixed%d.js", 4, "function parseUrl(x) { return x }\n")
+
+	for desc := range namingFindings(t, files) {
+		if strings.Contains(desc, "acronym") {
+			t.Errorf("reported %q although the codebase is split", desc)
+		}
+	}
+}
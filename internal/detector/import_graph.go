@@ -0,0 +1,745 @@
+package detector
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// importEdge is a single import statement between two modules of the project.
+// Go modules are package directories; TypeScript, Python and Rust modules are
+// source paths without extension, with index.ts, __init__.py, mod.rs and the
+// crate root collapsing to their directory.
+type importEdge struct {
+	from   string // importing module
+	to     string // imported module
+	path   string // import specifier as written
+	file   string
+	line   int
+	isTest bool
+}
+
+var (
+	jsImportRegex  = regexp.MustCompile(`(?:^|[^\w.$])(?:import|export)\s+(?:type\s+)?(?:[\w*${}\s,]+?\s+from\s+)?['"]([^'"\n]+)['"]`)
+	jsRequireRegex = regexp.MustCompile(`(?:^|[^\w.$])(?:require|import)\s*\(\s*['"]([^'"\n]+)['"]\s*\)`)
+
+	pyImportLineRegex = regexp.MustCompile(`^\s*import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pyFromModuleRegex = regexp.MustCompile(`^\s*from\s+(\.*)([\w.]*)\s+import\s+(.+)$`)
+
+	rustUseRegex      = regexp.MustCompile(`(?m)^[ \t]*(?:pub(?:\([^)]*\))?\s+)?use\s+([^;]+);`)
+	rustUseAliasRegex = regexp.MustCompile(`\s+as\s+\w+`)
+)
+
+// jsExtensions lists the source extensions tried when resolving JS/TS imports
+var jsExtensions = []string{".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"}
+
+// tsExtensions are the JS/TS source extensions reported as TypeScript
+var tsExtensions = map[string]bool{".ts": true, ".tsx": true, ".mts": true, ".cts": true}
+
+// importGraph resolves imports against the files of the project
+type importGraph struct {
+	rootPath  string
+	files     map[string]bool // slash-separated paths
+	tsConfigs map[string]*tsPathConfig
+	crates    map[string]string // crate name -> src dir
+}
+
+// tsPathConfig holds the module resolution settings of a tsconfig.json
+type tsPathConfig struct {
+	baseDir string              // directory paths and bare imports resolve from
+	paths   map[string][]string // compilerOptions.paths
+	hasBase bool                // baseUrl was set, so bare specifiers may be local
+}
+
+// importEdges collects imports between modules of the project with their
+// positions, for Go, TypeScript/JavaScript, Python and Rust sources
+func (a *ArchitectureAnalyzer) importEdges() []importEdge {
+	edges, _ := a.buildImportGraph()
+	return edges
+}
+
+// buildImportGraph returns the import edges along with the non-Go modules
+// they were collected from
+func (a *ArchitectureAnalyzer) buildImportGraph() ([]importEdge, []PackageInfo) {
+	g := &importGraph{rootPath: a.rootPath, files: make(map[string]bool)}
+	for _, f := range a.files {
+		if !f.IsDir {
+			g.files[filepath.ToSlash(f.Path)] = true
+		}
+	}
+	g.tsConfigs = g.loadTSConfigs()
+	g.crates = g.loadCrates()
+
+	edges := a.goImportEdges()
+	modules := make(map[string]*PackageInfo)
+	for _, f := range a.files {
+		if f.IsDir || f.Size > 500000 {
+			continue
+		}
+		rel := filepath.ToSlash(f.Path)
+		var fileEdges []importEdge
+		var module, language string
+		switch {
+		case isJSSource(rel):
+			fileEdges, module, language = g.jsEdges(rel), jsModule(rel), "JavaScript"
+			if tsExtensions[path.Ext(rel)] {
+				language = "TypeScript"
+			}
+		case strings.HasSuffix(rel, ".py"):
+			fileEdges, module, language = g.pythonEdges(rel), pyModule(rel), "Python"
+		case strings.HasSuffix(rel, ".rs"):
+			fileEdges, module, language = g.rustEdges(rel), g.rustModule(rel), "Rust"
+		default:
+			continue
+		}
+
+		test := isTestSourceFile(rel)
+		pkg, ok := modules[module]
+		if !ok {
+			pkg = &PackageInfo{Path: module, Name: path.Base(module), Language: language, IsTest: test}
+			modules[module] = pkg
+		}
+		pkg.Files = append(pkg.Files, f.Name)
+
+		for _, e := range fileEdges {
+			if e.to == "" || e.to == e.from {
+				continue
+			}
+			e.isTest = test
+			edges = append(edges, e)
+			if !sliceContainsString(pkg.Imports, e.path) {
+				pkg.Imports = append(pkg.Imports, e.path)
+			}
+		}
+	}
+
+	packages := make([]PackageInfo, 0, len(modules))
+	for _, pkg := range modules {
+		packages = append(packages, *pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Path < packages[j].Path })
+
+	return edges, packages
+}
+
+// dependencyGraph returns module -> imported modules, excluding test files
+func (a *ArchitectureAnalyzer) dependencyGraph() map[string][]string {
+	deps := make(map[string][]string)
+	for _, e := range a.importEdges() {
+		if !e.isTest && !sliceContainsString(deps[e.from], e.to) {
+			deps[e.from] = append(deps[e.from], e.to)
+		}
+	}
+	for from := range deps {
+		sort.Strings(deps[from])
+	}
+	return deps
+}
+
+// goImportEdges collects imports of packages inside the Go module
+func (a *ArchitectureAnalyzer) goImportEdges() []importEdge {
+	module := a.modulePath()
+	if module == "" {
+		return nil
+	}
+
+	var edges []importEdge
+	for _, f := range a.files {
+		if f.IsDir || !strings.HasSuffix(f.Name, ".go") {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		node, err := parser.ParseFile(fset, filepath.Join(a.rootPath, f.Path), nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+
+		from := filepath.ToSlash(filepath.Dir(f.Path))
+		for _, imp := range node.Imports {
+			importPath := strings.Trim(imp.Path.Value, `"`)
+			to := goPackageDir(module, importPath)
+			if to == "" || to == from {
+				continue
+			}
+			edges = append(edges, importEdge{
+				from:   from,
+				to:     to,
+				path:   importPath,
+				file:   filepath.ToSlash(f.Path),
+				line:   fset.Position(imp.Pos()).Line,
+				isTest: strings.HasSuffix(f.Name, "_test.go"),
+			})
+		}
+	}
+
+	return edges
+}
+
+// goPackageDir maps an import path inside module to its package directory
+func goPackageDir(module, importPath string) string {
+	switch {
+	case importPath == module:
+		return "."
+	case strings.HasPrefix(importPath, module+"/"):
+		return strings.TrimPrefix(importPath, module+"/")
+	}
+	return ""
+}
+
+// read returns the content of a project file
+func (g *importGraph) read(rel string) string {
+	content, err := os.ReadFile(filepath.Join(g.rootPath, rel))
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// lineAt returns the 1-based line of offset in content
+func lineAt(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// isJSSource reports whether rel is a JavaScript or TypeScript source file
+func isJSSource(rel string) bool {
+	if strings.HasSuffix(rel, ".d.ts") {
+		return false
+	}
+	ext := path.Ext(rel)
+	for _, e := range jsExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// jsModule names the module of a JS/TS file
+func jsModule(rel string) string {
+	mod := strings.TrimSuffix(rel, path.Ext(rel))
+	if path.Base(mod) == "index" {
+		return path.Dir(mod)
+	}
+	return mod
+}
+
+// jsEdges extracts static, re-export, require and dynamic imports
+func (g *importGraph) jsEdges(rel string) []importEdge {
+	content := g.read(rel)
+	if content == "" {
+		return nil
+	}
+
+	from := jsModule(rel)
+	var edges []importEdge
+	for _, re := range []*regexp.Regexp{jsImportRegex, jsRequireRegex} {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			spec := content[m[2]:m[3]]
+			target := g.resolveJS(rel, spec)
+			if target == "" {
+				continue
+			}
+			edges = append(edges, importEdge{
+				from: from,
+				to:   jsModule(target),
+				path: spec,
+				file: rel,
+				line: lineAt(content, m[2]),
+			})
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].line < edges[j].line })
+	return edges
+}
+
+// resolveJS resolves a specifier to a project file: relative paths first,
+// then tsconfig path aliases, then baseUrl
+func (g *importGraph) resolveJS(rel, spec string) string {
+	if spec == "." || spec == ".." || strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../") {
+		return g.jsFile(path.Join(path.Dir(rel), spec))
+	}
+
+	cfg := g.tsConfigFor(rel)
+	if cfg == nil {
+		return ""
+	}
+
+	patterns := make([]string, 0, len(cfg.paths))
+	for pattern := range cfg.paths {
+		patterns = append(patterns, pattern)
+	}
+	// TypeScript picks the pattern with the longest prefix before "*"
+	sort.Slice(patterns, func(i, j int) bool {
+		pi, pj := strings.Index(patterns[i]+"*", "*"), strings.Index(patterns[j]+"*", "*")
+		if pi != pj {
+			return pi > pj
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		star, ok := matchPathPattern(pattern, spec)
+		if !ok {
+			continue
+		}
+		for _, target := range cfg.paths[pattern] {
+			if file := g.jsFile(path.Join(cfg.baseDir, strings.Replace(target, "*", star, 1))); file != "" {
+				return file
+			}
+		}
+	}
+
+	if cfg.hasBase {
+		return g.jsFile(path.Join(cfg.baseDir, spec))
+	}
+	return ""
+}
+
+// matchPathPattern matches a tsconfig paths key, returning the text the
+// wildcard stands for
+func matchPathPattern(pattern, spec string) (string, bool) {
+	prefix, suffix, wildcard := strings.Cut(pattern, "*")
+	if !wildcard {
+		return "", pattern == spec
+	}
+	if len(spec) < len(prefix)+len(suffix) || !strings.HasPrefix(spec, prefix) || !strings.HasSuffix(spec, suffix) {
+		return "", false
+	}
+	return spec[len(prefix) : len(spec)-len(suffix)], true
+}
+
+// jsFile finds the source file a resolved path refers to, trying extensions
+// and index files the way bundlers do
+func (g *importGraph) jsFile(p string) string {
+	p = strings.TrimPrefix(p, "./")
+	if g.files[p] && isJSSource(p) {
+		return p
+	}
+	// ESM TypeScript imports name the compiled .js file
+	if ext := path.Ext(p); ext == ".js" || ext == ".jsx" || ext == ".mjs" {
+		p = strings.TrimSuffix(p, ext)
+	}
+	for _, ext := range jsExtensions {
+		if g.files[p+ext] {
+			return p + ext
+		}
+	}
+	for _, ext := range jsExtensions {
+		if g.files[p+"/index"+ext] {
+			return p + "/index" + ext
+		}
+	}
+	return ""
+}
+
+// tsConfigFor returns the nearest tsconfig.json or jsconfig.json above rel
+func (g *importGraph) tsConfigFor(rel string) *tsPathConfig {
+	for dir := path.Dir(rel); ; dir = path.Dir(dir) {
+		if cfg, ok := g.tsConfigs[dir]; ok {
+			return cfg
+		}
+		if dir == "." || dir == "/" {
+			return nil
+		}
+	}
+}
+
+// loadTSConfigs reads path mapping settings from every tsconfig.json and
+// jsconfig.json, keyed by directory
+func (g *importGraph) loadTSConfigs() map[string]*tsPathConfig {
+	configs := make(map[string]*tsPathConfig)
+	for rel := range g.files {
+		base := path.Base(rel)
+		if base != "tsconfig.json" && base != "jsconfig.json" {
+			continue
+		}
+		if _, ok := configs[path.Dir(rel)]; ok && base == "jsconfig.json" {
+			continue
+		}
+		if cfg := g.loadTSConfig(rel, 0); cfg != nil {
+			configs[path.Dir(rel)] = cfg
+		}
+	}
+	return configs
+}
+
+// loadTSConfig parses one config, following relative "extends" for settings
+// the file doesn't set itself
+func (g *importGraph) loadTSConfig(rel string, depth int) *tsPathConfig {
+	var raw struct {
+		Extends         string `json:"extends"`
+		CompilerOptions struct {
+			BaseURL *string             `json:"baseUrl"`
+			Paths   map[string][]string `json:"paths"`
+		} `json:"compilerOptions"`
+	}
+	content := g.read(rel)
+	if content == "" || json.Unmarshal([]byte(stripJSONC(content)), &raw) != nil {
+		return nil
+	}
+
+	dir := path.Dir(rel)
+	cfg := &tsPathConfig{baseDir: dir, paths: raw.CompilerOptions.Paths}
+	if raw.CompilerOptions.BaseURL != nil {
+		cfg.baseDir = path.Join(dir, *raw.CompilerOptions.BaseURL)
+		cfg.hasBase = true
+	}
+
+	if raw.Extends != "" && strings.HasPrefix(raw.Extends, ".") && depth < 3 {
+		parentPath := path.Join(dir, raw.Extends)
+		if !strings.HasSuffix(parentPath, ".json") {
+			parentPath += ".json"
+		}
+		if parent := g.loadTSConfig(parentPath, depth+1); parent != nil {
+			if !cfg.hasBase && parent.hasBase {
+				cfg.baseDir, cfg.hasBase = parent.baseDir, true
+			}
+			if cfg.paths == nil {
+				cfg.paths = parent.paths
+				if !cfg.hasBase {
+					cfg.baseDir = parent.baseDir
+				}
+			}
+		}
+	}
+
+	return cfg
+}
+
+// stripJSONC removes comments and trailing commas so tsconfig files parse
+// as plain JSON
+func stripJSONC(s string) string {
+	var out strings.Builder
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(s) {
+				i++
+				out.WriteByte(s[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(s) && s[i+1] == '/':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+			out.WriteByte('\n')
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return out.String()
+			}
+			i += end + 3
+		case c == ',':
+			rest := strings.TrimLeft(s[i+1:], " \t\r\n")
+			if rest == "" || rest[0] == '}' || rest[0] == ']' {
+				continue
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// pyModule names the module of a Python file
+func pyModule(rel string) string {
+	mod := strings.TrimSuffix(rel, ".py")
+	if path.Base(mod) == "__init__" {
+		return path.Dir(mod)
+	}
+	return mod
+}
+
+// pyResolve returns the module for a dotted path under dir, if it exists
+func (g *importGraph) pyResolve(dir, dotted string) string {
+	p := path.Join(dir, strings.ReplaceAll(dotted, ".", "/"))
+	if g.files[p+".py"] {
+		return p
+	}
+	if g.files[p+"/__init__.py"] {
+		return p
+	}
+	return ""
+}
+
+// pythonEdges extracts absolute and package-relative imports. Absolute
+// imports resolve from the project root, src/, or the importing script's
+// own directory.
+func (g *importGraph) pythonEdges(rel string) []importEdge {
+	content := g.read(rel)
+	if content == "" {
+		return nil
+	}
+
+	from := pyModule(rel)
+	fileDir := path.Dir(rel)
+	roots := []string{".", "src", fileDir}
+
+	resolveAbs := func(dotted string) string {
+		for _, root := range roots {
+			if mod := g.pyResolve(root, dotted); mod != "" {
+				return mod
+			}
+		}
+		return ""
+	}
+
+	var edges []importEdge
+	add := func(to, spec string, line int) {
+		if to != "" {
+			edges = append(edges, importEdge{from: from, to: to, path: spec, file: rel, line: line})
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if hash := strings.Index(line, "#"); hash >= 0 {
+			line = line[:hash]
+		}
+
+		if m := pyImportLineRegex.FindStringSubmatch(line); m != nil {
+			for _, part := range strings.Split(m[1], ",") {
+				dotted := strings.Fields(part)[0]
+				// import a.b.c binds a but loads every package on the way
+				for mod := dotted; mod != ""; {
+					if to := resolveAbs(mod); to != "" {
+						add(to, dotted, i+1)
+						break
+					}
+					dot := strings.LastIndex(mod, ".")
+					if dot < 0 {
+						break
+					}
+					mod = mod[:dot]
+				}
+			}
+			continue
+		}
+
+		m := pyFromModuleRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		dots, module, names := m[1], m[2], strings.TrimSpace(m[3])
+		lineNo := i + 1
+		if strings.HasPrefix(names, "(") {
+			for !strings.Contains(names, ")") && i+1 < len(lines) {
+				i++
+				names += " " + lines[i]
+			}
+			names = strings.Trim(names, "() ")
+		}
+
+		var base func(dotted string) string
+		if dots == "" {
+			base = resolveAbs
+		} else {
+			pkgDir := fileDir
+			for n := 1; n < len(dots); n++ {
+				pkgDir = path.Dir(pkgDir)
+			}
+			base = func(dotted string) string {
+				if dotted == "" {
+					if g.files[path.Join(pkgDir, "__init__.py")] {
+						return pkgDir
+					}
+					return ""
+				}
+				return g.pyResolve(pkgDir, dotted)
+			}
+		}
+
+		spec := dots + module
+		found := false
+		// from pkg import mod names submodules as well as attributes
+		for _, name := range strings.Split(names, ",") {
+			fields := strings.Fields(strings.Trim(name, "() "))
+			if len(fields) == 0 || fields[0] == "*" {
+				continue
+			}
+			sub := fields[0]
+			if module != "" {
+				sub = module + "." + sub
+			}
+			if to := base(sub); to != "" {
+				add(to, spec, lineNo)
+				found = true
+			}
+		}
+		if !found && module != "" {
+			add(base(module), spec, lineNo)
+		}
+	}
+
+	return edges
+}
+
+// loadCrates maps the crate names of Cargo packages to their src directory
+func (g *importGraph) loadCrates() map[string]string {
+	crates := make(map[string]string)
+	for rel := range g.files {
+		if path.Base(rel) != "Cargo.toml" {
+			continue
+		}
+		var cargo CargoToml
+		if _, err := toml.Decode(g.read(rel), &cargo); err != nil {
+			continue
+		}
+		name := cargo.Package.Name
+		if cargo.Lib != nil && cargo.Lib.Name != "" {
+			name = cargo.Lib.Name
+		}
+		if name != "" {
+			crates[strings.ReplaceAll(name, "-", "_")] = path.Join(path.Dir(rel), "src")
+		}
+	}
+	return crates
+}
+
+// rustCrateSrc returns the src directory of the crate containing rel
+func (g *importGraph) rustCrateSrc(rel string) string {
+	for dir := path.Dir(rel); ; dir = path.Dir(dir) {
+		if g.files[path.Join(dir, "Cargo.toml")] {
+			return path.Join(dir, "src")
+		}
+		if dir == "." || dir == "/" {
+			return ""
+		}
+	}
+}
+
+// rustModule names the module of a Rust file following the layout that
+// mod declarations map to: a.rs and a/mod.rs are module a, and lib.rs or
+// main.rs is the crate root
+func (g *importGraph) rustModule(rel string) string {
+	mod := strings.TrimSuffix(rel, ".rs")
+	switch path.Base(mod) {
+	case "mod":
+		return path.Dir(mod)
+	case "lib", "main":
+		if path.Dir(mod) == g.rustCrateSrc(rel) {
+			return path.Dir(mod)
+		}
+	}
+	return mod
+}
+
+// rustEdges extracts use declarations rooted at crate, self, super or a
+// workspace crate. Scanning stops at #[cfg(test)] so inline test modules
+// don't add their own super:: imports.
+func (g *importGraph) rustEdges(rel string) []importEdge {
+	content := g.read(rel)
+	if content == "" {
+		return nil
+	}
+	if idx := strings.Index(content, "#[cfg(test)]"); idx >= 0 {
+		content = content[:idx]
+	}
+
+	from := g.rustModule(rel)
+	crateSrc := g.rustCrateSrc(rel)
+
+	var edges []importEdge
+	for _, m := range rustUseRegex.FindAllStringSubmatchIndex(content, -1) {
+		tree := content[m[2]:m[3]]
+		seen := make(map[string]bool)
+		for _, usePath := range expandUseTree(tree) {
+			to := g.resolveRustPath(from, crateSrc, usePath)
+			if to == "" || seen[to] {
+				continue
+			}
+			seen[to] = true
+			edges = append(edges, importEdge{
+				from: from,
+				to:   to,
+				path: strings.Join(strings.Fields(tree), " "),
+				file: rel,
+				line: lineAt(content, m[2]),
+			})
+		}
+	}
+	return edges
+}
+
+// resolveRustPath maps a use path to the deepest module file it reaches
+func (g *importGraph) resolveRustPath(from, crateSrc, usePath string) string {
+	segs := strings.Split(strings.TrimPrefix(usePath, "::"), "::")
+	var base string
+	switch segs[0] {
+	case "crate":
+		base = crateSrc
+		segs = segs[1:]
+	case "self":
+		base = from
+		segs = segs[1:]
+	case "super":
+		base = from
+		for len(segs) > 0 && segs[0] == "super" {
+			if base == crateSrc {
+				return ""
+			}
+			base = path.Dir(base)
+			segs = segs[1:]
+		}
+	default:
+		src, ok := g.crates[segs[0]]
+		if !ok {
+			return ""
+		}
+		base = src
+		segs = segs[1:]
+	}
+	if base == "" {
+		return ""
+	}
+
+	for k := len(segs); k > 0; k-- {
+		candidate := path.Join(base, strings.Join(segs[:k], "/"))
+		if g.files[candidate+".rs"] || g.files[candidate+"/mod.rs"] {
+			return candidate
+		}
+	}
+	return base
+}
+
+// expandUseTree flattens a use tree like crate::{a::B, b::{self, C}} into
+// individual paths, dropping aliases
+func expandUseTree(tree string) []string {
+	tree = strings.Join(strings.Fields(rustUseAliasRegex.ReplaceAllString(tree, "")), "")
+	open := strings.Index(tree, "{")
+	if open < 0 {
+		return []string{strings.TrimSuffix(strings.TrimSuffix(tree, "::*"), "::self")}
+	}
+
+	prefix := tree[:open]
+	inner := strings.TrimSuffix(tree[open+1:], "}")
+	var paths []string
+	for inner != "" {
+		part, rest, _ := splitTopLevel(inner, ',')
+		if part = strings.TrimSpace(part); part != "" {
+			paths = append(paths, expandUseTree(prefix+part)...)
+		}
+		inner = rest
+	}
+	return paths
+}
@@ -0,0 +1,158 @@
+package detector
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestArchitectureAnalyzer_TypeScriptImports(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"tsconfig.base.json": `{
+  // shared options
+  "compilerOptions": {
+    "baseUrl": ".",
+    "paths": {
+      "@/*": ["src/*"],
+      "@ui": ["src/components/index.ts"],
+    },
+  },
+}
+`,
+		"tsconfig.json":             `{ "extends": "./tsconfig.base.json", "include": ["src"] }`,
+		"src/components/index.ts":   "export * from './Button';\n",
+		"src/components/Button.tsx": "import { cn } from '@/lib/utils';\nexport const Button = () => null;\n",
+		"src/lib/utils.ts":          "export const cn = () => '';\n",
+		"src/pages/home.tsx": `import React from 'react';
+import { Button } from '@ui';
+import type { Config } from "../config.js";
+const api = require('@/api/client');
+export const Home = () => import('./lazy');
+`,
+		"src/pages/lazy.tsx":      "export default 1;\n",
+		"src/config.ts":           "export type Config = {};\n",
+		"src/api/client.js":       "module.exports = {};\n",
+		"src/pages/home.test.tsx": "import { Home } from './home';\n",
+	})
+
+	info := NewArchitectureAnalyzer(tmpDir, files).Analyze()
+
+	want := []string{"src/api/client", "src/components", "src/config", "src/pages/lazy"}
+	if got := info.Dependencies["src/pages/home"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected home deps %v, got %v", want, got)
+	}
+	if got := info.Dependencies["src/components"]; !reflect.DeepEqual(got, []string{"src/components/Button"}) {
+		t.Errorf("expected re-export edge, got %v", got)
+	}
+	if got := info.Dependencies["src/components/Button"]; !reflect.DeepEqual(got, []string{"src/lib/utils"}) {
+		t.Errorf("expected alias resolved through extends, got %v", got)
+	}
+	if got := info.Dependents["src/pages/home"]; len(got) != 0 {
+		t.Errorf("expected test imports to be excluded, got %v", got)
+	}
+}
+
+func TestArchitectureAnalyzer_PythonImports(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"app/__init__.py":          "",
+		"app/models/__init__.py":   "from .user import User\n",
+		"app/models/user.py":       "class User: pass\n",
+		"app/services/__init__.py": "",
+		"app/services/billing.py": `import os
+import app.models.user as user_model
+from app.models import User
+from ..models import (
+    user,
+)
+from . import orders  # sibling module
+`,
+		"app/services/orders.py": "from app.services.billing import charge\n",
+		"tests/test_billing.py":  "from app.services import billing\n",
+	})
+
+	info := NewArchitectureAnalyzer(tmpDir, files).Analyze()
+
+	want := []string{"app/models", "app/models/user", "app/services/orders"}
+	if got := info.Dependencies["app/services/billing"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected billing deps %v, got %v", want, got)
+	}
+	if len(info.CyclicDeps) != 1 {
+		t.Errorf("expected billing <-> orders cycle, got %v", info.CyclicDeps)
+	}
+
+	var billing *PackageInfo
+	for i := range info.Packages {
+		if info.Packages[i].Path == "app/services/billing" {
+			billing = &info.Packages[i]
+		}
+	}
+	if billing == nil || billing.Language != "Python" {
+		t.Fatalf("expected billing module in packages, got %+v", billing)
+	}
+}
+
+func TestArchitectureAnalyzer_RustImports(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"Cargo.toml":              "[package]\nname = \"shop-core\"\n",
+		"src/lib.rs":              "pub mod db;\npub mod handlers;\n",
+		"src/db.rs":               "pub struct Pool;\n",
+		"src/handlers/mod.rs":     "pub mod orders;\nuse crate::{db::Pool, handlers::orders as o};\n",
+		"src/handlers/orders.rs":  "use super::super::db;\nuse std::fmt;\n\n#[cfg(test)]\nmod tests {\n    use super::*;\n    use crate::handlers;\n}\n",
+		"cli/Cargo.toml":          "[package]\nname = \"shop-cli\"\n",
+		"cli/src/main.rs":         "use shop_core::handlers::orders;\nmod args;\nuse self::args::Args;\n",
+		"cli/src/args.rs":         "pub struct Args;\n",
+		"cli/src/unused/thing.rs": "",
+	})
+
+	info := NewArchitectureAnalyzer(tmpDir, files).Analyze()
+
+	tests := []struct {
+		module string
+		want   []string
+	}{
+		{"src/handlers", []string{"src/db", "src/handlers/orders"}},
+		{"src/handlers/orders", []string{"src/db"}},
+		{"cli/src", []string{"cli/src/args", "src/handlers/orders"}},
+	}
+	for _, tt := range tests {
+		if got := info.Dependencies[tt.module]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.module, tt.want, got)
+		}
+	}
+}
+
+func TestExpandUseTree(t *testing.T) {
+	got := expandUseTree("crate::{a::B, b::{self, C as D}, e::*}")
+	want := []string{"crate::a::B", "crate::b", "crate::b::C", "crate::e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestArchitectureDetector_LayerDependenciesFromImports(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"services/users/api.py":      "from models.user.entity import User\nfrom services.users import auth\n",
+		"services/users/auth.py":     "",
+		"models/user/entity.py":      "from config.db.settings import DB\n",
+		"config/db/settings.py":      "DB = ''\n",
+		"services/users/test_api.py": "from config.db import settings\n",
+	})
+	for _, dir := range []string{"services", "services/users", "models", "models/user", "config", "config/db"} {
+		files = append(files, types.FileInfo{Path: dir, Name: filepath.Base(dir), IsDir: true})
+	}
+
+	info := NewArchitectureDetector(tmpDir, files).Detect()
+
+	deps := make(map[string][]string)
+	for _, layer := range info.Layers {
+		deps[layer.Name] = layer.DependsOn
+	}
+	if got := deps["services"]; !reflect.DeepEqual(got, []string{"models/user"}) {
+		t.Errorf("expected services -> models/user, got %v", got)
+	}
+	if got := deps["models"]; !reflect.DeepEqual(got, []string{"config/db"}) {
+		t.Errorf("expected models -> config/db, got %v", got)
+	}
+}
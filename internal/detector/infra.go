@@ -0,0 +1,417 @@
+package detector
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Infrastructure scan limits
+const (
+	infraMaxFileSize     = 500000
+	infraMaxK8sResources = 50
+)
+
+// backingServiceImages are image names of databases, caches, queues and
+// emulators that an application needs running during development
+var backingServiceImages = []string{
+	"postgres", "postgis", "timescaledb", "mysql", "mariadb", "mongo", "cockroach", "cassandra",
+	"clickhouse", "couchdb", "neo4j", "influxdb", "redis", "valkey", "memcached", "rabbitmq",
+	"kafka", "zookeeper", "nats", "elasticsearch", "opensearch", "meilisearch", "typesense",
+	"minio", "localstack", "azurite", "dynamodb-local", "mailhog", "mailpit", "keycloak",
+	"vault", "consul", "etcd", "jaeger", "prometheus", "grafana", "temporal", "qdrant",
+	"weaviate", "milvus",
+}
+
+// k8sKinds are the Kubernetes object kinds worth summarizing
+var k8sKinds = map[string]bool{
+	"Deployment": true, "StatefulSet": true, "DaemonSet": true, "Job": true, "CronJob": true,
+	"Service": true, "Ingress": true, "HorizontalPodAutoscaler": true,
+}
+
+var (
+	tfProviderBlockRegex    = regexp.MustCompile(`(?m)^\s*provider\s+"([\w-]+)"`)
+	tfRequiredProviderRegex = regexp.MustCompile(`(?m)^\s*([\w-]+)\s*=\s*\{[^}]*source\s*=\s*"[^"]+"`)
+	tfModuleRegex           = regexp.MustCompile(`(?s)module\s+"([^"]+)"\s*\{[^}]*?source\s*=\s*"([^"]+)"`)
+	tfBackendRegex          = regexp.MustCompile(`backend\s+"([\w-]+)"`)
+)
+
+// InfraDetector detects container, orchestration and infrastructure-as-code configuration
+type InfraDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewInfraDetector creates a new infrastructure detector
+func NewInfraDetector(rootPath string, files []types.FileInfo) *InfraDetector {
+	return &InfraDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// Detect returns the project's infrastructure, or nil when none is found
+func (d *InfraDetector) Detect() *types.Infrastructure {
+	infra := &types.Infrastructure{}
+	terraform := &types.Terraform{}
+
+	for _, f := range d.files {
+		if f.IsDir || f.Size > infraMaxFileSize {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = filepath.Base(f.Path)
+		}
+
+		switch {
+		case isDockerfile(name):
+			if content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path)); err == nil {
+				dockerfile := parseDockerfile(string(content))
+				if len(dockerfile.Stages) > 0 {
+					dockerfile.Path = f.Path
+					infra.Dockerfiles = append(infra.Dockerfiles, dockerfile)
+				}
+			}
+		case isComposeFile(name):
+			infra.ComposeServices = append(infra.ComposeServices, d.composeServices(f.Path)...)
+		case name == "Chart.yaml":
+			if chart := d.helmChart(f.Path); chart != nil {
+				infra.HelmCharts = append(infra.HelmCharts, *chart)
+			}
+		case name == "kustomization.yaml" || name == "kustomization.yml" || name == "Kustomization":
+			infra.Kustomizations = append(infra.Kustomizations, path.Dir(filepath.ToSlash(f.Path)))
+		case f.Extension == ".tf":
+			if !strings.Contains(f.Path, ".terraform/") {
+				d.addTerraform(terraform, f.Path)
+			}
+		case f.Extension == ".yaml" || f.Extension == ".yml":
+			if len(infra.Kubernetes) < infraMaxK8sResources && !strings.HasPrefix(f.Path, ".github/") {
+				infra.Kubernetes = append(infra.Kubernetes, d.k8sResources(f.Path)...)
+			}
+		}
+	}
+
+	sort.Slice(infra.Dockerfiles, func(i, j int) bool { return infra.Dockerfiles[i].Path < infra.Dockerfiles[j].Path })
+	sort.SliceStable(infra.ComposeServices, func(i, j int) bool {
+		return infra.ComposeServices[i].File < infra.ComposeServices[j].File
+	})
+	sort.SliceStable(infra.Kubernetes, func(i, j int) bool { return infra.Kubernetes[i].Path < infra.Kubernetes[j].Path })
+	sort.Slice(infra.HelmCharts, func(i, j int) bool { return infra.HelmCharts[i].Path < infra.HelmCharts[j].Path })
+	sort.Strings(infra.Kustomizations)
+	sort.Strings(terraform.Dirs)
+
+	if len(infra.Kubernetes) > infraMaxK8sResources {
+		infra.Kubernetes = infra.Kubernetes[:infraMaxK8sResources]
+	}
+	if len(terraform.Dirs) > 0 {
+		sort.Strings(terraform.Providers)
+		sort.Slice(terraform.Modules, func(i, j int) bool {
+			return terraform.Modules[i].Name < terraform.Modules[j].Name
+		})
+		infra.Terraform = terraform
+	}
+
+	if len(infra.Dockerfiles) == 0 && len(infra.ComposeServices) == 0 && len(infra.Kubernetes) == 0 &&
+		len(infra.HelmCharts) == 0 && len(infra.Kustomizations) == 0 && infra.Terraform == nil {
+		return nil
+	}
+	return infra
+}
+
+// isDockerfile matches Dockerfile, Dockerfile.prod and api.Dockerfile
+func isDockerfile(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "dockerfile" || strings.HasPrefix(lower, "dockerfile.") || strings.HasSuffix(lower, ".dockerfile")
+}
+
+// parseDockerfile extracts build stages, exposed ports and the final
+// stage's start command
+func parseDockerfile(content string) types.Dockerfile {
+	var dockerfile types.Dockerfile
+	var entrypoint, cmd string
+
+	for _, line := range shellLines(content) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		args := strings.TrimSpace(line[len(fields[0]):])
+
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			var parts []string
+			for _, field := range fields[1:] {
+				if !strings.HasPrefix(field, "--") {
+					parts = append(parts, field)
+				}
+			}
+			if len(parts) == 0 {
+				continue
+			}
+			stage := types.DockerStage{Image: parts[0]}
+			if len(parts) >= 3 && strings.EqualFold(parts[1], "AS") {
+				stage.Name = parts[2]
+			}
+			dockerfile.Stages = append(dockerfile.Stages, stage)
+			entrypoint, cmd = "", ""
+		case "EXPOSE":
+			for _, port := range fields[1:] {
+				dockerfile.ExposedPorts = appendUnique(dockerfile.ExposedPorts, strings.TrimSuffix(port, "/tcp"))
+			}
+		case "ENTRYPOINT":
+			entrypoint = dockerCommand(args)
+		case "CMD":
+			cmd = dockerCommand(args)
+		}
+	}
+
+	dockerfile.Command = strings.TrimSpace(entrypoint + " " + cmd)
+	return dockerfile
+}
+
+// dockerCommand turns an exec-form ["a", "b"] instruction into "a b"
+func dockerCommand(args string) string {
+	var exec []string
+	if strings.HasPrefix(args, "[") && json.Unmarshal([]byte(args), &exec) == nil {
+		return strings.Join(exec, " ")
+	}
+	return args
+}
+
+// composeServices parses the services in a docker-compose file
+func (d *InfraDetector) composeServices(relPath string) []types.ComposeService {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil {
+		return nil
+	}
+	var compose struct {
+		Services yaml.Node `yaml:"services"`
+	}
+	if yaml.Unmarshal(content, &compose) != nil || compose.Services.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var services []types.ComposeService
+	for i := 0; i+1 < len(compose.Services.Content); i += 2 {
+		var spec struct {
+			Image     string      `yaml:"image"`
+			Build     yaml.Node   `yaml:"build"`
+			Ports     []yaml.Node `yaml:"ports"`
+			DependsOn yaml.Node   `yaml:"depends_on"`
+		}
+		if compose.Services.Content[i+1].Decode(&spec) != nil {
+			continue
+		}
+
+		service := types.ComposeService{
+			Name:  compose.Services.Content[i].Value,
+			File:  relPath,
+			Image: spec.Image,
+		}
+		switch spec.Build.Kind {
+		case yaml.ScalarNode:
+			service.Build = spec.Build.Value
+		case yaml.MappingNode:
+			var build struct {
+				Context string `yaml:"context"`
+			}
+			_ = spec.Build.Decode(&build)
+			service.Build = build.Context
+			if service.Build == "" {
+				service.Build = "."
+			}
+		}
+		for _, port := range spec.Ports {
+			if port.Kind == yaml.ScalarNode {
+				service.Ports = append(service.Ports, port.Value)
+				continue
+			}
+			var long struct {
+				Published string `yaml:"published"`
+				Target    string `yaml:"target"`
+			}
+			if port.Decode(&long) == nil && long.Target != "" {
+				service.Ports = append(service.Ports, strings.TrimPrefix(long.Published+":"+long.Target, ":"))
+			}
+		}
+		switch spec.DependsOn.Kind {
+		case yaml.SequenceNode:
+			for _, n := range spec.DependsOn.Content {
+				service.DependsOn = append(service.DependsOn, n.Value)
+			}
+		case yaml.MappingNode:
+			for j := 0; j < len(spec.DependsOn.Content); j += 2 {
+				service.DependsOn = append(service.DependsOn, spec.DependsOn.Content[j].Value)
+			}
+		}
+		service.Backing = service.Build == "" && isBackingImage(service.Image)
+		services = append(services, service)
+	}
+	return services
+}
+
+// isBackingImage reports whether an image is a known database, cache, queue or emulator
+func isBackingImage(image string) bool {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if idx := strings.IndexAny(name, ":@"); idx >= 0 {
+		name = name[:idx]
+	}
+	name = strings.ToLower(name)
+	for _, backing := range backingServiceImages {
+		if strings.Contains(name, backing) {
+			return true
+		}
+	}
+	return false
+}
+
+// helmChart parses a Chart.yaml
+func (d *InfraDetector) helmChart(relPath string) *types.HelmChart {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil {
+		return nil
+	}
+	var chart struct {
+		Name         string `yaml:"name"`
+		Version      string `yaml:"version"`
+		AppVersion   string `yaml:"appVersion"`
+		Dependencies []struct {
+			Name string `yaml:"name"`
+		} `yaml:"dependencies"`
+	}
+	if yaml.Unmarshal(content, &chart) != nil || chart.Name == "" {
+		return nil
+	}
+
+	helm := &types.HelmChart{
+		Name:       chart.Name,
+		Path:       path.Dir(filepath.ToSlash(relPath)),
+		Version:    chart.Version,
+		AppVersion: chart.AppVersion,
+	}
+	for _, dep := range chart.Dependencies {
+		helm.Dependencies = append(helm.Dependencies, dep.Name)
+	}
+	return helm
+}
+
+// k8sPodTemplate is the part of a workload spec that lists containers
+type k8sPodTemplate struct {
+	Spec struct {
+		Containers []struct {
+			Image string `yaml:"image"`
+		} `yaml:"containers"`
+	} `yaml:"spec"`
+}
+
+// k8sResources parses the Kubernetes objects in a manifest. Templated Helm
+// manifests are skipped because they are not valid YAML until rendered.
+func (d *InfraDetector) k8sResources(relPath string) []types.K8sResource {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil || !bytes.Contains(content, []byte("apiVersion:")) || !bytes.Contains(content, []byte("kind:")) ||
+		bytes.Contains(content, []byte("{{")) {
+		return nil
+	}
+
+	var resources []types.K8sResource
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Replicas    int            `yaml:"replicas"`
+				Template    k8sPodTemplate `yaml:"template"`
+				JobTemplate struct {
+					Spec struct {
+						Template k8sPodTemplate `yaml:"template"`
+					} `yaml:"spec"`
+				} `yaml:"jobTemplate"`
+			} `yaml:"spec"`
+		}
+		if err := decoder.Decode(&obj); err != nil {
+			break
+		}
+		if !k8sKinds[obj.Kind] || obj.Metadata.Name == "" {
+			continue
+		}
+
+		resource := types.K8sResource{
+			Kind:     obj.Kind,
+			Name:     obj.Metadata.Name,
+			Path:     relPath,
+			Replicas: obj.Spec.Replicas,
+		}
+		containers := append(obj.Spec.Template.Spec.Containers, obj.Spec.JobTemplate.Spec.Template.Spec.Containers...)
+		for _, c := range containers {
+			if c.Image != "" {
+				resource.Images = appendUnique(resource.Images, c.Image)
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// addTerraform records a .tf file's directory, providers, modules and backend
+func (d *InfraDetector) addTerraform(terraform *types.Terraform, relPath string) {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil {
+		return
+	}
+	text := string(content)
+	terraform.Dirs = appendUnique(terraform.Dirs, path.Dir(filepath.ToSlash(relPath)))
+
+	for _, m := range tfProviderBlockRegex.FindAllStringSubmatch(text, -1) {
+		terraform.Providers = appendUnique(terraform.Providers, m[1])
+	}
+	if idx := strings.Index(text, "required_providers"); idx >= 0 {
+		for _, m := range tfRequiredProviderRegex.FindAllStringSubmatch(text[idx:], -1) {
+			terraform.Providers = appendUnique(terraform.Providers, m[1])
+		}
+	}
+	for _, m := range tfModuleRegex.FindAllStringSubmatch(text, -1) {
+		module := types.TerraformModule{Name: m[1], Source: m[2]}
+		seen := false
+		for _, existing := range terraform.Modules {
+			if existing == module {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			terraform.Modules = append(terraform.Modules, module)
+		}
+	}
+	if m := tfBackendRegex.FindStringSubmatch(text); m != nil && terraform.Backend == "" {
+		terraform.Backend = m[1]
+	}
+}
+
+// BackingServices returns the compose services an application needs running
+// locally, such as databases and caches
+func BackingServices(infra *types.Infrastructure) []types.ComposeService {
+	if infra == nil {
+		return nil
+	}
+	var services []types.ComposeService
+	seen := make(map[string]bool)
+	for _, s := range infra.ComposeServices {
+		if s.Backing && !seen[s.Name] {
+			seen[s.Name] = true
+			services = append(services, s)
+		}
+	}
+	return services
+}
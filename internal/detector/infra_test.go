@@ -0,0 +1,163 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	content := `# syntax=docker/dockerfile:1
+FROM --platform=$BUILDPLATFORM golang:1.22 AS builder
+WORKDIR /src
+RUN go build \
+    -o /app ./cmd/server
+
+FROM gcr.io/distroless/base
+COPY --from=builder /app /app
+EXPOSE 8080/tcp 9090
+ENTRYPOINT ["/app"]
+CMD ["serve", "--port=8080"]
+`
+	got := parseDockerfile(content)
+	want := types.Dockerfile{
+		Stages: []types.DockerStage{
+			{Name: "builder", Image: "golang:1.22"},
+			{Image: "gcr.io/distroless/base"},
+		},
+		ExposedPorts: []string{"8080", "9090"},
+		Command:      "/app serve --port=8080",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestInfraDetector_Compose(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"docker-compose.yml": `services:
+  api:
+    build:
+      context: ./api
+    ports:
+      - "8080:8080"
+    depends_on:
+      db:
+        condition: service_healthy
+      cache:
+        condition: service_started
+  db:
+    image: postgres:16
+    ports:
+      - target: 5432
+        published: 5432
+  cache:
+    image: bitnami/redis:7
+`,
+	})
+
+	infra := NewInfraDetector(tmpDir, files).Detect()
+	if infra == nil {
+		t.Fatal("expected infrastructure")
+	}
+	want := []types.ComposeService{
+		{Name: "api", File: "docker-compose.yml", Build: "./api", Ports: []string{"8080:8080"}, DependsOn: []string{"db", "cache"}},
+		{Name: "db", File: "docker-compose.yml", Image: "postgres:16", Ports: []string{"5432:5432"}, Backing: true},
+		{Name: "cache", File: "docker-compose.yml", Image: "bitnami/redis:7", Backing: true},
+	}
+	if !reflect.DeepEqual(infra.ComposeServices, want) {
+		t.Errorf("expected services %+v, got %+v", want, infra.ComposeServices)
+	}
+	if backing := BackingServices(infra); len(backing) != 2 || backing[0].Name != "db" {
+		t.Errorf("expected db and cache as backing services, got %+v", backing)
+	}
+}
+
+func TestInfraDetector_Deployment(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"deploy/k8s/app.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: api
+          image: ghcr.io/acme/api:1.0
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api-config
+`,
+		"deploy/k8s/kustomization.yaml":    "resources:\n  - app.yaml\n",
+		"charts/api/Chart.yaml":            "apiVersion: v2\nname: api\nversion: 0.2.0\nappVersion: \"1.0\"\ndependencies:\n  - name: postgresql\n",
+		"charts/api/templates/deploy.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\n",
+		"charts/api/values.yaml":           "replicaCount: 1\n",
+		"infra/main.tf": `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+  backend "s3" {
+    bucket = "acme-state"
+  }
+}
+
+module "vpc" {
+  source = "terraform-aws-modules/vpc/aws"
+}
+`,
+		"infra/dns.tf":                  "provider \"cloudflare\" {}\n",
+		"infra/.terraform/modules/x.tf": "provider \"google\" {}\n",
+		".github/workflows/ci.yml":      "jobs: {}\n",
+	})
+
+	infra := NewInfraDetector(tmpDir, files).Detect()
+	if infra == nil {
+		t.Fatal("expected infrastructure")
+	}
+
+	wantK8s := []types.K8sResource{
+		{Kind: "Deployment", Name: "api", Path: "deploy/k8s/app.yaml", Images: []string{"ghcr.io/acme/api:1.0"}, Replicas: 3},
+		{Kind: "Service", Name: "api", Path: "deploy/k8s/app.yaml"},
+	}
+	if !reflect.DeepEqual(infra.Kubernetes, wantK8s) {
+		t.Errorf("expected resources %+v, got %+v", wantK8s, infra.Kubernetes)
+	}
+	if !reflect.DeepEqual(infra.Kustomizations, []string{"deploy/k8s"}) {
+		t.Errorf("expected kustomization in deploy/k8s, got %v", infra.Kustomizations)
+	}
+
+	wantChart := []types.HelmChart{{Name: "api", Path: "charts/api", Version: "0.2.0", AppVersion: "1.0", Dependencies: []string{"postgresql"}}}
+	if !reflect.DeepEqual(infra.HelmCharts, wantChart) {
+		t.Errorf("expected chart %+v, got %+v", wantChart, infra.HelmCharts)
+	}
+
+	wantTF := &types.Terraform{
+		Dirs:      []string{"infra"},
+		Providers: []string{"aws", "cloudflare"},
+		Modules:   []types.TerraformModule{{Name: "vpc", Source: "terraform-aws-modules/vpc/aws"}},
+		Backend:   "s3",
+	}
+	if !reflect.DeepEqual(infra.Terraform, wantTF) {
+		t.Errorf("expected terraform %+v, got %+v", wantTF, infra.Terraform)
+	}
+}
+
+func TestInfraDetector_None(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{"main.go": "package main\n", "config.yaml": "port: 8080\n"})
+	if infra := NewInfraDetector(tmpDir, files).Detect(); infra != nil {
+		t.Errorf("expected no infrastructure, got %+v", infra)
+	}
+}
@@ -0,0 +1,616 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Priyans-hu/argus/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	eslintConfigFiles = []string{
+		"eslint.config.js", "eslint.config.mjs", "eslint.config.cjs", "eslint.config.ts",
+		".eslintrc.json", ".eslintrc", ".eslintrc.yml", ".eslintrc.yaml", ".eslintrc.js", ".eslintrc.cjs",
+	}
+	prettierConfigFiles = []string{
+		".prettierrc", ".prettierrc.json", ".prettierrc.yml", ".prettierrc.yaml",
+		".prettierrc.js", ".prettierrc.cjs", ".prettierrc.mjs", "prettier.config.js", "prettier.config.mjs", "prettier.config.cjs",
+	}
+	golangciConfigFiles = []string{".golangci.yml", ".golangci.yaml", ".golangci.json", ".golangci.toml"}
+
+	// A rule entry in a JS config whose value starts with a severity,
+	// e.g. `quotes: ['error', 'single']` or `"no-console": "warn"`
+	eslintJSRuleRegex = regexp.MustCompile(`['"]?([@\w][\w@/\-]*)['"]?\s*:\s*(\[\s*['"]?(?:error|warn|off|[012])['"]?[^\]]*\]|['"](?:error|warn|off)['"]|[012]\b)`)
+
+	// eslint-config-standard referenced by its short or full name
+	eslintStandardRegex = regexp.MustCompile(`['"](?:eslint-config-)?standard['"]|neostandard`)
+
+	// A scalar option in a JS Prettier config, e.g. `singleQuote: true`
+	prettierJSOptionRegex = regexp.MustCompile(`(\w+)\s*:\s*(true|false|\d+|['"][\w-]+['"])`)
+)
+
+// styleRules collects conventions keyed by the rule they describe so that
+// the first tool to set a rule wins: formatters are read before linters and
+// EditorConfig last
+type styleRules struct {
+	seen        map[string]bool
+	conventions []types.Convention
+	source      string // Config file the rules being added come from
+}
+
+func (r *styleRules) add(key, category, description string) {
+	if r.seen == nil {
+		r.seen = make(map[string]bool)
+	}
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+	r.conventions = append(r.conventions, configConvention(types.Convention{Category: category, Description: description}, r.source))
+}
+
+// detectLinterRules reads linter and formatter configuration and turns
+// their key settings into concrete conventions. The returned rules also
+// record which style keys are configured so inference can skip them
+func (d *ConventionDetector) detectLinterRules() *styleRules {
+	rules := &styleRules{}
+
+	d.addPrettierRules(rules)
+	d.addESLintRules(rules)
+	d.addGolangciRules(rules)
+	d.addPythonStyleRules(rules)
+	d.addRustStyleRules(rules)
+	d.addEditorConfigRules(rules)
+
+	return rules
+}
+
+// firstConfig returns the name and content of the first existing file
+func (d *ConventionDetector) firstConfig(names []string) (string, []byte) {
+	for _, name := range names {
+		if data, err := os.ReadFile(filepath.Join(d.rootPath, name)); err == nil {
+			return name, data
+		}
+	}
+	return "", nil
+}
+
+// isJSConfig reports whether a config file is JavaScript and can only be read statically
+func isJSConfig(name string) bool {
+	switch filepath.Ext(name) {
+	case ".js", ".mjs", ".cjs", ".ts":
+		return true
+	}
+	return false
+}
+
+func (d *ConventionDetector) addPrettierRules(rules *styleRules) {
+	name, data := d.firstConfig(prettierConfigFiles)
+
+	options := make(map[string]interface{})
+	switch {
+	case name == "":
+		// Fall back to the "prettier" key in package.json
+		pkgData, err := os.ReadFile(filepath.Join(d.rootPath, "package.json"))
+		if err != nil {
+			return
+		}
+		var pkg struct {
+			Prettier map[string]interface{} `json:"prettier"`
+		}
+		if json.Unmarshal(pkgData, &pkg) != nil || pkg.Prettier == nil {
+			return
+		}
+		options = pkg.Prettier
+		name = "package.json"
+	case isJSConfig(name):
+		for _, m := range prettierJSOptionRegex.FindAllStringSubmatch(string(data), -1) {
+			var value interface{}
+			if yaml.Unmarshal([]byte(m[2]), &value) == nil {
+				options[m[1]] = value
+			}
+		}
+	default:
+		// JSON is valid YAML, so one parser covers .prettierrc in either form
+		if yaml.Unmarshal(data, &options) != nil {
+			return
+		}
+	}
+
+	rules.source = name
+	if single, ok := options["singleQuote"].(bool); ok {
+		if single {
+			rules.add("js:quotes", "code-style", "Use single quotes for strings (Prettier)")
+		} else {
+			rules.add("js:quotes", "code-style", "Use double quotes for strings (Prettier)")
+		}
+	}
+	if semi, ok := options["semi"].(bool); ok {
+		if semi {
+			rules.add("js:semi", "code-style", "End statements with semicolons (Prettier)")
+		} else {
+			rules.add("js:semi", "code-style", "Omit semicolons at the end of statements (Prettier)")
+		}
+	}
+	if width, ok := intValue(options["printWidth"]); ok {
+		rules.add("js:line-length", "code-style", fmt.Sprintf("Keep lines within %d characters (Prettier printWidth)", width))
+	}
+	if tabs, _ := options["useTabs"].(bool); tabs {
+		rules.add("js:indent", "code-style", "Indent with tabs (Prettier)")
+	} else if width, ok := intValue(options["tabWidth"]); ok {
+		rules.add("js:indent", "code-style", fmt.Sprintf("Indent with %d spaces (Prettier)", width))
+	}
+	if trailing, ok := options["trailingComma"].(string); ok {
+		switch trailing {
+		case "none":
+			rules.add("js:trailing-comma", "code-style", "No trailing commas (Prettier)")
+		case "es5", "all":
+			rules.add("js:trailing-comma", "code-style", fmt.Sprintf("Use trailing commas in multi-line literals (Prettier trailingComma: %s)", trailing))
+		}
+	}
+}
+
+// eslintRule normalizes a rule value into whether it is enabled and its options
+func eslintRule(value interface{}) (bool, []interface{}) {
+	var level interface{} = value
+	var options []interface{}
+	if list, ok := value.([]interface{}); ok {
+		if len(list) == 0 {
+			return false, nil
+		}
+		level, options = list[0], list[1:]
+	}
+	switch v := level.(type) {
+	case string:
+		return v == "error" || v == "warn", options
+	default:
+		n, ok := intValue(v)
+		return ok && n > 0, options
+	}
+}
+
+func (d *ConventionDetector) addESLintRules(rules *styleRules) {
+	name, data := d.firstConfig(eslintConfigFiles)
+	if name == "" {
+		return
+	}
+	rules.source = name
+
+	ruleValues := make(map[string]interface{})
+	extends := string(data)
+	if isJSConfig(name) {
+		// Flat and legacy JS configs can't be evaluated; read rule entries whose value is a severity
+		for _, m := range eslintJSRuleRegex.FindAllStringSubmatch(string(data), -1) {
+			var value interface{}
+			if yaml.Unmarshal([]byte(m[2]), &value) == nil {
+				ruleValues[m[1]] = value
+			}
+		}
+	} else {
+		var cfg struct {
+			Extends interface{}            `yaml:"extends"`
+			Rules   map[string]interface{} `yaml:"rules"`
+		}
+		if yaml.Unmarshal(data, &cfg) != nil {
+			return
+		}
+		ruleValues = cfg.Rules
+		extends = ""
+		for _, preset := range stringList(cfg.Extends) {
+			extends += strconv.Quote(preset) + " "
+		}
+	}
+
+	if on, opts := eslintRule(ruleValues["quotes"]); on && len(opts) > 0 {
+		if style, ok := opts[0].(string); ok {
+			rules.add("js:quotes", "code-style", fmt.Sprintf("Use %s quotes for strings (ESLint quotes)", style))
+		}
+	}
+	if on, opts := eslintRule(ruleValues["semi"]); on {
+		if len(opts) > 0 && opts[0] == "never" {
+			rules.add("js:semi", "code-style", "Omit semicolons at the end of statements (ESLint semi)")
+		} else {
+			rules.add("js:semi", "code-style", "End statements with semicolons (ESLint semi)")
+		}
+	}
+	if on, opts := eslintRule(ruleValues["max-len"]); on && len(opts) > 0 {
+		width, ok := intValue(opts[0])
+		if m, isMap := opts[0].(map[string]interface{}); isMap {
+			width, ok = intValue(m["code"])
+		}
+		if ok {
+			rules.add("js:line-length", "code-style", fmt.Sprintf("Keep lines within %d characters (ESLint max-len)", width))
+		}
+	}
+	if on, opts := eslintRule(ruleValues["indent"]); on && len(opts) > 0 {
+		if opts[0] == "tab" {
+			rules.add("js:indent", "code-style", "Indent with tabs (ESLint indent)")
+		} else if width, ok := intValue(opts[0]); ok {
+			rules.add("js:indent", "code-style", fmt.Sprintf("Indent with %d spaces (ESLint indent)", width))
+		}
+	}
+	for _, rule := range []string{"import/order", "import-x/order", "simple-import-sort/imports", "perfectionist/sort-imports"} {
+		if on, _ := eslintRule(ruleValues[rule]); on {
+			rules.add("js:import-order", "imports", fmt.Sprintf("Keep imports grouped and sorted - %s is enforced, run the linter with --fix", rule))
+			break
+		}
+	}
+
+	ruleConventions := []struct {
+		rules       []string
+		category    string
+		description string
+	}{
+		{[]string{"eqeqeq"}, "code-style", "Use === and !== instead of == and != (ESLint eqeqeq)"},
+		{[]string{"no-console"}, "code-style", "Don't leave console.* calls in committed code (ESLint no-console)"},
+		{[]string{"no-var"}, "code-style", "Use let and const, never var (ESLint no-var)"},
+		{[]string{"prefer-const"}, "code-style", "Use const for bindings that are never reassigned (ESLint prefer-const)"},
+		{[]string{"@typescript-eslint/no-explicit-any"}, "typescript", "Don't use the `any` type (ESLint @typescript-eslint/no-explicit-any)"},
+		{[]string{"@typescript-eslint/no-unused-vars", "no-unused-vars"}, "code-style", "Remove unused variables and imports (ESLint no-unused-vars)"},
+	}
+	for _, rc := range ruleConventions {
+		for _, rule := range rc.rules {
+			if on, _ := eslintRule(ruleValues[rule]); on {
+				rules.add("eslint:"+rc.rules[0], rc.category, rc.description)
+				break
+			}
+		}
+	}
+
+	// Shared configs and presets named in extends (or anywhere in a flat config)
+	switch {
+	case strings.Contains(extends, "strictTypeChecked") || strings.Contains(extends, "@typescript-eslint/strict") || strings.Contains(extends, "configs.strict"):
+		rules.add("eslint:strict", "typescript", "typescript-eslint strict presets enabled - expect stricter checks than recommended")
+	}
+	switch {
+	case strings.Contains(extends, "airbnb"):
+		rules.add("eslint:style-guide", "code-style", "Follows the Airbnb JavaScript style guide (eslint-config-airbnb)")
+	case eslintStandardRegex.MatchString(extends):
+		rules.add("eslint:style-guide", "code-style", "Follows JavaScript Standard Style (eslint-config-standard)")
+	}
+}
+
+func (d *ConventionDetector) addGolangciRules(rules *styleRules) {
+	name, data := d.firstConfig(golangciConfigFiles)
+	if name == "" {
+		return
+	}
+	rules.source = name
+
+	type linterSettings map[string]map[string]interface{}
+	var cfg struct {
+		Linters struct {
+			Default    string         `yaml:"default" toml:"default"` // v2: standard, all, none, fast
+			DisableAll bool           `yaml:"disable-all" toml:"disable-all"`
+			Enable     []string       `yaml:"enable" toml:"enable"`
+			Disable    []string       `yaml:"disable" toml:"disable"`
+			Settings   linterSettings `yaml:"settings" toml:"settings"`
+		} `yaml:"linters" toml:"linters"`
+		LintersSettings linterSettings `yaml:"linters-settings" toml:"linters-settings"`
+		Formatters      struct {
+			Enable   []string       `yaml:"enable" toml:"enable"`
+			Settings linterSettings `yaml:"settings" toml:"settings"`
+		} `yaml:"formatters" toml:"formatters"`
+	}
+	var err error
+	if strings.HasSuffix(name, ".toml") {
+		_, err = toml.Decode(string(data), &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return
+	}
+
+	// Settings moved under linters.settings and formatters.settings in v2
+	settings := func(linter, key string) interface{} {
+		for _, s := range []linterSettings{cfg.LintersSettings, cfg.Linters.Settings, cfg.Formatters.Settings} {
+			if v, ok := s[linter][key]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+	enabled := func(linter string) bool {
+		if sliceContainsString(cfg.Linters.Disable, linter) {
+			return false
+		}
+		if sliceContainsString(cfg.Linters.Enable, linter) || sliceContainsString(cfg.Formatters.Enable, linter) {
+			return true
+		}
+		// errcheck is one of the linters golangci-lint runs by default
+		return linter == "errcheck" && !cfg.Linters.DisableAll && cfg.Linters.Default != "none"
+	}
+
+	if len(cfg.Linters.Enable) > 0 {
+		rules.add("go:linters", "code-style", fmt.Sprintf("golangci-lint enforces %s - run `golangci-lint run` before committing", strings.Join(cfg.Linters.Enable, ", ")))
+	}
+	if enabled("errcheck") {
+		rules.add("go:errcheck", "error-handling", "Check every returned error (golangci-lint errcheck)")
+	}
+	if enabled("wrapcheck") {
+		rules.add("go:wrapcheck", "error-handling", "Wrap errors returned from other packages before returning them (wrapcheck)")
+	}
+	if enabled("err113") || enabled("goerr113") {
+		rules.add("go:err113", "error-handling", "Return sentinel errors or wrapped errors, not dynamic errors.New values (err113)")
+	}
+	if enabled("lll") {
+		width := 120
+		if n, ok := intValue(settings("lll", "line-length")); ok {
+			width = n
+		}
+		rules.add("go:line-length", "code-style", fmt.Sprintf("Keep Go lines within %d characters (lll)", width))
+	}
+	if enabled("gofumpt") {
+		rules.add("go:format", "code-style", "Format Go code with gofumpt, a stricter gofmt")
+	}
+	if prefixes := stringList(settings("goimports", "local-prefixes")); len(prefixes) > 0 {
+		rules.add("go:import-order", "imports", fmt.Sprintf("Group imports: standard library, third-party, then %s (goimports local-prefixes)", strings.Join(prefixes, ", ")))
+	} else if enabled("gci") {
+		rules.add("go:import-order", "imports", "Import grouping and order are enforced by gci")
+	}
+	for _, linter := range []string{"gocyclo", "cyclop", "gocognit"} {
+		if !enabled(linter) {
+			continue
+		}
+		key := "min-complexity"
+		if linter == "cyclop" {
+			key = "max-complexity"
+		}
+		if n, ok := intValue(settings(linter, key)); ok {
+			rules.add("go:complexity", "code-style", fmt.Sprintf("Keep function complexity under %d (%s)", n, linter))
+		}
+	}
+	if enabled("funlen") {
+		if n, ok := intValue(settings("funlen", "lines")); ok {
+			rules.add("go:funlen", "code-style", fmt.Sprintf("Keep functions within %d lines (funlen)", n))
+		}
+	}
+	if enabled("godot") {
+		rules.add("go:godot", "code-style", "End comments with a period (godot)")
+	}
+}
+
+func (d *ConventionDetector) addPythonStyleRules(rules *styleRules) {
+	info := NewPyProjectDetector(d.rootPath).Detect()
+	if info == nil {
+		return
+	}
+	ruff, black, mypy := info.Ruff, info.Black, info.Mypy
+	rules.source = "pyproject.toml"
+
+	if ruff.LineLength > 0 {
+		rules.add("py:line-length", "code-style", fmt.Sprintf("Keep Python lines within %d characters (ruff line-length)", ruff.LineLength))
+	} else if black.LineLength > 0 {
+		rules.add("py:line-length", "code-style", fmt.Sprintf("Keep Python lines within %d characters (Black line-length)", black.LineLength))
+	}
+
+	switch ruff.Format.QuoteStyle {
+	case "single", "double":
+		rules.add("py:quotes", "code-style", fmt.Sprintf("Use %s quotes for strings (ruff format)", ruff.Format.QuoteStyle))
+	}
+	if sliceContainsString(info.Tools, "black") {
+		rules.add("py:format", "code-style", "Format Python code with Black")
+		rules.add("py:quotes", "code-style", "Use double quotes for strings (Black)")
+	}
+
+	var selected []string
+	for _, list := range [][]string{ruff.Select, ruff.ExtendSelect, ruff.Lint.Select, ruff.Lint.ExtendSelect} {
+		for _, code := range list {
+			selected = appendUnique(selected, code)
+		}
+	}
+	if len(selected) > 0 {
+		rules.add("py:ruff", "code-style", fmt.Sprintf("Ruff lint rules %s are enforced - run `ruff check`", strings.Join(selected, ", ")))
+	}
+	ruffRules := []struct {
+		code, key, category, description string
+	}{
+		{"I", "py:import-order", "imports", "Keep imports sorted isort-style - run `ruff check --select I --fix`"},
+		{"D", "py:docstrings", "code-style", "Write docstrings for public modules, classes and functions (ruff pydocstyle)"},
+		{"ANN", "py:annotations", "code-style", "Annotate function parameters and return types (ruff flake8-annotations)"},
+		{"UP", "py:pyupgrade", "code-style", "Use syntax for the project's minimum Python version (ruff pyupgrade)"},
+	}
+	for _, rr := range ruffRules {
+		if sliceContainsString(selected, rr.code) || sliceContainsString(selected, "ALL") {
+			rules.add(rr.key, rr.category, rr.description)
+		}
+	}
+
+	switch {
+	case mypy.Strict:
+		rules.add("py:mypy", "code-style", "mypy runs in strict mode - annotate every function and avoid implicit Any")
+	case mypy.DisallowUntypedDefs:
+		rules.add("py:mypy", "code-style", "mypy rejects unannotated functions - annotate every definition")
+	}
+}
+
+func (d *ConventionDetector) addRustStyleRules(rules *styleRules) {
+	var rustfmt struct {
+		MaxWidth           int    `toml:"max_width"`
+		HardTabs           bool   `toml:"hard_tabs"`
+		TabSpaces          int    `toml:"tab_spaces"`
+		GroupImports       string `toml:"group_imports"`
+		ImportsGranularity string `toml:"imports_granularity"`
+	}
+	if name, data := d.firstConfig([]string{"rustfmt.toml", ".rustfmt.toml"}); data != nil {
+		rules.source = name
+		if _, err := toml.Decode(string(data), &rustfmt); err == nil {
+			if rustfmt.MaxWidth > 0 {
+				rules.add("rust:line-length", "code-style", fmt.Sprintf("Keep Rust lines within %d characters (rustfmt max_width)", rustfmt.MaxWidth))
+			}
+			if rustfmt.HardTabs {
+				rules.add("rust:indent", "code-style", "Indent Rust code with tabs (rustfmt hard_tabs)")
+			} else if rustfmt.TabSpaces > 0 {
+				rules.add("rust:indent", "code-style", fmt.Sprintf("Indent Rust code with %d spaces (rustfmt tab_spaces)", rustfmt.TabSpaces))
+			}
+			if rustfmt.GroupImports == "StdExternalCrate" {
+				rules.add("rust:import-order", "imports", "Group imports: std, external crates, then crate-local (rustfmt group_imports)")
+			}
+			if rustfmt.ImportsGranularity != "" {
+				rules.add("rust:import-granularity", "imports", fmt.Sprintf("Merge use statements at %s granularity (rustfmt imports_granularity)", strings.ToLower(rustfmt.ImportsGranularity)))
+			}
+		}
+	}
+
+	var clippy map[string]interface{}
+	if name, data := d.firstConfig([]string{"clippy.toml", ".clippy.toml"}); data != nil {
+		rules.source = name
+		if _, err := toml.Decode(string(data), &clippy); err != nil {
+			return
+		}
+	}
+	if msrv, ok := clippy["msrv"].(string); ok {
+		rules.add("rust:msrv", "code-style", fmt.Sprintf("Stay compatible with Rust %s - clippy flags APIs newer than the MSRV", msrv))
+	}
+	thresholds := []struct{ key, format string }{
+		{"cognitive-complexity-threshold", "Keep cognitive complexity under %d (clippy)"},
+		{"too-many-arguments-threshold", "Keep functions to at most %d arguments (clippy)"},
+		{"too-many-lines-threshold", "Keep functions within %d lines (clippy)"},
+	}
+	for _, t := range thresholds {
+		if n, ok := intValue(clippy[t.key]); ok {
+			rules.add("rust:"+t.key, "code-style", fmt.Sprintf(t.format, n))
+		}
+	}
+	if disallowed, ok := clippy["disallowed-methods"].([]interface{}); ok {
+		var paths []string
+		for _, entry := range disallowed {
+			switch v := entry.(type) {
+			case string:
+				paths = append(paths, v)
+			case map[string]interface{}:
+				if p, ok := v["path"].(string); ok {
+					paths = append(paths, p)
+				}
+			}
+		}
+		if len(paths) > 0 {
+			rules.add("rust:disallowed-methods", "code-style", fmt.Sprintf("Don't call %s (clippy disallowed-methods)", strings.Join(paths, ", ")))
+		}
+	}
+}
+
+// editorConfigSection holds the properties of one [glob] section
+type editorConfigSection struct {
+	glob  string
+	props map[string]string
+}
+
+// parseEditorConfig reads sections in file order
+func parseEditorConfig(content string) []editorConfigSection {
+	var sections []editorConfigSection
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, editorConfigSection{glob: line[1 : len(line)-1], props: make(map[string]string)})
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || len(sections) == 0 {
+			continue
+		}
+		sections[len(sections)-1].props[strings.ToLower(strings.TrimSpace(key))] = strings.ToLower(strings.TrimSpace(value))
+	}
+	return sections
+}
+
+// editorConfigIndent describes a section's indentation, or "" if unset
+func editorConfigIndent(props map[string]string) string {
+	switch {
+	case props["indent_style"] == "tab":
+		return "tabs"
+	case props["indent_size"] != "" && props["indent_size"] != "tab":
+		return props["indent_size"] + " spaces"
+	}
+	return ""
+}
+
+func (d *ConventionDetector) addEditorConfigRules(rules *styleRules) {
+	data, err := os.ReadFile(filepath.Join(d.rootPath, ".editorconfig"))
+	if err != nil {
+		return
+	}
+	rules.source = ".editorconfig"
+
+	defaultIndent := ""
+	for _, section := range parseEditorConfig(string(data)) {
+		indent := editorConfigIndent(section.props)
+		if section.glob == "*" {
+			defaultIndent = indent
+			if indent != "" {
+				rules.add("*:indent", "code-style", fmt.Sprintf("Indent with %s unless a file type says otherwise (EditorConfig)", indent))
+			}
+			if n, err := strconv.Atoi(section.props["max_line_length"]); err == nil {
+				rules.add("*:line-length", "code-style", fmt.Sprintf("Keep lines within %d characters (EditorConfig)", n))
+			}
+
+			var hygiene []string
+			if eol := section.props["end_of_line"]; eol != "" {
+				hygiene = append(hygiene, strings.ToUpper(eol)+" line endings")
+			}
+			if section.props["insert_final_newline"] == "true" {
+				hygiene = append(hygiene, "a final newline")
+			}
+			if section.props["trim_trailing_whitespace"] == "true" {
+				hygiene = append(hygiene, "no trailing whitespace")
+			}
+			if len(hygiene) > 0 {
+				rules.add("*:whitespace", "code-style", fmt.Sprintf("Files use %s (EditorConfig)", strings.Join(hygiene, ", ")))
+			}
+			continue
+		}
+
+		// Per-language overrides only matter when they differ from the default
+		if indent != "" && indent != defaultIndent {
+			rules.add("ec:"+section.glob, "code-style", fmt.Sprintf("Indent `%s` files with %s (EditorConfig)", section.glob, indent))
+		}
+	}
+}
+
+// intValue converts numbers decoded from JSON, YAML or TOML to int
+func intValue(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// stringList accepts a single string (optionally comma separated) or a list of strings
+func stringList(v interface{}) []string {
+	var out []string
+	switch value := v.(type) {
+	case string:
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,213 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLinterRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "eslintrc json",
+			files: map[string]string{
+				".eslintrc.json": `{
+  "extends": ["airbnb", "plugin:@typescript-eslint/strict"],
+  "rules": {
+    "quotes": ["error", "single", {"avoidEscape": true}],
+    "semi": ["error", "never"],
+    "max-len": ["warn", {"code": 100}],
+    "import/order": "error",
+    "no-console": "off",
+    "eqeqeq": 2
+  }
+}`,
+			},
+			want: []string{
+				"Use single quotes for strings (ESLint quotes)",
+				"Omit semicolons at the end of statements (ESLint semi)",
+				"Keep lines within 100 characters (ESLint max-len)",
+				"Keep imports grouped and sorted - import/order is enforced, run the linter with --fix",
+				"Use === and !== instead of == and != (ESLint eqeqeq)",
+				"typescript-eslint strict presets enabled - expect stricter checks than recommended",
+				"Follows the Airbnb JavaScript style guide (eslint-config-airbnb)",
+			},
+		},
+		{
+			name: "flat config with prettier",
+			files: map[string]string{
+				"eslint.config.mjs": `import tseslint from 'typescript-eslint'
+import simpleImportSort from 'eslint-plugin-simple-import-sort'
+
+export default tseslint.config(
+  ...tseslint.configs.strictTypeChecked,
+  {
+    rules: {
+      'simple-import-sort/imports': 'error',
+      quotes: ['error', 'double'],
+      '@typescript-eslint/no-explicit-any': ['error'],
+    },
+  },
+)
+`,
+				".prettierrc": "singleQuote: true\nsemi: false\nprintWidth: 80\ntrailingComma: all\n",
+			},
+			want: []string{
+				"Use single quotes for strings (Prettier)",
+				"Omit semicolons at the end of statements (Prettier)",
+				"Keep lines within 80 characters (Prettier printWidth)",
+				"Use trailing commas in multi-line literals (Prettier trailingComma: all)",
+				"Keep imports grouped and sorted - simple-import-sort/imports is enforced, run the linter with --fix",
+				"Don't use the `any` type (ESLint @typescript-eslint/no-explicit-any)",
+				"typescript-eslint strict presets enabled - expect stricter checks than recommended",
+			},
+		},
+		{
+			name: "prettier in package.json",
+			files: map[string]string{
+				"package.json": `{"name": "app", "prettier": {"useTabs": true, "singleQuote": false}}`,
+			},
+			want: []string{
+				"Use double quotes for strings (Prettier)",
+				"Indent with tabs (Prettier)",
+			},
+		},
+		{
+			name: "golangci v1",
+			files: map[string]string{
+				".golangci.yml": `linters:
+  enable:
+    - gofumpt
+    - lll
+    - wrapcheck
+    - gocyclo
+linters-settings:
+  lll:
+    line-length: 140
+  goimports:
+    local-prefixes: github.com/acme/app
+  gocyclo:
+    min-complexity: 15
+`,
+			},
+			want: []string{
+				"golangci-lint enforces gofumpt, lll, wrapcheck, gocyclo - run `golangci-lint run` before committing",
+				"Check every returned error (golangci-lint errcheck)",
+				"Wrap errors returned from other packages before returning them (wrapcheck)",
+				"Keep Go lines within 140 characters (lll)",
+				"Format Go code with gofumpt, a stricter gofmt",
+				"Group imports: standard library, third-party, then github.com/acme/app (goimports local-prefixes)",
+				"Keep function complexity under 15 (gocyclo)",
+			},
+		},
+		{
+			name: "golangci v2",
+			files: map[string]string{
+				".golangci.yml": `version: "2"
+linters:
+  default: none
+  enable:
+    - godot
+formatters:
+  enable:
+    - gci
+`,
+			},
+			want: []string{
+				"golangci-lint enforces godot - run `golangci-lint run` before committing",
+				"Import grouping and order are enforced by gci",
+				"End comments with a period (godot)",
+			},
+		},
+		{
+			name: "pyproject",
+			files: map[string]string{
+				"pyproject.toml": `[project]
+name = "svc"
+
+[tool.ruff]
+line-length = 100
+
+[tool.ruff.lint]
+select = ["E", "F", "I", "ANN"]
+
+[tool.ruff.format]
+quote-style = "single"
+
+[tool.mypy]
+strict = true
+`,
+			},
+			want: []string{
+				"Keep Python lines within 100 characters (ruff line-length)",
+				"Use single quotes for strings (ruff format)",
+				"Ruff lint rules E, F, I, ANN are enforced - run `ruff check`",
+				"Keep imports sorted isort-style - run `ruff check --select I --fix`",
+				"Annotate function parameters and return types (ruff flake8-annotations)",
+				"mypy runs in strict mode - annotate every function and avoid implicit Any",
+			},
+		},
+		{
+			name: "rustfmt and clippy",
+			files: map[string]string{
+				"rustfmt.toml": "max_width = 120\ngroup_imports = \"StdExternalCrate\"\nimports_granularity = \"Crate\"\n",
+				"clippy.toml":  "msrv = \"1.70\"\ntoo-many-arguments-threshold = 5\ndisallowed-methods = [\"std::env::var\", { path = \"std::process::exit\", reason = \"return errors\" }]\n",
+			},
+			want: []string{
+				"Keep Rust lines within 120 characters (rustfmt max_width)",
+				"Group imports: std, external crates, then crate-local (rustfmt group_imports)",
+				"Merge use statements at crate granularity (rustfmt imports_granularity)",
+				"Stay compatible with Rust 1.70 - clippy flags APIs newer than the MSRV",
+				"Keep functions to at most 5 arguments (clippy)",
+				"Don't call std::env::var, std::process::exit (clippy disallowed-methods)",
+			},
+		},
+		{
+			name: "editorconfig",
+			files: map[string]string{
+				".editorconfig": `root = true
+
+[*]
+indent_style = space
+indent_size = 2
+end_of_line = lf
+insert_final_newline = true
+trim_trailing_whitespace = true
+max_line_length = 120
+
+[*.py]
+indent_size = 4
+
+[*.md]
+indent_size = 2
+
+[Makefile]
+indent_style = tab
+`,
+			},
+			want: []string{
+				"Indent with 2 spaces unless a file type says otherwise (EditorConfig)",
+				"Keep lines within 120 characters (EditorConfig)",
+				"Files use LF line endings, a final newline, no trailing whitespace (EditorConfig)",
+				"Indent `*.py` files with 4 spaces (EditorConfig)",
+				"Indent `Makefile` files with tabs (EditorConfig)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, files := writeProjectFixture(t, tt.files)
+			var got []string
+			for _, conv := range NewConventionDetector(tmpDir, files).detectLinterRules().conventions {
+				got = append(got, conv.Description)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected conventions:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
@@ -145,24 +145,13 @@ func (d *MLDetector) detectFrameworks() []MLFramework {
 		{"RLlib", "rl", "Ray's RL library", []string{"from ray.rllib", "import rllib"}},
 	}
 
-	// Scan Python files for imports
-	for _, f := range d.files {
-		if !strings.HasSuffix(f.Name, ".py") {
-			continue
-		}
-
-		content, err := os.ReadFile(f.Path)
-		if err != nil {
-			continue
-		}
-		contentStr := string(content)
-
+	countImports := func(path, contentStr string) {
 		for _, p := range patterns {
 			for _, imp := range p.imports {
 				if strings.Contains(contentStr, imp) {
 					frameworkCounts[p.name]++
 					if len(frameworkExamples[p.name]) < 3 {
-						frameworkExamples[p.name] = append(frameworkExamples[p.name], f.Path)
+						frameworkExamples[p.name] = append(frameworkExamples[p.name], path)
 					}
 					break
 				}
@@ -170,6 +159,24 @@ func (d *MLDetector) detectFrameworks() []MLFramework {
 		}
 	}
 
+	// Scan Python files for imports
+	for _, f := range d.files {
+		if !strings.HasSuffix(f.Name, ".py") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil {
+			continue
+		}
+		countImports(f.Path, string(content))
+	}
+
+	// Notebooks import frameworks from their code cells
+	for _, nb := range readNotebooks(d.rootPath, d.files) {
+		countImports(nb.path, nb.code())
+	}
+
 	// Convert to MLFramework slice
 	for _, p := range patterns {
 		if count, ok := frameworkCounts[p.name]; ok && count > 0 {
@@ -459,5 +466,6 @@ func (d *MLDetector) GetMLPatterns() []types.PatternInfo {
 		})
 	}
 
+	scorePatterns(d.rootPath, patterns)
 	return patterns
 }
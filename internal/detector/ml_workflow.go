@@ -0,0 +1,583 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Entry point kinds
+const (
+	MLEntryTrain = "train"
+	MLEntryEval  = "eval"
+)
+
+// DeviceHardcoded is the device selection method for code that assumes a GPU
+const DeviceHardcoded = "Hard-coded CUDA"
+
+const (
+	// maxTrackerCalls caps the logging calls listed per experiment tracker
+	maxTrackerCalls = 6
+	// maxMLExampleFiles caps the files listed per tracker, checkpoint or device finding
+	maxMLExampleFiles = 3
+)
+
+var (
+	hydraMainRegex      = regexp.MustCompile(`@hydra\.main\(`)
+	omegaConfLoadRegex  = regexp.MustCompile(`OmegaConf\.load\(\s*[rf]?["']([^"'{}]+\.ya?ml)["']`)
+	clickOptionRegex    = regexp.MustCompile(`@click\.option\(`)
+	pyMainGuardRegex    = regexp.MustCompile(`(?m)^if\s+__name__\s*==\s*["']__main__["']|@hydra\.main\(|@click\.command\(`)
+	pyStringRegex       = regexp.MustCompile(`[rRfFbBuU]{0,2}(?:"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*')`)
+	checkpointExtRegex  = regexp.MustCompile(`\.(pt|pth|ckpt|safetensors|bin|h5|keras|pkl|joblib|onnx)$`)
+	checkpointDirRegex  = regexp.MustCompile(`(?m)^\s*\w*(?:ckpt|checkpoint|save|output)_?dir\w*\s*=\s*[rf]?["']([^"'{}\s]+)["']`)
+	mlTrainScriptRegex  = regexp.MustCompile(`(?i)^(run_)?(pre)?train(ing|er)?(_\w+)?\.py$|^fine_?tune(_\w+)?\.py$`)
+	mlEvalScriptRegex   = regexp.MustCompile(`(?i)^(run_)?eval(uate|uation)?(_\w+)?\.py$|^(validate|benchmark)\.py$`)
+	checkpointFlagRegex = regexp.MustCompile(`(?i)(ckpt|checkpoint|save|output)[-_]?dir`)
+)
+
+// trackerCalls recognises experiment trackers by the calls that log to them.
+// project maps a call to the keyword argument naming the project or
+// experiment; imports, when set, must match before a file's calls count.
+var trackerCalls = []struct {
+	name    string
+	calls   *regexp.Regexp
+	project map[string]string
+	imports *regexp.Regexp
+}{
+	{"MLflow", regexp.MustCompile(`\b(mlflow(?:\.\w+)+|MLFlowLogger)\(`),
+		map[string]string{"mlflow.set_experiment": "experiment_name", "MLFlowLogger": "experiment_name"}, nil},
+	{"Weights & Biases", regexp.MustCompile(`\b(wandb(?:\.\w+)+|WandbLogger)\(`),
+		map[string]string{"wandb.init": "project", "WandbLogger": "project"}, nil},
+	{"DVC", regexp.MustCompile(`\b(dvclive\.\w+|Live|DVCLiveLogger|live\.log_\w+|dvc\.api\.\w+)\(`), nil,
+		regexp.MustCompile(`(?m)^\s*(?:from|import)\s+(?:dvclive|dvc\.api)\b`)},
+}
+
+// checkpointSaves are the calls that write model checkpoints
+var checkpointSaves = []struct {
+	label string
+	regex *regexp.Regexp
+}{
+	{"torch.save", regexp.MustCompile(`\btorch\.save\(`)},
+	{"save_pretrained", regexp.MustCompile(`\.save_pretrained\(`)},
+	{"save_checkpoint", regexp.MustCompile(`\.save_checkpoint\(`)},
+	{"ModelCheckpoint", regexp.MustCompile(`\bModelCheckpoint\(`)},
+	{"save_model", regexp.MustCompile(`\.save_model\(`)},
+	{"save_weights", regexp.MustCompile(`\.save_weights\(`)},
+	{"joblib.dump", regexp.MustCompile(`\bjoblib\.dump\(`)},
+	{"tf.train.Checkpoint", regexp.MustCompile(`\btf\.train\.Checkpoint\(`)},
+}
+
+// deviceSelectors are the ways code picks the device it runs on
+var deviceSelectors = []struct {
+	method string
+	regex  *regexp.Regexp
+}{
+	{"CUDA with CPU fallback", regexp.MustCompile(`torch\.cuda\.is_available\(\)`)},
+	{"Apple MPS", regexp.MustCompile(`torch\.backends\.mps\.is_available\(\)`)},
+	{"Hugging Face Accelerate", regexp.MustCompile(`\bAccelerator\(`)},
+	{"Lightning Trainer accelerator", regexp.MustCompile(`\baccelerator\s*=\s*["']`)},
+	{"Transformers device_map", regexp.MustCompile(`\bdevice_map\s*=`)},
+	{"JAX devices", regexp.MustCompile(`\bjax\.(?:local_)?devices\(`)},
+	{"TensorFlow GPU discovery", regexp.MustCompile(`\btf\.config\.(?:experimental\.)?list_physical_devices\(`)},
+	{"CUDA_VISIBLE_DEVICES", regexp.MustCompile(`CUDA_VISIBLE_DEVICES`)},
+	{DeviceHardcoded, regexp.MustCompile(`\.cuda\(\)|\.to\(\s*["']cuda(?::\d+)?["']\s*\)|device\s*=\s*["']cuda`)},
+}
+
+// mlSource is a non-test Python file read for workflow detection
+type mlSource struct {
+	path    string
+	content string
+	lines   []string
+}
+
+// DetectWorkflow maps how the project trains models: experiment trackers,
+// the DVC pipeline, Hydra or OmegaConf configs, train and eval entry points,
+// checkpoints and device selection. It returns nil when none are found.
+func (d *MLDetector) DetectWorkflow() *types.MLWorkflow {
+	sources := d.pythonSources()
+
+	workflow := &types.MLWorkflow{
+		Trackers:    trackersIn(sources),
+		Stages:      d.detectPipelineStages(),
+		Config:      d.detectConfigTree(sources),
+		EntryPoints: entryPointsIn(sources),
+	}
+	workflow.Checkpoints = checkpointsIn(sources, workflow.EntryPoints)
+
+	if len(workflow.Trackers) == 0 && len(workflow.Stages) == 0 && workflow.Config == nil &&
+		len(workflow.EntryPoints) == 0 && workflow.Checkpoints == nil {
+		return nil
+	}
+	workflow.Devices = deviceSelectionIn(sources)
+	return workflow
+}
+
+// pythonSources reads the project's non-test Python files in path order
+func (d *MLDetector) pythonSources() []mlSource {
+	var sources []mlSource
+	for _, f := range d.files {
+		if f.IsDir || f.Extension != ".py" || f.Size > maxStyleSampleSize || isTestSourceFile(f.Path) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil {
+			continue
+		}
+		text := strings.ReplaceAll(string(content), "\r\n", "\n")
+		sources = append(sources, mlSource{path: filepath.ToSlash(f.Path), content: text, lines: strings.Split(text, "\n")})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].path < sources[j].path })
+	return sources
+}
+
+// trackersIn finds MLflow, W&B and DVC logging calls and the project they log to
+func trackersIn(sources []mlSource) []types.ExperimentTracker {
+	var trackers []types.ExperimentTracker
+	for _, tc := range trackerCalls {
+		tracker := types.ExperimentTracker{Name: tc.name}
+		calls := make(map[string]bool)
+		for _, src := range sources {
+			if tc.imports != nil && !tc.imports.MatchString(src.content) {
+				continue
+			}
+			used := false
+			for i, line := range src.lines {
+				if isPyComment(line) {
+					continue
+				}
+				for _, m := range tc.calls.FindAllStringSubmatchIndex(line, -1) {
+					call := line[m[2]:m[3]]
+					calls[call] = true
+					used = true
+					key, ok := tc.project[call]
+					if !ok || tracker.Project != "" {
+						continue
+					}
+					args, _ := callArgsFrom(src.lines, i, m[1])
+					if v, ok := keyedArgValue(args, key); ok {
+						tracker.Project = pyLiteral(v)
+					} else if strings.HasSuffix(call, "set_experiment") && len(args) > 0 {
+						tracker.Project = pyLiteral(args[0])
+					}
+				}
+			}
+			if used && len(tracker.Files) < maxMLExampleFiles {
+				tracker.Files = append(tracker.Files, src.path)
+			}
+		}
+		if len(calls) == 0 {
+			continue
+		}
+		for call := range calls {
+			tracker.Calls = append(tracker.Calls, call)
+		}
+		sort.Strings(tracker.Calls)
+		if len(tracker.Calls) > maxTrackerCalls {
+			tracker.Calls = tracker.Calls[:maxTrackerCalls]
+		}
+		trackers = append(trackers, tracker)
+	}
+	return trackers
+}
+
+// detectPipelineStages reads the stages of the top-most dvc.yaml and links
+// each stage to the stages producing its dependencies
+func (d *MLDetector) detectPipelineStages() []types.PipelineStage {
+	path := d.shallowestFile("dvc.yaml")
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+	if err != nil {
+		return nil
+	}
+	var pipeline struct {
+		Stages yaml.Node `yaml:"stages"`
+	}
+	if yaml.Unmarshal(content, &pipeline) != nil || pipeline.Stages.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var stages []types.PipelineStage
+	for i := 0; i+1 < len(pipeline.Stages.Content); i += 2 {
+		var stage struct {
+			Cmd     yaml.Node `yaml:"cmd"`
+			Deps    yaml.Node `yaml:"deps"`
+			Outs    yaml.Node `yaml:"outs"`
+			Params  yaml.Node `yaml:"params"`
+			Metrics yaml.Node `yaml:"metrics"`
+		}
+		if pipeline.Stages.Content[i+1].Kind != yaml.MappingNode || pipeline.Stages.Content[i+1].Decode(&stage) != nil {
+			continue
+		}
+		stages = append(stages, types.PipelineStage{
+			Name:    pipeline.Stages.Content[i].Value,
+			Command: strings.Join(scriptNodeLines(&stage.Cmd), " && "),
+			Deps:    dvcEntries(&stage.Deps),
+			Outs:    dvcEntries(&stage.Outs),
+			Params:  dvcEntries(&stage.Params),
+			Metrics: dvcEntries(&stage.Metrics),
+		})
+	}
+
+	for i := range stages {
+		for _, other := range stages {
+			if other.Name != stages[i].Name && producesAny(other.Outs, stages[i].Deps) {
+				stages[i].After = append(stages[i].After, other.Name)
+			}
+		}
+	}
+	return stages
+}
+
+// dvcEntries lists the paths or parameter names of a dvc.yaml deps, outs,
+// params or metrics list. Entries may be plain strings, paths with options
+// ({model.pkl: {cache: false}}) or parameter files with keys ({params.yaml: [lr]}).
+func dvcEntries(node *yaml.Node) []string {
+	if node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var entries []string
+	for _, item := range node.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			entries = append(entries, item.Value)
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				if value := item.Content[i+1]; value.Kind == yaml.SequenceNode {
+					for _, key := range value.Content {
+						entries = append(entries, key.Value)
+					}
+				} else {
+					entries = append(entries, item.Content[i].Value)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// producesAny reports whether any output is one of the dependencies, or a
+// directory containing or inside one
+func producesAny(outs, deps []string) bool {
+	for _, out := range outs {
+		for _, dep := range deps {
+			if out == dep || strings.HasPrefix(dep, out+"/") || strings.HasPrefix(out, dep+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// detectConfigTree finds the Hydra config directory from @hydra.main, or the
+// file passed to OmegaConf.load, and lists its config groups
+func (d *MLDetector) detectConfigTree(sources []mlSource) *types.MLConfig {
+	var config *types.MLConfig
+	for _, src := range sources {
+		for i, line := range src.lines {
+			m := hydraMainRegex.FindStringIndex(line)
+			if m == nil {
+				continue
+			}
+			config = &types.MLConfig{Framework: "Hydra"}
+			args, _ := callArgsFrom(src.lines, i, m[1])
+			if v, ok := keyedArgValue(args, "config_path"); ok && pyLiteral(v) != "" {
+				config.Dir = filepath.ToSlash(filepath.Join(filepath.Dir(src.path), pyLiteral(v)))
+			}
+			if v, ok := keyedArgValue(args, "config_name"); ok && pyLiteral(v) != "" {
+				config.Primary = pyLiteral(v)
+				if filepath.Ext(config.Primary) == "" {
+					config.Primary += ".yaml"
+				}
+			}
+			break
+		}
+		if config != nil {
+			break
+		}
+	}
+
+	if config == nil {
+		for _, src := range sources {
+			if m := omegaConfLoadRegex.FindStringSubmatch(src.content); m != nil {
+				path := filepath.ToSlash(filepath.Clean(m[1]))
+				config = &types.MLConfig{Framework: "OmegaConf", Primary: filepath.Base(path)}
+				if dir := filepath.ToSlash(filepath.Dir(path)); dir != "." {
+					config.Dir = dir
+				}
+				break
+			}
+		}
+	}
+	if config == nil || config.Dir == "" {
+		return config
+	}
+
+	options := make(map[string][]string)
+	for _, f := range d.files {
+		path := filepath.ToSlash(f.Path)
+		if f.IsDir || (f.Extension != ".yaml" && f.Extension != ".yml") || !strings.HasPrefix(path, config.Dir+"/") {
+			continue
+		}
+		rel := strings.TrimPrefix(path, config.Dir+"/")
+		group := filepath.ToSlash(filepath.Dir(rel))
+		if group == "." {
+			continue
+		}
+		options[group] = append(options[group], strings.TrimSuffix(filepath.Base(rel), f.Extension))
+	}
+	for _, group := range sortedKeys(options) {
+		sort.Strings(options[group])
+		config.Groups = append(config.Groups, types.ConfigGroup{Name: group, Options: options[group]})
+	}
+
+	if config.Primary != "" {
+		config.Defaults = hydraDefaults(filepath.Join(d.rootPath, config.Dir, config.Primary))
+	}
+	return config
+}
+
+// hydraDefaults returns the group=option entries of a config's defaults list
+func hydraDefaults(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var config struct {
+		Defaults yaml.Node `yaml:"defaults"`
+	}
+	if yaml.Unmarshal(content, &config) != nil || config.Defaults.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var defaults []string
+	for _, item := range config.Defaults.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			group := strings.TrimPrefix(item.Content[i].Value, "override ")
+			if item.Content[i+1].Kind == yaml.ScalarNode {
+				defaults = append(defaults, group+"="+item.Content[i+1].Value)
+			}
+		}
+	}
+	return defaults
+}
+
+// entryPointsIn finds runnable train and eval scripts with the flags they accept
+func entryPointsIn(sources []mlSource) []types.MLEntryPoint {
+	var entries []types.MLEntryPoint
+	// flag parsing is shared with the CLI detector; without project files,
+	// identifier defaults are left empty rather than resolved
+	cli := &CLIDetector{}
+	for _, src := range sources {
+		name := filepath.Base(src.path)
+		kind := ""
+		switch {
+		case mlTrainScriptRegex.MatchString(name):
+			kind = MLEntryTrain
+		case mlEvalScriptRegex.MatchString(name):
+			kind = MLEntryEval
+		default:
+			continue
+		}
+		if !pyMainGuardRegex.MatchString(src.content) {
+			continue
+		}
+
+		entry := types.MLEntryPoint{Path: src.path, Kind: kind, Hydra: hydraMainRegex.MatchString(src.content)}
+		cmd := types.CLICommand{}
+		for i, line := range src.lines {
+			if m := argparseArgumentRegex.FindStringSubmatchIndex(line); m != nil {
+				args, _ := callArgsFrom(src.lines, i, m[1])
+				cli.argparseArgument(&cmd, args)
+			} else if m := clickOptionRegex.FindStringIndex(line); m != nil {
+				args, _ := callArgsFrom(src.lines, i, m[1])
+				if flag, ok := cli.clickOption(args); ok {
+					cmd.Flags = append(cmd.Flags, flag)
+				}
+			}
+		}
+		entry.Command = strings.TrimSpace("python " + src.path + " " + cmd.Args)
+		entry.Args = cmd.Flags
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Kind == MLEntryTrain && entries[j].Kind != MLEntryTrain
+	})
+	return entries
+}
+
+// checkpointsIn finds the calls that save checkpoints and the directory and
+// file name pattern they write to
+func checkpointsIn(sources []mlSource, entries []types.MLEntryPoint) *types.CheckpointInfo {
+	info := &types.CheckpointInfo{}
+	saves := make(map[string]bool)
+	for _, src := range sources {
+		used := false
+		for i, line := range src.lines {
+			if isPyComment(line) {
+				continue
+			}
+			for _, save := range checkpointSaves {
+				m := save.regex.FindStringIndex(line)
+				if m == nil {
+					continue
+				}
+				saves[save.label] = true
+				used = true
+				args, _ := callArgsFrom(src.lines, i, m[1])
+				checkpointTarget(info, save.label, args)
+			}
+		}
+		if used && len(info.Files) < maxMLExampleFiles {
+			info.Files = append(info.Files, src.path)
+		}
+	}
+	if len(saves) == 0 {
+		return nil
+	}
+	for _, save := range checkpointSaves {
+		if saves[save.label] {
+			info.Saves = append(info.Saves, save.label)
+		}
+	}
+
+	// Fall back to a *_dir assignment or an entry point's --checkpoint-dir default
+	if info.Dir == "" {
+		for _, src := range sources {
+			if m := checkpointDirRegex.FindStringSubmatch(src.content); m != nil {
+				info.Dir = m[1]
+				break
+			}
+		}
+	}
+	for _, entry := range entries {
+		for _, flag := range entry.Args {
+			if info.Dir == "" && flag.Default != "" && checkpointFlagRegex.MatchString(flag.Name) {
+				info.Dir = flag.Default
+			}
+		}
+	}
+	if info.Format == "" && info.Pattern != "" {
+		info.Format = filepath.Ext(info.Pattern)
+	}
+	return info
+}
+
+// checkpointTarget records the directory and file name a save call writes,
+// taken from its string literal arguments
+func checkpointTarget(info *types.CheckpointInfo, label string, args []string) {
+	if label == "ModelCheckpoint" {
+		info.Format = ".ckpt"
+		if v, ok := keyedArgValue(args, "dirpath"); ok && info.Dir == "" {
+			info.Dir = pyLiteral(v)
+		}
+		if v, ok := keyedArgValue(args, "filename"); ok && info.Pattern == "" && pyLiteral(v) != "" {
+			info.Pattern = pyLiteral(v) + ".ckpt"
+		}
+		return
+	}
+
+	var dir, pattern string
+	text := strings.Join(args, ", ")
+	for _, m := range pyStringRegex.FindAllStringIndex(text, -1) {
+		value := pyLiteral(text[m[0]:m[1]])
+		// Skip dict keys such as {"epoch": epoch}
+		if value == "" || strings.ContainsAny(value, " \t") || strings.HasPrefix(strings.TrimSpace(text[m[1]:]), ":") {
+			continue
+		}
+		if checkpointExtRegex.MatchString(value) {
+			if pattern == "" {
+				pattern = value
+			}
+		} else if dir == "" && !strings.Contains(value, "{") && !strings.HasPrefix(value, ".") {
+			dir = value
+		}
+	}
+	if strings.Contains(pattern, "/") {
+		dir, pattern = filepath.ToSlash(filepath.Dir(pattern)), filepath.Base(pattern)
+	}
+	// A bare string is only a directory when it is joined with a file name or
+	// passed to save_pretrained, which writes a directory
+	if pattern == "" && label != "save_pretrained" {
+		dir = ""
+	}
+	if info.Dir == "" && dir != "" && !strings.Contains(dir, "{") {
+		info.Dir = dir
+	}
+	if info.Pattern == "" && pattern != "" {
+		info.Pattern = pattern
+	}
+}
+
+// deviceSelectionIn finds how code picks its device, most widespread first
+func deviceSelectionIn(sources []mlSource) []types.DeviceSelection {
+	var devices []types.DeviceSelection
+	for _, sel := range deviceSelectors {
+		device := types.DeviceSelection{Method: sel.method}
+		files := 0
+		for _, src := range sources {
+			found := false
+			for _, line := range src.lines {
+				if isPyComment(line) || !sel.regex.MatchString(line) {
+					continue
+				}
+				// device = "cuda" if torch.cuda.is_available() else "cpu" is a fallback
+				if sel.method == DeviceHardcoded && strings.Contains(line, "is_available") {
+					continue
+				}
+				if device.Example == "" {
+					device.Example = strings.TrimSpace(line)
+				}
+				found = true
+				break
+			}
+			if found {
+				files++
+				if len(device.Files) < maxMLExampleFiles {
+					device.Files = append(device.Files, src.path)
+				}
+			}
+		}
+		if files > 0 {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// shallowestFile returns the path of the named file closest to the root
+func (d *MLDetector) shallowestFile(name string) string {
+	best := ""
+	for _, f := range d.files {
+		if f.IsDir || f.Name != name {
+			continue
+		}
+		path := filepath.ToSlash(f.Path)
+		if best == "" || strings.Count(path, "/") < strings.Count(best, "/") ||
+			(strings.Count(path, "/") == strings.Count(best, "/") && path < best) {
+			best = path
+		}
+	}
+	return best
+}
+
+// pyLiteral returns the value of a Python string literal, including raw and
+// f-strings, or "" if v is not one
+func pyLiteral(v string) string {
+	v = strings.TrimSpace(v)
+	return quotedValue(strings.TrimLeft(v, "rRfFbBuU"))
+}
+
+func isPyComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
@@ -0,0 +1,249 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestMLDetector_DetectWorkflow(t *testing.T) {
+	files := map[string]string{
+		"dvc.yaml": `stages:
+  prepare:
+    cmd: python src/prepare.py data/raw data/prepared
+    deps:
+      - src/prepare.py
+      - data/raw
+    outs:
+      - data/prepared
+  train:
+    cmd: python src/train.py
+    deps:
+      - src/train.py
+      - data/prepared
+    params:
+      - train.lr
+      - params.yaml:
+          - train.epochs
+    outs:
+      - models/model.pt:
+          cache: false
+    metrics:
+      - metrics.json:
+          cache: false
+  evaluate:
+    cmd: python src/evaluate.py --checkpoint models/model.pt
+    deps:
+      - models/model.pt
+      - data/prepared/test
+`,
+		"conf/config.yaml": `defaults:
+  - model: resnet
+  - optimizer: adam
+  - _self_
+
+seed: 42
+`,
+		"conf/model/resnet.yaml":   "depth: 50\n",
+		"conf/model/vit.yaml":      "patch: 16\n",
+		"conf/optimizer/adam.yaml": "lr: 0.001\n",
+		"conf/optimizer/sgd.yaml":  "lr: 0.1\n",
+		"src/train.py": `import os
+
+import hydra
+import mlflow
+import torch
+from omegaconf import DictConfig
+
+
+@hydra.main(config_path="../conf", config_name="config", version_base=None)
+def main(cfg: DictConfig) -> None:
+    device = torch.device("cuda" if torch.cuda.is_available() else "cpu")
+    mlflow.set_experiment("resnet-baseline")
+    with mlflow.start_run():
+        for epoch in range(cfg.epochs):
+            mlflow.log_metric("loss", 0.1, step=epoch)
+            torch.save({"epoch": epoch, "model": model.state_dict()}, os.path.join("checkpoints", f"epoch_{epoch}.pt"))
+
+
+if __name__ == "__main__":
+    main()
+`,
+		"src/evaluate.py": `import argparse
+
+import wandb
+
+
+def main():
+    parser = argparse.ArgumentParser()
+    parser.add_argument("--checkpoint", required=True, help="Checkpoint to evaluate")
+    parser.add_argument("--batch-size", type=int, default=64)
+    args = parser.parse_args()
+    wandb.init(project="vision-eval", job_type="eval")
+    model = load(args.checkpoint).cuda()
+    wandb.log({"accuracy": 0.9})
+
+
+if __name__ == "__main__":
+    main()
+`,
+		"src/prepare.py":      "import sys\n\nprint(sys.argv)\n",
+		"tests/test_train.py": "import mlflow\n\nmlflow.log_param('fixture', 1)\n",
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	workflow := NewMLDetector(tmpDir, infos).DetectWorkflow()
+	if workflow == nil {
+		t.Fatal("expected an ML workflow")
+	}
+
+	wantTrackers := []types.ExperimentTracker{
+		{Name: "MLflow", Project: "resnet-baseline", Calls: []string{"mlflow.log_metric", "mlflow.set_experiment", "mlflow.start_run"}, Files: []string{"src/train.py"}},
+		{Name: "Weights & Biases", Project: "vision-eval", Calls: []string{"wandb.init", "wandb.log"}, Files: []string{"src/evaluate.py"}},
+	}
+	if !reflect.DeepEqual(workflow.Trackers, wantTrackers) {
+		t.Errorf("trackers = %+v, want %+v", workflow.Trackers, wantTrackers)
+	}
+
+	if len(workflow.Stages) != 3 {
+		t.Fatalf("stages = %+v, want 3", workflow.Stages)
+	}
+	train, evaluate := workflow.Stages[1], workflow.Stages[2]
+	if train.Command != "python src/train.py" || !reflect.DeepEqual(train.After, []string{"prepare"}) {
+		t.Errorf("train stage = %+v", train)
+	}
+	if !reflect.DeepEqual(train.Params, []string{"train.lr", "train.epochs"}) ||
+		!reflect.DeepEqual(train.Outs, []string{"models/model.pt"}) ||
+		!reflect.DeepEqual(train.Metrics, []string{"metrics.json"}) {
+		t.Errorf("train params/outs/metrics = %v / %v / %v", train.Params, train.Outs, train.Metrics)
+	}
+	if !reflect.DeepEqual(evaluate.After, []string{"prepare", "train"}) {
+		t.Errorf("evaluate after = %v, want [prepare train]", evaluate.After)
+	}
+
+	wantConfig := &types.MLConfig{
+		Framework: "Hydra",
+		Dir:       "conf",
+		Primary:   "config.yaml",
+		Groups: []types.ConfigGroup{
+			{Name: "model", Options: []string{"resnet", "vit"}},
+			{Name: "optimizer", Options: []string{"adam", "sgd"}},
+		},
+		Defaults: []string{"model=resnet", "optimizer=adam"},
+	}
+	if !reflect.DeepEqual(workflow.Config, wantConfig) {
+		t.Errorf("config = %+v, want %+v", workflow.Config, wantConfig)
+	}
+
+	if len(workflow.EntryPoints) != 2 {
+		t.Fatalf("entry points = %+v, want train and evaluate", workflow.EntryPoints)
+	}
+	trainEntry, evalEntry := workflow.EntryPoints[0], workflow.EntryPoints[1]
+	if trainEntry.Path != "src/train.py" || trainEntry.Kind != MLEntryTrain || !trainEntry.Hydra {
+		t.Errorf("train entry = %+v", trainEntry)
+	}
+	if evalEntry.Kind != MLEntryEval || evalEntry.Command != "python src/evaluate.py" {
+		t.Errorf("eval entry = %+v", evalEntry)
+	}
+	wantArgs := []types.CLIFlag{
+		{Name: "checkpoint", Type: "string", Usage: "Checkpoint to evaluate"},
+		{Name: "batch-size", Type: "int", Default: "64"},
+	}
+	if !reflect.DeepEqual(evalEntry.Args, wantArgs) {
+		t.Errorf("eval args = %+v, want %+v", evalEntry.Args, wantArgs)
+	}
+
+	wantCheckpoints := &types.CheckpointInfo{
+		Dir:     "checkpoints",
+		Format:  ".pt",
+		Pattern: "epoch_{epoch}.pt",
+		Saves:   []string{"torch.save"},
+		Files:   []string{"src/train.py"},
+	}
+	if !reflect.DeepEqual(workflow.Checkpoints, wantCheckpoints) {
+		t.Errorf("checkpoints = %+v, want %+v", workflow.Checkpoints, wantCheckpoints)
+	}
+
+	var methods []string
+	for _, device := range workflow.Devices {
+		methods = append(methods, device.Method)
+	}
+	if !reflect.DeepEqual(methods, []string{"CUDA with CPU fallback", DeviceHardcoded}) {
+		t.Errorf("device methods = %v", methods)
+	}
+}
+
+func TestMLDetector_DetectWorkflow_Lightning(t *testing.T) {
+	files := map[string]string{
+		"configs/base.yaml": "trainer:\n  max_epochs: 10\n",
+		"train.py": `import click
+from dvclive.lightning import DVCLiveLogger
+from lightning.pytorch import Trainer
+from lightning.pytorch.callbacks import ModelCheckpoint
+from lightning.pytorch.loggers import WandbLogger
+from omegaconf import OmegaConf
+
+
+@click.command()
+@click.option("--epochs", type=int, default=10, help="Epochs to train")
+@click.option("--output-dir", default="runs")
+def main(epochs, output_dir):
+    cfg = OmegaConf.load("configs/base.yaml")
+    logger = WandbLogger(project="segmenter")
+    checkpoint = ModelCheckpoint(dirpath="artifacts/ckpt", filename="{epoch}-{val_loss:.2f}")
+    trainer = Trainer(accelerator="auto", max_epochs=epochs, logger=logger, callbacks=[checkpoint])
+
+
+if __name__ == "__main__":
+    main()
+`,
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	workflow := NewMLDetector(tmpDir, infos).DetectWorkflow()
+	if workflow == nil {
+		t.Fatal("expected an ML workflow")
+	}
+
+	if len(workflow.Trackers) != 1 || workflow.Trackers[0].Name != "Weights & Biases" || workflow.Trackers[0].Project != "segmenter" {
+		t.Errorf("trackers = %+v, want W&B logging to segmenter", workflow.Trackers)
+	}
+	if c := workflow.Config; c == nil || c.Framework != "OmegaConf" || c.Dir != "configs" || c.Primary != "base.yaml" {
+		t.Errorf("config = %+v, want OmegaConf configs/base.yaml", c)
+	}
+	if len(workflow.EntryPoints) != 1 || workflow.EntryPoints[0].Hydra || len(workflow.EntryPoints[0].Args) != 2 {
+		t.Errorf("entry points = %+v, want train.py with two click options", workflow.EntryPoints)
+	}
+	wantCheckpoints := &types.CheckpointInfo{
+		Dir:     "artifacts/ckpt",
+		Format:  ".ckpt",
+		Pattern: "{epoch}-{val_loss:.2f}.ckpt",
+		Saves:   []string{"ModelCheckpoint"},
+		Files:   []string{"train.py"},
+	}
+	if !reflect.DeepEqual(workflow.Checkpoints, wantCheckpoints) {
+		t.Errorf("checkpoints = %+v, want %+v", workflow.Checkpoints, wantCheckpoints)
+	}
+	if len(workflow.Devices) != 1 || workflow.Devices[0].Method != "Lightning Trainer accelerator" {
+		t.Errorf("devices = %+v", workflow.Devices)
+	}
+}
+
+func TestMLDetector_DetectWorkflow_NotML(t *testing.T) {
+	files := map[string]string{
+		"app/cli.py": `from rich.live import Live
+
+
+def main():
+    with Live() as live:
+        live.update("done")
+
+
+if __name__ == "__main__":
+    main()
+`,
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	if workflow := NewMLDetector(tmpDir, infos).DetectWorkflow(); workflow != nil {
+		t.Errorf("expected nil, got %+v", workflow)
+	}
+}
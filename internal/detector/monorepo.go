@@ -3,12 +3,23 @@ package detector
 import (
 	"encoding/json"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
+var (
+	goWorkUseRegex        = regexp.MustCompile(`(?m)^\s*use\s+(?:\(([^)]*)\)|(\S+))`)
+	gradleIncludeRegex    = regexp.MustCompile(`(?m)^\s*include(Build)?\s*\(?([^)\n]*)`)
+	gradleQuotedRegex     = regexp.MustCompile(`['"]([^'"]+)['"]`)
+	gradleProjectDirRegex = regexp.MustCompile(`project\(\s*['"]([^'"]+)['"]\s*\)\.projectDir\s*=\s*(?:file|new File)\(\s*(?:(?:rootDir|settingsDir)\s*,\s*)?['"]([^'"]+)['"]`)
+)
+
 // MonorepoDetector detects monorepo structure and workspace configuration
 type MonorepoDetector struct {
 	rootPath string
@@ -33,6 +44,13 @@ func (d *MonorepoDetector) Detect() *types.MonorepoInfo {
 	// Check for workspace structure
 	d.detectWorkspaces(info)
 
+	// Check build systems and language workspaces beyond JavaScript
+	d.detectBuildSystemPackages(info)
+	d.detectGradleProjects(info)
+	d.detectGoWorkspace(info)
+	d.detectCargoWorkspace(info)
+	d.detectPythonWorkspace(info)
+
 	// Check for apps/packages directories
 	d.detectAppPackageStructure(info)
 
@@ -114,6 +132,252 @@ func (d *MonorepoDetector) detectWorkspaces(info *types.MonorepoInfo) {
 	}
 }
 
+// addWorkspace records a workspace directory or glob relative to the root.
+// The root itself is recorded as "." but only makes a monorepo alongside
+// other workspaces.
+func addWorkspace(info *types.MonorepoInfo, tool, dir string) {
+	dir = strings.Trim(strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(dir)), "./"), "/")
+	if dir == "" || strings.HasPrefix(dir, "..") {
+		return
+	}
+	if dir == "." {
+		info.WorkspacePaths = appendUnique(info.WorkspacePaths, dir)
+		return
+	}
+	info.IsMonorepo = true
+	if info.Tool == "" {
+		info.Tool = tool
+	}
+	info.WorkspacePaths = appendUnique(info.WorkspacePaths, dir)
+}
+
+// detectGoWorkspace reads go.work, falling back to nested go.mod files for
+// multi-module repositories without one
+func (d *MonorepoDetector) detectGoWorkspace(info *types.MonorepoInfo) {
+	if content, err := os.ReadFile(filepath.Join(d.rootPath, "go.work")); err == nil {
+		for _, m := range goWorkUseRegex.FindAllStringSubmatch(string(content), -1) {
+			dirs := []string{m[2]}
+			if m[1] != "" {
+				dirs = nil
+				for _, line := range strings.Split(m[1], "\n") {
+					if fields := strings.Fields(line); len(fields) > 0 && !strings.HasPrefix(fields[0], "//") {
+						dirs = append(dirs, fields[0])
+					}
+				}
+			}
+			for _, dir := range dirs {
+				addWorkspace(info, "Go workspace", strings.Trim(dir, `"`))
+			}
+		}
+		return
+	}
+
+	var modules []string
+	hasRoot := false
+	for _, f := range d.files {
+		if f.IsDir || filepath.Base(f.Path) != "go.mod" || strings.Contains(f.Path, "testdata") {
+			continue
+		}
+		dir := path.Dir(filepath.ToSlash(f.Path))
+		if dir == "." {
+			hasRoot = true
+			continue
+		}
+		modules = append(modules, dir)
+	}
+	if len(modules) >= 2 || (hasRoot && len(modules) >= 1) {
+		sort.Strings(modules)
+		if hasRoot {
+			modules = append([]string{"."}, modules...)
+		}
+		for _, dir := range modules {
+			addWorkspace(info, "Go modules", dir)
+		}
+	}
+}
+
+// detectCargoWorkspace reads [workspace] members from the root Cargo.toml
+func (d *MonorepoDetector) detectCargoWorkspace(info *types.MonorepoInfo) {
+	var cargo struct {
+		Workspace *CargoWorkspace `toml:"workspace"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(d.rootPath, "Cargo.toml"), &cargo); err != nil || cargo.Workspace == nil {
+		return
+	}
+	for _, member := range cargo.Workspace.Members {
+		addWorkspace(info, "Cargo workspace", member)
+	}
+	info.ExcludePaths = append(info.ExcludePaths, cargo.Workspace.Exclude...)
+}
+
+// detectPythonWorkspace reads uv and Hatch workspace members and Poetry
+// path dependencies from the root pyproject.toml
+func (d *MonorepoDetector) detectPythonWorkspace(info *types.MonorepoInfo) {
+	var pyproject struct {
+		Tool struct {
+			UV struct {
+				Workspace struct {
+					Members []string `toml:"members"`
+					Exclude []string `toml:"exclude"`
+				} `toml:"workspace"`
+			} `toml:"uv"`
+			Hatch struct {
+				Envs map[string]struct {
+					Workspace struct {
+						Members []interface{} `toml:"members"`
+						Exclude []string      `toml:"exclude"`
+					} `toml:"workspace"`
+				} `toml:"envs"`
+			} `toml:"hatch"`
+			Poetry struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+				Group        map[string]struct {
+					Dependencies map[string]interface{} `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(d.rootPath, "pyproject.toml"), &pyproject); err != nil {
+		return
+	}
+
+	for _, member := range pyproject.Tool.UV.Workspace.Members {
+		addWorkspace(info, "uv workspace", member)
+	}
+	info.ExcludePaths = append(info.ExcludePaths, pyproject.Tool.UV.Workspace.Exclude...)
+
+	var envNames []string
+	for name := range pyproject.Tool.Hatch.Envs {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		env := pyproject.Tool.Hatch.Envs[name]
+		for _, member := range env.Workspace.Members {
+			switch m := member.(type) {
+			case string:
+				addWorkspace(info, "Hatch workspace", m)
+			case map[string]interface{}:
+				if p, ok := m["path"].(string); ok {
+					addWorkspace(info, "Hatch workspace", p)
+				}
+			}
+		}
+		info.ExcludePaths = append(info.ExcludePaths, env.Workspace.Exclude...)
+	}
+
+	deps := []map[string]interface{}{pyproject.Tool.Poetry.Dependencies}
+	for _, group := range pyproject.Tool.Poetry.Group {
+		deps = append(deps, group.Dependencies)
+	}
+	var paths []string
+	for _, group := range deps {
+		for _, spec := range group {
+			if m, ok := spec.(map[string]interface{}); ok {
+				if p, ok := m["path"].(string); ok {
+					paths = append(paths, strings.TrimPrefix(p, "./"))
+				}
+			}
+		}
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		addWorkspace(info, "Poetry", p)
+	}
+}
+
+// detectGradleProjects reads included projects and builds from settings.gradle
+func (d *MonorepoDetector) detectGradleProjects(info *types.MonorepoInfo) {
+	var content []byte
+	for _, name := range []string{"settings.gradle.kts", "settings.gradle"} {
+		if data, err := os.ReadFile(filepath.Join(d.rootPath, name)); err == nil {
+			content = data
+			break
+		}
+	}
+	if content == nil {
+		return
+	}
+	text := string(content)
+
+	projectDirs := make(map[string]string)
+	for _, m := range gradleProjectDirRegex.FindAllStringSubmatch(text, -1) {
+		projectDirs[strings.TrimPrefix(m[1], ":")] = m[2]
+	}
+
+	for _, m := range gradleIncludeRegex.FindAllStringSubmatch(text, -1) {
+		for _, q := range gradleQuotedRegex.FindAllStringSubmatch(m[2], -1) {
+			if m[1] != "" {
+				// includeBuild takes a directory
+				addWorkspace(info, "Gradle", q[1])
+				continue
+			}
+			project := strings.TrimPrefix(q[1], ":")
+			if dir, ok := projectDirs[project]; ok {
+				addWorkspace(info, "Gradle", dir)
+			} else {
+				addWorkspace(info, "Gradle", strings.ReplaceAll(project, ":", "/"))
+			}
+		}
+	}
+}
+
+// detectBuildSystemPackages finds Bazel and Pants packages. Each outermost
+// directory with a BUILD file becomes a workspace.
+func (d *MonorepoDetector) detectBuildSystemPackages(info *types.MonorepoInfo) {
+	tool := ""
+	switch {
+	case d.rootHasAny("MODULE.bazel", "WORKSPACE", "WORKSPACE.bazel"):
+		tool = "Bazel"
+	case d.rootHasAny("pants.toml"):
+		tool = "Pants"
+	default:
+		return
+	}
+
+	var dirs []string
+	for _, f := range d.files {
+		name := filepath.Base(f.Path)
+		if f.IsDir || (name != "BUILD" && name != "BUILD.bazel") {
+			continue
+		}
+		if dir := path.Dir(filepath.ToSlash(f.Path)); dir != "." {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+
+	var outermost []string
+	for _, dir := range dirs {
+		nested := false
+		for _, parent := range outermost {
+			if strings.HasPrefix(dir, parent+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			outermost = append(outermost, dir)
+		}
+	}
+	if len(outermost) < 2 {
+		return
+	}
+	for _, dir := range outermost {
+		addWorkspace(info, tool, dir)
+	}
+}
+
+// rootHasAny reports whether any of the files exist at the project root
+func (d *MonorepoDetector) rootHasAny(names ...string) bool {
+	for _, name := range names {
+		if fileExists(filepath.Join(d.rootPath, name)) {
+			return true
+		}
+	}
+	return false
+}
+
 // detectAppPackageStructure detects apps/ and packages/ structure
 func (d *MonorepoDetector) detectAppPackageStructure(info *types.MonorepoInfo) {
 	// Check for common monorepo directory structures
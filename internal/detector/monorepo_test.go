@@ -0,0 +1,134 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMonorepoDetector_LanguageWorkspaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		tool     string
+		paths    []string
+		excludes []string
+	}{
+		{
+			name: "go.work",
+			files: map[string]string{
+				"go.work": "go 1.22\n\nuse (\n\t.\n\t./services/api // main API\n\t./libs/shared\n)\n\nuse ./tools\n",
+			},
+			tool:  "Go workspace",
+			paths: []string{".", "services/api", "libs/shared", "tools"},
+		},
+		{
+			name: "go modules without go.work",
+			files: map[string]string{
+				"go.mod":              "module github.com/acme/root\n",
+				"svc/billing/go.mod":  "module github.com/acme/billing\n",
+				"svc/payments/go.mod": "module github.com/acme/payments\n",
+				"testdata/x/go.mod":   "module x\n",
+			},
+			tool:  "Go modules",
+			paths: []string{".", "svc/billing", "svc/payments"},
+		},
+		{
+			name: "cargo",
+			files: map[string]string{
+				"Cargo.toml": "[workspace]\nmembers = [\"crates/*\", \"cli\"]\nexclude = [\"crates/legacy\"]\n",
+			},
+			tool:     "Cargo workspace",
+			paths:    []string{"crates/*", "cli"},
+			excludes: []string{"crates/legacy"},
+		},
+		{
+			name: "uv",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"root\"\n\n[tool.uv.workspace]\nmembers = [\"packages/*\"]\nexclude = [\"packages/scratch\"]\n",
+			},
+			tool:     "uv workspace",
+			paths:    []string{"packages/*"},
+			excludes: []string{"packages/scratch"},
+		},
+		{
+			name: "poetry path dependencies",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry.dependencies]\npython = \"^3.11\"\ncore = { path = \"libs/core\", develop = true }\n\n[tool.poetry.group.dev.dependencies]\ntesting = { path = \"./libs/testing\" }\n",
+			},
+			tool:  "Poetry",
+			paths: []string{"libs/core", "libs/testing"},
+		},
+		{
+			name: "hatch",
+			files: map[string]string{
+				"pyproject.toml": "[tool.hatch.envs.default.workspace]\nmembers = [\"pkgs/a\", { path = \"pkgs/b\" }]\n",
+			},
+			tool:  "Hatch workspace",
+			paths: []string{"pkgs/a", "pkgs/b"},
+		},
+		{
+			name: "gradle",
+			files: map[string]string{
+				"settings.gradle.kts": "rootProject.name = \"shop\"\ninclude(\":app\", \":lib:core\")\ninclude(\":legacy\")\nproject(\":legacy\").projectDir = file(\"old/legacy\")\nincludeBuild(\"build-logic\")\n",
+			},
+			tool:  "Gradle",
+			paths: []string{"app", "lib/core", "old/legacy", "build-logic"},
+		},
+		{
+			name: "bazel",
+			files: map[string]string{
+				"MODULE.bazel":                "module(name = \"shop\")\n",
+				"BUILD.bazel":                 "",
+				"services/api/BUILD.bazel":    "",
+				"services/api/handlers/BUILD": "",
+				"libs/proto/BUILD.bazel":      "",
+			},
+			tool:  "Bazel",
+			paths: []string{"libs/proto", "services/api"},
+		},
+		{
+			name: "pants",
+			files: map[string]string{
+				"pants.toml":      "[GLOBAL]\npants_version = \"2.20.0\"\n",
+				"src/app/BUILD":   "python_sources()\n",
+				"src/lib/BUILD":   "python_sources()\n",
+				"tests/app/BUILD": "python_tests()\n",
+			},
+			tool:  "Pants",
+			paths: []string{"src/app", "src/lib", "tests/app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, files := writeProjectFixture(t, tt.files)
+			info := NewMonorepoDetector(tmpDir, files).Detect()
+			if info == nil {
+				t.Fatal("expected monorepo info")
+			}
+			if info.Tool != tt.tool {
+				t.Errorf("expected tool %q, got %q", tt.tool, info.Tool)
+			}
+			if !reflect.DeepEqual(info.WorkspacePaths, tt.paths) {
+				t.Errorf("expected workspaces %v, got %v", tt.paths, info.WorkspacePaths)
+			}
+			if !reflect.DeepEqual(info.ExcludePaths, tt.excludes) {
+				t.Errorf("expected excludes %v, got %v", tt.excludes, info.ExcludePaths)
+			}
+		})
+	}
+}
+
+func TestMonorepoDetector_SingleGoModule(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{"go.mod": "module github.com/acme/app\n", "main.go": "package main\n"})
+	if info := NewMonorepoDetector(tmpDir, files).Detect(); info != nil {
+		t.Errorf("expected a single module not to be a monorepo, got %+v", info)
+	}
+}
+
+func TestMonorepoDetector_RootOnlyGoWork(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{"go.work": "go 1.22\n\nuse .\n", "go.mod": "module github.com/acme/app\n"})
+	if info := NewMonorepoDetector(tmpDir, files).Detect(); info != nil {
+		t.Errorf("expected a go.work using only the root not to be a monorepo, got %+v", info)
+	}
+}
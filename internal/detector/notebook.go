@@ -0,0 +1,417 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+	"github.com/go-python/gpython/ast"
+	"github.com/go-python/gpython/parser"
+	"github.com/go-python/gpython/py"
+)
+
+const (
+	// maxNotebookSize skips notebooks whose saved outputs make them too large to read
+	maxNotebookSize = 5 * 1024 * 1024
+	// maxNotebookSections caps the headings kept to summarize a notebook
+	maxNotebookSections = 6
+	// maxNotebookDataPaths caps the data files listed per notebook
+	maxNotebookDataPaths = 8
+	// minDuplicateBodyLines is the shortest function body matched by content alone
+	minDuplicateBodyLines = 3
+)
+
+var (
+	notebookHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	notebookMagicRegex   = regexp.MustCompile(`^(%%?\w+|!\s*[\w.-]+)`)
+	notebookAssignMagic  = regexp.MustCompile(`^[\w, ]+=\s*[%!]`)
+	dataPathRegex        = regexp.MustCompile(`(?i)\.(csv|tsv|parquet|feather|jsonl|json|xlsx?|pkl|pickle|h5|hdf5|npy|npz|arrow|txt|zip|gz)$`)
+	markdownLinkRegex    = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// pythonBodyCellMagics are cell magics whose body is still Python
+var pythonBodyCellMagics = map[string]bool{
+	"%%time": true, "%%timeit": true, "%%capture": true, "%%prun": true, "%%debug": true,
+}
+
+// genericFunctionNames are too common to flag as duplicated on name alone
+var genericFunctionNames = map[string]bool{
+	"main": true, "run": true, "setup": true, "plot": true, "show": true, "display": true,
+	"test": true, "helper": true, "wrapper": true, "func": true,
+}
+
+// notebookFile is the subset of the nbformat 4 JSON that Argus reads
+type notebookFile struct {
+	Cells []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"`
+	} `json:"cells"`
+	Metadata struct {
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+// notebookHeading is a markdown heading from a notebook
+type notebookHeading struct {
+	level int
+	text  string
+}
+
+// notebook is a parsed Jupyter notebook. cells holds the Python source of
+// each code cell with magics and shell escapes replaced by pass.
+type notebook struct {
+	path     string
+	cells    []string
+	magics   []string
+	headings []notebookHeading
+}
+
+// parseNotebook reads the code and markdown cells of an .ipynb file. Code
+// cells of notebooks running a kernel other than Python are left out.
+func parseNotebook(path string, content []byte) (*notebook, error) {
+	var file notebookFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("parsing notebook %s: %w", path, err)
+	}
+	language := file.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = file.Metadata.Kernelspec.Language
+	}
+	python := language == "" || strings.EqualFold(language, "python")
+
+	nb := &notebook{path: path}
+	magics := make(map[string]bool)
+	for _, cell := range file.Cells {
+		source := cellSource(cell.Source)
+		switch cell.CellType {
+		case "markdown":
+			for _, line := range strings.Split(source, "\n") {
+				if m := notebookHeadingRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+					nb.headings = append(nb.headings, notebookHeading{level: len(m[1]), text: markdownText(m[2])})
+				}
+			}
+		case "code":
+			if !python {
+				continue
+			}
+			if code, ok := pythonCell(source, magics); ok {
+				nb.cells = append(nb.cells, code)
+			}
+		}
+	}
+	for magic := range magics {
+		nb.magics = append(nb.magics, magic)
+	}
+	sort.Strings(nb.magics)
+	return nb, nil
+}
+
+// cellSource joins a cell source, which nbformat stores as a string or a list of lines
+func cellSource(raw json.RawMessage) string {
+	var lines []string
+	if json.Unmarshal(raw, &lines) == nil {
+		return strings.ReplaceAll(strings.Join(lines, ""), "\r\n", "\n")
+	}
+	var source string
+	if json.Unmarshal(raw, &source) == nil {
+		return strings.ReplaceAll(source, "\r\n", "\n")
+	}
+	return ""
+}
+
+// pythonCell converts a code cell to plain Python, recording the magics and
+// shell escapes it uses. ok is false for cells in another language (%%bash, %%sql).
+func pythonCell(source string, magics map[string]bool) (string, bool) {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "%%") {
+			name := strings.Fields(trimmed)[0]
+			magics[name] = true
+			if !pythonBodyCellMagics[name] {
+				return "", false
+			}
+			lines[i] = ""
+		}
+		break
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		isMagic := strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "!")
+		if !isMagic && !notebookAssignMagic.MatchString(trimmed) && !strings.HasSuffix(trimmed, "?") {
+			continue
+		}
+		rest := trimmed
+		if !isMagic && notebookAssignMagic.MatchString(trimmed) {
+			rest = strings.TrimSpace(trimmed[strings.IndexAny(trimmed, "%!"):])
+		}
+		if m := notebookMagicRegex.FindString(rest); m != "" {
+			magics[strings.ReplaceAll(m, " ", "")] = true
+		}
+		lines[i] = line[:len(line)-len(strings.TrimLeft(line, " \t"))] + "pass"
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// markdownText strips links, emphasis and code spans from heading text
+func markdownText(s string) string {
+	s = markdownLinkRegex.ReplaceAllString(s, "$1")
+	s = strings.NewReplacer("**", "", "__", "", "`", "").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+// code returns the notebook's Python as one module, cells separated by blank lines
+func (nb *notebook) code() string {
+	return strings.Join(nb.cells, "\n\n")
+}
+
+// modules parses the notebook's Python, falling back to one module per cell
+// when the notebook as a whole does not parse
+func (nb *notebook) modules() []*ast.Module {
+	if module := parsePythonModule(nb.code(), nb.path); module != nil {
+		return []*ast.Module{module}
+	}
+	var modules []*ast.Module
+	for _, cell := range nb.cells {
+		if module := parsePythonModule(cell, nb.path); module != nil {
+			modules = append(modules, module)
+		}
+	}
+	return modules
+}
+
+// parsePythonModule parses source as a Python module, or returns nil
+func parsePythonModule(source, path string) *ast.Module {
+	mod, err := parser.Parse(strings.NewReader(source), path, py.ExecMode)
+	if err != nil {
+		return nil
+	}
+	module, _ := mod.(*ast.Module)
+	return module
+}
+
+// readNotebooks parses the project's notebooks in path order, skipping
+// Jupyter's .ipynb_checkpoints copies
+func readNotebooks(rootPath string, files []types.FileInfo) []*notebook {
+	var notebooks []*notebook
+	for _, f := range files {
+		if f.IsDir || f.Extension != ".ipynb" || f.Size > maxNotebookSize || strings.Contains(f.Path, ".ipynb_checkpoints") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(rootPath, f.Path))
+		if err != nil {
+			continue
+		}
+		if nb, err := parseNotebook(filepath.ToSlash(f.Path), content); err == nil {
+			notebooks = append(notebooks, nb)
+		}
+	}
+	sort.Slice(notebooks, func(i, j int) bool { return notebooks[i].path < notebooks[j].path })
+	return notebooks
+}
+
+// NotebookDetector reads what Jupyter notebooks import, load and define
+type NotebookDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewNotebookDetector creates a new notebook detector
+func NewNotebookDetector(rootPath string, files []types.FileInfo) *NotebookDetector {
+	return &NotebookDetector{rootPath: rootPath, files: files}
+}
+
+// Detect returns each notebook's imports, magics, data paths, functions and headings
+func (d *NotebookDetector) Detect() []types.Notebook {
+	var notebooks []types.Notebook
+	for _, nb := range readNotebooks(d.rootPath, d.files) {
+		info := types.Notebook{Path: nb.path, Cells: len(nb.cells), Magics: nb.magics}
+
+		imports := make(map[string]bool)
+		dataPaths := make(map[string]bool)
+		for _, line := range strings.Split(nb.code(), "\n") {
+			trimmed := strings.TrimSpace(line)
+			if m := pyImportRegex.FindStringSubmatch(trimmed); m != nil {
+				module := m[1] + m[2]
+				if !strings.HasPrefix(module, ".") {
+					imports[strings.Split(module, ".")[0]] = true
+				}
+			}
+			if m := pyDefRegex.FindStringSubmatch(line); m != nil && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				info.Functions = append(info.Functions, m[1])
+			}
+			if isPyComment(trimmed) {
+				continue
+			}
+			for _, literal := range pyStringRegex.FindAllString(line, -1) {
+				value := pyLiteral(literal)
+				if dataPathRegex.MatchString(value) && !strings.Contains(value, "://") && !strings.ContainsAny(value, " \t") {
+					dataPaths[value] = true
+				}
+			}
+		}
+		for module := range imports {
+			info.Imports = append(info.Imports, module)
+		}
+		sort.Strings(info.Imports)
+		for path := range dataPaths {
+			info.DataPaths = append(info.DataPaths, path)
+		}
+		sort.Strings(info.DataPaths)
+		if len(info.DataPaths) > maxNotebookDataPaths {
+			info.DataPaths = info.DataPaths[:maxNotebookDataPaths]
+		}
+
+		info.Title, info.Sections = notebookOutline(nb.headings)
+		notebooks = append(notebooks, info)
+	}
+	return notebooks
+}
+
+// notebookOutline returns a notebook's title, its first top-level heading,
+// and the headings one level below it
+func notebookOutline(headings []notebookHeading) (string, []string) {
+	if len(headings) == 0 {
+		return "", nil
+	}
+	top := headings[0].level
+	for _, h := range headings {
+		top = min(top, h.level)
+	}
+	title := ""
+	var sections []string
+	for _, h := range headings {
+		switch {
+		case h.level == top && title == "":
+			title = h.text
+		case h.level == top+1 && len(sections) < maxNotebookSections:
+			sections = append(sections, h.text)
+		}
+	}
+	return title, sections
+}
+
+// pyFunction is a top-level Python function and its normalized body
+type pyFunction struct {
+	name string
+	file string
+	line int
+	body string
+	size int // Body lines, ignoring blanks and comments
+}
+
+// topLevelFunctions returns the module-level functions in Python source
+func topLevelFunctions(file, source string) []pyFunction {
+	var functions []pyFunction
+	var current *pyFunction
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.body = strings.Join(body, "\n")
+			current.size = len(body)
+			functions = append(functions, *current)
+		}
+		current, body = nil, nil
+	}
+	for n, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || isPyComment(trimmed) {
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if !indented {
+			flush()
+			if m := pyDefRegex.FindStringSubmatch(line); m != nil {
+				current = &pyFunction{name: m[1], file: file, line: n + 1}
+			}
+			continue
+		}
+		if current != nil {
+			body = append(body, trimmed)
+		}
+	}
+	flush()
+	return functions
+}
+
+// detectNotebookDuplication flags notebook functions that redefine a function
+// from the project's Python modules, by name or by identical body
+func (d *ConventionDetector) detectNotebookDuplication() []types.Convention {
+	notebooks := readNotebooks(d.rootPath, d.files)
+	if len(notebooks) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]pyFunction)
+	byBody := make(map[string]pyFunction)
+	for _, f := range d.files {
+		if f.IsDir || f.Extension != ".py" || f.Size > maxStyleSampleSize || isTestSourceFile(f.Path) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+		if err != nil {
+			continue
+		}
+		path := filepath.ToSlash(f.Path)
+		for _, fn := range topLevelFunctions(path, strings.ReplaceAll(string(content), "\r\n", "\n")) {
+			if _, seen := byName[fn.name]; !seen || path < byName[fn.name].file {
+				byName[fn.name] = fn
+			}
+			if fn.size >= minDuplicateBodyLines {
+				byBody[fn.body] = fn
+			}
+		}
+	}
+	if len(byName) == 0 {
+		return nil
+	}
+
+	var examples, samples []string
+	files := 0
+	for _, nb := range notebooks {
+		found := false
+		for _, fn := range topLevelFunctions(nb.path, nb.code()) {
+			original, ok := byName[fn.name]
+			if !ok || genericFunctionNames[fn.name] || len(fn.name) <= 3 {
+				original, ok = byBody[fn.body]
+				ok = ok && fn.size >= minDuplicateBodyLines
+			}
+			if !ok {
+				continue
+			}
+			found = true
+			examples = append(examples, fmt.Sprintf("%s in %s (%s)", fn.name, nb.path, original.file))
+			if len(samples) < maxEvidenceSamples {
+				samples = append(samples, fmt.Sprintf("%s:%d", original.file, original.line))
+			}
+		}
+		if found {
+			files++
+		}
+	}
+	if len(examples) == 0 {
+		return nil
+	}
+
+	conv := countedConvention(types.Convention{
+		Category:    "structure",
+		Description: "Notebooks redefine functions that already exist in the project's modules; import them from the package instead of copying them into cells",
+		Example:     strings.Join(limitSlice(examples, maxEvidenceSamples), "; "),
+	}, len(examples), 0, files)
+	conv.Evidence.Samples = samples
+	return []types.Convention{conv}
+}
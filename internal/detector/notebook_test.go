@@ -0,0 +1,195 @@
+package detector
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// notebookJSON builds an nbformat 4 notebook. Cells starting with "# " are
+// markdown; the rest are code.
+func notebookJSON(t *testing.T, language string, cells ...string) string {
+	t.Helper()
+	type cell struct {
+		CellType string   `json:"cell_type"`
+		Source   []string `json:"source"`
+	}
+	nb := map[string]interface{}{
+		"nbformat": 4,
+		"metadata": map[string]interface{}{"language_info": map[string]string{"name": language}},
+	}
+	var out []cell
+	for _, source := range cells {
+		cellType := "code"
+		if strings.HasPrefix(source, "# ") {
+			cellType = "markdown"
+		}
+		lines := strings.SplitAfter(source, "\n")
+		out = append(out, cell{CellType: cellType, Source: lines})
+	}
+	nb["cells"] = out
+	data, err := json.Marshal(nb)
+	if err != nil {
+		t.Fatalf("marshal notebook: %v", err)
+	}
+	return string(data)
+}
+
+func TestParseNotebook(t *testing.T) {
+	content := `{
+ "cells": [
+  {"cell_type": "markdown", "source": "# Churn model\n\n## Load data\n"},
+  {"cell_type": "code", "source": ["%matplotlib inline\n", "import pandas as pd\n", "files = !ls data\n"]},
+  {"cell_type": "code", "source": "%%bash\npip freeze > requirements.txt\n"},
+  {"cell_type": "code", "source": ["%%time\n", "for i in range(3):\n", "    !echo {i}\n", "    pd.read_csv?\n"]},
+  {"cell_type": "markdown", "source": ["## [Train](#train) the **model**"]}
+ ],
+ "metadata": {"kernelspec": {"language": "python"}},
+ "nbformat": 4
+}`
+	nb, err := parseNotebook("eda.ipynb", []byte(content))
+	if err != nil {
+		t.Fatalf("parseNotebook: %v", err)
+	}
+
+	wantCells := []string{
+		"pass\nimport pandas as pd\npass\n",
+		"\nfor i in range(3):\n    pass\n    pass\n",
+	}
+	if !reflect.DeepEqual(nb.cells, wantCells) {
+		t.Errorf("cells = %q, want %q", nb.cells, wantCells)
+	}
+	wantMagics := []string{"!echo", "!ls", "%%bash", "%%time", "%matplotlib"}
+	if !reflect.DeepEqual(nb.magics, wantMagics) {
+		t.Errorf("magics = %v, want %v", nb.magics, wantMagics)
+	}
+	title, sections := notebookOutline(nb.headings)
+	if title != "Churn model" || !reflect.DeepEqual(sections, []string{"Load data", "Train the model"}) {
+		t.Errorf("outline = %q %q", title, sections)
+	}
+	if len(nb.modules()) != 1 {
+		t.Error("expected the notebook code to parse as one module")
+	}
+
+	rNotebook := `{"cells": [{"cell_type": "code", "source": "library(dplyr)"}], "metadata": {"language_info": {"name": "R"}}}`
+	nb, err = parseNotebook("analysis.ipynb", []byte(rNotebook))
+	if err != nil || len(nb.cells) != 0 {
+		t.Errorf("R notebook cells = %v, err = %v; want none", nb.cells, err)
+	}
+
+	if _, err := parseNotebook("broken.ipynb", []byte("{")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestNotebookDetector_Detect(t *testing.T) {
+	files := map[string]string{
+		"notebooks/01_eda.ipynb": notebookJSON(t, "python",
+			"# Customer churn exploration\n\n## Load\n\n## Feature engineering",
+			"%pip install seaborn\nimport pandas as pd\nimport seaborn as sns\nfrom sklearn.model_selection import train_test_split\nfrom .local import helper",
+			"df = pd.read_csv(\"../data/raw/customers.csv\")\nlabels = pd.read_parquet('data/labels.parquet')\n# pd.read_csv(\"old.csv\")\nurl = \"https://example.com/data.csv\"",
+			"def clean(df):\n    return df.dropna()\n\nsummary = f\"{len(df)} rows\"",
+		),
+		"notebooks/.ipynb_checkpoints/01_eda-checkpoint.ipynb": notebookJSON(t, "python", "import os"),
+		"notebooks/broken.ipynb":                               "not json",
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	notebooks := NewNotebookDetector(tmpDir, infos).Detect()
+	if len(notebooks) != 1 {
+		t.Fatalf("notebooks = %+v, want only 01_eda.ipynb", notebooks)
+	}
+
+	nb := notebooks[0]
+	if nb.Path != "notebooks/01_eda.ipynb" || nb.Cells != 3 {
+		t.Errorf("path/cells = %s/%d", nb.Path, nb.Cells)
+	}
+	if want := []string{"pandas", "seaborn", "sklearn"}; !reflect.DeepEqual(nb.Imports, want) {
+		t.Errorf("imports = %v, want %v", nb.Imports, want)
+	}
+	if want := []string{"%pip"}; !reflect.DeepEqual(nb.Magics, want) {
+		t.Errorf("magics = %v, want %v", nb.Magics, want)
+	}
+	if want := []string{"../data/raw/customers.csv", "data/labels.parquet"}; !reflect.DeepEqual(nb.DataPaths, want) {
+		t.Errorf("data paths = %v, want %v", nb.DataPaths, want)
+	}
+	if want := []string{"clean"}; !reflect.DeepEqual(nb.Functions, want) {
+		t.Errorf("functions = %v, want %v", nb.Functions, want)
+	}
+	if nb.Title != "Customer churn exploration" || !reflect.DeepEqual(nb.Sections, []string{"Load", "Feature engineering"}) {
+		t.Errorf("title/sections = %q %v", nb.Title, nb.Sections)
+	}
+}
+
+func TestConventionDetector_NotebookDuplication(t *testing.T) {
+	files := map[string]string{
+		"src/features.py": `import re
+
+
+def clean_text(text):
+    text = text.lower()
+    return re.sub(r"\s+", " ", text)
+
+
+def scale(values):
+    low, high = min(values), max(values)
+    span = high - low
+    return [(v - low) / span for v in values]
+`,
+		"notebooks/eda.ipynb": notebookJSON(t, "python",
+			"def clean_text(s):\n    return s.strip()",
+			"def normalize(values):\n    # copied from src\n    low, high = min(values), max(values)\n    span = high - low\n\n    return [(v - low) / span for v in values]",
+			"def main():\n    pass\ndef plot(df):\n    df.plot()",
+		),
+		"notebooks/clean.ipynb": notebookJSON(t, "python", "import pandas as pd"),
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	convs := NewConventionDetector(tmpDir, infos).detectNotebookDuplication()
+	if len(convs) != 1 {
+		t.Fatalf("conventions = %+v, want one", convs)
+	}
+
+	conv := convs[0]
+	wantExample := "clean_text in notebooks/eda.ipynb (src/features.py); normalize in notebooks/eda.ipynb (src/features.py)"
+	if conv.Example != wantExample {
+		t.Errorf("example = %q, want %q", conv.Example, wantExample)
+	}
+	if conv.Evidence == nil || conv.Evidence.Matches != 2 || conv.Evidence.Files != 1 {
+		t.Errorf("evidence = %+v, want 2 matches in 1 notebook", conv.Evidence)
+	}
+	if want := []string{"src/features.py:4", "src/features.py:9"}; !reflect.DeepEqual(conv.Evidence.Samples, want) {
+		t.Errorf("samples = %v, want %v", conv.Evidence.Samples, want)
+	}
+	if conv.Confidence < 0.4 {
+		t.Errorf("confidence = %v, want above the default threshold", conv.Confidence)
+	}
+
+	noDuplicates := map[string]string{
+		"src/features.py":     "def clean_text(text):\n    return text\n",
+		"notebooks/eda.ipynb": notebookJSON(t, "python", "def explore(df):\n    return df.describe()"),
+	}
+	tmpDir, infos = writeProjectFixture(t, noDuplicates)
+	if convs := NewConventionDetector(tmpDir, infos).detectNotebookDuplication(); len(convs) != 0 {
+		t.Errorf("expected no conventions, got %+v", convs)
+	}
+}
+
+func TestPythonASTDetector_Notebooks(t *testing.T) {
+	files := map[string]string{
+		// f-strings don't parse, so the cells are parsed one at a time
+		"analysis.ipynb": notebookJSON(t, "python",
+			"%load_ext autoreload\nimport pandas as pd\nimport matplotlib.pyplot as plt",
+			"print(f\"{pd.__version__}\")",
+		),
+	}
+	tmpDir, infos := writeProjectFixture(t, files)
+	found := make(map[string][]string)
+	for _, p := range NewPythonASTDetector(tmpDir, infos).Detect() {
+		found[p.Name] = p.Examples
+	}
+	for _, name := range []string{"pandas", "Matplotlib"} {
+		if !reflect.DeepEqual(found[name], []string{"analysis.ipynb"}) {
+			t.Errorf("%s examples = %v, want [analysis.ipynb]", name, found[name])
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// OwnershipSourceGit marks ownership derived from commit history
+const OwnershipSourceGit = "git history"
+
+// Git history fallback limits
+const (
+	ownershipMaxCommits = 500
+	ownershipTopAuthors = 3
+)
+
+// codeownersLocations are checked in the order GitHub and GitLab resolve them
+var codeownersLocations = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// codeownersSectionRegex matches GitLab section headers: [Name], ^[Name], [Name][2] @owner
+var codeownersSectionRegex = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(?:\s+(.*))?$`)
+
+// OwnershipDetector maps paths to owners from CODEOWNERS, falling back to
+// the top contributors per directory in git history
+type OwnershipDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewOwnershipDetector creates a new ownership detector
+func NewOwnershipDetector(rootPath string, files []types.FileInfo) *OwnershipDetector {
+	return &OwnershipDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// Detect returns path ownership, or nil when neither source is available
+func (d *OwnershipDetector) Detect() *types.Ownership {
+	for _, location := range codeownersLocations {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, location))
+		if err != nil || len(content) > 500000 {
+			continue
+		}
+		entries := parseCodeowners(string(content))
+		if len(entries) == 0 {
+			continue
+		}
+		return &types.Ownership{Source: location, Entries: entries}
+	}
+
+	return d.detectFromHistory()
+}
+
+// detectFromHistory credits each structure directory to its top committers
+func (d *OwnershipDetector) detectFromHistory() *types.Ownership {
+	gitDetector := NewGitDetectorGoGit(d.rootPath)
+	contributors := gitDetector.DirectoryContributors(ownershipMaxCommits, ownershipTopAuthors, func(path string) string {
+		if dir := structureDir(path); dir != "" {
+			return dir
+		}
+		return "."
+	})
+	if len(contributors) == 0 {
+		return nil
+	}
+
+	ownership := &types.Ownership{Source: OwnershipSourceGit}
+	for _, dir := range sortedKeys(contributors) {
+		ownership.Entries = append(ownership.Entries, types.OwnershipEntry{
+			Path:   dir,
+			Owners: contributors[dir],
+		})
+	}
+	return ownership
+}
+
+// parseCodeowners parses GitHub and GitLab CODEOWNERS syntax. Rules keep
+// file order; GitLab rules without owners inherit their section defaults.
+func parseCodeowners(content string) []types.OwnershipEntry {
+	var entries []types.OwnershipEntry
+	var section string
+	var sectionOwners []string
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := codeownersFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if m := codeownersSectionRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			section = strings.TrimSpace(m[1])
+			sectionOwners = codeownersFields(m[2])
+			continue
+		}
+
+		owners := fields[1:]
+		if len(owners) == 0 && section != "" {
+			owners = sectionOwners
+		}
+		entries = append(entries, types.OwnershipEntry{
+			Path:    fields[0],
+			Owners:  owners,
+			Section: section,
+		})
+	}
+
+	return entries
+}
+
+// codeownersFields splits a line on unescaped whitespace and drops comments
+func codeownersFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+			continue
+		case ch == '#':
+			i = len(line)
+		case ch == ' ' || ch == '\t' || ch == '\r':
+		default:
+			current.WriteByte(ch)
+			continue
+		}
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// codeownersPattern compiles a gitignore-style CODEOWNERS pattern. Patterns
+// with a leading or inner slash are anchored to the root; others match at
+// any depth. A match also covers everything below it, except for "dir/*"
+// which only covers files directly inside dir.
+func codeownersPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+	if pattern == "" || pattern == "*" || pattern == "**" {
+		return regexp.MustCompile(`^.*$`)
+	}
+
+	var re strings.Builder
+	if anchored {
+		re.WriteString("^")
+	} else {
+		re.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			re.WriteString("[^/]*")
+		case pattern[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+
+	if strings.HasSuffix(pattern, "/*") {
+		re.WriteString("$")
+	} else {
+		re.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.MustCompile(re.String())
+}
+
+// ownerResolver answers who owns a path
+type ownerResolver struct {
+	ownership *types.Ownership
+	patterns  []*regexp.Regexp
+	byDir     map[string][]string
+}
+
+func newOwnerResolver(ownership *types.Ownership) *ownerResolver {
+	r := &ownerResolver{ownership: ownership}
+	if ownership.Source == OwnershipSourceGit {
+		r.byDir = make(map[string][]string)
+		for _, entry := range ownership.Entries {
+			r.byDir[entry.Path] = entry.Owners
+		}
+		return r
+	}
+	for _, entry := range ownership.Entries {
+		r.patterns = append(r.patterns, codeownersPattern(entry.Path))
+	}
+	return r
+}
+
+// ownersFor returns the owners of a file or directory path. Within a section
+// the last matching rule wins; owners from every GitLab section are combined.
+func (r *ownerResolver) ownersFor(path string) []string {
+	path = filepath.ToSlash(path)
+	if r.byDir != nil {
+		if owners, ok := r.byDir[path]; ok {
+			return owners
+		}
+		if dir := structureDir(path); dir != "" {
+			return r.byDir[dir]
+		}
+		return r.byDir["."]
+	}
+
+	var sections []string
+	matched := make(map[string][]string)
+	for i, entry := range r.ownership.Entries {
+		if !r.patterns[i].MatchString(path) {
+			continue
+		}
+		if _, ok := matched[entry.Section]; !ok {
+			sections = append(sections, entry.Section)
+		}
+		matched[entry.Section] = entry.Owners
+	}
+
+	var owners []string
+	for _, section := range sections {
+		for _, owner := range matched[section] {
+			owners = appendUnique(owners, owner)
+		}
+	}
+	return owners
+}
+
+// AnnotateOwners fills in owners on structure directories and key files
+func AnnotateOwners(ownership *types.Ownership, structure *types.ProjectStructure, keyFiles []types.KeyFile) {
+	if ownership == nil {
+		return
+	}
+	resolver := newOwnerResolver(ownership)
+
+	if structure != nil {
+		for i := range structure.Directories {
+			structure.Directories[i].Owners = resolver.ownersFor(structure.Directories[i].Path)
+		}
+	}
+	for i := range keyFiles {
+		keyFiles[i].Owners = resolver.ownersFor(keyFiles[i].Path)
+	}
+}
@@ -0,0 +1,166 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestOwnershipDetector_GitHubCodeowners(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		".github/CODEOWNERS": `# Default owners
+*                 @acme/core
+
+*.go              @gopher       # Go reviewers
+/docs/            docs@acme.io
+apps/             @acme/frontend
+/internal/auth/   @acme/security @alice
+internal/gen/*    @bot
+/vendor/
+my\ file.txt      @spaces
+`,
+	})
+
+	ownership := NewOwnershipDetector(tmpDir, files).Detect()
+	if ownership == nil || ownership.Source != ".github/CODEOWNERS" {
+		t.Fatalf("expected ownership from .github/CODEOWNERS, got %+v", ownership)
+	}
+	if len(ownership.Entries) != 8 {
+		t.Fatalf("expected 8 rules, got %d: %+v", len(ownership.Entries), ownership.Entries)
+	}
+	if ownership.Entries[7].Path != "my file.txt" {
+		t.Errorf("expected escaped space in pattern, got %q", ownership.Entries[7].Path)
+	}
+
+	resolver := newOwnerResolver(ownership)
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@acme/core"}},
+		{"cmd/argus/main.go", []string{"@gopher"}},
+		{"docs/guide/intro.md", []string{"docs@acme.io"}},
+		{"packages/web/apps/page.tsx", []string{"@acme/frontend"}},
+		{"internal/auth", []string{"@acme/security", "@alice"}},
+		{"internal/auth/token.go", []string{"@acme/security", "@alice"}},
+		{"internal/gen/types.go", []string{"@bot"}},
+		{"internal/gen/nested/types.go", []string{"@gopher"}},
+		{"vendor/lib/lib.go", nil},
+	}
+	for _, tt := range tests {
+		if got := resolver.ownersFor(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestParseCodeowners_GitLabSections(t *testing.T) {
+	entries := parseCodeowners(`* @admins
+
+[Frontend] @frontend-team
+/web/
+/web/legacy/ @legacy
+
+^[Docs][2] @writers
+*.md
+`)
+
+	want := []types.OwnershipEntry{
+		{Path: "*", Owners: []string{"@admins"}},
+		{Path: "/web/", Owners: []string{"@frontend-team"}, Section: "Frontend"},
+		{Path: "/web/legacy/", Owners: []string{"@legacy"}, Section: "Frontend"},
+		{Path: "*.md", Owners: []string{"@writers"}, Section: "Docs"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("expected %+v, got %+v", want, entries)
+	}
+
+	// Owners from every matching section are combined
+	resolver := newOwnerResolver(&types.Ownership{Source: "CODEOWNERS", Entries: entries})
+	if got := resolver.ownersFor("web/legacy/README.md"); !reflect.DeepEqual(got, []string{"@admins", "@legacy", "@writers"}) {
+		t.Errorf("expected owners combined across sections, got %v", got)
+	}
+}
+
+func TestAnnotateOwners(t *testing.T) {
+	ownership := &types.Ownership{
+		Source: "CODEOWNERS",
+		Entries: []types.OwnershipEntry{
+			{Path: "*", Owners: []string{"@core"}},
+			{Path: "/internal/detector/", Owners: []string{"@detectors"}},
+		},
+	}
+	structure := &types.ProjectStructure{Directories: []types.Directory{
+		{Path: "internal/detector"},
+		{Path: "cmd"},
+	}}
+	keyFiles := []types.KeyFile{{Path: "go.mod"}, {Path: "internal/detector/structure.go"}}
+
+	AnnotateOwners(ownership, structure, keyFiles)
+
+	if got := structure.Directories[0].Owners; !reflect.DeepEqual(got, []string{"@detectors"}) {
+		t.Errorf("expected detector owners, got %v", got)
+	}
+	if got := structure.Directories[1].Owners; !reflect.DeepEqual(got, []string{"@core"}) {
+		t.Errorf("expected default owners on cmd, got %v", got)
+	}
+	if got := keyFiles[1].Owners; !reflect.DeepEqual(got, []string{"@detectors"}) {
+		t.Errorf("expected key file owners, got %v", got)
+	}
+}
+
+func TestOwnershipDetector_GitHistoryFallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := runGitCommand(tmpDir, "init"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	commit := func(author, file string) {
+		t.Helper()
+		path := filepath.Join(tmpDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = f.WriteString(author + "\n")
+		_ = f.Close()
+		if err := runGitCommand(tmpDir, "add", "-A"); err != nil {
+			t.Fatal(err)
+		}
+		if err := runGitCommand(tmpDir, "-c", "user.name="+author, "-c", "user.email="+author+"@example.com", "commit", "-m", "update "+file); err != nil {
+			t.Fatalf("commit failed: %v", err)
+		}
+	}
+
+	commit("Alice", "internal/api/server.go")
+	commit("Alice", "internal/api/routes.go")
+	commit("Bob", "internal/api/server.go")
+	commit("Bob", "docs/guide.md")
+	commit("Carol", "go.mod")
+
+	ownership := NewOwnershipDetector(tmpDir, nil).Detect()
+	if ownership == nil || ownership.Source != OwnershipSourceGit {
+		t.Fatalf("expected git history ownership, got %+v", ownership)
+	}
+
+	resolver := newOwnerResolver(ownership)
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"internal/api", []string{"Alice", "Bob"}},
+		{"docs", []string{"Bob"}},
+		{"go.mod", []string{"Carol"}},
+	}
+	for _, tt := range tests {
+		if got := resolver.ownersFor(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+}
@@ -56,6 +56,7 @@ func (d *PatternDetector) detectCommentPatterns() []types.Convention {
 	goDocCount := 0
 	xmlDocCount := 0
 	todoCount := 0
+	todoFiles := 0
 	fixmeCount := 0
 
 	// Regex patterns
@@ -114,6 +115,7 @@ func (d *PatternDetector) detectCommentPatterns() []types.Convention {
 		if todoRegex.MatchString(contentStr) {
 			matches := todoRegex.FindAllString(contentStr, -1)
 			todoCount += len(matches)
+			todoFiles++
 			for _, match := range matches {
 				if strings.Contains(strings.ToUpper(match), "FIXME") {
 					fixmeCount++
@@ -126,50 +128,50 @@ func (d *PatternDetector) detectCommentPatterns() []types.Convention {
 
 	// Report detected patterns
 	if jsdocCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "JSDoc comments for function documentation",
 			Example:     "/** @param {string} name - User name */",
-		})
+		}, jsdocCount, 0, jsdocCount))
 	}
 
 	if javadocCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "Javadoc comments for class and method documentation",
 			Example:     "/** @param name the user name */",
-		})
+		}, javadocCount, 0, javadocCount))
 	}
 
 	if pythonDocCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "Google-style Python docstrings",
 			Example:     "\"\"\"Args:\\n    name: User name\\n\"\"\"",
-		})
+		}, pythonDocCount, 0, pythonDocCount))
 	}
 
 	if goDocCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "Go doc comments (start with function name)",
 			Example:     "// HandleRequest processes incoming HTTP requests",
-		})
+		}, goDocCount, 0, goDocCount))
 	}
 
 	if xmlDocCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "XML documentation comments (C#)",
 			Example:     "/// <summary>Handles the request</summary>",
-		})
+		}, xmlDocCount, 0, xmlDocCount))
 	}
 
 	if todoCount >= 10 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "documentation",
 			Description: "TODO/FIXME comments used for tracking work items",
-		})
+		}, todoCount, 0, todoFiles))
 	}
 
 	return conventions
@@ -278,10 +280,10 @@ func (d *PatternDetector) detectLoggingPatterns() []types.Convention {
 			"ruby":       "Ruby Logger / Rails.logger",
 		}
 
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "logging",
 			Description: loggerDescriptions[dominantLogger],
-		})
+		}, maxCount, 0, maxCount))
 	}
 
 	return conventions
@@ -339,25 +341,25 @@ func (d *PatternDetector) detectErrorHandling() []types.Convention {
 	}
 
 	if goErrorCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "error-handling",
 			Description: "Go-style explicit error checking (if err != nil)",
 			Example:     "if err != nil { return fmt.Errorf(\"context: %w\", err) }",
-		})
+		}, goErrorCount, 0, goErrorCount))
 	}
 
 	if resultTypeCount >= 3 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "error-handling",
 			Description: "Result/Option types for error handling (Rust-style)",
-		})
+		}, resultTypeCount, 0, resultTypeCount))
 	}
 
 	if asyncAwaitCount >= 5 {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, countedConvention(types.Convention{
 			Category:    "async",
 			Description: "Async/await pattern for asynchronous operations",
-		})
+		}, asyncAwaitCount, 0, asyncAwaitCount))
 	}
 
 	return conventions
@@ -377,47 +379,65 @@ func (d *PatternDetector) detectArchitecturalPatterns() []types.Convention {
 
 	// MVC pattern
 	if dirNames["models"] && dirNames["views"] && dirNames["controllers"] {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, d.directoryConvention(types.Convention{
 			Category:    "architecture",
 			Description: "MVC (Model-View-Controller) architecture",
-		})
+		}, "models", "views", "controllers"))
 	}
 
 	// Clean/Hexagonal architecture
 	if dirNames["domain"] && (dirNames["infrastructure"] || dirNames["adapters"]) {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, d.directoryConvention(types.Convention{
 			Category:    "architecture",
 			Description: "Clean/Hexagonal architecture (domain separation)",
-		})
+		}, "domain", "infrastructure", "adapters"))
 	}
 
 	// Feature-based/Module-based
 	if dirNames["features"] || dirNames["modules"] {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, d.directoryConvention(types.Convention{
 			Category:    "architecture",
 			Description: "Feature/Module-based architecture",
-		})
+		}, "features", "modules"))
 	}
 
 	// Repository pattern
 	if dirNames["repositories"] || dirNames["repository"] {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, d.directoryConvention(types.Convention{
 			Category:    "architecture",
 			Description: "Repository pattern for data access",
-		})
+		}, "repositories", "repository"))
 	}
 
 	// Service layer
 	if dirNames["services"] || dirNames["service"] {
-		conventions = append(conventions, types.Convention{
+		conventions = append(conventions, d.directoryConvention(types.Convention{
 			Category:    "architecture",
 			Description: "Service layer for business logic",
-		})
+		}, "services", "service"))
 	}
 
 	return conventions
 }
 
+// directoryConvention attaches the number of files under the directories
+// an architectural convention was inferred from
+func (d *PatternDetector) directoryConvention(conv types.Convention, dirs ...string) types.Convention {
+	files := 0
+	for _, f := range d.files {
+		if f.IsDir {
+			continue
+		}
+		for _, part := range strings.Split(filepath.ToSlash(filepath.Dir(f.Path)), "/") {
+			if sliceContainsString(dirs, strings.ToLower(part)) {
+				files++
+				break
+			}
+		}
+	}
+	return countedConvention(conv, files, 0, files)
+}
+
 // Helper function to check if file should be analyzed for documentation
 func isDocumentableFile(ext string) bool {
 	documentableExts := map[string]bool{
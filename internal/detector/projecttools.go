@@ -75,6 +75,7 @@ func (d *ProjectToolsDetector) detectSelfTool() *types.ProjectTool {
 
 	// Build usage examples from README or commands
 	usageExamples := d.extractUsageExamples()
+	usageExamples = d.appendCommandExamples(usageExamples)
 
 	// Determine when to use
 	whenToUse := d.determineWhenToUse()
@@ -226,6 +227,52 @@ func (d *ProjectToolsDetector) extractUsageExamples() []string {
 	return examples
 }
 
+// appendCommandExamples fills the usage examples up to 5 with invocations of
+// the subcommands found in the CLI command tree
+func (d *ProjectToolsDetector) appendCommandExamples(examples []string) []string {
+	if d.cliInfo == nil {
+		return examples
+	}
+
+	seen := make(map[string]bool)
+	for _, ex := range examples {
+		seen[ex] = true
+	}
+
+	var walk func(commands []types.CLICommand, prefix string)
+	walk = func(commands []types.CLICommand, prefix string) {
+		for _, cmd := range commands {
+			if len(examples) >= 5 {
+				return
+			}
+			path := cmd.Name
+			if prefix != "" {
+				path = prefix + " " + cmd.Name
+			}
+			// Leaf commands are the ones that do the actual work
+			if len(cmd.Subcommands) > 0 {
+				walk(cmd.Subcommands, path)
+				continue
+			}
+			example := strings.TrimSpace(path + " " + cmd.Args)
+			if !seen[example] && prefix != "" {
+				seen[example] = true
+				examples = append(examples, example)
+			}
+		}
+	}
+
+	for _, root := range d.cliInfo.Commands {
+		// A single root command is the project's own binary
+		name := root.Name
+		if len(d.cliInfo.Commands) == 1 {
+			name = d.projectName
+		}
+		walk(root.Subcommands, name)
+	}
+	return examples
+}
+
 // determineWhenToUse determines when Claude should use this tool
 func (d *ProjectToolsDetector) determineWhenToUse() string {
 	// Check README for semantic search, AI, or special capabilities
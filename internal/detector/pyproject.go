@@ -93,17 +93,26 @@ type BlackSection struct {
 
 // RuffSection represents [tool.ruff] section
 type RuffSection struct {
-	LineLength int             `toml:"line-length"`
-	Select     []string        `toml:"select"`
-	Ignore     []string        `toml:"ignore"`
-	FixableAll bool            `toml:"fixable"`
-	Lint       RuffLintSection `toml:"lint"` // [tool.ruff.lint] subsection
+	LineLength   int               `toml:"line-length"`
+	Select       []string          `toml:"select"`
+	ExtendSelect []string          `toml:"extend-select"`
+	Ignore       []string          `toml:"ignore"`
+	FixableAll   bool              `toml:"fixable"`
+	Lint         RuffLintSection   `toml:"lint"`   // [tool.ruff.lint] subsection
+	Format       RuffFormatSection `toml:"format"` // [tool.ruff.format] subsection
 }
 
 // RuffLintSection represents [tool.ruff.lint] section
 type RuffLintSection struct {
-	Select []string `toml:"select"`
-	Ignore []string `toml:"ignore"`
+	Select       []string `toml:"select"`
+	ExtendSelect []string `toml:"extend-select"`
+	Ignore       []string `toml:"ignore"`
+}
+
+// RuffFormatSection represents [tool.ruff.format] section
+type RuffFormatSection struct {
+	QuoteStyle  string `toml:"quote-style"`
+	IndentStyle string `toml:"indent-style"`
 }
 
 // MypySection represents [tool.mypy] section
@@ -111,6 +120,7 @@ type MypySection struct {
 	PythonVersion        string   `toml:"python_version"`
 	Strict               bool     `toml:"strict"`
 	StrictOptional       bool     `toml:"strict_optional"`
+	DisallowUntypedDefs  bool     `toml:"disallow_untyped_defs"`
 	IgnoreMissingImports bool     `toml:"ignore_missing_imports"`
 	Plugins              []string `toml:"plugins"`
 }
@@ -200,8 +210,11 @@ func (d *PyProjectDetector) Detect() *PyProjectInfo {
 		}
 	}
 
-	// Detect tools
+	// Detect tools and keep their style settings
 	info.Tools = d.detectTools(pyproject)
+	info.Black = pyproject.Tool.Black
+	info.Ruff = pyproject.Tool.Ruff
+	info.Mypy = pyproject.Tool.Mypy
 
 	// Detect build backend
 	info.BuildBackend = pyproject.BuildSystem.BuildBackend
@@ -222,6 +235,9 @@ type PyProjectInfo struct {
 	Tools           []string
 	BuildBackend    string
 	UsesPoetry      bool
+	Black           BlackSection
+	Ruff            RuffSection
+	Mypy            MypySection
 }
 
 // detectTools detects configured development tools
@@ -237,12 +253,12 @@ func (d *PyProjectDetector) detectTools(pyproject PyProject) []string {
 	}
 	// Check both [tool.ruff] and [tool.ruff.lint] sections
 	if len(pyproject.Tool.Ruff.Select) > 0 || pyproject.Tool.Ruff.LineLength > 0 ||
-		len(pyproject.Tool.Ruff.Lint.Select) > 0 {
+		len(pyproject.Tool.Ruff.Lint.Select) > 0 || pyproject.Tool.Ruff.Format.QuoteStyle != "" {
 		tools = append(tools, "ruff")
 	}
 	// Check for mypy config (various fields indicate presence)
 	if pyproject.Tool.Mypy.PythonVersion != "" || pyproject.Tool.Mypy.StrictOptional ||
-		pyproject.Tool.Mypy.Strict || len(pyproject.Tool.Mypy.Plugins) > 0 {
+		pyproject.Tool.Mypy.Strict || pyproject.Tool.Mypy.DisallowUntypedDefs || len(pyproject.Tool.Mypy.Plugins) > 0 {
 		tools = append(tools, "mypy")
 	}
 	// Check for coverage config
@@ -0,0 +1,456 @@
+package detector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Priyans-hu/argus/pkg/types"
+	"github.com/go-git/go-git/v5/plumbing"
+	"gopkg.in/yaml.v3"
+)
+
+// Changelog formats
+const (
+	ChangelogKeepAChangelog = "Keep a Changelog"
+	ChangelogGenerated      = "generated"
+	ChangelogCustom         = "custom"
+)
+
+// Release tool names
+const (
+	ReleaseToolGoReleaser      = "GoReleaser"
+	ReleaseToolSemanticRelease = "semantic-release"
+	ReleaseToolChangesets      = "Changesets"
+	ReleaseToolReleasePlease   = "release-please"
+	ReleaseToolCargoRelease    = "cargo-release"
+)
+
+// releaseToolConfigs lists the config files each release tool reads
+var releaseToolConfigs = []struct {
+	name    string
+	command string
+	files   []string
+	ci      []string // Workflow substrings that show the tool runs in CI
+}{
+	{
+		name:    ReleaseToolGoReleaser,
+		command: "goreleaser release --clean",
+		files:   []string{".goreleaser.yml", ".goreleaser.yaml", "goreleaser.yml", "goreleaser.yaml"},
+		ci:      []string{"goreleaser"},
+	},
+	{
+		name:    ReleaseToolSemanticRelease,
+		command: "npx semantic-release",
+		files: []string{
+			".releaserc", ".releaserc.json", ".releaserc.yaml", ".releaserc.yml",
+			".releaserc.js", ".releaserc.cjs", ".releaserc.mjs",
+			"release.config.js", "release.config.cjs", "release.config.mjs",
+		},
+		ci: []string{"semantic-release"},
+	},
+	{
+		name:    ReleaseToolChangesets,
+		command: "npx changeset",
+		files:   []string{".changeset/config.json"},
+		ci:      []string{"changesets/action"},
+	},
+	{
+		name:  ReleaseToolReleasePlease,
+		files: []string{"release-please-config.json", ".release-please-manifest.json"},
+		ci:    []string{"release-please"},
+	},
+	{
+		name:    ReleaseToolCargoRelease,
+		command: "cargo release",
+		files:   []string{"release.toml"},
+		ci:      []string{"cargo release", "cargo-release"},
+	},
+}
+
+// changelogNames are checked in order; the first existing file wins
+var changelogNames = []string{"CHANGELOG.md", "CHANGELOG", "CHANGES.md", "HISTORY.md", "changelog.md"}
+
+var (
+	changelogUnreleasedRegex = regexp.MustCompile(`(?mi)^##\s*\[?unreleased\]?`)
+	changelogKACSectionRegex = regexp.MustCompile(`(?m)^###\s+(Added|Changed|Deprecated|Removed|Fixed|Security)\s*$`)
+	changelogCompareRegex    = regexp.MustCompile(`(?m)^#{1,3}\s+\[?v?\d+\.\d+\.\d+[^\]]*\]?\(https?://[^)]*/compare/`)
+	changelogAnchorRegex     = regexp.MustCompile(`(?m)^<a name="v?\d+\.\d+\.\d+`)
+	changesetsSectionRegex   = regexp.MustCompile(`(?m)^###\s+(Major|Minor|Patch) Changes\s*$`)
+	goVersionRegex           = regexp.MustCompile(`(?m)^\s*(?:const|var)?\s*Version\s*(?:string\s*)?=\s*"([^"]+)"`)
+)
+
+// tagPatterns classify release tags. Order breaks ties between equally common patterns.
+var tagPatterns = []struct {
+	pattern string
+	regex   *regexp.Regexp
+}{
+	{"v<major>.<minor>.<patch>", regexp.MustCompile(`^v\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)},
+	{"<major>.<minor>.<patch>", regexp.MustCompile(`^\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)},
+	{"<package>@<version>", regexp.MustCompile(`^@?[^@\s]+@v?\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)},
+	{"<component>-v<version>", regexp.MustCompile(`^[\w.-]+-v\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)},
+	{"<path>/v<version>", regexp.MustCompile(`^[\w./-]+/v\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)},
+	{"v<major>.<minor>", regexp.MustCompile(`^v\d+\.\d+$`)},
+}
+
+// ReleaseDetector detects how a project is versioned and released
+type ReleaseDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewReleaseDetector creates a new release detector
+func NewReleaseDetector(rootPath string, files []types.FileInfo) *ReleaseDetector {
+	return &ReleaseDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// Detect returns the release process, or nil when the project shows no sign of one
+func (d *ReleaseDetector) Detect() *types.ReleaseInfo {
+	info := &types.ReleaseInfo{
+		Tools:        d.detectTools(),
+		VersionFiles: d.detectVersionFiles(),
+	}
+	info.Changelog = d.detectChangelog(info.Tools)
+	info.TagPattern, info.LatestTag = NewGitDetectorGoGit(d.rootPath).releaseTagPattern()
+
+	if len(info.Tools) == 0 && info.Changelog == nil && len(info.VersionFiles) == 0 && info.TagPattern == "" {
+		return nil
+	}
+	return info
+}
+
+// detectTools finds release tools by config file, package.json or CI workflow
+func (d *ReleaseDetector) detectTools() []types.ReleaseTool {
+	workflows := d.readWorkflows()
+	pkg := d.readPackageJSON()
+
+	var tools []types.ReleaseTool
+	for _, cfg := range releaseToolConfigs {
+		tool := types.ReleaseTool{Name: cfg.name, Command: cfg.command}
+		for _, name := range cfg.files {
+			if fileExists(filepath.Join(d.rootPath, name)) {
+				tool.ConfigFile = name
+				break
+			}
+		}
+
+		if tool.ConfigFile == "" {
+			switch cfg.name {
+			case ReleaseToolSemanticRelease:
+				if pkg["release"] != nil || packageHasDependency(pkg, "semantic-release") {
+					tool.ConfigFile = "package.json"
+				}
+			case ReleaseToolCargoRelease:
+				if content, err := os.ReadFile(filepath.Join(d.rootPath, "Cargo.toml")); err == nil &&
+					(strings.Contains(string(content), "[package.metadata.release]") || strings.Contains(string(content), "[workspace.metadata.release]")) {
+					tool.ConfigFile = "Cargo.toml"
+				}
+			}
+		}
+
+		for _, path := range sortedStringKeys(workflows) {
+			for _, marker := range cfg.ci {
+				if strings.Contains(workflows[path], marker) {
+					tool.CI = true
+					break
+				}
+			}
+			// release-please can run from its GitHub Action with no config file
+			if tool.CI && tool.ConfigFile == "" && cfg.name == ReleaseToolReleasePlease {
+				tool.ConfigFile = path
+			}
+			if tool.CI {
+				break
+			}
+		}
+
+		if tool.ConfigFile != "" {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// readWorkflows returns CI configuration contents keyed by path
+func (d *ReleaseDetector) readWorkflows() map[string]string {
+	workflows := make(map[string]string)
+	for _, cfg := range ciConfigFiles(d.rootPath) {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, cfg.path))
+		if err != nil {
+			continue
+		}
+		workflows[cfg.path] = string(content)
+	}
+	return workflows
+}
+
+// readPackageJSON returns the root package.json, or nil
+func (d *ReleaseDetector) readPackageJSON() map[string]interface{} {
+	data, err := readJSON(filepath.Join(d.rootPath, "package.json"))
+	if err != nil {
+		return nil
+	}
+	pkg, _ := data.(map[string]interface{})
+	return pkg
+}
+
+// packageHasDependency reports whether package.json depends on name
+func packageHasDependency(pkg map[string]interface{}, name string) bool {
+	for _, key := range []string{"dependencies", "devDependencies"} {
+		if deps, ok := pkg[key].(map[string]interface{}); ok && deps[name] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// detectChangelog finds the changelog and classifies its format
+func (d *ReleaseDetector) detectChangelog(tools []types.ReleaseTool) *types.Changelog {
+	for _, name := range changelogNames {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, name))
+		if err != nil {
+			continue
+		}
+		if len(content) > 65536 {
+			content = content[:65536]
+		}
+		changelog := classifyChangelog(string(content), tools)
+		changelog.Path = name
+		return changelog
+	}
+	return nil
+}
+
+// classifyChangelog tells a hand-maintained Keep a Changelog file apart from
+// one written by a release tool
+func classifyChangelog(content string, tools []types.ReleaseTool) *types.Changelog {
+	changelog := &types.Changelog{
+		Format:     ChangelogCustom,
+		Unreleased: changelogUnreleasedRegex.MatchString(content),
+	}
+
+	hasTool := func(name string) bool {
+		for _, t := range tools {
+			if t.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case strings.Contains(content, "git-cliff"):
+		changelog.Format, changelog.Generator = ChangelogGenerated, "git-cliff"
+	case strings.Contains(content, "auto-changelog"):
+		changelog.Format, changelog.Generator = ChangelogGenerated, "auto-changelog"
+	case changesetsSectionRegex.MatchString(content):
+		changelog.Format, changelog.Generator = ChangelogGenerated, ReleaseToolChangesets
+	case strings.Contains(content, "keepachangelog.com"):
+		changelog.Format = ChangelogKeepAChangelog
+	case changelogCompareRegex.MatchString(content) || changelogAnchorRegex.MatchString(content):
+		changelog.Format = ChangelogGenerated
+		switch {
+		case hasTool(ReleaseToolReleasePlease):
+			changelog.Generator = ReleaseToolReleasePlease
+		case hasTool(ReleaseToolSemanticRelease):
+			changelog.Generator = ReleaseToolSemanticRelease
+		default:
+			changelog.Generator = "conventional-changelog"
+		}
+	case changelog.Unreleased && changelogKACSectionRegex.MatchString(content):
+		changelog.Format = ChangelogKeepAChangelog
+	}
+
+	return changelog
+}
+
+// detectVersionFiles finds files that record the project version
+func (d *ReleaseDetector) detectVersionFiles() []types.VersionFile {
+	var versionFiles []types.VersionFile
+	add := func(path, version string) {
+		version = strings.TrimSpace(version)
+		if version != "" {
+			versionFiles = append(versionFiles, types.VersionFile{Path: path, Version: version})
+		}
+	}
+
+	for _, name := range []string{"VERSION", "VERSION.txt", "version.txt"} {
+		if version := readFirstLine(filepath.Join(d.rootPath, name)); version != "" {
+			add(name, version)
+		}
+	}
+
+	if pkg := d.readPackageJSON(); pkg != nil {
+		if version, ok := pkg["version"].(string); ok {
+			add("package.json", version)
+		}
+	}
+
+	var cargo struct {
+		Package struct {
+			Version interface{} `toml:"version"`
+		} `toml:"package"`
+		Workspace struct {
+			Package struct {
+				Version string `toml:"version"`
+			} `toml:"package"`
+		} `toml:"workspace"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(d.rootPath, "Cargo.toml"), &cargo); err == nil {
+		// version.workspace = true inherits from [workspace.package]
+		if version, ok := cargo.Package.Version.(string); ok {
+			add("Cargo.toml", version)
+		} else {
+			add("Cargo.toml", cargo.Workspace.Package.Version)
+		}
+	}
+
+	var pyproject struct {
+		Project struct {
+			Version string `toml:"version"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Version string `toml:"version"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(d.rootPath, "pyproject.toml"), &pyproject); err == nil {
+		if pyproject.Project.Version != "" {
+			add("pyproject.toml", pyproject.Project.Version)
+		} else {
+			add("pyproject.toml", pyproject.Tool.Poetry.Version)
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(d.rootPath, "gradle.properties")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "version" {
+				add("gradle.properties", value)
+				break
+			}
+		}
+	}
+
+	if data, err := readJSON(filepath.Join(d.rootPath, ".release-please-manifest.json")); err == nil {
+		if manifest, ok := data.(map[string]interface{}); ok {
+			if version, ok := manifest["."].(string); ok {
+				add(".release-please-manifest.json", version)
+			}
+		}
+	}
+
+	var nested []string
+	for _, f := range d.files {
+		if name := filepath.Base(f.Path); !f.IsDir && (name == "Chart.yaml" || name == "version.go") {
+			nested = append(nested, f.Path)
+		}
+	}
+	sort.Strings(nested)
+
+	for _, path := range nested {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+		if err != nil {
+			continue
+		}
+		if filepath.Base(path) == "Chart.yaml" {
+			var chart struct {
+				Version string `yaml:"version"`
+			}
+			if yaml.Unmarshal(content, &chart) == nil {
+				add(path, chart.Version)
+			}
+		} else if m := goVersionRegex.FindStringSubmatch(string(content)); m != nil {
+			add(path, m[1])
+		}
+	}
+
+	return versionFiles
+}
+
+// readFirstLine returns the first line of a small file, or ""
+func readFirstLine(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text())
+	}
+	return ""
+}
+
+// releaseTagPattern returns the most common release tag pattern and the tag
+// on the most recent commit following it
+func (d *GitDetectorGoGit) releaseTagPattern() (pattern, latest string) {
+	if !d.openRepo() {
+		return "", ""
+	}
+	refs, err := d.repo.Tags()
+	if err != nil {
+		return "", ""
+	}
+
+	type tag struct {
+		name string
+		when int64
+	}
+	byPattern := make(map[string][]tag)
+	_ = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		for _, p := range tagPatterns {
+			if !p.regex.MatchString(name) {
+				continue
+			}
+			t := tag{name: name}
+			if obj, err := d.repo.TagObject(ref.Hash()); err == nil {
+				if c, err := obj.Commit(); err == nil {
+					t.when = c.Committer.When.Unix()
+				}
+			} else if c, err := d.repo.CommitObject(ref.Hash()); err == nil {
+				t.when = c.Committer.When.Unix()
+			}
+			byPattern[p.pattern] = append(byPattern[p.pattern], t)
+			break
+		}
+		return nil
+	})
+
+	for _, p := range tagPatterns {
+		if len(byPattern[p.pattern]) > len(byPattern[pattern]) {
+			pattern = p.pattern
+		}
+	}
+	if pattern == "" {
+		return "", ""
+	}
+
+	tags := byPattern[pattern]
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].when != tags[j].when {
+			return tags[i].when > tags[j].when
+		}
+		return tags[i].name > tags[j].name
+	})
+	return pattern, tags[0].name
+}
+
+// sortedStringKeys returns the keys of m in order
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestReleaseDetector_Tools(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		".goreleaser.yaml":              "builds:\n  - main: ./cmd/app\n",
+		"package.json":                  `{"name": "app", "version": "2.3.0", "devDependencies": {"semantic-release": "^22.0.0"}}`,
+		"Cargo.toml":                    "[package]\nname = \"app\"\nversion = \"0.4.1\"\n\n[package.metadata.release]\npublish = false\n",
+		".github/workflows/release.yml": "jobs:\n  release:\n    steps:\n      - uses: googleapis/release-please-action@v4\n      - uses: goreleaser/goreleaser-action@v5\n",
+	})
+
+	info := NewReleaseDetector(tmpDir, files).Detect()
+	if info == nil {
+		t.Fatal("expected release info")
+	}
+
+	want := []types.ReleaseTool{
+		{Name: ReleaseToolGoReleaser, ConfigFile: ".goreleaser.yaml", Command: "goreleaser release --clean", CI: true},
+		{Name: ReleaseToolSemanticRelease, ConfigFile: "package.json", Command: "npx semantic-release"},
+		{Name: ReleaseToolReleasePlease, ConfigFile: ".github/workflows/release.yml", CI: true},
+		{Name: ReleaseToolCargoRelease, ConfigFile: "Cargo.toml", Command: "cargo release"},
+	}
+	if !reflect.DeepEqual(info.Tools, want) {
+		t.Errorf("expected tools %+v, got %+v", want, info.Tools)
+	}
+
+	wantVersions := []types.VersionFile{{Path: "package.json", Version: "2.3.0"}, {Path: "Cargo.toml", Version: "0.4.1"}}
+	if !reflect.DeepEqual(info.VersionFiles, wantVersions) {
+		t.Errorf("expected version files %+v, got %+v", wantVersions, info.VersionFiles)
+	}
+}
+
+func TestReleaseDetector_VersionFiles(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"VERSION":                     "1.0.2\n",
+		"pyproject.toml":              "[tool.poetry]\nname = \"app\"\nversion = \"0.9.0\"\n",
+		"charts/app/Chart.yaml":       "apiVersion: v2\nname: app\nversion: 0.1.5\n",
+		"internal/version/version.go": "package version\n\n// Version is set at build time\nvar Version = \"1.0.2\"\n",
+	})
+
+	got := NewReleaseDetector(tmpDir, files).detectVersionFiles()
+	want := []types.VersionFile{
+		{Path: "VERSION", Version: "1.0.2"},
+		{Path: "pyproject.toml", Version: "0.9.0"},
+		{Path: "charts/app/Chart.yaml", Version: "0.1.5"},
+		{Path: "internal/version/version.go", Version: "1.0.2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestClassifyChangelog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tools   []types.ReleaseTool
+		want    types.Changelog
+	}{
+		{
+			name:    "keep a changelog",
+			content: "# Changelog\n\nThe format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).\n\n## [Unreleased]\n\n### Added\n- Feature\n",
+			want:    types.Changelog{Format: ChangelogKeepAChangelog, Unreleased: true},
+		},
+		{
+			name:    "keep a changelog without link",
+			content: "# Changelog\n\n## [Unreleased]\n\n### Fixed\n- Bug\n\n## [1.0.0] - 2024-01-01\n",
+			want:    types.Changelog{Format: ChangelogKeepAChangelog, Unreleased: true},
+		},
+		{
+			name:    "release-please",
+			content: "# Changelog\n\n## [1.2.0](https://github.com/acme/app/compare/v1.1.0...v1.2.0) (2024-03-01)\n\n### Features\n",
+			tools:   []types.ReleaseTool{{Name: ReleaseToolReleasePlease}},
+			want:    types.Changelog{Format: ChangelogGenerated, Generator: ReleaseToolReleasePlease},
+		},
+		{
+			name:    "conventional-changelog",
+			content: "<a name=\"1.0.0\"></a>\n# 1.0.0 (2024-01-01)\n",
+			want:    types.Changelog{Format: ChangelogGenerated, Generator: "conventional-changelog"},
+		},
+		{
+			name:    "changesets",
+			content: "# @acme/ui\n\n## 2.1.0\n\n### Minor Changes\n\n- abc123: add button\n",
+			want:    types.Changelog{Format: ChangelogGenerated, Generator: ReleaseToolChangesets},
+		},
+		{
+			name:    "custom",
+			content: "# Release notes\n\n## 1.0.0\n\nFirst release.\n",
+			want:    types.Changelog{Format: ChangelogCustom},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyChangelog(tt.content, tt.tools); !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestGitDetectorGoGit_ReleaseTagPattern(t *testing.T) {
+	tmpDir, _ := writeProjectFixture(t, map[string]string{"README.md": "# app\n"})
+	if err := runGitCommand(tmpDir, "init"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	commit := func(message string) {
+		t.Helper()
+		if err := runGitCommand(tmpDir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", message); err != nil {
+			t.Fatalf("commit failed: %v", err)
+		}
+	}
+	tag := func(name string) {
+		t.Helper()
+		if err := runGitCommand(tmpDir, "tag", name); err != nil {
+			t.Fatalf("tag failed: %v", err)
+		}
+	}
+
+	commit("initial")
+	tag("v1.0.0")
+	tag("nightly")
+	commit("fix")
+	tag("v1.0.1")
+	tag("1.0.1")
+	if err := runGitCommand(tmpDir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "tag", "-a", "v1.1.0-rc.1", "-m", "rc"); err != nil {
+		t.Fatalf("annotated tag failed: %v", err)
+	}
+
+	pattern, latest := NewGitDetectorGoGit(tmpDir).releaseTagPattern()
+	if pattern != "v<major>.<minor>.<patch>" {
+		t.Errorf("expected v-prefixed semver tags, got %q", pattern)
+	}
+	if latest != "v1.1.0-rc.1" {
+		t.Errorf("expected latest tag v1.1.0-rc.1, got %q", latest)
+	}
+}
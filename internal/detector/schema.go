@@ -0,0 +1,1122 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// SchemaDetector parses database migrations and ORM model definitions into a
+// table summary (Prisma, golang-migrate, goose, Flyway, dbmate, sqlx, plain
+// SQL, Alembic, Django models, TypeORM entities and Rails schema.rb)
+type SchemaDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewSchemaDetector creates a new database schema detector
+func NewSchemaDetector(rootPath string, files []types.FileInfo) *SchemaDetector {
+	return &SchemaDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// schemaBuilder accumulates tables in the order they are first defined so
+// ALTER/DROP statements in later migrations apply to earlier CREATEs
+type schemaBuilder struct {
+	tables   map[string]*types.SchemaTable
+	order    []string
+	tools    []string
+	dirs     []string
+	models   map[string]string // ORM model name -> table name
+	toolSet  map[string]bool
+	dirSet   map[string]bool
+	toolDirs map[string]string // first migration directory seen per tool
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		tables:   make(map[string]*types.SchemaTable),
+		models:   make(map[string]string),
+		toolSet:  make(map[string]bool),
+		dirSet:   make(map[string]bool),
+		toolDirs: make(map[string]string),
+	}
+}
+
+// table returns the table with the given name, creating it if needed
+func (b *schemaBuilder) table(name, file string) *types.SchemaTable {
+	key := strings.ToLower(name)
+	if t, ok := b.tables[key]; ok {
+		return t
+	}
+	t := &types.SchemaTable{Name: name, File: file}
+	b.tables[key] = t
+	b.order = append(b.order, key)
+	return t
+}
+
+// model registers an ORM model and returns its table
+func (b *schemaBuilder) model(model, table, file string) *types.SchemaTable {
+	b.models[model] = table
+	t := b.table(table, file)
+	if model != table {
+		t.Model = model
+	}
+	return t
+}
+
+// drop removes a table so a later CREATE starts it afresh at the end of the order
+func (b *schemaBuilder) drop(name string) {
+	key := strings.ToLower(name)
+	if _, ok := b.tables[key]; !ok {
+		return
+	}
+	delete(b.tables, key)
+	for i, k := range b.order {
+		if k == key {
+			b.order = append(b.order[:i], b.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (b *schemaBuilder) addTool(tool string) {
+	if !b.toolSet[tool] {
+		b.toolSet[tool] = true
+		b.tools = append(b.tools, tool)
+	}
+}
+
+func (b *schemaBuilder) addDir(tool, dir string) {
+	dir = filepath.ToSlash(dir)
+	if dir == "" || dir == "." {
+		return
+	}
+	if _, ok := b.toolDirs[tool]; !ok {
+		b.toolDirs[tool] = dir
+	}
+	if !b.dirSet[dir] {
+		b.dirSet[dir] = true
+		b.dirs = append(b.dirs, dir)
+	}
+}
+
+// column returns the named column of t, creating it if needed
+func schemaColumn(t *types.SchemaTable, name string) *types.SchemaColumn {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	t.Columns = append(t.Columns, types.SchemaColumn{Name: name})
+	return &t.Columns[len(t.Columns)-1]
+}
+
+func dropSchemaColumn(t *types.SchemaTable, name string) {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+			return
+		}
+	}
+}
+
+// Detect parses all schema sources and returns nil when none are found
+func (d *SchemaDetector) Detect() *types.DatabaseSchema {
+	files := make([]types.FileInfo, 0, len(d.files))
+	for _, f := range d.files {
+		if !f.IsDir && !strings.Contains(f.Path, "node_modules") {
+			files = append(files, f)
+		}
+	}
+	// Migration file names sort in apply order
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	b := newSchemaBuilder()
+	var alembicFiles []alembicRevision
+	hasPrisma := false
+	for _, f := range files {
+		if f.Extension == ".prisma" {
+			hasPrisma = true
+		}
+	}
+
+	for _, f := range files {
+		switch {
+		case f.Extension == ".prisma":
+			if content, ok := d.read(f.Path); ok {
+				d.parsePrisma(b, f.Path, content)
+			}
+		case f.Extension == ".sql":
+			// Prisma generates migration.sql from schema.prisma
+			if hasPrisma && f.Name == "migration.sql" {
+				continue
+			}
+			if content, ok := d.read(f.Path); ok {
+				d.parseSQLMigration(b, f, content)
+			}
+		case filepath.ToSlash(f.Path) == "db/schema.rb" || strings.HasSuffix(filepath.ToSlash(f.Path), "/db/schema.rb"):
+			if content, ok := d.read(f.Path); ok {
+				d.parseRailsSchema(b, f.Path, content)
+			}
+		case f.Extension == ".rb" && strings.Contains(filepath.ToSlash(f.Path), "db/migrate/"):
+			b.addTool("Rails")
+			b.addDir("Rails", filepath.Dir(f.Path))
+		case f.Extension == ".py" && strings.Contains(filepath.ToSlash(f.Path), "/versions/"):
+			if content, ok := d.read(f.Path); ok && strings.Contains(content, "from alembic import op") {
+				alembicFiles = append(alembicFiles, newAlembicRevision(f.Path, content))
+			}
+		case f.Name == "models.py" || (f.Extension == ".py" && filepath.Base(filepath.Dir(f.Path)) == "models"):
+			if content, ok := d.read(f.Path); ok && strings.Contains(content, "models.") {
+				parseDjangoModels(b, f.Path, content)
+			}
+		case (f.Extension == ".ts" || f.Extension == ".js") && !strings.Contains(f.Path, ".spec.") && !strings.Contains(f.Path, ".test."):
+			if content, ok := d.read(f.Path); ok {
+				if strings.Contains(content, "@Entity(") {
+					parseTypeORMEntities(b, f.Path, content)
+				} else if strings.Contains(content, "implements MigrationInterface") {
+					b.addDir("TypeORM", filepath.Dir(f.Path))
+				}
+			}
+		}
+	}
+
+	for _, rev := range orderAlembicRevisions(alembicFiles) {
+		parseAlembicRevision(b, rev)
+	}
+
+	if len(b.order) == 0 && len(b.tools) == 0 {
+		return nil
+	}
+
+	schema := &types.DatabaseSchema{
+		Tools:         b.tools,
+		MigrationDirs: b.dirs,
+		Commands:      d.migrationCommands(b),
+	}
+	for _, key := range b.order {
+		t, ok := b.tables[key]
+		if !ok {
+			continue // dropped by a later migration
+		}
+		// Point ORM relations at table names
+		for i := range t.Relations {
+			if table, ok := b.models[t.Relations[i].Target]; ok {
+				t.Relations[i].Target = table
+			}
+		}
+		schema.Tables = append(schema.Tables, *t)
+	}
+	return schema
+}
+
+func (d *SchemaDetector) read(relPath string) (string, bool) {
+	content, err := os.ReadFile(filepath.Join(d.rootPath, relPath))
+	if err != nil || len(content) > 500000 {
+		return "", false
+	}
+	return string(content), true
+}
+
+// migrationCommands returns the commands to create and apply a migration for
+// each detected tool
+func (d *SchemaDetector) migrationCommands(b *schemaBuilder) []types.Command {
+	dir := func(tool, fallback string) string {
+		if d, ok := b.toolDirs[tool]; ok {
+			return d
+		}
+		return fallback
+	}
+
+	var commands []types.Command
+	add := func(create, apply string) {
+		if create != "" {
+			commands = append(commands, types.Command{Name: create, Description: "Create a new migration"})
+		}
+		commands = append(commands, types.Command{Name: apply, Description: "Apply pending migrations"})
+	}
+
+	for _, tool := range b.tools {
+		switch tool {
+		case "Prisma":
+			add("npx prisma migrate dev --name <name>", "npx prisma migrate deploy")
+		case "golang-migrate":
+			dir := dir(tool, "migrations")
+			add("migrate create -ext sql -dir "+dir+" -seq <name>", "migrate -path "+dir+" -database \"$DATABASE_URL\" up")
+		case "goose":
+			dir := dir(tool, "migrations")
+			add("goose -dir "+dir+" create <name> sql", "goose -dir "+dir+" up")
+		case "Flyway":
+			add("", "flyway migrate")
+		case "dbmate":
+			add("dbmate new <name>", "dbmate up")
+		case "sqlx":
+			add("sqlx migrate add <name>", "sqlx migrate run")
+		case "Alembic":
+			add("alembic revision --autogenerate -m \"<message>\"", "alembic upgrade head")
+		case "Django":
+			manage := "manage.py"
+			for _, f := range d.files {
+				if f.Name == "manage.py" {
+					manage = filepath.ToSlash(f.Path)
+					break
+				}
+			}
+			add("python "+manage+" makemigrations", "python "+manage+" migrate")
+		case "TypeORM":
+			add("npx typeorm migration:create "+dir(tool, "src/migrations")+"/<Name>", "npx typeorm migration:run -d <data-source>")
+		case "Rails":
+			add("bin/rails generate migration <Name>", "bin/rails db:migrate")
+		}
+	}
+	return commands
+}
+
+// =============================================================================
+// Prisma
+// =============================================================================
+
+var (
+	prismaBlockRegex    = regexp.MustCompile(`^\s*(model|enum|type|view)\s+(\w+)\s*\{`)
+	prismaFieldRegex    = regexp.MustCompile(`^\s*(\w+)\s+(\w+)(\[\])?(\?)?\s*(.*)$`)
+	prismaMapRegex      = regexp.MustCompile(`@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	prismaTableMapRegex = regexp.MustCompile(`@@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	prismaCompoundIDRe  = regexp.MustCompile(`@@id\(\s*(?:fields:\s*)?\[([^\]]*)\]`)
+	prismaRelationRegex = regexp.MustCompile(`@relation\([^)]*fields:\s*\[([^\]]*)\]`)
+
+	prismaScalars = map[string]bool{
+		"String": true, "Int": true, "BigInt": true, "Float": true, "Decimal": true,
+		"Boolean": true, "DateTime": true, "Json": true, "Bytes": true, "Unsupported": true,
+	}
+)
+
+func (d *SchemaDetector) parsePrisma(b *schemaBuilder, path, content string) {
+	lines := strings.Split(content, "\n")
+
+	// Enums and composite types are scalar columns, not relations
+	scalars := make(map[string]bool)
+	for _, line := range lines {
+		if m := prismaBlockRegex.FindStringSubmatch(line); m != nil && m[1] != "model" {
+			scalars[m[2]] = true
+		}
+	}
+
+	found := false
+	var t *types.SchemaTable
+	tableName := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if t == nil {
+			if m := prismaBlockRegex.FindStringSubmatch(line); m != nil && m[1] == "model" {
+				t = &types.SchemaTable{Name: m[2], File: path}
+				tableName = m[2]
+			}
+			continue
+		}
+
+		switch {
+		case trimmed == "}":
+			// The table name is only known once @@map has been seen
+			table := b.model(t.Name, tableName, path)
+			table.Columns = append(table.Columns, t.Columns...)
+			table.Relations = append(table.Relations, t.Relations...)
+			t = nil
+			found = true
+		case strings.HasPrefix(trimmed, "@@"):
+			if m := prismaTableMapRegex.FindStringSubmatch(trimmed); m != nil {
+				tableName = m[1]
+			}
+			if m := prismaCompoundIDRe.FindStringSubmatch(trimmed); m != nil {
+				for _, col := range strings.Split(m[1], ",") {
+					schemaColumn(t, strings.TrimSpace(col)).Primary = true
+				}
+			}
+		case trimmed == "" || strings.HasPrefix(trimmed, "//"):
+		default:
+			m := prismaFieldRegex.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			name, typ, list, attrs := m[1], m[2], m[3] != "", m[5]
+			if !prismaScalars[typ] && !scalars[typ] {
+				rel := types.SchemaRelation{Target: typ, Kind: "one-to-one"}
+				if list {
+					rel.Kind = "has-many"
+				} else if fk := prismaRelationRegex.FindStringSubmatch(attrs); fk != nil {
+					rel.Kind = "belongs-to"
+					rel.Column = strings.TrimSpace(strings.Split(fk[1], ",")[0])
+				}
+				t.Relations = append(t.Relations, rel)
+				continue
+			}
+			if mm := prismaMapRegex.FindStringSubmatch(attrs); mm != nil {
+				name = mm[1]
+			}
+			col := schemaColumn(t, name)
+			col.Type = typ
+			if list {
+				col.Type += "[]"
+			}
+			col.Primary = col.Primary || strings.Contains(attrs, "@id")
+			col.Unique = strings.Contains(attrs, "@unique")
+		}
+	}
+
+	if found {
+		b.addTool("Prisma")
+		migrations := filepath.Join(filepath.Dir(path), "migrations")
+		if info, err := os.Stat(filepath.Join(d.rootPath, migrations)); err == nil && info.IsDir() {
+			b.addDir("Prisma", migrations)
+		}
+	}
+}
+
+// =============================================================================
+// SQL migrations
+// =============================================================================
+
+var (
+	sqlStatementRegex   = regexp.MustCompile(`(?is)\b(CREATE\s+(?:UNLOGGED\s+|TEMPORARY\s+|TEMP\s+)?TABLE|ALTER\s+TABLE|DROP\s+TABLE)\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?(?:ONLY\s+)?([\w."\x60\[\]]+)`)
+	sqlReferencesRegex  = regexp.MustCompile(`(?i)\bREFERENCES\s+([\w."\x60\[\]]+)(?:\s*\(\s*([\w"\x60\[\]]+)\s*\))?`)
+	sqlForeignKeyRegex  = regexp.MustCompile(`(?i)FOREIGN\s+KEY\s*\(([^)]*)\)\s*REFERENCES\s+([\w."\x60\[\]]+)`)
+	sqlPrimaryKeyRegex  = regexp.MustCompile(`(?i)^PRIMARY\s+KEY\s*\(([^)]*)\)`)
+	sqlUniqueRegex      = regexp.MustCompile(`(?i)^UNIQUE(?:\s+(?:KEY|INDEX)\s*[\w"\x60]*)?\s*\(([^)]*)\)`)
+	sqlConstraintPrefix = regexp.MustCompile(`(?i)^CONSTRAINT\s+[\w"\x60\[\]]+\s+`)
+	sqlAddColumnRegex   = regexp.MustCompile(`(?i)^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(.*)$`)
+	sqlDropColumnRegex  = regexp.MustCompile(`(?i)^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?([\w"\x60\[\]]+)`)
+	sqlUpMigrationRegex = regexp.MustCompile(`^\d+_.+\.up\.sql$`)
+	sqlFlywayRegex      = regexp.MustCompile(`^V\d+(?:[._]\d+)*__.+\.sql$`)
+	sqlTimestampRegex   = regexp.MustCompile(`^\d{8,}_.+\.sql$`)
+)
+
+func (d *SchemaDetector) parseSQLMigration(b *schemaBuilder, f types.FileInfo, content string) {
+	if strings.HasSuffix(f.Name, ".down.sql") {
+		return
+	}
+
+	// Only the "up" half of single-file migrations describes the schema
+	tool := ""
+	switch {
+	case strings.Contains(content, "+goose Up"):
+		tool = "goose"
+		if i := strings.Index(content, "+goose Down"); i >= 0 {
+			content = content[:i]
+		}
+	case strings.Contains(content, "migrate:up"):
+		tool = "dbmate"
+		if i := strings.Index(content, "migrate:down"); i >= 0 {
+			content = content[:i]
+		}
+	case sqlFlywayRegex.MatchString(f.Name):
+		tool = "Flyway"
+	case sqlUpMigrationRegex.MatchString(f.Name) || sqlTimestampRegex.MatchString(f.Name):
+		if cargo, ok := d.read("Cargo.toml"); ok && strings.Contains(cargo, "sqlx") {
+			tool = "sqlx"
+		} else if sqlUpMigrationRegex.MatchString(f.Name) {
+			tool = "golang-migrate"
+		}
+	}
+
+	content = stripSQLComments(content)
+	found := false
+	for _, loc := range sqlStatementRegex.FindAllStringSubmatchIndex(content, -1) {
+		verb := strings.ToUpper(strings.Fields(content[loc[2]:loc[3]])[0])
+		name := sqlIdentifier(content[loc[4]:loc[5]])
+		rest := content[loc[1]:]
+		switch verb {
+		case "CREATE":
+			open := strings.Index(rest, "(")
+			if open < 0 || strings.TrimSpace(rest[:open]) != "" {
+				continue // CREATE TABLE ... AS SELECT
+			}
+			defs, _ := splitCallArgs(rest[open+1:])
+			t := b.table(name, f.Path)
+			for _, def := range defs {
+				applySQLDefinition(t, def)
+			}
+			found = true
+		case "ALTER":
+			end := strings.Index(rest, ";")
+			if end < 0 {
+				end = len(rest)
+			}
+			actions, _ := splitCallArgs(rest[:end])
+			t := b.table(name, f.Path)
+			for _, action := range actions {
+				action = strings.TrimSpace(action)
+				if m := sqlDropColumnRegex.FindStringSubmatch(action); m != nil && !strings.HasPrefix(strings.ToUpper(m[1]), "CONSTRAINT") {
+					dropSchemaColumn(t, sqlIdentifier(m[1]))
+				} else if m := sqlAddColumnRegex.FindStringSubmatch(action); m != nil {
+					applySQLDefinition(t, m[1])
+				}
+			}
+			found = true
+		case "DROP":
+			b.drop(name)
+		}
+	}
+
+	if found || tool != "" {
+		if tool == "" {
+			tool = "SQL"
+		} else {
+			b.addDir(tool, filepath.Dir(f.Path))
+		}
+		b.addTool(tool)
+	}
+}
+
+// applySQLDefinition applies one column or table constraint definition
+func applySQLDefinition(t *types.SchemaTable, def string) {
+	def = strings.TrimSpace(sqlConstraintPrefix.ReplaceAllString(strings.TrimSpace(def), ""))
+	if def == "" {
+		return
+	}
+	upper := strings.ToUpper(def)
+
+	switch {
+	case sqlPrimaryKeyRegex.MatchString(def):
+		for _, col := range strings.Split(sqlPrimaryKeyRegex.FindStringSubmatch(def)[1], ",") {
+			schemaColumn(t, sqlIdentifier(col)).Primary = true
+		}
+	case strings.HasPrefix(upper, "FOREIGN KEY"):
+		if m := sqlForeignKeyRegex.FindStringSubmatch(def); m != nil {
+			t.Relations = append(t.Relations, types.SchemaRelation{
+				Column: sqlIdentifier(strings.Split(m[1], ",")[0]),
+				Target: sqlIdentifier(m[2]),
+				Kind:   "belongs-to",
+			})
+		}
+	case sqlUniqueRegex.MatchString(def):
+		cols := strings.Split(sqlUniqueRegex.FindStringSubmatch(def)[1], ",")
+		if len(cols) == 1 {
+			schemaColumn(t, sqlIdentifier(cols[0])).Unique = true
+		}
+	case strings.HasPrefix(upper, "CHECK"), strings.HasPrefix(upper, "INDEX"), strings.HasPrefix(upper, "KEY"),
+		strings.HasPrefix(upper, "EXCLUDE"), strings.HasPrefix(upper, "FULLTEXT"), strings.HasPrefix(upper, "SPATIAL"),
+		strings.HasPrefix(upper, "LIKE"):
+	default:
+		fields := strings.Fields(def)
+		col := schemaColumn(t, sqlIdentifier(fields[0]))
+		if len(fields) > 1 {
+			col.Type = strings.ToLower(fields[1])
+		}
+		col.Primary = col.Primary || strings.Contains(upper, "PRIMARY KEY")
+		col.Unique = col.Unique || strings.Contains(upper, " UNIQUE")
+		if m := sqlReferencesRegex.FindStringSubmatch(def); m != nil {
+			t.Relations = append(t.Relations, types.SchemaRelation{
+				Column: col.Name,
+				Target: sqlIdentifier(m[1]),
+				Kind:   "belongs-to",
+			})
+		}
+	}
+}
+
+// sqlIdentifier strips quoting and schema qualification from an identifier
+func sqlIdentifier(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.Trim(s, "\"`[] ")
+}
+
+// stripSQLComments removes -- line comments and /* */ block comments
+func stripSQLComments(content string) string {
+	var out strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "--"); i >= 0 {
+			line = line[:i]
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+	s := out.String()
+	for {
+		start := strings.Index(s, "/*")
+		if start < 0 {
+			return s
+		}
+		end := strings.Index(s[start:], "*/")
+		if end < 0 {
+			return s[:start]
+		}
+		s = s[:start] + s[start+end+2:]
+	}
+}
+
+// =============================================================================
+// Alembic
+// =============================================================================
+
+var (
+	alembicRevisionRegex = regexp.MustCompile(`(?m)^revision(?:\s*:\s*str)?\s*=\s*['"]([^'"]+)['"]`)
+	alembicDownRegex     = regexp.MustCompile(`(?m)^down_revision(?:\s*:\s*[^=]+)?\s*=\s*['"]([^'"]+)['"]`)
+	alembicOpRegex       = regexp.MustCompile(`\bop\.(create_table|add_column|drop_column|drop_table|create_foreign_key)\s*\(`)
+)
+
+// alembicRevision is one file in an Alembic versions directory
+type alembicRevision struct {
+	path     string
+	content  string
+	revision string
+	down     string
+}
+
+func newAlembicRevision(path, content string) alembicRevision {
+	rev := alembicRevision{path: path, content: content}
+	if m := alembicRevisionRegex.FindStringSubmatch(content); m != nil {
+		rev.revision = m[1]
+	}
+	if m := alembicDownRegex.FindStringSubmatch(content); m != nil {
+		rev.down = m[1]
+	}
+	// Only upgrade() describes the resulting schema
+	if i := strings.Index(rev.content, "def downgrade"); i >= 0 {
+		rev.content = rev.content[:i]
+	}
+	return rev
+}
+
+// orderAlembicRevisions follows the down_revision chain from the base
+// revision, since Alembic file names are not ordered
+func orderAlembicRevisions(revs []alembicRevision) []alembicRevision {
+	children := make(map[string][]int)
+	for i, rev := range revs {
+		children[rev.down] = append(children[rev.down], i)
+	}
+
+	var ordered []alembicRevision
+	visited := make(map[int]bool)
+	var visit func(parent string)
+	visit = func(parent string) {
+		for _, i := range children[parent] {
+			if !visited[i] {
+				visited[i] = true
+				ordered = append(ordered, revs[i])
+				visit(revs[i].revision)
+			}
+		}
+	}
+	visit("")
+	for i, rev := range revs {
+		if !visited[i] {
+			ordered = append(ordered, rev)
+		}
+	}
+	return ordered
+}
+
+func parseAlembicRevision(b *schemaBuilder, rev alembicRevision) {
+	b.addTool("Alembic")
+	b.addDir("Alembic", filepath.Dir(rev.path))
+
+	for _, loc := range alembicOpRegex.FindAllStringSubmatchIndex(rev.content, -1) {
+		op := rev.content[loc[2]:loc[3]]
+		args, _ := splitCallArgs(rev.content[loc[1]:])
+		if len(args) == 0 || !isQuoted(args[0]) {
+			continue
+		}
+		name := unquote(args[0])
+
+		switch op {
+		case "create_table":
+			t := b.table(name, rev.path)
+			for _, arg := range args[1:] {
+				applyAlembicArg(t, arg)
+			}
+		case "add_column":
+			if len(args) > 1 {
+				applyAlembicArg(b.table(name, rev.path), args[1])
+			}
+		case "drop_column":
+			if len(args) > 1 {
+				dropSchemaColumn(b.table(name, rev.path), unquote(args[1]))
+			}
+		case "drop_table":
+			b.drop(name)
+		case "create_foreign_key":
+			// op.create_foreign_key(name, source, referent, local_cols, remote_cols)
+			if len(args) >= 4 && isQuoted(args[1]) && isQuoted(args[2]) {
+				cols := pythonStringList(args[3])
+				rel := types.SchemaRelation{Target: unquote(args[2]), Kind: "belongs-to"}
+				if len(cols) > 0 {
+					rel.Column = cols[0]
+				}
+				t := b.table(unquote(args[1]), rev.path)
+				t.Relations = append(t.Relations, rel)
+			}
+		}
+	}
+}
+
+// applyAlembicArg applies a sa.Column or table constraint argument
+func applyAlembicArg(t *types.SchemaTable, arg string) {
+	callee := calleeName(arg)
+	open := strings.Index(arg, "(")
+	if open < 0 {
+		return
+	}
+	inner, _ := splitCallArgs(arg[open+1:])
+	if len(inner) == 0 {
+		return
+	}
+
+	switch callee[strings.LastIndex(callee, ".")+1:] {
+	case "Column":
+		if !isQuoted(inner[0]) {
+			return
+		}
+		col := schemaColumn(t, unquote(inner[0]))
+		for _, opt := range inner[1:] {
+			switch {
+			case opt == "primary_key=True":
+				col.Primary = true
+			case opt == "unique=True":
+				col.Unique = true
+			case strings.Contains(opt, "ForeignKey("):
+				if refs := pythonStringList(opt[strings.Index(opt, "(")+1:]); len(refs) > 0 {
+					target := refs[0]
+					if i := strings.Index(target, "."); i >= 0 {
+						target = target[:i]
+					}
+					t.Relations = append(t.Relations, types.SchemaRelation{Column: col.Name, Target: target, Kind: "belongs-to"})
+				}
+			case col.Type == "" && !isKeyedArg(opt):
+				typ := calleeName(opt)
+				col.Type = typ[strings.LastIndex(typ, ".")+1:]
+			}
+		}
+	case "PrimaryKeyConstraint":
+		for _, a := range inner {
+			if isQuoted(a) {
+				schemaColumn(t, unquote(a)).Primary = true
+			}
+		}
+	case "UniqueConstraint":
+		if len(inner) == 1 && isQuoted(inner[0]) {
+			schemaColumn(t, unquote(inner[0])).Unique = true
+		}
+	case "ForeignKeyConstraint":
+		cols := pythonStringList(inner[0])
+		if len(inner) > 1 {
+			if refs := pythonStringList(inner[1]); len(refs) > 0 && len(cols) > 0 {
+				target := refs[0]
+				if i := strings.Index(target, "."); i >= 0 {
+					target = target[:i]
+				}
+				t.Relations = append(t.Relations, types.SchemaRelation{Column: cols[0], Target: target, Kind: "belongs-to"})
+			}
+		}
+	}
+}
+
+func isKeyedArg(arg string) bool {
+	_, _, keyed := splitKeyedArg(arg)
+	return keyed
+}
+
+// pythonStringList returns the string literals in a list literal or argument
+// list: ['a', 'b'] or 'a', 'b')
+func pythonStringList(s string) []string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimPrefix(s, "(")
+	args, _ := splitCallArgs(s)
+	var values []string
+	for _, a := range args {
+		if isQuoted(a) {
+			values = append(values, unquote(a))
+		}
+	}
+	return values
+}
+
+// =============================================================================
+// Django
+// =============================================================================
+
+var (
+	djangoClassRegex   = regexp.MustCompile(`^class\s+(\w+)\s*\(([^)]*)\)\s*:`)
+	djangoFieldRegex   = regexp.MustCompile(`^\s+(\w+)\s*=\s*(?:models\.)?(\w+Field|ForeignKey)\s*\(`)
+	djangoDBTableRegex = regexp.MustCompile(`^\s+db_table\s*=\s*['"]([^'"]+)['"]`)
+	djangoAbstractRe   = regexp.MustCompile(`^\s+abstract\s*=\s*True`)
+)
+
+// djangoModel is a model class collected before its table name is known
+type djangoModel struct {
+	name     string
+	bases    []string
+	table    string
+	abstract bool
+	columns  []types.SchemaColumn
+	rels     []types.SchemaRelation
+}
+
+func parseDjangoModels(b *schemaBuilder, path, content string) {
+	app := filepath.Base(filepath.Dir(path))
+	if app == "models" {
+		app = filepath.Base(filepath.Dir(filepath.Dir(path)))
+	}
+
+	var models []*djangoModel
+	byName := make(map[string]*djangoModel)
+	var current *djangoModel
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if m := djangoClassRegex.FindStringSubmatch(line); m != nil {
+			current = nil
+			var bases []string
+			isModel := false
+			for _, base := range strings.Split(m[2], ",") {
+				base = strings.TrimSpace(base)
+				bases = append(bases, base)
+				// Subclasses of models defined earlier in the file are models too
+				if byName[base] != nil || strings.HasSuffix(base, "Model") {
+					isModel = true
+				}
+			}
+			if isModel {
+				current = &djangoModel{name: m[1], bases: bases}
+				models = append(models, current)
+				byName[current.name] = current
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "#") {
+			current = nil
+			continue
+		}
+		if m := djangoDBTableRegex.FindStringSubmatch(line); m != nil {
+			current.table = m[1]
+			continue
+		}
+		if djangoAbstractRe.MatchString(line) {
+			current.abstract = true
+			continue
+		}
+
+		loc := djangoFieldRegex.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		name, fieldType := line[loc[2]:loc[3]], line[loc[4]:loc[5]]
+		args, _ := callArgsFrom(lines, i, loc[1])
+
+		switch fieldType {
+		case "ForeignKey", "OneToOneField":
+			kind := "belongs-to"
+			if fieldType == "OneToOneField" {
+				kind = "one-to-one"
+			}
+			col := types.SchemaColumn{Name: name + "_id"}
+			for _, a := range args {
+				if a == "primary_key=True" {
+					col.Primary = true
+				}
+			}
+			current.columns = append(current.columns, col)
+			current.rels = append(current.rels, types.SchemaRelation{Column: col.Name, Target: djangoTarget(args, current.name), Kind: kind})
+		case "ManyToManyField":
+			current.rels = append(current.rels, types.SchemaRelation{Target: djangoTarget(args, current.name), Kind: "many-to-many"})
+		default:
+			col := types.SchemaColumn{Name: name, Type: strings.TrimSuffix(fieldType, "Field")}
+			for _, a := range args {
+				switch a {
+				case "primary_key=True":
+					col.Primary = true
+				case "unique=True":
+					col.Unique = true
+				}
+			}
+			current.columns = append(current.columns, col)
+		}
+	}
+
+	found := false
+	for _, m := range models {
+		// Fields of abstract parents are created on the child table
+		for _, base := range m.bases {
+			if parent := byName[base]; parent != nil && parent.abstract {
+				m.columns = append(append([]types.SchemaColumn{}, parent.columns...), m.columns...)
+				m.rels = append(append([]types.SchemaRelation{}, parent.rels...), m.rels...)
+			}
+		}
+		if m.abstract || (len(m.columns) == 0 && len(m.rels) == 0) {
+			continue
+		}
+		table := m.table
+		if table == "" {
+			table = app + "_" + strings.ToLower(m.name)
+		}
+		t := b.model(m.name, table, path)
+
+		hasPrimary := false
+		for _, c := range m.columns {
+			hasPrimary = hasPrimary || c.Primary
+		}
+		if !hasPrimary {
+			schemaColumn(t, "id").Primary = true
+		}
+		for _, c := range m.columns {
+			col := schemaColumn(t, c.Name)
+			*col = c
+		}
+		t.Relations = append(t.Relations, m.rels...)
+		found = true
+	}
+	if found {
+		b.addTool("Django")
+	}
+}
+
+// djangoTarget returns the model a relation field points to
+func djangoTarget(args []string, self string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	target := args[0]
+	if _, v, ok := splitKeyedArg(target); ok {
+		target = v
+	}
+	target = unquote(target)
+	switch target {
+	case "self":
+		return self
+	case "settings.AUTH_USER_MODEL":
+		return "User"
+	}
+	return target[strings.LastIndex(target, ".")+1:]
+}
+
+// =============================================================================
+// TypeORM
+// =============================================================================
+
+var (
+	typeormEntityRegex   = regexp.MustCompile(`@Entity\s*\(([^)]*)\)`)
+	typeormClassRegex    = regexp.MustCompile(`\bclass\s+(\w+)`)
+	typeormDecoratorRe   = regexp.MustCompile(`@(PrimaryGeneratedColumn|PrimaryColumn|ObjectIdColumn|Column|CreateDateColumn|UpdateDateColumn|DeleteDateColumn|VersionColumn|ManyToOne|OneToMany|OneToOne|ManyToMany|JoinColumn)\s*\(`)
+	typeormPropertyRegex = regexp.MustCompile(`^\s*(?:(?:public|private|protected|readonly|declare)\s+)*(\w+)[!?]?\s*:\s*([^;=]+)`)
+	typeormTargetRegex   = regexp.MustCompile(`=>\s*(\w+)`)
+	typeormNameRegex     = regexp.MustCompile(`name:\s*['"]([^'"]+)['"]`)
+)
+
+func parseTypeORMEntities(b *schemaBuilder, path, content string) {
+	lines := strings.Split(content, "\n")
+
+	var t *types.SchemaTable
+	entityName := ""
+	inEntity := false
+	type pending struct {
+		decorator string
+		args      []string
+	}
+	var decorators []pending
+	skipUntil := -1
+
+	for i, line := range lines {
+		if i <= skipUntil {
+			continue // inside a multi-line decorator argument list
+		}
+		if m := typeormEntityRegex.FindStringSubmatch(line); m != nil {
+			inEntity = true
+			entityName = ""
+			if arg := strings.TrimSpace(m[1]); isQuoted(arg) {
+				entityName = unquote(arg)
+			} else if nm := typeormNameRegex.FindStringSubmatch(arg); nm != nil {
+				entityName = nm[1]
+			}
+		}
+		if inEntity {
+			if m := typeormClassRegex.FindStringSubmatch(line); m != nil {
+				table := entityName
+				if table == "" {
+					table = snakeCase(m[1])
+				}
+				t = b.model(m[1], table, path)
+				inEntity = false
+				decorators = nil
+			}
+			continue
+		}
+		if t == nil {
+			continue
+		}
+
+		rest := line
+		for _, loc := range typeormDecoratorRe.FindAllStringSubmatchIndex(line, -1) {
+			args, end := callArgsFrom(lines, i, loc[1])
+			decorators = append(decorators, pending{decorator: line[loc[2]:loc[3]], args: args})
+			rest = line[loc[1]:]
+			if end > i {
+				skipUntil = end
+				rest = ""
+			}
+		}
+		if len(decorators) == 0 {
+			continue
+		}
+		// Property may follow the decorator on the same line
+		if i := strings.LastIndex(rest, ")"); i >= 0 && rest != line {
+			rest = rest[i+1:]
+		}
+		m := typeormPropertyRegex.FindStringSubmatch(rest)
+		if m == nil || strings.HasPrefix(strings.TrimSpace(rest), "@") {
+			continue
+		}
+
+		prop, propType := m[1], strings.TrimSpace(m[2])
+		joinColumn := ""
+		var rel *types.SchemaRelation
+		for _, dec := range decorators {
+			switch dec.decorator {
+			case "JoinColumn":
+				if nm := typeormNameRegex.FindStringSubmatch(strings.Join(dec.args, ",")); nm != nil {
+					joinColumn = nm[1]
+				}
+			case "ManyToOne", "OneToMany", "OneToOne", "ManyToMany":
+				kinds := map[string]string{"ManyToOne": "belongs-to", "OneToMany": "has-many", "OneToOne": "one-to-one", "ManyToMany": "many-to-many"}
+				target := ""
+				if len(dec.args) > 0 {
+					if tm := typeormTargetRegex.FindStringSubmatch(dec.args[0]); tm != nil {
+						target = tm[1]
+					} else {
+						target = unquote(dec.args[0])
+					}
+				}
+				rel = &types.SchemaRelation{Target: target, Kind: kinds[dec.decorator]}
+			default:
+				name := prop
+				if nm := typeormNameRegex.FindStringSubmatch(strings.Join(dec.args, ",")); nm != nil {
+					name = nm[1]
+				}
+				col := schemaColumn(t, name)
+				col.Type = propType
+				if len(dec.args) > 0 && isQuoted(dec.args[0]) {
+					col.Type = unquote(dec.args[0])
+				}
+				col.Primary = strings.HasPrefix(dec.decorator, "Primary") || dec.decorator == "ObjectIdColumn"
+				col.Unique = strings.Contains(strings.Join(dec.args, ","), "unique: true")
+			}
+		}
+		if rel != nil {
+			if rel.Kind == "belongs-to" || (rel.Kind == "one-to-one" && joinColumn != "") {
+				rel.Column = joinColumn
+				if rel.Column == "" {
+					rel.Column = prop + "Id"
+				}
+				schemaColumn(t, rel.Column)
+			}
+			t.Relations = append(t.Relations, *rel)
+		}
+		decorators = nil
+	}
+
+	b.addTool("TypeORM")
+}
+
+// snakeCase converts a class name to TypeORM's default table name
+func snakeCase(s string) string {
+	var out strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && (s[i-1] < 'A' || s[i-1] > 'Z') {
+				out.WriteByte('_')
+			}
+			out.WriteRune(r + ('a' - 'A'))
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// =============================================================================
+// Rails schema.rb
+// =============================================================================
+
+var (
+	railsCreateTableRegex = regexp.MustCompile(`^\s*create_table\s+"([^"]+)"(.*)\bdo\s*\|`)
+	railsColumnRegex      = regexp.MustCompile(`^\s*t\.(\w+)\s+"([^"]+)"(.*)$`)
+	railsIndexRegex       = regexp.MustCompile(`^\s*t\.index\s+\[\s*"([^"]+)"\s*\].*unique:\s*true`)
+	railsForeignKeyRegex  = regexp.MustCompile(`^\s*add_foreign_key\s+"([^"]+)",\s*"([^"]+)"(.*)$`)
+	railsFKColumnRegex    = regexp.MustCompile(`column:\s*"([^"]+)"`)
+	railsIDTypeRegex      = regexp.MustCompile(`id:\s*:(\w+)`)
+)
+
+func (d *SchemaDetector) parseRailsSchema(b *schemaBuilder, path, content string) {
+	b.addTool("Rails")
+
+	var t *types.SchemaTable
+	for _, line := range strings.Split(content, "\n") {
+		if m := railsCreateTableRegex.FindStringSubmatch(line); m != nil {
+			t = b.table(m[1], path)
+			if !strings.Contains(m[2], "id: false") {
+				col := schemaColumn(t, "id")
+				col.Primary = true
+				if im := railsIDTypeRegex.FindStringSubmatch(m[2]); im != nil {
+					col.Type = im[1]
+				}
+			}
+			continue
+		}
+		if m := railsForeignKeyRegex.FindStringSubmatch(line); m != nil {
+			column := singularize(m[2]) + "_id"
+			if cm := railsFKColumnRegex.FindStringSubmatch(m[3]); cm != nil {
+				column = cm[1]
+			}
+			from := b.table(m[1], path)
+			from.Relations = append(from.Relations, types.SchemaRelation{Column: column, Target: m[2], Kind: "belongs-to"})
+			continue
+		}
+		if t == nil {
+			continue
+		}
+		if strings.TrimSpace(line) == "end" {
+			t = nil
+			continue
+		}
+		if m := railsIndexRegex.FindStringSubmatch(line); m != nil {
+			schemaColumn(t, m[1]).Unique = true
+			continue
+		}
+		if m := railsColumnRegex.FindStringSubmatch(line); m != nil && m[1] != "index" {
+			switch m[1] {
+			case "references", "belongs_to":
+				col := schemaColumn(t, m[2]+"_id")
+				col.Type = "bigint"
+			default:
+				col := schemaColumn(t, m[2])
+				col.Type = m[1]
+			}
+		}
+	}
+	migrate := filepath.Join(filepath.Dir(path), "migrate")
+	if info, err := os.Stat(filepath.Join(d.rootPath, migrate)); err == nil && info.IsDir() {
+		b.addDir("Rails", migrate)
+	}
+}
+
+// singularize naively singularizes a Rails table name
+func singularize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "ses"), strings.HasSuffix(s, "xes"):
+		return strings.TrimSuffix(s, "es")
+	case strings.HasSuffix(s, "s"):
+		return strings.TrimSuffix(s, "s")
+	}
+	return s
+}
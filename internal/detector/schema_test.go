@@ -0,0 +1,365 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func findTable(schema *types.DatabaseSchema, name string) *types.SchemaTable {
+	for i := range schema.Tables {
+		if schema.Tables[i].Name == name {
+			return &schema.Tables[i]
+		}
+	}
+	return nil
+}
+
+func findColumn(t *types.SchemaTable, name string) *types.SchemaColumn {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+func hasRelation(t *types.SchemaTable, column, target, kind string) bool {
+	for _, r := range t.Relations {
+		if r.Column == column && r.Target == target && r.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSchemaCommand(schema *types.DatabaseSchema, command string) bool {
+	for _, c := range schema.Commands {
+		if c.Name == command {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSchemaDetector_Prisma(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"prisma/schema.prisma": `datasource db {
+  provider = "postgresql"
+}
+
+enum Role {
+  USER
+  ADMIN
+}
+
+model User {
+  id    Int     @id @default(autoincrement())
+  email String  @unique
+  role  Role    @default(USER)
+  posts Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int  @map("author_id")
+  author   User @relation(fields: [authorId], references: [id])
+}
+`,
+		"prisma/migrations/20240101_init/migration.sql": `CREATE TABLE "ignored" ("id" SERIAL PRIMARY KEY);`,
+	})
+
+	schema := NewSchemaDetector(tmpDir, files).Detect()
+	if schema == nil {
+		t.Fatal("expected schema to be detected")
+	}
+
+	users := findTable(schema, "users")
+	if users == nil || users.Model != "User" {
+		t.Fatalf("expected users table for model User, got %+v", users)
+	}
+	if c := findColumn(users, "id"); c == nil || !c.Primary {
+		t.Error("expected users.id primary key")
+	}
+	if c := findColumn(users, "email"); c == nil || !c.Unique {
+		t.Error("expected users.email unique")
+	}
+	if c := findColumn(users, "role"); c == nil || c.Type != "Role" {
+		t.Error("expected enum field role to be a column")
+	}
+	if !hasRelation(users, "", "Post", "has-many") {
+		t.Errorf("expected users has-many Post, got %+v", users.Relations)
+	}
+
+	post := findTable(schema, "Post")
+	if post == nil || !hasRelation(post, "authorId", "users", "belongs-to") {
+		t.Fatalf("expected Post belongs-to users via authorId, got %+v", post)
+	}
+	if findTable(schema, "ignored") != nil {
+		t.Error("expected Prisma-generated migration.sql to be skipped")
+	}
+	if !hasSchemaCommand(schema, "npx prisma migrate dev --name <name>") {
+		t.Errorf("expected prisma migrate command, got %+v", schema.Commands)
+	}
+}
+
+func TestSchemaDetector_SQLMigrations(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"db/migrations/000001_init.up.sql": `-- users and orgs
+CREATE TABLE IF NOT EXISTS orgs (
+    id BIGSERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE users (
+    id UUID NOT NULL,
+    org_id BIGINT REFERENCES orgs(id),
+    email TEXT NOT NULL,
+    legacy TEXT,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    UNIQUE (email)
+);
+
+CREATE TABLE scratch (id INT);
+`,
+		"db/migrations/000001_init.down.sql": `DROP TABLE users; DROP TABLE orgs;`,
+		"db/migrations/000002_teams.up.sql": `CREATE TABLE teams (id SERIAL PRIMARY KEY, name TEXT);
+ALTER TABLE users ADD COLUMN team_id INT, ADD CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES teams (id);
+ALTER TABLE users DROP COLUMN legacy;
+DROP TABLE IF EXISTS scratch;
+`,
+	})
+
+	schema := NewSchemaDetector(tmpDir, files).Detect()
+	if schema == nil {
+		t.Fatal("expected schema to be detected")
+	}
+	if len(schema.Tools) != 1 || schema.Tools[0] != "golang-migrate" {
+		t.Errorf("expected golang-migrate, got %v", schema.Tools)
+	}
+
+	users := findTable(schema, "users")
+	if users == nil {
+		t.Fatal("expected users table")
+	}
+	if c := findColumn(users, "id"); c == nil || !c.Primary {
+		t.Error("expected table-level PRIMARY KEY on users.id")
+	}
+	if c := findColumn(users, "email"); c == nil || !c.Unique {
+		t.Error("expected UNIQUE (email)")
+	}
+	if findColumn(users, "legacy") != nil {
+		t.Error("expected legacy column to be dropped")
+	}
+	if !hasRelation(users, "org_id", "orgs", "belongs-to") || !hasRelation(users, "team_id", "teams", "belongs-to") {
+		t.Errorf("expected org and team foreign keys, got %+v", users.Relations)
+	}
+	if findTable(schema, "scratch") != nil {
+		t.Error("expected scratch table to be dropped")
+	}
+	if !hasSchemaCommand(schema, "migrate create -ext sql -dir db/migrations -seq <name>") {
+		t.Errorf("expected migrate create command, got %+v", schema.Commands)
+	}
+}
+
+func TestSchemaDetector_DropAndRecreate(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"db/migrations/000001_init.up.sql": `CREATE TABLE sessions (id INT, token TEXT);
+CREATE TABLE accounts (id SERIAL PRIMARY KEY);
+`,
+		"db/migrations/000002_sessions.up.sql": `DROP TABLE sessions;
+CREATE TABLE sessions (id UUID PRIMARY KEY, account_id INT REFERENCES accounts(id));
+`,
+	})
+
+	schema := NewSchemaDetector(tmpDir, files).Detect()
+	if schema == nil {
+		t.Fatal("expected schema to be detected")
+	}
+
+	count := 0
+	for _, table := range schema.Tables {
+		if table.Name == "sessions" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected sessions listed once after drop and recreate, got %d in %+v", count, schema.Tables)
+	}
+	sessions := findTable(schema, "sessions")
+	if findColumn(sessions, "token") != nil {
+		t.Error("expected recreated sessions table not to keep dropped columns")
+	}
+	if c := findColumn(sessions, "id"); c == nil || !c.Primary {
+		t.Error("expected recreated sessions.id primary key")
+	}
+}
+
+func TestSchemaDetector_PythonORMs(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"manage.py": "",
+		"shop/models.py": `from django.db import models
+
+
+class Base(models.Model):
+    created = models.DateTimeField(auto_now_add=True)
+
+    class Meta:
+        abstract = True
+
+
+class Product(Base):
+    sku = models.CharField(max_length=32, unique=True)
+    category = models.ForeignKey("Category", on_delete=models.CASCADE)
+    tags = models.ManyToManyField("Tag")
+
+
+class Category(models.Model):
+    name = models.CharField(max_length=64)
+
+    class Meta:
+        db_table = "categories"
+`,
+		"alembic/versions/b2_add_orders.py": `from alembic import op
+import sqlalchemy as sa
+
+revision = "b2"
+down_revision = "a1"
+
+def upgrade():
+    op.add_column("orders", sa.Column("customer_id", sa.Integer(), sa.ForeignKey("customers.id")))
+
+def downgrade():
+    op.drop_column("orders", "customer_id")
+`,
+		"alembic/versions/a1_init.py": `from alembic import op
+import sqlalchemy as sa
+
+revision = "a1"
+down_revision = None
+
+def upgrade():
+    op.create_table(
+        "orders",
+        sa.Column("id", sa.Integer(), primary_key=True),
+        sa.Column("number", sa.String(length=32), nullable=False),
+        sa.UniqueConstraint("number"),
+    )
+`,
+	})
+
+	schema := NewSchemaDetector(tmpDir, files).Detect()
+	if schema == nil {
+		t.Fatal("expected schema to be detected")
+	}
+
+	product := findTable(schema, "shop_product")
+	if product == nil {
+		t.Fatalf("expected shop_product table, got %+v", schema.Tables)
+	}
+	if c := findColumn(product, "id"); c == nil || !c.Primary {
+		t.Error("expected implicit Django id primary key")
+	}
+	if c := findColumn(product, "sku"); c == nil || !c.Unique {
+		t.Error("expected unique sku")
+	}
+	if !hasRelation(product, "category_id", "categories", "belongs-to") || !hasRelation(product, "", "Tag", "many-to-many") {
+		t.Errorf("unexpected product relations: %+v", product.Relations)
+	}
+	if findTable(schema, "shop_base") != nil {
+		t.Error("expected abstract model to be skipped")
+	}
+
+	orders := findTable(schema, "orders")
+	if orders == nil {
+		t.Fatal("expected orders table from Alembic")
+	}
+	if c := findColumn(orders, "number"); c == nil || !c.Unique || c.Type != "String" {
+		t.Errorf("expected unique String number column, got %+v", c)
+	}
+	if !hasRelation(orders, "customer_id", "customers", "belongs-to") {
+		t.Errorf("expected add_column foreign key, got %+v", orders.Relations)
+	}
+	if !hasSchemaCommand(schema, "python manage.py makemigrations") || !hasSchemaCommand(schema, "alembic upgrade head") {
+		t.Errorf("expected Django and Alembic commands, got %+v", schema.Commands)
+	}
+}
+
+func TestSchemaDetector_TypeORMAndRails(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"src/entities/BlogPost.ts": `import { Entity, PrimaryGeneratedColumn, Column, ManyToOne, JoinColumn } from 'typeorm';
+
+@Entity()
+export class BlogPost {
+  @PrimaryGeneratedColumn('uuid')
+  id: string;
+
+  @Column({
+    unique: true,
+  })
+  slug: string;
+
+  @ManyToOne(() => Account, (account) => account.posts)
+  @JoinColumn({ name: 'account_id' })
+  account: Account;
+}
+
+@Entity({ name: 'accounts' })
+export class Account {
+  @PrimaryGeneratedColumn()
+  id: number;
+}
+`,
+		"db/schema.rb": `ActiveRecord::Schema[7.1].define(version: 2024_01_01_000000) do
+  create_table "comments", force: :cascade do |t|
+    t.text "body"
+    t.bigint "article_id", null: false
+    t.index ["article_id"], name: "index_comments_on_article_id"
+  end
+
+  create_table "articles", force: :cascade do |t|
+    t.string "slug"
+    t.index ["slug"], name: "index_articles_on_slug", unique: true
+  end
+
+  add_foreign_key "comments", "articles"
+end
+`,
+	})
+
+	schema := NewSchemaDetector(tmpDir, files).Detect()
+	if schema == nil {
+		t.Fatal("expected schema to be detected")
+	}
+
+	post := findTable(schema, "blog_post")
+	if post == nil {
+		t.Fatalf("expected snake_case table for BlogPost, got %+v", schema.Tables)
+	}
+	if c := findColumn(post, "slug"); c == nil || !c.Unique {
+		t.Errorf("expected unique slug from multi-line @Column, got %+v", post.Columns)
+	}
+	if !hasRelation(post, "account_id", "accounts", "belongs-to") {
+		t.Errorf("expected account relation, got %+v", post.Relations)
+	}
+
+	comments := findTable(schema, "comments")
+	if comments == nil || !hasRelation(comments, "article_id", "articles", "belongs-to") {
+		t.Fatalf("expected comments -> articles foreign key, got %+v", comments)
+	}
+	if c := findColumn(findTable(schema, "articles"), "slug"); c == nil || !c.Unique {
+		t.Error("expected unique index on articles.slug")
+	}
+}
+
+func TestSchemaDetector_NoSchema(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"main.go": "package main\n",
+	})
+
+	if schema := NewSchemaDetector(tmpDir, files).Detect(); schema != nil {
+		t.Errorf("expected nil schema, got %+v", schema)
+	}
+}
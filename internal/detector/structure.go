@@ -40,29 +40,12 @@ func (d *StructureDetector) Detect() (*types.ProjectStructure, error) {
 
 	// Collect directories at multiple levels (up to 2 levels deep for src/, app/, etc.)
 	dirCounts := make(map[string]int)
-	expandDirs := map[string]bool{
-		"src": true, "app": true, "apps": true, "packages": true,
-		"lib": true, "internal": true, "pkg": true,
-	}
-
 	for _, f := range d.files {
 		if f.IsDir {
 			continue
 		}
-		dir := filepath.Dir(f.Path)
-		if dir == "." {
-			continue
-		}
-
-		parts := strings.Split(dir, string(filepath.Separator))
-		topLevel := parts[0]
-
-		// For expandable directories, also count second level
-		if expandDirs[topLevel] && len(parts) >= 2 {
-			secondLevel := parts[0] + "/" + parts[1]
-			dirCounts[secondLevel]++
-		} else {
-			dirCounts[topLevel]++
+		if dir := structureDir(f.Path); dir != "" {
+			dirCounts[dir]++
 		}
 	}
 
@@ -79,6 +62,28 @@ func (d *StructureDetector) Detect() (*types.ProjectStructure, error) {
 	return structure, nil
 }
 
+// expandDirs are top-level directories whose children are listed individually
+var expandDirs = map[string]bool{
+	"src": true, "app": true, "apps": true, "packages": true,
+	"lib": true, "internal": true, "pkg": true,
+}
+
+// structureDir returns the structure directory a file belongs to: its
+// top-level directory, or the second level under expandable directories.
+// Root files return "".
+func structureDir(path string) string {
+	dir := filepath.Dir(filepath.FromSlash(path))
+	if dir == "." {
+		return ""
+	}
+
+	parts := strings.Split(dir, string(filepath.Separator))
+	if expandDirs[parts[0]] && len(parts) >= 2 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
 // inferDirectoryPurpose guesses the purpose of a directory from its name
 func inferDirectoryPurpose(dirName string) string {
 	dirLower := strings.ToLower(dirName)
@@ -0,0 +1,577 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+const (
+	// maxStyleSampleFiles caps how many files per language are read
+	maxStyleSampleFiles = 150
+	// maxStyleSampleSize skips generated or minified files
+	maxStyleSampleSize = 256 * 1024
+	// minInferenceFiles is the fewest files a finding must be seen in
+	minInferenceFiles = 3
+	// minInferenceConfidence hides findings the source doesn't clearly support
+	minInferenceConfidence = 0.7
+)
+
+// styleLanguages maps source extensions to the language key used by style rules
+var styleLanguages = map[string]string{
+	".js": "js", ".jsx": "js", ".mjs": "js", ".cjs": "js", ".ts": "js", ".tsx": "js",
+	".py": "py",
+	".go": "go",
+}
+
+var styleLanguageNames = map[string]string{
+	"js": "JavaScript/TypeScript",
+	"py": "Python",
+	"go": "Go",
+}
+
+// Standard line limits, tightest first
+var styleLineLimits = []int{80, 88, 100, 120}
+
+var (
+	jsImportFromRegex = regexp.MustCompile(`^import\s.*?['"]([^'"]+)['"]`)
+	pyImportRegex     = regexp.MustCompile(`^(?:from\s+(\S+)\s+import\b|import\s+([\w.]+))`)
+	goImportRegex     = regexp.MustCompile(`^(?:[\w.]+\s+)?"([^"]+)"`)
+)
+
+// sampledFile is a source file read for style inference
+type sampledFile struct {
+	path  string
+	ext   string
+	lines []string
+}
+
+// styleVotes tallies how often each variant of a style choice appears, in
+// how many files it was seen and where
+type styleVotes struct {
+	counts  map[string]int
+	files   map[string]bool
+	samples map[string][]string
+}
+
+// add records one vote; line is 0 for votes that cover a whole file
+func (v *styleVotes) add(variant, file string, line int) {
+	if v.counts == nil {
+		v.counts = make(map[string]int)
+		v.files = make(map[string]bool)
+		v.samples = make(map[string][]string)
+	}
+	v.counts[variant]++
+	v.files[file] = true
+	if len(v.samples[variant]) >= maxEvidenceSamples {
+		return
+	}
+	// One sample per file shows how widespread the choice is
+	for _, sample := range v.samples[variant] {
+		if sample == file || strings.HasPrefix(sample, file+":") {
+			return
+		}
+	}
+	sample := file
+	if line > 0 {
+		sample = fmt.Sprintf("%s:%d", file, line)
+	}
+	v.samples[variant] = append(v.samples[variant], sample)
+}
+
+// dominant returns the most common variant, its count and the total
+func (v *styleVotes) dominant() (string, int, int) {
+	best, bestCount, total := "", 0, 0
+	variants := make([]string, 0, len(v.counts))
+	for variant := range v.counts {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+	for _, variant := range variants {
+		count := v.counts[variant]
+		total += count
+		if count > bestCount {
+			best, bestCount = variant, count
+		}
+	}
+	return best, bestCount, total
+}
+
+// inferredConvention builds a convention whose description carries its
+// evidence, or reports false when the source doesn't clearly support it
+func inferredConvention(category, description string, agree, total, files int, samples []string) (types.Convention, bool) {
+	if total == 0 || files < minInferenceFiles {
+		return types.Convention{}, false
+	}
+	conv := countedConvention(types.Convention{
+		Category:    category,
+		Description: fmt.Sprintf("%s (inferred from %d files, %d%% consistent)", description, files, agree*100/total),
+	}, agree, total, files)
+	if conv.Confidence < minInferenceConfidence {
+		return types.Convention{}, false
+	}
+	conv.Evidence.Samples = samples
+	return conv, true
+}
+
+// inferCodeStyle samples source files and infers the style choices that no
+// linter or formatter config already states
+func (d *ConventionDetector) inferCodeStyle(configured *styleRules) []types.Convention {
+	var conventions []types.Convention
+	add := func(key string, conv types.Convention, ok bool) {
+		if ok && !configured.seen[key] {
+			conventions = append(conventions, conv)
+		}
+	}
+
+	samples := d.sampleStyleFiles()
+	for _, lang := range []string{"js", "py", "go"} {
+		files := samples[lang]
+		if len(files) < minInferenceFiles {
+			continue
+		}
+		name := styleLanguageNames[lang]
+
+		if lang != "go" && !configured.seen["*:indent"] {
+			conv, ok := inferIndentation(name, files)
+			add(lang+":indent", conv, ok)
+		}
+		if lang != "go" {
+			conv, ok := inferQuotes(name, files)
+			add(lang+":quotes", conv, ok)
+			conv, ok = inferTrailingCommas(name, files)
+			add(lang+":trailing-comma", conv, ok)
+		}
+		if lang == "js" {
+			conv, ok := inferSemicolons(files)
+			add("js:semi", conv, ok)
+		}
+		if !configured.seen["*:line-length"] {
+			conv, ok := inferLineLength(name, files)
+			add(lang+":line-length", conv, ok)
+		}
+		conv, ok := d.inferImportOrder(lang, name, files)
+		add(lang+":import-order", conv, ok)
+	}
+
+	return conventions
+}
+
+// sampleStyleFiles reads the source sample once per detector
+func (d *ConventionDetector) sampleStyleFiles() map[string][]sampledFile {
+	if d.styleSamples == nil {
+		d.styleSamples = sampleSourceFiles(d.rootPath, d.files)
+	}
+	return d.styleSamples
+}
+
+// sampleSourceFiles reads an evenly spaced sample of source files per language
+func sampleSourceFiles(rootPath string, files []types.FileInfo) map[string][]sampledFile {
+	candidates := make(map[string][]types.FileInfo)
+	for _, f := range files {
+		lang, ok := styleLanguages[f.Extension]
+		if !ok || f.IsDir || f.Size > maxStyleSampleSize || isGeneratedSource(f.Name) {
+			continue
+		}
+		candidates[lang] = append(candidates[lang], f)
+	}
+
+	samples := make(map[string][]sampledFile)
+	for lang, files := range candidates {
+		sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+		step := 1
+		if len(files) > maxStyleSampleFiles {
+			step = (len(files) + maxStyleSampleFiles - 1) / maxStyleSampleFiles
+		}
+		for i := 0; i < len(files); i += step {
+			content, err := os.ReadFile(filepath.Join(rootPath, files[i].Path))
+			if err != nil || strings.HasPrefix(string(content), "// Code generated") {
+				continue
+			}
+			samples[lang] = append(samples[lang], sampledFile{
+				path:  files[i].Path,
+				ext:   files[i].Extension,
+				lines: strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"),
+			})
+		}
+	}
+	return samples
+}
+
+// isGeneratedSource skips minified bundles, type declarations and generated code
+func isGeneratedSource(name string) bool {
+	for _, suffix := range []string{".min.js", ".d.ts", ".pb.go", "_pb2.py", "_gen.go", ".generated.ts"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommentLine reports whether a trimmed line is a comment in any sampled language
+func isCommentLine(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "#") ||
+		strings.HasPrefix(trimmed, "*") || strings.HasPrefix(trimmed, "/*")
+}
+
+// inferIndentation votes once per file for tabs or the smallest space indent
+func inferIndentation(name string, files []sampledFile) (types.Convention, bool) {
+	var votes styleVotes
+	for _, f := range files {
+		tabs, smallest := 0, 0
+		for _, line := range f.lines {
+			trimmed := strings.TrimLeft(line, " \t")
+			if trimmed == "" || isCommentLine(trimmed) {
+				continue
+			}
+			indent := line[:len(line)-len(trimmed)]
+			switch {
+			case strings.HasPrefix(indent, "\t"):
+				tabs++
+			case len(indent) > 0 && (smallest == 0 || len(indent) < smallest):
+				smallest = len(indent)
+			}
+		}
+		switch {
+		case tabs > 0 && smallest == 0:
+			votes.add("tabs", f.path, 0)
+		case smallest == 2 || smallest == 4:
+			votes.add(fmt.Sprintf("%d spaces", smallest), f.path, 0)
+		case smallest > 0:
+			votes.add("other", f.path, 0)
+		}
+	}
+
+	variant, agree, total := votes.dominant()
+	if variant == "" || variant == "other" {
+		return types.Convention{}, false
+	}
+	return inferredConvention("code-style", fmt.Sprintf("Indent %s with %s", name, variant), agree, total, len(votes.files), votes.samples[variant])
+}
+
+// inferQuotes counts the delimiter of each string literal outside comments
+func inferQuotes(name string, files []sampledFile) (types.Convention, bool) {
+	var votes styleVotes
+	for _, f := range files {
+		jsx := f.ext == ".jsx" || f.ext == ".tsx"
+		for n, line := range f.lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || isCommentLine(trimmed) {
+				continue
+			}
+			for i := 0; i < len(line); i++ {
+				c := line[i]
+				if c != '\'' && c != '"' && c != '`' {
+					continue
+				}
+				// Docstrings and JSX attributes follow their own rules
+				if strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], "'''") {
+					break
+				}
+				end := strings.IndexByte(line[i+1:], c)
+				if end < 0 {
+					break
+				}
+				if c != '`' && !(jsx && i > 0 && line[i-1] == '=') {
+					variant := "double"
+					if c == '\'' {
+						variant = "single"
+					}
+					votes.add(variant, f.path, n+1)
+				}
+				i += end + 1
+			}
+		}
+	}
+
+	variant, agree, total := votes.dominant()
+	if variant == "" {
+		return types.Convention{}, false
+	}
+	return inferredConvention("code-style", fmt.Sprintf("Use %s quotes for %s strings", variant, name), agree, total, len(votes.files), votes.samples[variant])
+}
+
+// inferSemicolons looks at lines that clearly end a statement
+func inferSemicolons(files []sampledFile) (types.Convention, bool) {
+	starts := []string{"import ", "const ", "let ", "var ", "return ", "throw ", "export const ", "export default "}
+	continued := []string{"{", "(", "[", ",", "=", "=>", "+", "-", "&&", "||", "?", ":", "`"}
+
+	var votes styleVotes
+	for _, f := range files {
+		for n, line := range f.lines {
+			trimmed := strings.TrimSpace(line)
+			isStatement := false
+			for _, s := range starts {
+				if strings.HasPrefix(trimmed, s) {
+					isStatement = true
+					break
+				}
+			}
+			if !isStatement {
+				continue
+			}
+			open := false
+			for _, c := range continued {
+				if strings.HasSuffix(trimmed, c) {
+					open = true
+					break
+				}
+			}
+			switch {
+			case strings.HasSuffix(trimmed, ";"):
+				votes.add("always", f.path, n+1)
+			case !open:
+				votes.add("never", f.path, n+1)
+			}
+		}
+	}
+
+	variant, agree, total := votes.dominant()
+	switch variant {
+	case "always":
+		return inferredConvention("code-style", "End statements with semicolons", agree, total, len(votes.files), votes.samples[variant])
+	case "never":
+		return inferredConvention("code-style", "Omit semicolons at the end of statements", agree, total, len(votes.files), votes.samples[variant])
+	}
+	return types.Convention{}, false
+}
+
+// inferTrailingCommas checks the last element of multi-line lists: a line
+// before a closing ) or ] whose previous element ended with a comma
+func inferTrailingCommas(name string, files []sampledFile) (types.Convention, bool) {
+	var votes styleVotes
+	for _, f := range files {
+		var prev, last string
+		for n, line := range f.lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || isCommentLine(trimmed) {
+				continue
+			}
+			closer := strings.HasPrefix(trimmed, ")") || strings.HasPrefix(trimmed, "]") ||
+				(f.ext == ".py" && strings.HasPrefix(trimmed, "}"))
+			if closer && strings.HasSuffix(prev, ",") && !strings.HasSuffix(last, "(") && !strings.HasSuffix(last, "[") {
+				if strings.HasSuffix(last, ",") {
+					votes.add("trailing", f.path, n+1)
+				} else {
+					votes.add("none", f.path, n+1)
+				}
+			}
+			prev, last = last, trimmed
+		}
+	}
+
+	variant, agree, total := votes.dominant()
+	switch variant {
+	case "trailing":
+		return inferredConvention("code-style", fmt.Sprintf("Add a trailing comma after the last item of multi-line %s lists and calls", name), agree, total, len(votes.files), votes.samples[variant])
+	case "none":
+		return inferredConvention("code-style", fmt.Sprintf("No trailing comma after the last item of multi-line %s lists and calls", name), agree, total, len(votes.files), votes.samples[variant])
+	}
+	return types.Convention{}, false
+}
+
+// inferLineLength picks the tightest standard limit that 99% of lines fit
+func inferLineLength(name string, files []sampledFile) (types.Convention, bool) {
+	var lengths []int
+	contributing := make(map[string]bool)
+	for _, f := range files {
+		for _, line := range f.lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			lengths = append(lengths, len(strings.ReplaceAll(line, "\t", "    ")))
+			contributing[f.path] = true
+		}
+	}
+	if len(lengths) == 0 {
+		return types.Convention{}, false
+	}
+	sort.Ints(lengths)
+	p99 := lengths[(len(lengths)*99)/100]
+
+	for _, limit := range styleLineLimits {
+		if p99 > limit {
+			continue
+		}
+		within := sort.SearchInts(lengths, limit+1)
+		return inferredConvention("code-style", fmt.Sprintf("Keep %s lines within %d characters", name, limit), within, len(lengths), len(contributing), nil)
+	}
+	return types.Convention{}, false
+}
+
+// importLine is one import with its class and the blank-line group it sits in
+type importLine struct {
+	class int
+	group int
+}
+
+var importClassNames = map[string][3]string{
+	"js": {"Node built-ins", "packages", "relative and aliased local modules"},
+	"py": {"standard library", "third-party", "local packages"},
+	"go": {"standard library", "third-party", "module-local"},
+}
+
+// nodeBuiltins are Node.js core modules imported without the node: prefix
+var nodeBuiltins = map[string]bool{
+	"fs": true, "path": true, "os": true, "http": true, "https": true, "url": true, "util": true,
+	"crypto": true, "events": true, "stream": true, "child_process": true, "assert": true, "zlib": true,
+}
+
+// pythonStdlib lists commonly imported standard library modules
+var pythonStdlib = map[string]bool{
+	"abc": true, "argparse": true, "asyncio": true, "base64": true, "collections": true, "contextlib": true,
+	"copy": true, "csv": true, "dataclasses": true, "datetime": true, "enum": true, "functools": true,
+	"glob": true, "hashlib": true, "io": true, "itertools": true, "json": true, "logging": true, "math": true,
+	"os": true, "pathlib": true, "pickle": true, "random": true, "re": true, "shutil": true, "signal": true,
+	"socket": true, "sqlite3": true, "string": true, "subprocess": true, "sys": true, "tempfile": true,
+	"threading": true, "time": true, "typing": true, "unittest": true, "urllib": true, "uuid": true,
+	"warnings": true, "__future__": true,
+}
+
+// inferImportOrder checks files that import from at least two classes:
+// whether classes appear in rank order and whether blank lines separate them
+func (d *ConventionDetector) inferImportOrder(lang, name string, files []sampledFile) (types.Convention, bool) {
+	module := ""
+	if lang == "go" {
+		if data, err := os.ReadFile(filepath.Join(d.rootPath, "go.mod")); err == nil {
+			if m := regexp.MustCompile(`(?m)^module\s+(\S+)`).FindSubmatch(data); m != nil {
+				module = string(m[1])
+			}
+		}
+	}
+
+	var ordered, separated styleVotes
+	for _, f := range files {
+		imports := d.fileImports(lang, module, f.lines)
+		classes := make(map[int]bool)
+		for _, imp := range imports {
+			classes[imp.class] = true
+		}
+		if len(classes) < 2 {
+			continue
+		}
+
+		inOrder, split := true, true
+		for i := 1; i < len(imports); i++ {
+			prev, cur := imports[i-1], imports[i]
+			if cur.class < prev.class {
+				inOrder = false
+			}
+			// A blank line between imports should mark a class boundary and vice versa
+			if (cur.group != prev.group) != (cur.class != prev.class) {
+				split = false
+			}
+		}
+		ordered.add(fmt.Sprint(inOrder), f.path, 0)
+		separated.add(fmt.Sprint(split), f.path, 0)
+	}
+
+	agree := ordered.counts["true"]
+	_, _, total := ordered.dominant()
+	if total == 0 {
+		return types.Convention{}, false
+	}
+	classes := importClassNames[lang]
+	description := fmt.Sprintf("Order %s imports: %s, then %s, then %s", name, classes[0], classes[1], classes[2])
+	if separated.counts["true"]*10 >= total*8 {
+		description += ", with a blank line between groups"
+	}
+	return inferredConvention("imports", description, agree, total, len(ordered.files), ordered.samples["true"])
+}
+
+// fileImports extracts the leading imports of a file with their class and blank-line group
+func (d *ConventionDetector) fileImports(lang, module string, lines []string) []importLine {
+	var imports []importLine
+	group := 0
+	inGoBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if len(imports) > 0 {
+				group++
+			}
+			continue
+		}
+
+		var path string
+		switch lang {
+		case "go":
+			switch {
+			case strings.HasPrefix(trimmed, "import ("):
+				inGoBlock = true
+				continue
+			case inGoBlock && trimmed == ")":
+				return imports
+			case inGoBlock:
+				if m := goImportRegex.FindStringSubmatch(trimmed); m != nil {
+					path = m[1]
+				}
+			case strings.HasPrefix(trimmed, "import "):
+				if m := goImportRegex.FindStringSubmatch(strings.TrimPrefix(trimmed, "import ")); m != nil {
+					path = m[1]
+				}
+			case strings.HasPrefix(trimmed, "package ") || isCommentLine(trimmed):
+				continue
+			default:
+				return imports
+			}
+		case "js":
+			if m := jsImportFromRegex.FindStringSubmatch(trimmed); m != nil {
+				path = m[1]
+			} else if isCommentLine(trimmed) || strings.HasPrefix(trimmed, "'use ") || strings.HasPrefix(trimmed, "\"use ") {
+				continue
+			} else if len(imports) > 0 || !strings.HasPrefix(trimmed, "import") {
+				return imports
+			}
+		case "py":
+			if m := pyImportRegex.FindStringSubmatch(trimmed); m != nil {
+				path = m[1] + m[2]
+			} else if isCommentLine(trimmed) || strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, "'''") {
+				continue
+			} else {
+				return imports
+			}
+		}
+		if path == "" {
+			continue
+		}
+		imports = append(imports, importLine{class: d.importClass(lang, module, path), group: group})
+	}
+	return imports
+}
+
+// importClass ranks an import: 0 standard library, 1 third-party, 2 local
+func (d *ConventionDetector) importClass(lang, module, path string) int {
+	switch lang {
+	case "go":
+		switch {
+		case module != "" && (path == module || strings.HasPrefix(path, module+"/")):
+			return 2
+		case !strings.Contains(strings.Split(path, "/")[0], "."):
+			return 0
+		}
+		return 1
+	case "js":
+		switch {
+		case strings.HasPrefix(path, "node:") || nodeBuiltins[path]:
+			return 0
+		case strings.HasPrefix(path, ".") || strings.HasPrefix(path, "@/") || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "#"):
+			return 2
+		}
+		return 1
+	default:
+		top := strings.Split(path, ".")[0]
+		switch {
+		case strings.HasPrefix(path, ".") || fileExists(filepath.Join(d.rootPath, top)) || fileExists(filepath.Join(d.rootPath, "src", top)):
+			return 2
+		case pythonStdlib[top]:
+			return 0
+		}
+		return 1
+	}
+}
@@ -0,0 +1,217 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// inferredDescriptions runs inference over the fixture, dropping the evidence suffix
+func inferredDescriptions(t *testing.T, files map[string]string, configured *styleRules) map[string]float64 {
+	t.Helper()
+	tmpDir, infos := writeProjectFixture(t, files)
+	got := make(map[string]float64)
+	for _, conv := range NewConventionDetector(tmpDir, infos).inferCodeStyle(configured) {
+		desc := conv.Description
+		if i := strings.Index(desc, " (inferred from "); i >= 0 {
+			desc = desc[:i]
+		}
+		got[desc] = conv.Confidence
+	}
+	return got
+}
+
+// repeatFixture writes the same content under n numbered file names
+func repeatFixture(files map[string]string, pattern string, n int, content string) {
+	for i := 0; i < n; i++ {
+		files[fmt.Sprintf(pattern, i)] = content
+	}
+}
+
+func TestInferCodeStyle(t *testing.T) {
+	jsSource := `import fs from 'node:fs'
+import React from 'react'
+
+import { api } from '@/lib/api'
+import { helper } from './helper'
+
+const items = [
+  'one',
+  'two',
+]
+
+export function load(name) {
+  const data = fs.readFileSync(name, 'utf8')
+  return helper(data, {
+    strict: true,
+  })
+}
+`
+	pySource := `import os
+import sys
+
+import requests
+
+from .models import User
+
+
+def fetch(url):
+    logger.info("fetching %s", url)
+    return requests.get(
+        url,
+        timeout=10,
+    )
+`
+	goSource := `package store
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"example.com/app/internal/config"
+)
+
+func Load(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("stat config: %w", err)
+	}
+	slog.Info("loaded", "path", path)
+	return nil
+}
+`
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+		skip  []string
+	}{
+		{
+			name: "javascript",
+			files: func() map[string]string {
+				files := map[string]string{}
+				repeatFixture(files, "src/mod%d.js", 12, jsSource)
+				return files
+			}(),
+			want: []string{
+				"Indent JavaScript/TypeScript with 2 spaces",
+				"Use single quotes for JavaScript/TypeScript strings",
+				"Omit semicolons at the end of statements",
+				"Add a trailing comma after the last item of multi-line JavaScript/TypeScript lists and calls",
+				"Keep JavaScript/TypeScript lines within 80 characters",
+				"Order JavaScript/TypeScript imports: Node built-ins, then packages, then relative and aliased local modules",
+			},
+		},
+		{
+			name: "python",
+			files: func() map[string]string {
+				files := map[string]string{}
+				repeatFixture(files, "app/mod%d.py", 12, pySource)
+				return files
+			}(),
+			want: []string{
+				"Indent Python with 4 spaces",
+				"Use double quotes for Python strings",
+				"Add a trailing comma after the last item of multi-line Python lists and calls",
+				"Order Python imports: standard library, then third-party, then local packages, with a blank line between groups",
+			},
+			// logging is reported by the error handling detector
+			skip: []string{"Log through the logging module with lazy %-style arguments, e.g. `logger.info(\"loaded %s\", name)`"},
+		},
+		{
+			name: "go",
+			files: func() map[string]string {
+				files := map[string]string{"go.mod": "module example.com/app\n\ngo 1.22\n"}
+				repeatFixture(files, "internal/store/file%d.go", 12, goSource)
+				return files
+			}(),
+			want: []string{
+				"Keep Go lines within 80 characters",
+				"Order Go imports: standard library, then third-party, then module-local, with a blank line between groups",
+			},
+			// error wrapping and logging are reported by the error handling detector
+			skip: []string{
+				"Wrap errors with `fmt.Errorf(\"doing x: %w\", err)`: lowercase context, then `: %w`",
+				"Log with log/slog and key-value pairs, e.g. `slog.Info(\"msg\", \"key\", value)`",
+			},
+		},
+		{
+			name: "too few files",
+			files: func() map[string]string {
+				files := map[string]string{}
+				repeatFixture(files, "src/mod%d.js", 2, jsSource)
+				return files
+			}(),
+			skip: []string{"Use single quotes for JavaScript/TypeScript strings"},
+		},
+		{
+			name: "inconsistent quotes stay hidden",
+			files: func() map[string]string {
+				files := map[string]string{}
+				repeatFixture(files, "src/single%d.js", 6, "const a = 'x'\nconst b = 'y'\n")
+				repeatFixture(files, "src/double%d.js", 6, "const a = \"x\"\nconst b = \"y\"\n")
+				return files
+			}(),
+			want: []string{"Omit semicolons at the end of statements"},
+			skip: []string{
+				"Use single quotes for JavaScript/TypeScript strings",
+				"Use double quotes for JavaScript/TypeScript strings",
+			},
+		},
+		{
+			name: "generated files are ignored",
+			files: func() map[string]string {
+				files := map[string]string{}
+				repeatFixture(files, "dist/bundle%d.min.js", 12, jsSource)
+				repeatFixture(files, "gen/api%d.go", 6, "// Code generated by protoc. DO NOT EDIT.\n\npackage api\n")
+				return files
+			}(),
+			skip: []string{"Use single quotes for JavaScript/TypeScript strings", "Keep Go lines within 80 characters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inferredDescriptions(t, tt.files, &styleRules{})
+			for _, want := range tt.want {
+				confidence, ok := got[want]
+				if !ok {
+					t.Errorf("missing %q, got %v", want, got)
+					continue
+				}
+				if confidence < minInferenceConfidence || confidence > 1 {
+					t.Errorf("%q confidence = %v, want within [%v, 1]", want, confidence, minInferenceConfidence)
+				}
+			}
+			for _, skip := range tt.skip {
+				if _, ok := got[skip]; ok {
+					t.Errorf("unexpected %q", skip)
+				}
+			}
+		})
+	}
+}
+
+func TestInferCodeStyle_ConfiguredRulesWin(t *testing.T) {
+	files := map[string]string{}
+	repeatFixture(files, "src/mod%d.js", 12, "const a = 'x';\nconst b = 'y';\n")
+
+	configured := &styleRules{}
+	configured.add("js:quotes", "code-style", "Use double quotes for strings (Prettier)")
+	configured.add("*:line-length", "code-style", "Keep lines within 100 characters (.editorconfig)")
+
+	got := inferredDescriptions(t, files, configured)
+	if _, ok := got["End statements with semicolons"]; !ok {
+		t.Errorf("expected semicolons to be inferred, got %v", got)
+	}
+	for _, skip := range []string{
+		"Use single quotes for JavaScript/TypeScript strings",
+		"Keep JavaScript/TypeScript lines within 80 characters",
+	} {
+		if _, ok := got[skip]; ok {
+			t.Errorf("inferred %q although a config sets it", skip)
+		}
+	}
+}
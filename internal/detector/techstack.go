@@ -291,6 +291,8 @@ func (d *TechStackDetector) parsePythonDeps(content string, stack *types.TechSta
 		"pandas":      {"pandas", "data"},
 		"numpy":       {"NumPy", "data"},
 		"pydantic":    {"Pydantic", "validation"},
+		"click":       {"Click", "cli"},
+		"typer":       {"Typer", "cli"},
 	}
 
 	contentLower := strings.ToLower(content)
@@ -0,0 +1,695 @@
+package detector
+
+import (
+	"bufio"
+	"encoding/xml"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Test suite scan limits
+const (
+	testSuiteMaxFileSize = 300000
+	testSuiteMaxUntested = 20
+)
+
+// testSourceExts are the source extensions considered when mapping tests to code
+var testSourceExts = map[string]string{
+	".go": "Go", ".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".vue": "JavaScript", ".svelte": "JavaScript",
+	".py": "Python", ".rs": "Rust", ".java": "Java", ".kt": "Kotlin", ".rb": "Ruby",
+}
+
+// testDirNames are directories that hold a separate test tree
+var testDirNames = map[string]bool{
+	"test": true, "tests": true, "spec": true, "__tests__": true, "e2e": true,
+}
+
+// testFixtureDirs hold data files used by tests
+var testFixtureDirs = map[string]bool{
+	"testdata": true, "fixtures": true, "__fixtures__": true, "test-fixtures": true, "__snapshots__": true,
+}
+
+// testHelperDirs hold shared test utilities
+var testHelperDirs = map[string]bool{
+	"testutil": true, "testutils": true, "testhelper": true, "testhelpers": true,
+	"test-utils": true, "test_utils": true, "testsupport": true,
+}
+
+// testHelperFiles are shared setup files picked up by test frameworks
+var testHelperFiles = map[string]bool{
+	"conftest.py": true, "setupTests.js": true, "setupTests.ts": true,
+	"jest.setup.js": true, "jest.setup.ts": true, "vitest.setup.js": true, "vitest.setup.ts": true,
+	"test_helper.rb": true, "spec_helper.rb": true, "rails_helper.rb": true,
+	"main_test.go": true, "helpers_test.go": true, "helper_test.go": true,
+}
+
+// nonSourceDirs never count as untested code
+var nonSourceDirs = map[string]bool{
+	"examples": true, "example": true, "docs": true, "scripts": true, "migrations": true,
+	"generated": true, "mocks": true, "mock": true, "__mocks__": true, "vendor": true, "node_modules": true,
+}
+
+// mockMarkers identify mocking libraries and generators by their import
+// statements, //go:generate directives and call sites. Each pattern is
+// anchored to the start of a line so comments, docs and string literals that
+// merely mention a library are not counted as usage.
+var mockMarkers = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"mockery", regexp.MustCompile(`(?m)^//go:generate\s+(?:go run \S*)?mockery\b`)},
+	{"gomock", regexp.MustCompile(`(?m)^//go:generate\s+(?:go run \S*)?mockgen\b`)},
+	{"gomock", regexp.MustCompile(`(?m)^\s*(?:import\s+)?(?:[\w.]+\s+)?"[\w./-]+/mock/gomock"\s*\)?\s*$`)},
+	{"testify/mock", regexp.MustCompile(`(?m)^\s*(?:import\s+)?(?:[\w.]+\s+)?"github\.com/stretchr/testify/mock"\s*\)?\s*$`)},
+	{"jest mocks", regexp.MustCompile(`(?m)^\s*jest\.mock\s*\(`)},
+	{"vitest mocks", regexp.MustCompile(`(?m)^\s*vi\.mock\s*\(`)},
+	{"unittest.mock", regexp.MustCompile(`(?m)^\s*(?:from\s+unittest\.mock\s+import\b|import\s+unittest\.mock\b|from\s+unittest\s+import\s+.*\bmock\b)`)},
+	{"pytest-mock", regexp.MustCompile(`(?m)^[^#'"\n]*\bmocker\.patch(?:\.\w+)?\s*\(`)},
+	{"mockall", regexp.MustCompile(`(?m)^\s*(?:pub\s+)?use\s+mockall\b`)},
+	{"mockall", regexp.MustCompile(`(?m)^\s*#\[(?:cfg_attr\([^)]*,\s*)?automock\)?\]`)},
+	{"Mockito", regexp.MustCompile(`(?m)^\s*import\s+(?:static\s+)?org\.mockito\.`)},
+	{"MockK", regexp.MustCompile(`(?m)^\s*import\s+io\.mockk\.`)},
+}
+
+// mockeryConfigFiles configure mockery's generated mocks
+var mockeryConfigFiles = []string{".mockery.yaml", ".mockery.yml", "mockery.yaml"}
+
+// testCountRegexes count test cases per language
+var testCountRegexes = map[string]*regexp.Regexp{
+	"Go":         regexp.MustCompile(`(?m)^func (?:Test|Fuzz)\w*\(\s*\w+\s+\*testing\.[TF]\s*\)`),
+	"JavaScript": regexp.MustCompile(`(?m)^\s*(?:it|test)(?:\.(?:only|skip|concurrent|each\([^)]*\)))?\s*\(`),
+	"Python":     regexp.MustCompile(`(?m)^\s*(?:async\s+)?def test\w*\s*\(`),
+	"Rust":       regexp.MustCompile(`#\[(?:tokio::)?test\b`),
+	"Java":       regexp.MustCompile(`@(?:Test|ParameterizedTest)\b`),
+	"Ruby":       regexp.MustCompile(`(?m)^\s*(?:(?:it|specify|scenario|test)\s*(?:\(|['"])|def test_)`),
+}
+
+// coverageCandidates are report locations in the order they are tried
+var coverageCandidates = []struct {
+	path   string
+	format string
+}{
+	{"coverage.out", "go"},
+	{"cover.out", "go"},
+	{"coverage.txt", "go"},
+	{"lcov.info", "lcov"},
+	{"coverage/lcov.info", "lcov"},
+	{"coverage.xml", "cobertura"},
+	{"coverage/cobertura-coverage.xml", "cobertura"},
+}
+
+// TestSuiteDetector inventories tests, test support files and coverage
+type TestSuiteDetector struct {
+	rootPath string
+	files    []types.FileInfo
+}
+
+// NewTestSuiteDetector creates a new test suite detector
+func NewTestSuiteDetector(rootPath string, files []types.FileInfo) *TestSuiteDetector {
+	return &TestSuiteDetector{
+		rootPath: rootPath,
+		files:    files,
+	}
+}
+
+// testFile is a test file and the number of tests it declares
+type testFile struct {
+	path     string
+	language string
+	tests    int
+}
+
+// Detect returns the test inventory, or nil when the project has no tests
+func (d *TestSuiteDetector) Detect() *types.TestInventory {
+	files := make([]types.FileInfo, 0, len(d.files))
+	for _, f := range d.files {
+		if !f.IsDir {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	inventory := &types.TestInventory{}
+	sourceDirs := make(map[string]int)     // dir -> source file count
+	stemDirs := make(map[string][]string)  // lowercased file stem -> source dirs
+	mockSources := make(map[string]string) // mock tool -> first file using it
+	languages := make(map[string]bool)
+	var tests []testFile
+
+	for _, f := range files {
+		name := f.Name
+		if name == "" {
+			name = filepath.Base(f.Path)
+		}
+		relPath := filepath.ToSlash(f.Path)
+		segments := strings.Split(path.Dir(relPath), "/")
+
+		for i, seg := range segments {
+			dir := strings.Join(segments[:i+1], "/")
+			if testFixtureDirs[seg] {
+				inventory.Fixtures = appendUnique(inventory.Fixtures, dir)
+			}
+			if testHelperDirs[seg] {
+				inventory.Helpers = appendUnique(inventory.Helpers, dir+"/")
+			}
+			if seg == "__mocks__" {
+				if _, ok := mockSources["jest mocks"]; !ok {
+					mockSources["jest mocks"] = dir
+				}
+			}
+		}
+		if testHelperFiles[name] {
+			inventory.Helpers = appendUnique(inventory.Helpers, relPath)
+		}
+
+		language, ok := testSourceExts[f.Extension]
+		if !ok || f.Size > testSuiteMaxFileSize || hasSegment(segments, testFixtureDirs) {
+			continue
+		}
+		if language == "TypeScript" {
+			language = "JavaScript"
+		}
+
+		isTest := isTestFile(relPath, name, language)
+		var content string
+		if isTest || language == "Go" || language == "Rust" {
+			data, err := os.ReadFile(filepath.Join(d.rootPath, f.Path))
+			if err != nil {
+				continue
+			}
+			content = string(data)
+			for _, m := range mockMarkers {
+				if _, seen := mockSources[m.name]; !seen && m.pattern.MatchString(content) {
+					mockSources[m.name] = relPath
+				}
+			}
+		}
+
+		// Rust unit tests live in the source file they test
+		if !isTest && language == "Rust" && strings.Contains(content, "#[cfg(test)]") {
+			if n := len(testCountRegexes["Rust"].FindAllStringIndex(content, -1)); n > 0 {
+				tests = append(tests, testFile{path: relPath, language: language, tests: n})
+				languages[language] = true
+			}
+		}
+
+		if isTest {
+			count := 0
+			if re := testCountRegexes[language]; re != nil {
+				count = len(re.FindAllStringIndex(content, -1))
+			}
+			if language == "Kotlin" {
+				count = len(testCountRegexes["Java"].FindAllStringIndex(content, -1))
+			}
+			tests = append(tests, testFile{path: relPath, language: language, tests: count})
+			languages[language] = true
+			continue
+		}
+
+		if isSupportSource(name, segments) {
+			continue
+		}
+		dir := path.Dir(relPath)
+		sourceDirs[dir]++
+		stem := strings.ToLower(strings.TrimSuffix(name, f.Extension))
+		stemDirs[stem] = appendUnique(stemDirs[stem], dir)
+	}
+
+	for _, name := range mockeryConfigFiles {
+		if d.hasAnyFile(name) {
+			mockSources["mockery"] = name
+			break
+		}
+	}
+
+	coverage, dirCoverage := d.readCoverage()
+	if len(tests) == 0 && coverage == nil {
+		return nil
+	}
+	inventory.Coverage = coverage
+
+	// Group test files into areas by the source directory they cover
+	tested := make(map[string]bool)
+	areas := make(map[[2]string]*types.TestArea)
+	var areaKeys [][2]string
+	for _, t := range tests {
+		testDir := path.Dir(t.path)
+		dir := testSourceDir(t.path, sourceDirs, stemDirs)
+		for _, stemDir := range stemDirs[testStem(t.path)] {
+			tested[stemDir] = true
+		}
+		tested[dir] = true
+
+		key := [2]string{dir, testDir}
+		area, ok := areas[key]
+		if !ok {
+			area = &types.TestArea{Dir: dir, TestDir: testDir}
+			areas[key] = area
+			areaKeys = append(areaKeys, key)
+		}
+		area.Files++
+		area.Tests += t.tests
+		inventory.TestFiles++
+		inventory.TestCount += t.tests
+	}
+	sort.Slice(areaKeys, func(i, j int) bool {
+		if areaKeys[i][0] != areaKeys[j][0] {
+			return areaKeys[i][0] < areaKeys[j][0]
+		}
+		return areaKeys[i][1] < areaKeys[j][1]
+	})
+	for _, key := range areaKeys {
+		area := areas[key]
+		if pct, ok := dirCoverage[area.Dir]; ok {
+			area.Coverage = pct
+		}
+		inventory.Areas = append(inventory.Areas, *area)
+	}
+
+	// Untested source directories, largest first
+	for dir := range sourceDirs {
+		if !tested[dir] {
+			inventory.Untested = append(inventory.Untested, dir)
+		}
+	}
+	sort.Slice(inventory.Untested, func(i, j int) bool {
+		a, b := inventory.Untested[i], inventory.Untested[j]
+		if sourceDirs[a] != sourceDirs[b] {
+			return sourceDirs[a] > sourceDirs[b]
+		}
+		return a < b
+	})
+	if len(inventory.Untested) > testSuiteMaxUntested {
+		inventory.Untested = inventory.Untested[:testSuiteMaxUntested]
+	}
+
+	for _, name := range sortedStringKeys(mockSources) {
+		inventory.Mocks = append(inventory.Mocks, types.MockTool{Name: name, Source: mockSources[name]})
+	}
+	inventory.Runners = d.testRunners(languages)
+
+	return inventory
+}
+
+// isTestFile reports whether a source file holds tests by its language's naming rules
+func isTestFile(relPath, name, language string) bool {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	switch language {
+	case "Go":
+		return strings.HasSuffix(base, "_test")
+	case "JavaScript":
+		return strings.HasSuffix(base, ".test") || strings.HasSuffix(base, ".spec") ||
+			strings.Contains("/"+relPath, "/__tests__/")
+	case "Python":
+		return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test")
+	case "Rust":
+		return strings.HasPrefix(relPath, "tests/") || strings.Contains(relPath, "/tests/")
+	case "Java", "Kotlin":
+		return strings.HasSuffix(base, "Test") || strings.HasSuffix(base, "Tests") || strings.HasSuffix(base, "IT")
+	case "Ruby":
+		return strings.HasSuffix(base, "_spec") || strings.HasSuffix(base, "_test")
+	}
+	return false
+}
+
+// isSupportSource reports whether a non-test source file is test support,
+// generated or configuration code that should not count as untested
+func isSupportSource(name string, segments []string) bool {
+	if hasSegment(segments, testDirNames) || hasSegment(segments, testHelperDirs) || hasSegment(segments, nonSourceDirs) {
+		return true
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "__init__.py" || lower == "setup.py" || lower == "doc.go":
+		return true
+	case strings.HasSuffix(lower, ".pb.go") || strings.HasSuffix(lower, "_gen.go") || strings.HasSuffix(lower, ".d.ts"):
+		return true
+	case strings.Contains(lower, ".config.") || strings.HasPrefix(lower, "mock_") || strings.HasSuffix(lower, "_mock.go"):
+		return true
+	}
+	return false
+}
+
+// hasSegment reports whether any path segment is in the set
+func hasSegment(segments []string, set map[string]bool) bool {
+	for _, seg := range segments {
+		if set[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// testStem returns the lowercased name of the source file a test file is named after
+func testStem(testPath string) string {
+	base := path.Base(testPath)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	for _, suffix := range []string{"_test", ".test", ".spec", "_spec", "Tests", "Test", "IT"} {
+		if strings.HasSuffix(stem, suffix) && len(stem) > len(suffix) {
+			stem = strings.TrimSuffix(stem, suffix)
+			break
+		}
+	}
+	stem = strings.TrimPrefix(stem, "test_")
+	return strings.ToLower(stem)
+}
+
+// testSourceDir returns the source directory a test file covers. Colocated
+// tests cover their own directory; tests in a separate tree are mapped to
+// the mirrored source directory, or to the directory of the file they are
+// named after.
+func testSourceDir(testPath string, sourceDirs map[string]int, stemDirs map[string][]string) string {
+	dir := path.Dir(testPath)
+	segments := strings.Split(dir, "/")
+
+	idx := -1
+	for i, seg := range segments {
+		if testDirNames[seg] {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return dir
+	}
+
+	prefix := segments[:idx]
+	rest := segments[idx+1:]
+	if segments[idx] == "__tests__" {
+		candidate := path.Join(append(append([]string{}, prefix...), rest...)...)
+		if candidate == "" {
+			candidate = "."
+		}
+		return candidate
+	}
+
+	if len(rest) > 0 {
+		for _, mid := range []string{"", "main", "src", "lib", "app"} {
+			parts := append([]string{}, prefix...)
+			if mid != "" {
+				parts = append(parts, mid)
+			}
+			candidate := path.Join(append(parts, rest...)...)
+			if sourceDirs[candidate] > 0 {
+				return candidate
+			}
+		}
+	}
+
+	if dirs := stemDirs[testStem(testPath)]; len(dirs) == 1 {
+		return dirs[0]
+	}
+	return dir
+}
+
+// testRunners returns how to run one file and one test for each language with tests
+func (d *TestSuiteDetector) testRunners(languages map[string]bool) []types.TestRunner {
+	var runners []types.TestRunner
+
+	if languages["Go"] {
+		runners = append(runners, types.TestRunner{
+			Language: "Go", Framework: "go test",
+			RunFile:   "go test ./<package>",
+			RunSingle: "go test ./<package> -run '^<name>$'",
+		})
+	}
+
+	if languages["JavaScript"] {
+		var pkg map[string]interface{}
+		if data, err := readJSON(filepath.Join(d.rootPath, "package.json")); err == nil {
+			pkg, _ = data.(map[string]interface{})
+		}
+		runner := types.TestRunner{Language: "JavaScript", Framework: "node:test",
+			RunFile: "node --test <file>", RunSingle: "node --test --test-name-pattern='<name>' <file>"}
+		switch {
+		case packageHasDependency(pkg, "vitest"):
+			runner = types.TestRunner{Language: "JavaScript", Framework: "Vitest",
+				RunFile: "npx vitest run <file>", RunSingle: "npx vitest run <file> -t '<name>'"}
+		case packageHasDependency(pkg, "jest") || d.hasAnyFile("jest.config.js", "jest.config.ts", "jest.config.mjs"):
+			runner = types.TestRunner{Language: "JavaScript", Framework: "Jest",
+				RunFile: "npx jest <file>", RunSingle: "npx jest <file> -t '<name>'"}
+		case packageHasDependency(pkg, "mocha"):
+			runner = types.TestRunner{Language: "JavaScript", Framework: "Mocha",
+				RunFile: "npx mocha <file>", RunSingle: "npx mocha <file> --grep '<name>'"}
+		}
+		runners = append(runners, runner)
+	}
+
+	if languages["Python"] {
+		runner := types.TestRunner{Language: "Python", Framework: "pytest",
+			RunFile: "pytest <file>", RunSingle: "pytest <file>::<name>"}
+		if !d.usesPytest() {
+			runner = types.TestRunner{Language: "Python", Framework: "unittest",
+				RunFile: "python -m unittest <module>", RunSingle: "python -m unittest <module>.<class>.<name>"}
+		}
+		runners = append(runners, runner)
+	}
+
+	if languages["Rust"] {
+		runners = append(runners, types.TestRunner{
+			Language: "Rust", Framework: "cargo test",
+			RunFile:   "cargo test --test <file>",
+			RunSingle: "cargo test <name>",
+		})
+	}
+
+	if languages["Java"] || languages["Kotlin"] {
+		language := "Java"
+		if !languages["Java"] {
+			language = "Kotlin"
+		}
+		runner := types.TestRunner{Language: language, Framework: "JUnit (Maven)",
+			RunFile: "mvn test -Dtest=<class>", RunSingle: "mvn test -Dtest='<class>#<name>'"}
+		if !d.hasAnyFile("pom.xml") {
+			gradle := "gradle"
+			if d.hasAnyFile("gradlew") {
+				gradle = "./gradlew"
+			}
+			runner = types.TestRunner{Language: language, Framework: "JUnit (Gradle)",
+				RunFile: gradle + " test --tests '<class>'", RunSingle: gradle + " test --tests '<class>.<name>'"}
+		}
+		runners = append(runners, runner)
+	}
+
+	if languages["Ruby"] {
+		runner := types.TestRunner{Language: "Ruby", Framework: "Minitest",
+			RunFile: "bundle exec ruby -Itest <file>", RunSingle: "bundle exec ruby -Itest <file> -n <name>"}
+		if d.hasAnyFile(".rspec", "spec/spec_helper.rb") {
+			runner = types.TestRunner{Language: "Ruby", Framework: "RSpec",
+				RunFile: "bundle exec rspec <file>", RunSingle: "bundle exec rspec <file> -e '<name>'"}
+		} else if d.hasAnyFile("bin/rails") {
+			runner.RunFile = "bin/rails test <file>"
+			runner.RunSingle = "bin/rails test <file> -n <name>"
+		}
+		runners = append(runners, runner)
+	}
+
+	return runners
+}
+
+// hasAnyFile reports whether any of the root-relative files exist
+func (d *TestSuiteDetector) hasAnyFile(names ...string) bool {
+	for _, name := range names {
+		if fileExists(filepath.Join(d.rootPath, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesPytest reports whether a Python project is set up for pytest
+func (d *TestSuiteDetector) usesPytest() bool {
+	if d.hasAnyFile("pytest.ini", "conftest.py", "tests/conftest.py") {
+		return true
+	}
+	for _, name := range []string{"pyproject.toml", "setup.cfg", "tox.ini", "requirements-dev.txt", "requirements.txt"} {
+		if content, err := os.ReadFile(filepath.Join(d.rootPath, name)); err == nil && strings.Contains(string(content), "pytest") {
+			return true
+		}
+	}
+	return false
+}
+
+// readCoverage parses the first coverage report found, returning the overall
+// line coverage and the coverage of each directory
+func (d *TestSuiteDetector) readCoverage() (*types.CoverageReport, map[string]float64) {
+	for _, candidate := range coverageCandidates {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, candidate.path))
+		if err != nil {
+			continue
+		}
+
+		var counts map[string][2]int
+		var total float64
+		switch candidate.format {
+		case "go":
+			if !strings.HasPrefix(string(content), "mode:") {
+				continue
+			}
+			counts = parseGoCoverProfile(string(content), d.goModulePath())
+		case "lcov":
+			counts = d.parseLcov(string(content))
+		case "cobertura":
+			counts, total = parseCobertura(content)
+		}
+
+		covered, lines := 0, 0
+		dirCoverage := make(map[string]float64)
+		for dir, c := range counts {
+			covered += c[0]
+			lines += c[1]
+			if c[1] > 0 {
+				dirCoverage[dir] = coveragePercent(c[0], c[1])
+			}
+		}
+		if lines > 0 {
+			total = coveragePercent(covered, lines)
+		}
+		return &types.CoverageReport{Path: candidate.path, Format: candidate.format, Percent: total}, dirCoverage
+	}
+	return nil, nil
+}
+
+// coveragePercent returns covered/total as a percentage with one decimal
+func coveragePercent(covered, total int) float64 {
+	return math.Round(float64(covered)*1000/float64(total)) / 10
+}
+
+// goModulePath returns the module path declared in go.mod
+func (d *TestSuiteDetector) goModulePath() string {
+	file, err := os.Open(filepath.Join(d.rootPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// parseGoCoverProfile returns covered and total statements per directory
+// from a `go test -coverprofile` file
+func parseGoCoverProfile(content, module string) map[string][2]int {
+	type block struct {
+		stmts   int
+		covered bool
+	}
+	blocks := make(map[string]*block)
+	var order []string
+
+	for _, line := range strings.Split(content, "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		stmts, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		b, ok := blocks[fields[0]]
+		if !ok {
+			b = &block{stmts: stmts}
+			blocks[fields[0]] = b
+			order = append(order, fields[0])
+		}
+		b.covered = b.covered || count > 0
+	}
+
+	counts := make(map[string][2]int)
+	for _, key := range order {
+		file := key[:strings.LastIndex(key, ":")]
+		if module != "" {
+			file = strings.TrimPrefix(strings.TrimPrefix(file, module), "/")
+		}
+		dir := path.Dir(file)
+		c := counts[dir]
+		if blocks[key].covered {
+			c[0] += blocks[key].stmts
+		}
+		c[1] += blocks[key].stmts
+		counts[dir] = c
+	}
+	return counts
+}
+
+// parseLcov returns hit and found lines per directory from an lcov report
+func (d *TestSuiteDetector) parseLcov(content string) map[string][2]int {
+	counts := make(map[string][2]int)
+	root := filepath.ToSlash(d.rootPath)
+	dir := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file := filepath.ToSlash(strings.TrimPrefix(line, "SF:"))
+			file = strings.TrimPrefix(strings.TrimPrefix(file, root), "/")
+			dir = path.Dir(file)
+		case strings.HasPrefix(line, "LH:"), strings.HasPrefix(line, "LF:"):
+			n, err := strconv.Atoi(line[3:])
+			if err != nil {
+				continue
+			}
+			c := counts[dir]
+			if line[1] == 'H' {
+				c[0] += n
+			} else {
+				c[1] += n
+			}
+			counts[dir] = c
+		}
+	}
+	return counts
+}
+
+// coberturaReport is the subset of a Cobertura XML report that Argus reads
+type coberturaReport struct {
+	LineRate float64 `xml:"line-rate,attr"`
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Hits int `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// parseCobertura returns covered and total lines per directory, plus the
+// report's overall line rate as a percentage
+func parseCobertura(content []byte) (map[string][2]int, float64) {
+	var report coberturaReport
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return nil, 0
+	}
+
+	counts := make(map[string][2]int)
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			dir := path.Dir(filepath.ToSlash(class.Filename))
+			c := counts[dir]
+			for _, line := range class.Lines {
+				if line.Hits > 0 {
+					c[0]++
+				}
+				c[1]++
+			}
+			counts[dir] = c
+		}
+	}
+	return counts, math.Round(report.LineRate*1000) / 10
+}
@@ -0,0 +1,196 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestTestSuiteDetector_Go(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"go.mod":                            "module github.com/acme/app\n\ngo 1.22\n",
+		".mockery.yaml":                     "with-expecter: true\n",
+		"internal/api/handler.go":           "package api\n",
+		"internal/api/handler_test.go":      "package api\n\nimport \"testing\"\n\nfunc TestHandler(t *testing.T) {}\n\nfunc TestHandler_NotFound(t *testing.T) {}\n\nfunc BenchmarkHandler(b *testing.B) {}\n",
+		"internal/api/testdata/req.json":    "{}\n",
+		"internal/store/store.go":           "package store\n\n//go:generate mockgen -destination=mocks/store.go . Store\n",
+		"internal/store/postgres.go":        "package store\n",
+		"internal/store/mocks/store.go":     "package mocks\n",
+		"internal/testutil/server.go":       "package testutil\n",
+		"cmd/app/main.go":                   "package main\n",
+		"coverage.out":                      "mode: set\ngithub.com/acme/app/internal/api/handler.go:3.1,5.2 3 1\ngithub.com/acme/app/internal/api/handler.go:6.1,8.2 1 0\ngithub.com/acme/app/internal/store/store.go:3.1,5.2 4 0\n",
+		"internal/api/handler_fuzz_test.go": "package api\n\nimport \"testing\"\n\nfunc FuzzHandler(f *testing.F) {}\n",
+	})
+
+	inv := NewTestSuiteDetector(tmpDir, files).Detect()
+	if inv == nil {
+		t.Fatal("expected test inventory")
+	}
+	if inv.TestFiles != 2 || inv.TestCount != 3 {
+		t.Errorf("expected 2 test files with 3 tests, got %d files and %d tests", inv.TestFiles, inv.TestCount)
+	}
+
+	wantAreas := []types.TestArea{{Dir: "internal/api", TestDir: "internal/api", Files: 2, Tests: 3, Coverage: 75}}
+	if !reflect.DeepEqual(inv.Areas, wantAreas) {
+		t.Errorf("expected areas %+v, got %+v", wantAreas, inv.Areas)
+	}
+	if !reflect.DeepEqual(inv.Untested, []string{"internal/store", "cmd/app"}) {
+		t.Errorf("expected untested store and cmd/app, largest first, got %v", inv.Untested)
+	}
+	if !reflect.DeepEqual(inv.Fixtures, []string{"internal/api/testdata"}) {
+		t.Errorf("expected testdata fixtures, got %v", inv.Fixtures)
+	}
+	if !reflect.DeepEqual(inv.Helpers, []string{"internal/testutil/"}) {
+		t.Errorf("expected testutil helpers, got %v", inv.Helpers)
+	}
+
+	wantMocks := []types.MockTool{{Name: "gomock", Source: "internal/store/store.go"}, {Name: "mockery", Source: ".mockery.yaml"}}
+	if !reflect.DeepEqual(inv.Mocks, wantMocks) {
+		t.Errorf("expected mocks %+v, got %+v", wantMocks, inv.Mocks)
+	}
+
+	if inv.Coverage == nil || inv.Coverage.Format != "go" || inv.Coverage.Percent != 37.5 {
+		t.Errorf("expected 37.5%% go coverage, got %+v", inv.Coverage)
+	}
+	if len(inv.Runners) != 1 || inv.Runners[0].RunSingle != "go test ./<package> -run '^<name>$'" {
+		t.Errorf("expected go test runner, got %+v", inv.Runners)
+	}
+}
+
+func TestTestSuiteDetector_SeparateTestTrees(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"package.json":                          `{"devDependencies": {"vitest": "^1.0.0"}}`,
+		"src/components/Button.tsx":             "export const Button = () => null\n",
+		"src/components/__tests__/Button.tsx":   "it('renders', () => {})\ntest.each([1, 2])('n %i', () => {})\n",
+		"src/utils/format.ts":                   "export {}\n",
+		"src/utils/format.test.ts":              "import { vi } from 'vitest'\nvi.mock('./api')\ndescribe('format', () => {\n  it('formats', () => {})\n})\n",
+		"app/services/billing.py":               "",
+		"app/services/__init__.py":              "",
+		"tests/conftest.py":                     "import pytest\n",
+		"tests/services/test_billing.py":        "def test_charge():\n    pass\n\nasync def test_refund():\n    pass\n",
+		"tests/test_cli.py":                     "from unittest import mock\n\ndef test_main():\n    pass\n",
+		"app/cli.py":                            "",
+		"src/main/java/com/acme/Order.java":     "class Order {}\n",
+		"src/test/java/com/acme/OrderTest.java": "class OrderTest {\n  @Test void total() {}\n  @ParameterizedTest void tax() {}\n}\n",
+		"pom.xml":                               "<project/>\n",
+	})
+
+	inv := NewTestSuiteDetector(tmpDir, files).Detect()
+	if inv == nil {
+		t.Fatal("expected test inventory")
+	}
+
+	wantAreas := []types.TestArea{
+		{Dir: "app", TestDir: "tests", Files: 1, Tests: 1},
+		{Dir: "app/services", TestDir: "tests/services", Files: 1, Tests: 2},
+		{Dir: "src/components", TestDir: "src/components/__tests__", Files: 1, Tests: 2},
+		{Dir: "src/main/java/com/acme", TestDir: "src/test/java/com/acme", Files: 1, Tests: 2},
+		{Dir: "src/utils", TestDir: "src/utils", Files: 1, Tests: 1},
+	}
+	if !reflect.DeepEqual(inv.Areas, wantAreas) {
+		t.Errorf("expected areas %+v, got %+v", wantAreas, inv.Areas)
+	}
+	if len(inv.Untested) != 0 {
+		t.Errorf("expected every source directory to be tested, got %v", inv.Untested)
+	}
+	if !reflect.DeepEqual(inv.Helpers, []string{"tests/conftest.py"}) {
+		t.Errorf("expected conftest helper, got %v", inv.Helpers)
+	}
+
+	wantMocks := []types.MockTool{{Name: "unittest.mock", Source: "tests/test_cli.py"}, {Name: "vitest mocks", Source: "src/utils/format.test.ts"}}
+	if !reflect.DeepEqual(inv.Mocks, wantMocks) {
+		t.Errorf("expected mocks %+v, got %+v", wantMocks, inv.Mocks)
+	}
+
+	var frameworks []string
+	for _, r := range inv.Runners {
+		frameworks = append(frameworks, r.Framework)
+	}
+	if !reflect.DeepEqual(frameworks, []string{"Vitest", "pytest", "JUnit (Maven)"}) {
+		t.Errorf("expected Vitest, pytest and Maven runners, got %v", frameworks)
+	}
+}
+
+func TestTestSuiteDetector_CoverageReports(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    float64
+		dir     string
+		dirPct  float64
+	}{
+		{
+			name:    "lcov",
+			file:    "coverage/lcov.info",
+			content: "TN:\nSF:src/a.ts\nLF:10\nLH:8\nend_of_record\nSF:lib/b.ts\nLF:10\nLH:2\nend_of_record\n",
+			want:    50,
+			dir:     "src",
+			dirPct:  80,
+		},
+		{
+			name:    "cobertura",
+			file:    "coverage.xml",
+			content: `<coverage line-rate="0.9"><packages><package><classes><class filename="app/a.py"><lines><line number="1" hits="1"/><line number="2" hits="0"/></lines></class></classes></package></packages></coverage>`,
+			want:    50,
+			dir:     "app",
+			dirPct:  50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, _ := writeProjectFixture(t, map[string]string{tt.file: tt.content})
+			report, dirs := NewTestSuiteDetector(tmpDir, nil).readCoverage()
+			if report == nil || report.Path != tt.file || report.Percent != tt.want {
+				t.Fatalf("expected %.1f%% from %s, got %+v", tt.want, tt.file, report)
+			}
+			if dirs[tt.dir] != tt.dirPct {
+				t.Errorf("expected %s at %.1f%%, got %v", tt.dir, tt.dirPct, dirs)
+			}
+		})
+	}
+}
+
+func TestTestSuiteDetector_NoTests(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{"main.go": "package main\n"})
+	if inv := NewTestSuiteDetector(tmpDir, files).Detect(); inv != nil {
+		t.Errorf("expected nil inventory, got %+v", inv)
+	}
+}
+
+func TestTestSuiteDetector_MockMentionsAreNotUsage(t *testing.T) {
+	tmpDir, files := writeProjectFixture(t, map[string]string{
+		"go.mod": "module example.com/app\n",
+		"internal/docs/docs.go": `package docs
+
+// Mocks are generated with gomock; see "go:generate mockgen" in the README.
+var tools = []string{"jest.mock(", "unittest.mock", "org.mockito"}
+`,
+		"internal/docs/docs_test.go": `package docs
+
+import "testing"
+
+func TestTools(t *testing.T) {
+	if len(tools) == 0 {
+		t.Fatal("mocker.patch and #[automock] are documented")
+	}
+}
+`,
+		"tests/test_notes.py": `"""Explain when to use unittest.mock versus mocker.patch()."""
+
+# jest.mock( is the JS equivalent
+def test_notes():
+    assert "from unittest import mock"
+`,
+	})
+
+	inv := NewTestSuiteDetector(tmpDir, files).Detect()
+	if inv == nil {
+		t.Fatal("expected test inventory")
+	}
+	if len(inv.Mocks) != 0 {
+		t.Errorf("expected no mocking tools from comments and strings, got %+v", inv.Mocks)
+	}
+}
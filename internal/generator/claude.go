@@ -3,6 +3,7 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"unicode"
@@ -13,7 +14,8 @@ import (
 
 // ClaudeGenerator generates CLAUDE.md files
 type ClaudeGenerator struct {
-	compact bool // Generate compact output for token efficiency
+	compact bool   // Generate compact output for token efficiency
+	diagram string // Architecture diagram format: ascii (default), mermaid, dot
 }
 
 // NewClaudeGenerator creates a new Claude generator
@@ -26,6 +28,11 @@ func (g *ClaudeGenerator) SetCompact(compact bool) {
 	g.compact = compact
 }
 
+// SetDiagramFormat selects how the architecture diagram is drawn
+func (g *ClaudeGenerator) SetDiagramFormat(format string) {
+	g.diagram = format
+}
+
 // Name returns the generator name
 func (g *ClaudeGenerator) Name() string {
 	return "claude"
@@ -67,11 +74,26 @@ func (g *ClaudeGenerator) Generate(analysis *types.Analysis) ([]byte, error) {
 	// Project Structure
 	g.writeStructure(&buf, &analysis.Structure)
 
-	// Key Files (limit in compact mode)
+	// Links to local context files in significant subdirectories
+	if g.compact {
+		g.writeNestedContextsCompact(&buf, analysis.NestedContexts)
+	} else {
+		g.writeNestedContexts(&buf, analysis.NestedContexts)
+	}
+
+	// Key Files (limit in compact mode); files covered by a nested context live there
+	keyFiles := keyFilesOutside(analysis.KeyFiles, analysis.NestedContexts)
+	if g.compact {
+		g.writeKeyFilesCompact(&buf, keyFiles)
+	} else {
+		g.writeKeyFiles(&buf, keyFiles)
+	}
+
+	// Code Ownership (for reviewer suggestions)
 	if g.compact {
-		g.writeKeyFilesCompact(&buf, analysis.KeyFiles)
+		g.writeOwnershipCompact(&buf, analysis.Ownership)
 	} else {
-		g.writeKeyFiles(&buf, analysis.KeyFiles)
+		g.writeOwnership(&buf, analysis.Ownership)
 	}
 
 	// Configuration System (skip in compact mode)
@@ -82,11 +104,60 @@ func (g *ClaudeGenerator) Generate(analysis *types.Analysis) ([]byte, error) {
 	// Development Setup
 	g.writeDevelopmentSetup(&buf, analysis.DevelopmentInfo)
 
+	// Infrastructure & Deployment
+	if g.compact {
+		g.writeInfrastructureCompact(&buf, analysis.Infrastructure)
+	} else {
+		g.writeInfrastructure(&buf, analysis.Infrastructure)
+	}
+
 	// Available Commands (detailed) - skip in compact, Quick Reference has essentials
 	if !g.compact {
 		g.writeCommands(&buf, analysis.Commands)
 	}
 
+	// Test inventory and how to run a single test
+	if g.compact {
+		g.writeTestsCompact(&buf, analysis.Tests)
+	} else {
+		g.writeTests(&buf, analysis.Tests)
+	}
+
+	// ML training workflow: entry points, configs, pipeline and tracking
+	if g.compact {
+		g.writeMLWorkflowCompact(&buf, analysis.MLWorkflow)
+	} else {
+		g.writeMLWorkflow(&buf, analysis.MLWorkflow)
+	}
+
+	// Jupyter notebooks: what each one imports, loads and is for
+	if g.compact {
+		g.writeNotebooksCompact(&buf, analysis.Notebooks)
+	} else {
+		g.writeNotebooks(&buf, analysis.Notebooks)
+	}
+
+	// CI pipelines and the checks to run before pushing
+	if g.compact {
+		g.writeCICompact(&buf, analysis.CIInfo)
+	} else {
+		g.writeCI(&buf, analysis.CIInfo)
+	}
+
+	// Release process (steps only in compact mode)
+	if g.compact {
+		g.writeReleaseCompact(&buf, analysis.Release)
+	} else {
+		g.writeRelease(&buf, analysis.Release)
+	}
+
+	// CLI command tree (command list only in compact mode)
+	if g.compact {
+		g.writeCLIReferenceCompact(&buf, analysis.CLIInfo)
+	} else {
+		g.writeCLIReference(&buf, analysis.CLIInfo)
+	}
+
 	// CLI Output & Verbosity (skip in compact mode)
 	if !g.compact {
 		g.writeCLIOutput(&buf, analysis.CLIInfo)
@@ -99,9 +170,39 @@ func (g *ClaudeGenerator) Generate(analysis *types.Analysis) ([]byte, error) {
 		g.writeEndpoints(&buf, analysis.Endpoints)
 	}
 
+	// Message queue / event bus topics (limit in compact mode)
+	if g.compact {
+		g.writeEventsCompact(&buf, analysis.Events)
+	} else {
+		g.writeEvents(&buf, analysis.Events)
+	}
+
+	// Database tables and migration commands (limit in compact mode)
+	if g.compact {
+		g.writeDatabaseSchemaCompact(&buf, analysis.DatabaseSchema)
+	} else {
+		g.writeDatabaseSchema(&buf, analysis.DatabaseSchema)
+	}
+
+	// Environment variables (limit in compact mode)
+	if g.compact {
+		g.writeEnvVarsCompact(&buf, analysis.EnvVars)
+	} else {
+		g.writeEnvVars(&buf, analysis.EnvVars)
+	}
+
 	// Conventions (includes git conventions)
 	g.writeConventions(&buf, analysis.Conventions, analysis.GitConventions)
 
+	// Hotspots and co-change coupling from git history
+	if analysis.GitConventions != nil {
+		if g.compact {
+			g.writeChurnCompact(&buf, analysis.GitConventions.Churn)
+		} else {
+			g.writeChurn(&buf, analysis.GitConventions.Churn)
+		}
+	}
+
 	// Guidelines based on tech stack
 	g.writeGuidelines(&buf, &analysis.TechStack)
 
@@ -219,6 +320,10 @@ func (g *ClaudeGenerator) writeArchitecture(buf *bytes.Buffer, mono *types.Monor
 		buf.WriteString("**Workspaces:** ")
 		buf.WriteString("`" + strings.Join(mono.WorkspacePaths, "`, `") + "`\n\n")
 	}
+	if len(mono.ExcludePaths) > 0 {
+		buf.WriteString("**Excluded:** ")
+		buf.WriteString("`" + strings.Join(mono.ExcludePaths, "`, `") + "`\n\n")
+	}
 
 	// Package descriptions
 	if len(mono.Packages) > 0 {
@@ -271,7 +376,11 @@ func (g *ClaudeGenerator) writeArchitectureDiagram(buf *bytes.Buffer, arch *type
 	}
 
 	// Write diagram only if it has meaningful structure
-	if arch.Diagram != "" && len(arch.Layers) > 1 {
+	if g.diagram == DiagramMermaid || g.diagram == DiagramDOT {
+		if diagram, err := RenderDiagram(arch, g.diagram, DefaultDiagramNodes); err == nil {
+			fmt.Fprintf(buf, "```%s\n%s```\n\n", g.diagram, diagram)
+		}
+	} else if arch.Diagram != "" && len(arch.Layers) > 1 {
 		buf.WriteString(arch.Diagram)
 		buf.WriteString("\n")
 	}
@@ -408,10 +517,63 @@ func (g *ClaudeGenerator) writeStructure(buf *bytes.Buffer, structure *types.Pro
 	buf.WriteString("```\n\n")
 }
 
+// writeNestedContexts links the CLAUDE.md files generated in subdirectories
+func (g *ClaudeGenerator) writeNestedContexts(buf *bytes.Buffer, nested []types.NestedContext) {
+	if len(nested) == 0 {
+		return
+	}
+
+	buf.WriteString("## Directory Guides\n\n")
+	buf.WriteString("These directories have their own `CLAUDE.md` with local layout, key files, endpoints and tests. Read the one for the area you are changing:\n\n")
+	for _, n := range nested {
+		if n.Purpose != "" {
+			fmt.Fprintf(buf, "- [`%s/`](%s/CLAUDE.md) - %s\n", n.Path, n.Path, n.Purpose)
+		} else {
+			fmt.Fprintf(buf, "- [`%s/`](%s/CLAUDE.md)\n", n.Path, n.Path)
+		}
+	}
+	buf.WriteString("\n")
+}
+
+// writeNestedContextsCompact lists nested CLAUDE.md files on one line
+func (g *ClaudeGenerator) writeNestedContextsCompact(buf *bytes.Buffer, nested []types.NestedContext) {
+	if len(nested) == 0 {
+		return
+	}
+
+	paths := make([]string, len(nested))
+	for i, n := range nested {
+		paths[i] = n.Path + "/CLAUDE.md"
+	}
+	fmt.Fprintf(buf, "**Directory guides:** %s\n\n", codeList(paths))
+}
+
+// keyFilesOutside drops key files that a nested context file already lists
+func keyFilesOutside(keyFiles []types.KeyFile, nested []types.NestedContext) []types.KeyFile {
+	if len(nested) == 0 {
+		return keyFiles
+	}
+	var kept []types.KeyFile
+	for _, kf := range keyFiles {
+		covered := false
+		for _, n := range nested {
+			if strings.HasPrefix(kf.Path, n.Path+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			kept = append(kept, kf)
+		}
+	}
+	return kept
+}
+
 // treeNode represents a node in the directory tree
 type treeNode struct {
 	name     string
 	purpose  string
+	owners   []string
 	children map[string]*treeNode
 }
 
@@ -432,9 +594,10 @@ func buildDirectoryTree(dirs []types.Directory) *treeNode {
 			}
 			current = current.children[part]
 
-			// Set purpose on the deepest node
+			// Set purpose and owners on the deepest node
 			if i == len(parts)-1 {
 				current.purpose = dir.Purpose
+				current.owners = dir.Owners
 			}
 		}
 	}
@@ -466,9 +629,13 @@ func renderTree(buf *bytes.Buffer, node *treeNode, prefix string, isRoot bool, r
 			connector = "└── "
 		}
 
-		// Format line with purpose comment
-		if child.purpose != "" {
-			fmt.Fprintf(buf, "%s%s%s/          # %s\n", prefix, connector, name, child.purpose)
+		// Format line with purpose and owners comment
+		comment := child.purpose
+		if len(child.owners) > 0 {
+			comment = strings.TrimSpace(comment + " (owners: " + strings.Join(child.owners, ", ") + ")")
+		}
+		if comment != "" {
+			fmt.Fprintf(buf, "%s%s%s/          # %s\n", prefix, connector, name, comment)
 		} else {
 			fmt.Fprintf(buf, "%s%s%s/\n", prefix, connector, name)
 		}
@@ -508,20 +675,309 @@ func (g *ClaudeGenerator) writeKeyFiles(buf *bytes.Buffer, keyFiles []types.KeyF
 		return
 	}
 
+	hasOwners := false
+	for _, kf := range keyFiles {
+		if len(kf.Owners) > 0 {
+			hasOwners = true
+			break
+		}
+	}
+
 	buf.WriteString("## Key Files\n\n")
-	buf.WriteString("| File | Purpose | Description |\n")
-	buf.WriteString("|------|---------|-------------|\n")
+	if hasOwners {
+		buf.WriteString("| File | Purpose | Description | Owners |\n")
+		buf.WriteString("|------|---------|-------------|--------|\n")
+	} else {
+		buf.WriteString("| File | Purpose | Description |\n")
+		buf.WriteString("|------|---------|-------------|\n")
+	}
 
 	for _, kf := range keyFiles {
 		desc := kf.Description
 		if desc == "" {
 			desc = "-"
 		}
-		fmt.Fprintf(buf, "| `%s` | %s | %s |\n", kf.Path, kf.Purpose, desc)
+		if hasOwners {
+			fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", kf.Path, kf.Purpose, desc, ownerList(kf.Owners))
+		} else {
+			fmt.Fprintf(buf, "| `%s` | %s | %s |\n", kf.Path, kf.Purpose, desc)
+		}
 	}
 	buf.WriteString("\n")
 }
 
+// writeOwnership writes CODEOWNERS rules, or top contributors per directory
+// when the repository has no CODEOWNERS file
+func (g *ClaudeGenerator) writeOwnership(buf *bytes.Buffer, ownership *types.Ownership) {
+	if ownership == nil || len(ownership.Entries) == 0 {
+		return
+	}
+
+	buf.WriteString("## Code Ownership\n\n")
+	if ownership.Source == detector.OwnershipSourceGit {
+		buf.WriteString("No CODEOWNERS file; owners are the top committers per directory in recent git history.\n\n")
+	} else {
+		fmt.Fprintf(buf, "Rules from `%s`. The last matching rule wins.\n\n", ownership.Source)
+	}
+
+	hasSections := false
+	for _, entry := range ownership.Entries {
+		if entry.Section != "" {
+			hasSections = true
+			break
+		}
+	}
+
+	if hasSections {
+		buf.WriteString("| Section | Path | Owners |\n")
+		buf.WriteString("|---------|------|--------|\n")
+	} else {
+		buf.WriteString("| Path | Owners |\n")
+		buf.WriteString("|------|--------|\n")
+	}
+
+	maxEntries := 25
+	for i, entry := range ownership.Entries {
+		if i >= maxEntries {
+			break
+		}
+		if hasSections {
+			fmt.Fprintf(buf, "| %s | `%s` | %s |\n", entry.Section, entry.Path, ownerList(entry.Owners))
+		} else {
+			fmt.Fprintf(buf, "| `%s` | %s |\n", entry.Path, ownerList(entry.Owners))
+		}
+	}
+	if len(ownership.Entries) > maxEntries {
+		fmt.Fprintf(buf, "\n*...and %d more rules*\n", len(ownership.Entries)-maxEntries)
+	}
+
+	buf.WriteString("\nWhen suggesting reviewers for a change, use the owners of the touched paths.\n\n")
+}
+
+// ownerList formats owners for a table cell
+func ownerList(owners []string) string {
+	if len(owners) == 0 {
+		return "*unowned*"
+	}
+	return strings.Join(owners, ", ")
+}
+
+// writeCI writes the commands CI runs and a summary of each pipeline
+func (g *ClaudeGenerator) writeCI(buf *bytes.Buffer, ci *types.CIInfo) {
+	if ci == nil || len(ci.Pipelines) == 0 {
+		return
+	}
+
+	buf.WriteString("## CI Pipeline\n\n")
+	writeCIChecks(buf, ci.Commands, 0)
+
+	buf.WriteString("| Pipeline | Provider | Triggers | Jobs |\n")
+	buf.WriteString("|----------|----------|----------|------|\n")
+	for _, pipeline := range ci.Pipelines {
+		var jobs []string
+		for _, job := range pipeline.Jobs {
+			jobs = append(jobs, job.Name)
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", pipeline.File, pipeline.Provider,
+			strings.Join(pipeline.Triggers, ", "), strings.Join(jobs, ", "))
+	}
+	buf.WriteString("\n")
+
+	if len(ci.RequiredChecks) > 0 {
+		var checks []string
+		for _, check := range ci.RequiredChecks {
+			checks = append(checks, "`"+check+"`")
+		}
+		fmt.Fprintf(buf, "**Required checks:** %s\n\n", strings.Join(checks, ", "))
+	}
+}
+
+// writeCIChecks writes the commands CI runs as a block to run before pushing
+func writeCIChecks(buf *bytes.Buffer, commands []types.CICommand, maxCommands int) {
+	if len(commands) == 0 {
+		return
+	}
+
+	buf.WriteString("CI runs these checks; run them locally before pushing:\n\n")
+	buf.WriteString("```bash\n")
+	for i, c := range commands {
+		if maxCommands > 0 && i >= maxCommands {
+			break
+		}
+		fmt.Fprintf(buf, "%s\n", detector.CICommandName(c))
+	}
+	buf.WriteString("```\n\n")
+}
+
+// writeRelease writes release tooling, versioning and the steps to cut a release
+func (g *ClaudeGenerator) writeRelease(buf *bytes.Buffer, release *types.ReleaseInfo) {
+	if release == nil {
+		return
+	}
+
+	buf.WriteString("## Release Process\n\n")
+
+	if len(release.Tools) > 0 {
+		buf.WriteString("| Tool | Config | Runs in CI |\n")
+		buf.WriteString("|------|--------|------------|\n")
+		for _, tool := range release.Tools {
+			ci := "No"
+			if tool.CI {
+				ci = "Yes"
+			}
+			fmt.Fprintf(buf, "| %s | `%s` | %s |\n", tool.Name, tool.ConfigFile, ci)
+		}
+		buf.WriteString("\n")
+	}
+
+	if release.TagPattern != "" {
+		fmt.Fprintf(buf, "- **Tags:** `%s`", release.TagPattern)
+		if release.LatestTag != "" {
+			fmt.Fprintf(buf, " (latest: `%s`)", release.LatestTag)
+		}
+		buf.WriteString("\n")
+	}
+	if len(release.VersionFiles) > 0 {
+		var files []string
+		for _, vf := range release.VersionFiles {
+			files = append(files, fmt.Sprintf("`%s` (%s)", vf.Path, vf.Version))
+		}
+		fmt.Fprintf(buf, "- **Version files:** %s\n", strings.Join(files, ", "))
+	}
+	if cl := release.Changelog; cl != nil {
+		fmt.Fprintf(buf, "- **Changelog:** `%s` - %s\n", cl.Path, changelogGuidance(cl))
+	}
+	if release.TagPattern != "" || len(release.VersionFiles) > 0 || release.Changelog != nil {
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("### Steps\n\n")
+	for i, step := range releaseSteps(release) {
+		fmt.Fprintf(buf, "%d. %s\n", i+1, step)
+	}
+	buf.WriteString("\n")
+}
+
+// changelogGuidance describes how the changelog is maintained
+func changelogGuidance(cl *types.Changelog) string {
+	switch cl.Format {
+	case detector.ChangelogGenerated:
+		if cl.Generator != "" {
+			return fmt.Sprintf("generated by %s; do not edit it by hand", cl.Generator)
+		}
+		return "generated; do not edit it by hand"
+	case detector.ChangelogKeepAChangelog:
+		if cl.Unreleased {
+			return "Keep a Changelog format; add user-facing changes under `## [Unreleased]`"
+		}
+		return "Keep a Changelog format"
+	default:
+		return "maintained by hand"
+	}
+}
+
+// releaseSteps returns the ordered steps to cut a release with the detected tooling
+func releaseSteps(release *types.ReleaseInfo) []string {
+	tools := make(map[string]types.ReleaseTool)
+	for _, tool := range release.Tools {
+		tools[tool.Name] = tool
+	}
+	has := func(name string) bool {
+		_, ok := tools[name]
+		return ok
+	}
+	goreleaser, hasGoReleaser := tools[detector.ReleaseToolGoReleaser]
+	tag := exampleTag(release.TagPattern)
+
+	changelog := "the changelog"
+	if release.Changelog != nil {
+		changelog = "`" + release.Changelog.Path + "`"
+	}
+
+	var steps []string
+	switch {
+	case has(detector.ReleaseToolReleasePlease):
+		steps = append(steps,
+			"Merge changes to the default branch with commit messages that follow the commit convention",
+			fmt.Sprintf("release-please opens or updates a release PR that bumps versions and updates %s", changelog),
+			fmt.Sprintf("Review and merge the release PR; release-please tags `%s` and creates the GitHub release", tag),
+		)
+
+	case has(detector.ReleaseToolSemanticRelease):
+		steps = append(steps,
+			"Merge changes to the release branch with commit messages that follow the commit convention",
+			fmt.Sprintf("CI runs `npx semantic-release`, which derives the next version from the commits, tags `%s` and publishes the release", tag),
+			"Preview the next release locally with `npx semantic-release --dry-run`",
+		)
+
+	case has(detector.ReleaseToolChangesets):
+		steps = append(steps, "Add a changeset with `npx changeset` in every PR that changes a published package")
+		if tools[detector.ReleaseToolChangesets].CI {
+			steps = append(steps, "Merge the \"Version Packages\" PR opened by the Changesets action; it bumps versions, writes changelogs and publishes")
+		} else {
+			steps = append(steps,
+				"Run `npx changeset version` to bump versions and write changelogs, then commit the result",
+				fmt.Sprintf("Run `npx changeset publish` to publish packages and create `%s` tags", tag),
+				"Push the commit and tags with `git push --follow-tags`",
+			)
+		}
+
+	case has(detector.ReleaseToolCargoRelease):
+		steps = append(steps,
+			"Preview with `cargo release <patch|minor|major>` (dry run by default)",
+			fmt.Sprintf("Run `cargo release <patch|minor|major> --execute` to bump `Cargo.toml`, commit, tag `%s`, publish and push", tag),
+		)
+
+	default:
+		if hasGoReleaser {
+			steps = append(steps, "Check the build with `goreleaser release --snapshot --clean`")
+		}
+		if len(release.VersionFiles) > 0 {
+			var files []string
+			for _, vf := range release.VersionFiles {
+				files = append(files, "`"+vf.Path+"`")
+			}
+			steps = append(steps, "Bump the version in "+strings.Join(files, ", "))
+		}
+		if cl := release.Changelog; cl != nil && cl.Format != detector.ChangelogGenerated {
+			if cl.Format == detector.ChangelogKeepAChangelog {
+				steps = append(steps, fmt.Sprintf("Move the `## [Unreleased]` entries in `%s` under a new `## [X.Y.Z] - YYYY-MM-DD` heading", cl.Path))
+			} else {
+				steps = append(steps, fmt.Sprintf("Add release notes to `%s`", cl.Path))
+			}
+		}
+		if len(steps) > 0 && (len(release.VersionFiles) > 0 || release.Changelog != nil) {
+			steps = append(steps, "Commit the release changes")
+		}
+		steps = append(steps,
+			fmt.Sprintf("Tag the release: `git tag -a %s -m \"%s\"`", tag, tag),
+			fmt.Sprintf("Push the tag: `git push origin %s`", tag),
+		)
+		if hasGoReleaser && !goreleaser.CI {
+			steps = append(steps, "Run `goreleaser release --clean` to build and publish artifacts")
+		}
+	}
+
+	if hasGoReleaser && goreleaser.CI {
+		steps = append(steps, "CI runs GoReleaser on the new tag to build and publish artifacts")
+	}
+	return steps
+}
+
+// exampleTag turns a detected tag pattern into a placeholder tag
+func exampleTag(pattern string) string {
+	switch pattern {
+	case "", "v<major>.<minor>.<patch>":
+		return "vX.Y.Z"
+	case "<major>.<minor>.<patch>":
+		return "X.Y.Z"
+	case "v<major>.<minor>":
+		return "vX.Y"
+	default:
+		return strings.NewReplacer("<version>", "X.Y.Z").Replace(pattern)
+	}
+}
+
 // writeCommands writes the available commands section
 func (g *ClaudeGenerator) writeCommands(buf *bytes.Buffer, commands []types.Command) {
 	if len(commands) == 0 {
@@ -546,6 +1002,369 @@ func (g *ClaudeGenerator) writeCommands(buf *bytes.Buffer, commands []types.Comm
 	buf.WriteString("```\n\n")
 }
 
+// writeTests writes where tests live, how to run one test and which areas lack tests
+func (g *ClaudeGenerator) writeTests(buf *bytes.Buffer, tests *types.TestInventory) {
+	if tests == nil {
+		return
+	}
+
+	buf.WriteString("## Testing\n\n")
+	fmt.Fprintf(buf, "%d tests in %d files", tests.TestCount, tests.TestFiles)
+	if tests.Coverage != nil {
+		fmt.Fprintf(buf, "; %.1f%% line coverage (`%s`)", tests.Coverage.Percent, tests.Coverage.Path)
+	}
+	buf.WriteString(".\n\n")
+
+	if len(tests.Runners) > 0 {
+		buf.WriteString("### Running Tests\n\n")
+		buf.WriteString("| Framework | One file | One test |\n")
+		buf.WriteString("|-----------|----------|----------|\n")
+		for _, r := range tests.Runners {
+			fmt.Fprintf(buf, "| %s | `%s` | `%s` |\n", r.Framework, r.RunFile, r.RunSingle)
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(tests.Areas) > 0 {
+		const maxAreas = 25
+		buf.WriteString("### Where Tests Live\n\n")
+		if tests.Coverage != nil {
+			buf.WriteString("| Source | Tests in | Files | Cases | Coverage |\n")
+			buf.WriteString("|--------|----------|-------|-------|----------|\n")
+		} else {
+			buf.WriteString("| Source | Tests in | Files | Cases |\n")
+			buf.WriteString("|--------|----------|-------|-------|\n")
+		}
+		for i, area := range tests.Areas {
+			if i >= maxAreas {
+				break
+			}
+			location := "colocated"
+			if area.TestDir != area.Dir {
+				location = "`" + area.TestDir + "`"
+			}
+			fmt.Fprintf(buf, "| `%s` | %s | %d | %d |", area.Dir, location, area.Files, area.Tests)
+			if tests.Coverage != nil {
+				if area.Coverage > 0 {
+					fmt.Fprintf(buf, " %.1f%% |", area.Coverage)
+				} else {
+					buf.WriteString(" - |")
+				}
+			}
+			buf.WriteString("\n")
+		}
+		if len(tests.Areas) > maxAreas {
+			fmt.Fprintf(buf, "\n*...and %d more*\n", len(tests.Areas)-maxAreas)
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(tests.Fixtures) > 0 || len(tests.Helpers) > 0 || len(tests.Mocks) > 0 {
+		buf.WriteString("### Test Support\n\n")
+		if len(tests.Fixtures) > 0 {
+			fmt.Fprintf(buf, "- **Fixtures:** %s\n", codeList(tests.Fixtures))
+		}
+		if len(tests.Helpers) > 0 {
+			fmt.Fprintf(buf, "- **Helpers:** %s\n", codeList(tests.Helpers))
+		}
+		if len(tests.Mocks) > 0 {
+			var mocks []string
+			for _, m := range tests.Mocks {
+				mocks = append(mocks, fmt.Sprintf("%s (`%s`)", m.Name, m.Source))
+			}
+			fmt.Fprintf(buf, "- **Mocks:** %s\n", strings.Join(mocks, ", "))
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(tests.Untested) > 0 {
+		buf.WriteString("### Untested Areas\n\n")
+		buf.WriteString("No tests cover these directories; add tests alongside changes here:\n\n")
+		fmt.Fprintf(buf, "%s\n\n", codeList(tests.Untested))
+	}
+}
+
+// writeMLWorkflow writes how models are trained, configured, tracked and saved
+func (g *ClaudeGenerator) writeMLWorkflow(buf *bytes.Buffer, wf *types.MLWorkflow) {
+	if wf == nil {
+		return
+	}
+
+	buf.WriteString("## ML Workflow\n\n")
+
+	if len(wf.EntryPoints) > 0 {
+		buf.WriteString("### Entry Points\n\n")
+		buf.WriteString("| Script | Purpose | Run |\n")
+		buf.WriteString("|--------|---------|-----|\n")
+		for _, e := range wf.EntryPoints {
+			fmt.Fprintf(buf, "| `%s` | %s | `%s` |\n", e.Path, e.Kind, e.Command)
+		}
+		buf.WriteString("\n")
+		for _, e := range wf.EntryPoints {
+			if len(e.Args) > 0 {
+				fmt.Fprintf(buf, "- `%s` flags: %s\n", e.Path, mlArgList(e.Args))
+			}
+		}
+		if example := hydraOverrideExample(wf); example != "" {
+			fmt.Fprintf(buf, "- Hydra scripts take `key=value` overrides instead of flags: `%s`\n", example)
+		}
+		buf.WriteString("\n")
+	}
+
+	if c := wf.Config; c != nil {
+		buf.WriteString("### Configuration\n\n")
+		location := "`" + path.Join(c.Dir, c.Primary) + "`"
+		if c.Primary == "" {
+			location = "`" + c.Dir + "/`"
+		}
+		fmt.Fprintf(buf, "Configuration is loaded with %s from %s", c.Framework, location)
+		if len(c.Defaults) > 0 {
+			fmt.Fprintf(buf, "; defaults: %s", codeList(c.Defaults))
+		}
+		buf.WriteString(".\n\n")
+		if len(c.Groups) > 0 {
+			buf.WriteString("| Group | Options |\n")
+			buf.WriteString("|-------|---------|\n")
+			for _, group := range c.Groups {
+				fmt.Fprintf(buf, "| `%s` | %s |\n", group.Name, codeList(group.Options))
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	if len(wf.Stages) > 0 {
+		buf.WriteString("### DVC Pipeline\n\n")
+		buf.WriteString("Run the pipeline with `dvc repro`, or one stage and everything upstream of it with `dvc repro <stage>`.\n\n")
+		buf.WriteString("| Stage | Command | After | Outputs |\n")
+		buf.WriteString("|-------|---------|-------|---------|\n")
+		for _, stage := range wf.Stages {
+			fmt.Fprintf(buf, "| %s | `%s` | %s | %s |\n", stage.Name, stage.Command,
+				strings.Join(stage.After, ", "), codeList(append(append([]string{}, stage.Outs...), stage.Metrics...)))
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(wf.Trackers) > 0 {
+		buf.WriteString("### Experiment Tracking\n\n")
+		for _, tracker := range wf.Trackers {
+			fmt.Fprintf(buf, "- **%s**", tracker.Name)
+			if tracker.Project != "" {
+				fmt.Fprintf(buf, " (project `%s`)", tracker.Project)
+			}
+			fmt.Fprintf(buf, ": %s\n", codeList(tracker.Calls))
+		}
+		buf.WriteString("\nLog new metrics and parameters through the same tracker rather than printing them.\n\n")
+	}
+
+	if ckpt := wf.Checkpoints; ckpt != nil {
+		buf.WriteString("### Checkpoints\n\n")
+		fmt.Fprintf(buf, "%s.\n\n", checkpointSummary(ckpt))
+	}
+
+	if len(wf.Devices) > 0 {
+		buf.WriteString("### Device Selection\n\n")
+		for _, device := range wf.Devices {
+			if device.Method == detector.DeviceHardcoded {
+				fmt.Fprintf(buf, "- **%s** in %s: `%s`. This fails on machines without a GPU; use the selected device instead\n",
+					device.Method, codeList(device.Files), device.Example)
+				continue
+			}
+			fmt.Fprintf(buf, "- **%s**: `%s` (%s)\n", device.Method, device.Example, codeList(device.Files))
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// writeMLWorkflowCompact writes the commands to train and evaluate and where results go
+func (g *ClaudeGenerator) writeMLWorkflowCompact(buf *bytes.Buffer, wf *types.MLWorkflow) {
+	if wf == nil {
+		return
+	}
+
+	buf.WriteString("## ML Workflow\n\n")
+	for _, e := range wf.EntryPoints {
+		fmt.Fprintf(buf, "- **%s:** `%s`\n", titleCase(e.Kind), e.Command)
+	}
+	if len(wf.Stages) > 0 {
+		fmt.Fprintf(buf, "- **Pipeline:** `dvc repro` (%d stages)\n", len(wf.Stages))
+	}
+	if c := wf.Config; c != nil {
+		fmt.Fprintf(buf, "- **Config:** %s in `%s`\n", c.Framework, path.Join(c.Dir, c.Primary))
+	}
+	if len(wf.Trackers) > 0 {
+		var names []string
+		for _, tracker := range wf.Trackers {
+			names = append(names, tracker.Name)
+		}
+		fmt.Fprintf(buf, "- **Tracking:** %s\n", strings.Join(names, ", "))
+	}
+	if ckpt := wf.Checkpoints; ckpt != nil && (ckpt.Dir != "" || ckpt.Pattern != "") {
+		fmt.Fprintf(buf, "- **Checkpoints:** `%s`\n", path.Join(ckpt.Dir, ckpt.Pattern))
+	}
+	buf.WriteString("\n")
+}
+
+// writeNotebooks writes a table of notebooks with their purpose, imports and data files
+func (g *ClaudeGenerator) writeNotebooks(buf *bytes.Buffer, notebooks []types.Notebook) {
+	if len(notebooks) == 0 {
+		return
+	}
+	const maxNotebooks, maxNotebookImports = 20, 6
+
+	buf.WriteString("## Notebooks\n\n")
+
+	summarized := false
+	magics := make(map[string]bool)
+	for _, nb := range notebooks {
+		summarized = summarized || nb.Title != ""
+		for _, magic := range nb.Magics {
+			magics[magic] = true
+		}
+	}
+
+	if summarized {
+		buf.WriteString("| Notebook | Purpose | Imports | Data |\n")
+		buf.WriteString("|----------|---------|---------|------|\n")
+	} else {
+		buf.WriteString("| Notebook | Imports | Data |\n")
+		buf.WriteString("|----------|---------|------|\n")
+	}
+	for i, nb := range notebooks {
+		if i == maxNotebooks {
+			break
+		}
+		imports := nb.Imports
+		if len(imports) > maxNotebookImports {
+			imports = imports[:maxNotebookImports]
+		}
+		row := fmt.Sprintf("| `%s` |", nb.Path)
+		if summarized {
+			row += " " + notebookPurpose(nb) + " |"
+		}
+		fmt.Fprintf(buf, "%s %s | %s |\n", row, codeList(imports), codeList(nb.DataPaths))
+	}
+	if len(notebooks) > maxNotebooks {
+		fmt.Fprintf(buf, "\n...and %d more notebooks.\n", len(notebooks)-maxNotebooks)
+	}
+	buf.WriteString("\n")
+
+	if len(magics) > 0 {
+		var names []string
+		for magic := range magics {
+			names = append(names, magic)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(buf, "Magics and shell commands in use: %s.", codeList(names))
+		if magics["!pip"] || magics["%pip"] {
+			buf.WriteString(" Add packages installed with pip in cells to the project's dependencies instead.")
+		}
+		buf.WriteString("\n\n")
+	}
+}
+
+// writeNotebooksCompact writes the notebooks, with their purpose when summarized
+func (g *ClaudeGenerator) writeNotebooksCompact(buf *bytes.Buffer, notebooks []types.Notebook) {
+	if len(notebooks) == 0 {
+		return
+	}
+
+	buf.WriteString("## Notebooks\n\n")
+	summarized := false
+	for _, nb := range notebooks {
+		if nb.Title != "" {
+			fmt.Fprintf(buf, "- `%s`: %s\n", nb.Path, nb.Title)
+			summarized = true
+		}
+	}
+	if !summarized {
+		fmt.Fprintf(buf, "- %d notebooks; import shared code from the package instead of copying it into cells\n", len(notebooks))
+	}
+	buf.WriteString("\n")
+}
+
+// notebookPurpose describes a notebook by its title and section headings
+func notebookPurpose(nb types.Notebook) string {
+	purpose := nb.Title
+	if len(nb.Sections) > 0 {
+		if purpose != "" {
+			purpose += ": "
+		}
+		purpose += strings.Join(nb.Sections, ", ")
+	}
+	return strings.ReplaceAll(purpose, "|", "\\|")
+}
+
+// mlArgList formats script flags with their defaults
+func mlArgList(args []types.CLIFlag) string {
+	const maxArgs = 8
+	var parts []string
+	for i, arg := range args {
+		if i == maxArgs {
+			parts = append(parts, fmt.Sprintf("and %d more", len(args)-maxArgs))
+			break
+		}
+		part := "`--" + arg.Name + "`"
+		if arg.Default != "" {
+			part += fmt.Sprintf(" (default `%s`)", arg.Default)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// hydraOverrideExample returns a Hydra training command that switches a config
+// group away from its default, or "" when no entry point uses Hydra
+func hydraOverrideExample(wf *types.MLWorkflow) string {
+	var entry *types.MLEntryPoint
+	for i := range wf.EntryPoints {
+		if wf.EntryPoints[i].Hydra {
+			entry = &wf.EntryPoints[i]
+			break
+		}
+	}
+	if entry == nil {
+		return ""
+	}
+	if wf.Config != nil {
+		defaults := make(map[string]string)
+		for _, d := range wf.Config.Defaults {
+			group, option, _ := strings.Cut(d, "=")
+			defaults[group] = option
+		}
+		for _, group := range wf.Config.Groups {
+			for _, option := range group.Options {
+				if option != defaults[group.Name] {
+					return fmt.Sprintf("%s %s=%s", entry.Command, group.Name, option)
+				}
+			}
+		}
+	}
+	return entry.Command + " key=value"
+}
+
+// checkpointSummary describes how and where checkpoints are saved
+func checkpointSummary(ckpt *types.CheckpointInfo) string {
+	summary := "Checkpoints are saved with " + codeList(ckpt.Saves)
+	if ckpt.Dir != "" {
+		summary += fmt.Sprintf(" to `%s/`", strings.TrimSuffix(ckpt.Dir, "/"))
+	}
+	if ckpt.Pattern != "" {
+		summary += fmt.Sprintf(", named like `%s`", ckpt.Pattern)
+	} else if ckpt.Format != "" {
+		summary += fmt.Sprintf(" as `%s` files", ckpt.Format)
+	}
+	return summary
+}
+
+// codeList formats items as a comma-separated list of inline code spans
+func codeList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // writeEndpoints writes the API endpoints section grouped by resource
 func (g *ClaudeGenerator) writeEndpoints(buf *bytes.Buffer, endpoints []types.Endpoint) {
 	if len(endpoints) == 0 {
@@ -577,42 +1396,281 @@ func (g *ClaudeGenerator) writeEndpoints(buf *bytes.Buffer, endpoints []types.En
 			continue
 		}
 
-		// Write resource header
-		displayResource := resource
-		if displayResource == "" || displayResource == "/" {
-			displayResource = "Root"
+		// Write resource header
+		displayResource := resource
+		if displayResource == "" || displayResource == "/" {
+			displayResource = "Root"
+		}
+		fmt.Fprintf(buf, "### %s\n\n", displayResource)
+		buf.WriteString("| Method | Path | Auth | File |\n")
+		buf.WriteString("|--------|------|------|------|\n")
+
+		for _, ep := range eps {
+			if totalShown >= maxEndpoints {
+				break
+			}
+
+			file := ep.File
+			if ep.Line > 0 {
+				file = fmt.Sprintf("%s:%d", ep.File, ep.Line)
+			}
+
+			fmt.Fprintf(buf, "| %s | `%s` | %s | `%s` |\n", ep.Method, ep.Path, endpointAuthLabel(ep), file)
+			totalShown++
+		}
+
+		buf.WriteString("\n")
+	}
+
+	remaining := len(endpoints) - totalShown
+	if remaining > 0 {
+		fmt.Fprintf(buf, "*...and %d more endpoints*\n\n", remaining)
+	}
+}
+
+// endpointAuthLabel describes how an endpoint is guarded for the endpoints table
+func endpointAuthLabel(ep types.Endpoint) string {
+	switch {
+	case ep.Auth != "":
+		return fmt.Sprintf("🔒 `%s`", strings.ReplaceAll(ep.Auth, "|", "\\|"))
+	case ep.Public:
+		return "public"
+	default:
+		return "-"
+	}
+}
+
+// eventTopic aggregates the producers and consumers of one topic
+type eventTopic struct {
+	broker    string
+	topic     string
+	producers []string
+	consumers []string
+}
+
+// groupEventsByTopic groups events by broker and topic, keeping first-seen order
+func groupEventsByTopic(events []types.Event) []*eventTopic {
+	var topics []*eventTopic
+	index := make(map[string]*eventTopic)
+	for _, ev := range events {
+		key := ev.Broker + "|" + ev.Topic
+		t, ok := index[key]
+		if !ok {
+			t = &eventTopic{broker: ev.Broker, topic: ev.Topic}
+			index[key] = t
+			topics = append(topics, t)
+		}
+
+		location := ev.File
+		if ev.Line > 0 {
+			location = fmt.Sprintf("%s:%d", ev.File, ev.Line)
+		}
+		location = "`" + location + "`"
+		if ev.Handler != "" {
+			location += fmt.Sprintf(" (`%s`)", ev.Handler)
+		}
+
+		if ev.Role == "consumer" {
+			t.consumers = append(t.consumers, location)
+		} else {
+			t.producers = append(t.producers, location)
+		}
+	}
+	return topics
+}
+
+// eventLocations joins up to 3 producer/consumer locations for a table cell
+func eventLocations(locations []string) string {
+	if len(locations) == 0 {
+		return "-"
+	}
+	if len(locations) > 3 {
+		return strings.Join(locations[:3], ", ") + fmt.Sprintf(" +%d more", len(locations)-3)
+	}
+	return strings.Join(locations, ", ")
+}
+
+// writeEvents writes the message queue / event bus section grouped by broker
+func (g *ClaudeGenerator) writeEvents(buf *bytes.Buffer, events []types.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	buf.WriteString("## Events\n\n")
+	buf.WriteString("Topics, subjects and queues this project produces to or consumes from.\n\n")
+
+	topics := groupEventsByTopic(events)
+	maxTopics := 50
+	currentBroker := ""
+	for i, t := range topics {
+		if i >= maxTopics {
+			fmt.Fprintf(buf, "\n*...and %d more topics*\n", len(topics)-maxTopics)
+			break
+		}
+		if t.broker != currentBroker {
+			if currentBroker != "" {
+				buf.WriteString("\n")
+			}
+			currentBroker = t.broker
+			fmt.Fprintf(buf, "### %s\n\n", t.broker)
+			buf.WriteString("| Topic | Producers | Consumers |\n")
+			buf.WriteString("|-------|-----------|-----------|\n")
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s |\n", t.topic, eventLocations(t.producers), eventLocations(t.consumers))
+	}
+	buf.WriteString("\n")
+}
+
+// writeEnvVars writes the environment variables the project reads, their
+// defaults and where they are set, flagging undocumented ones
+func (g *ClaudeGenerator) writeEnvVars(buf *bytes.Buffer, envVars []types.EnvVar) {
+	if len(envVars) == 0 {
+		return
+	}
+
+	buf.WriteString("## Environment Variables\n\n")
+	buf.WriteString("| Variable | Default | Used In | Set In |\n")
+	buf.WriteString("|----------|---------|---------|--------|\n")
+
+	maxVars := 40
+	var undocumented []string
+	for i, v := range envVars {
+		if !v.Documented && len(v.UsedIn) > 0 {
+			undocumented = append(undocumented, "`"+v.Name+"`")
+		}
+		if i >= maxVars {
+			continue
+		}
+		def := "-"
+		if v.Default != "" {
+			def = "`" + strings.ReplaceAll(v.Default, "|", "\\|") + "`"
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", v.Name, def, eventLocations(backtickAll(v.UsedIn)), eventLocations(backtickAll(v.DefinedIn)))
+	}
+	if len(envVars) > maxVars {
+		fmt.Fprintf(buf, "\n*...and %d more variables*\n", len(envVars)-maxVars)
+	}
+	buf.WriteString("\n")
+
+	if len(undocumented) > 0 {
+		fmt.Fprintf(buf, "**Undocumented:** %s - read in code but missing from env templates and the README.\n\n", strings.Join(undocumented, ", "))
+	}
+}
+
+// backtickAll wraps each value in backticks
+func backtickAll(values []string) []string {
+	wrapped := make([]string, len(values))
+	for i, v := range values {
+		wrapped[i] = "`" + v + "`"
+	}
+	return wrapped
+}
+
+// writeDatabaseSchema writes parsed tables, their key columns and relations,
+// and the commands to create and apply migrations
+func (g *ClaudeGenerator) writeDatabaseSchema(buf *bytes.Buffer, schema *types.DatabaseSchema) {
+	if schema == nil || (len(schema.Tables) == 0 && len(schema.Commands) == 0) {
+		return
+	}
+
+	buf.WriteString("## Database Schema\n\n")
+	if len(schema.Tools) > 0 {
+		fmt.Fprintf(buf, "**Managed by:** %s\n", strings.Join(schema.Tools, ", "))
+	}
+	if len(schema.MigrationDirs) > 0 {
+		dirs := make([]string, len(schema.MigrationDirs))
+		for i, d := range schema.MigrationDirs {
+			dirs[i] = "`" + d + "/`"
 		}
-		fmt.Fprintf(buf, "### %s\n\n", displayResource)
-		buf.WriteString("| Method | Path | File |\n")
-		buf.WriteString("|--------|------|------|\n")
+		fmt.Fprintf(buf, "**Migrations:** %s\n", strings.Join(dirs, ", "))
+	}
+	buf.WriteString("\n")
 
-		for _, ep := range eps {
-			if totalShown >= maxEndpoints {
+	if len(schema.Tables) > 0 {
+		buf.WriteString("| Table | Key Columns | Relations |\n")
+		buf.WriteString("|-------|-------------|-----------|\n")
+
+		maxTables := 50
+		for i, t := range schema.Tables {
+			if i >= maxTables {
 				break
 			}
-
-			file := ep.File
-			if ep.Line > 0 {
-				file = fmt.Sprintf("%s:%d", ep.File, ep.Line)
+			name := "`" + t.Name + "`"
+			if t.Model != "" {
+				name += fmt.Sprintf(" (%s)", t.Model)
 			}
+			fmt.Fprintf(buf, "| %s | %s | %s |\n", name, schemaKeyColumns(t), schemaRelations(t))
+		}
+		if len(schema.Tables) > maxTables {
+			fmt.Fprintf(buf, "\n*...and %d more tables*\n", len(schema.Tables)-maxTables)
+		}
+		buf.WriteString("\n")
+	}
 
-			// Add auth indicator if present
-			path := ep.Path
-			if ep.Auth != "" {
-				path = fmt.Sprintf("%s 🔒", ep.Path)
-			}
+	if len(schema.Commands) > 0 {
+		buf.WriteString("```bash\n")
+		for _, cmd := range schema.Commands {
+			fmt.Fprintf(buf, "# %s\n%s\n", cmd.Description, cmd.Name)
+		}
+		buf.WriteString("```\n\n")
+	}
+}
 
-			fmt.Fprintf(buf, "| %s | `%s` | `%s` |\n", ep.Method, path, file)
-			totalShown++
+// schemaKeyColumns lists primary, foreign and unique columns first, then
+// other columns up to a total of 6
+func schemaKeyColumns(t types.SchemaTable) string {
+	foreign := make(map[string]bool)
+	for _, r := range t.Relations {
+		if r.Column != "" {
+			foreign[r.Column] = true
 		}
+	}
 
-		buf.WriteString("\n")
+	var key, other []string
+	for _, c := range t.Columns {
+		switch {
+		case c.Primary:
+			key = append(key, fmt.Sprintf("`%s` PK", c.Name))
+		case foreign[c.Name]:
+			key = append(key, fmt.Sprintf("`%s` FK", c.Name))
+		case c.Unique:
+			key = append(key, fmt.Sprintf("`%s` unique", c.Name))
+		default:
+			other = append(other, "`"+c.Name+"`")
+		}
 	}
 
-	remaining := len(endpoints) - totalShown
-	if remaining > 0 {
-		fmt.Fprintf(buf, "*...and %d more endpoints*\n\n", remaining)
+	columns := key
+	for _, c := range other {
+		if len(columns) >= 6 {
+			break
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return "-"
+	}
+	result := strings.Join(columns, ", ")
+	if hidden := len(key) + len(other) - len(columns); hidden > 0 {
+		result += fmt.Sprintf(", +%d more", hidden)
+	}
+	return result
+}
+
+// schemaRelations describes a table's relations for the schema table
+func schemaRelations(t types.SchemaTable) string {
+	if len(t.Relations) == 0 {
+		return "-"
 	}
+	relations := make([]string, 0, len(t.Relations))
+	for _, r := range t.Relations {
+		if r.Column != "" {
+			relations = append(relations, fmt.Sprintf("`%s` → `%s`", r.Column, r.Target))
+		} else {
+			relations = append(relations, fmt.Sprintf("%s `%s`", r.Kind, r.Target))
+		}
+	}
+	return strings.Join(relations, ", ")
 }
 
 // groupEndpointsByResource groups endpoints by their resource path prefix
@@ -730,7 +1788,7 @@ func (g *ClaudeGenerator) writeConventions(buf *bytes.Buffer, conventions []type
 	for cat, convs := range byCategory {
 		fmt.Fprintf(buf, "### %s\n\n", titleCase(cat))
 		for _, conv := range convs {
-			fmt.Fprintf(buf, "- %s\n", conv.Description)
+			fmt.Fprintf(buf, "- %s\n", conventionText(conv))
 			if conv.Example != "" {
 				fmt.Fprintf(buf, "  ```\n  %s\n  ```\n", conv.Example)
 			}
@@ -827,6 +1885,60 @@ func (g *ClaudeGenerator) writeGitConventions(buf *bytes.Buffer, git *types.GitC
 	}
 }
 
+// writeChurn writes the most frequently changed files and the file pairs
+// that are usually committed together
+func (g *ClaudeGenerator) writeChurn(buf *bytes.Buffer, churn *types.ChurnInfo) {
+	if churn == nil || (len(churn.Hotspots) == 0 && len(churn.CoChanges) == 0) {
+		return
+	}
+
+	if len(churn.Hotspots) > 0 {
+		buf.WriteString("## Frequently Changed Areas\n\n")
+		fmt.Fprintf(buf, "From %d commits in the %d days before the latest commit. Changes here are the most likely to conflict or regress.\n\n", churn.CommitsAnalyzed, churn.WindowDays)
+		buf.WriteString("| File | Changes | Last 30 days |\n")
+		buf.WriteString("|------|---------|--------------|\n")
+		for _, h := range churn.Hotspots {
+			fmt.Fprintf(buf, "| `%s` | %d | %d |\n", h.Path, h.Changes, h.Recent)
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(churn.CoChanges) > 0 {
+		buf.WriteString("## Files That Change Together\n\n")
+		buf.WriteString("When editing one of these files, check whether its partner needs the same change.\n\n")
+		for _, c := range churn.CoChanges {
+			fmt.Fprintf(buf, "- `%s` ↔ `%s` (%d commits, %.0f%%)\n", c.Files[0], c.Files[1], c.Together, c.Coupling*100)
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// writeChurnCompact writes the top hotspots and co-change pairs on a few lines
+func (g *ClaudeGenerator) writeChurnCompact(buf *bytes.Buffer, churn *types.ChurnInfo) {
+	if churn == nil || (len(churn.Hotspots) == 0 && len(churn.CoChanges) == 0) {
+		return
+	}
+
+	buf.WriteString("## Hotspots\n\n")
+	if len(churn.Hotspots) > 0 {
+		var files []string
+		for i, h := range churn.Hotspots {
+			if i >= 5 {
+				break
+			}
+			files = append(files, fmt.Sprintf("`%s` (%d)", h.Path, h.Changes))
+		}
+		fmt.Fprintf(buf, "- Frequently changed: %s\n", strings.Join(files, ", "))
+	}
+	for i, c := range churn.CoChanges {
+		if i >= 5 {
+			break
+		}
+		fmt.Fprintf(buf, "- Change together: `%s` ↔ `%s`\n", c.Files[0], c.Files[1])
+	}
+	buf.WriteString("\n")
+}
+
 // writeGuidelines writes actionable coding guidelines based on tech stack
 func (g *ClaudeGenerator) writeGuidelines(buf *bytes.Buffer, stack *types.TechStack) {
 	var dos []string
@@ -1149,6 +2261,9 @@ func (g *ClaudeGenerator) writeQuickReference(buf *bytes.Buffer, commands []type
 		fmt.Fprintf(buf, "# %s\n", cat)
 		for _, cmd := range cmds {
 			// Format command with description as comment
+			if cmd.CIVerified {
+				cmd.Description = strings.TrimSpace(cmd.Description + " (CI)")
+			}
 			if cmd.Description != "" {
 				fmt.Fprintf(buf, "%-24s # %s\n", cmd.Name, cmd.Description)
 			} else {
@@ -1222,6 +2337,158 @@ func (g *ClaudeGenerator) writeDevelopmentSetup(buf *bytes.Buffer, devInfo *type
 	}
 }
 
+// writeInfrastructure writes how the project runs in production and which
+// services local development needs
+func (g *ClaudeGenerator) writeInfrastructure(buf *bytes.Buffer, infra *types.Infrastructure) {
+	if infra == nil {
+		return
+	}
+
+	buf.WriteString("## Infrastructure & Deployment\n\n")
+
+	if lines := infraProductionLines(infra); len(lines) > 0 {
+		buf.WriteString("### How It Runs in Production\n\n")
+		for _, line := range lines {
+			fmt.Fprintf(buf, "- %s\n", line)
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(infra.ComposeServices) > 0 {
+		buf.WriteString("### Local Development Services\n\n")
+		buf.WriteString("| Service | Image | Ports | Depends on |\n")
+		buf.WriteString("|---------|-------|-------|------------|\n")
+		for _, s := range infra.ComposeServices {
+			image := "`" + s.Image + "`"
+			if s.Build != "" {
+				image = fmt.Sprintf("built from `%s`", s.Build)
+			}
+			fmt.Fprintf(buf, "| %s | %s | %s | %s |\n", s.Name, image,
+				strings.Join(s.Ports, ", "), strings.Join(s.DependsOn, ", "))
+		}
+		buf.WriteString("\n")
+
+		if start := composeUpCommand(infra); start != "" {
+			fmt.Fprintf(buf, "Start the backing services before running the app: `%s`\n\n", start)
+		}
+	}
+}
+
+// infraProductionLines summarizes images, manifests and provisioning, one line per source
+func infraProductionLines(infra *types.Infrastructure) []string {
+	var lines []string
+
+	for _, df := range infra.Dockerfiles {
+		var images []string
+		for _, stage := range df.Stages {
+			images = append(images, "`"+stage.Image+"`")
+		}
+		line := fmt.Sprintf("**Docker** (`%s`): ", df.Path)
+		if len(df.Stages) > 1 {
+			line += fmt.Sprintf("%d-stage build %s", len(df.Stages), strings.Join(images, " → "))
+		} else {
+			line += "based on " + images[0]
+		}
+		if len(df.ExposedPorts) > 0 {
+			line += ", exposes " + strings.Join(df.ExposedPorts, ", ")
+		}
+		if df.Command != "" {
+			line += fmt.Sprintf(", runs `%s`", df.Command)
+		}
+		lines = append(lines, line)
+	}
+
+	var manifestPaths []string
+	manifests := make(map[string][]string)
+	for _, r := range infra.Kubernetes {
+		desc := fmt.Sprintf("%s `%s`", r.Kind, r.Name)
+		var details []string
+		if r.Replicas > 0 {
+			details = append(details, fmt.Sprintf("%d replicas", r.Replicas))
+		}
+		for _, image := range r.Images {
+			details = append(details, "`"+image+"`")
+		}
+		if len(details) > 0 {
+			desc += " (" + strings.Join(details, ", ") + ")"
+		}
+		if _, ok := manifests[r.Path]; !ok {
+			manifestPaths = append(manifestPaths, r.Path)
+		}
+		manifests[r.Path] = append(manifests[r.Path], desc)
+	}
+	for _, path := range manifestPaths {
+		lines = append(lines, fmt.Sprintf("**Kubernetes** (`%s`): %s", path, strings.Join(manifests[path], ", ")))
+	}
+
+	for _, chart := range infra.HelmCharts {
+		line := fmt.Sprintf("**Helm** chart `%s`", chart.Name)
+		if chart.Version != "" {
+			line += " " + chart.Version
+		}
+		if chart.AppVersion != "" {
+			line += fmt.Sprintf(" (app %s)", chart.AppVersion)
+		}
+		line += fmt.Sprintf(" in `%s`", chart.Path)
+		if len(chart.Dependencies) > 0 {
+			line += ", depends on " + strings.Join(chart.Dependencies, ", ")
+		}
+		lines = append(lines, line)
+	}
+
+	if len(infra.Kustomizations) > 0 {
+		var dirs []string
+		for _, dir := range infra.Kustomizations {
+			dirs = append(dirs, "`"+dir+"`")
+		}
+		lines = append(lines, "**Kustomize** overlays: "+strings.Join(dirs, ", "))
+	}
+
+	if tf := infra.Terraform; tf != nil {
+		line := fmt.Sprintf("**Terraform** (`%s`)", strings.Join(tf.Dirs, "`, `"))
+		var details []string
+		if len(tf.Providers) > 0 {
+			details = append(details, "providers "+strings.Join(tf.Providers, ", "))
+		}
+		if len(tf.Modules) > 0 {
+			var modules []string
+			for _, m := range tf.Modules {
+				modules = append(modules, fmt.Sprintf("`%s` (%s)", m.Name, m.Source))
+			}
+			details = append(details, "modules "+strings.Join(modules, ", "))
+		}
+		if tf.Backend != "" {
+			details = append(details, fmt.Sprintf("state in `%s` backend", tf.Backend))
+		}
+		if len(details) > 0 {
+			line += ": " + strings.Join(details, "; ")
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// composeUpCommand returns the command that starts the backing services
+func composeUpCommand(infra *types.Infrastructure) string {
+	backing := detector.BackingServices(infra)
+	if len(backing) == 0 {
+		return ""
+	}
+
+	cmd := "docker compose"
+	if strings.Contains(backing[0].File, "/") {
+		cmd += " -f " + backing[0].File
+	}
+	cmd += " up -d"
+	for _, s := range backing {
+		if s.File == backing[0].File {
+			cmd += " " + s.Name
+		}
+	}
+	return cmd
+}
+
 // writeConfigurationSystem writes the configuration files section
 func (g *ClaudeGenerator) writeConfigurationSystem(buf *bytes.Buffer, configs []types.ConfigFileInfo) {
 	if len(configs) == 0 {
@@ -1285,6 +2552,114 @@ func (g *ClaudeGenerator) writeCLIOutput(buf *bytes.Buffer, cliInfo *types.CLIIn
 	}
 }
 
+// cliCommandRow is a command of a CLI tree with its full invocation path
+type cliCommandRow struct {
+	path string
+	cmd  types.CLICommand
+}
+
+// flattenCLICommands walks command trees depth-first
+func flattenCLICommands(commands []types.CLICommand, prefix string) []cliCommandRow {
+	var rows []cliCommandRow
+	for _, cmd := range commands {
+		path := strings.TrimSpace(prefix + " " + cmd.Name)
+		rows = append(rows, cliCommandRow{path: path, cmd: cmd})
+		rows = append(rows, flattenCLICommands(cmd.Subcommands, path)...)
+	}
+	return rows
+}
+
+// cliUsage renders a command path with its positional arguments
+func cliUsage(row cliCommandRow) string {
+	if row.cmd.Args == "" {
+		return row.path
+	}
+	return row.path + " " + row.cmd.Args
+}
+
+// cliFlagLabel renders a flag as -s, --name
+func cliFlagLabel(flag types.CLIFlag) string {
+	name := "--" + flag.Name
+	if len(flag.Name) == 1 {
+		name = "-" + flag.Name
+	}
+	if flag.Shorthand != "" {
+		return "-" + flag.Shorthand + ", " + name
+	}
+	return name
+}
+
+// writeCLIReference writes the command tree with each command's flags
+func (g *ClaudeGenerator) writeCLIReference(buf *bytes.Buffer, cliInfo *types.CLIInfo) {
+	if cliInfo == nil || len(cliInfo.Commands) == 0 {
+		return
+	}
+
+	buf.WriteString("## CLI Reference\n\n")
+	if cliInfo.Framework != "" {
+		fmt.Fprintf(buf, "**Framework:** %s\n\n", cliInfo.Framework)
+	}
+
+	rows := flattenCLICommands(cliInfo.Commands, "")
+	maxCommands := 30
+	buf.WriteString("| Command | Description |\n")
+	buf.WriteString("|---------|-------------|\n")
+	for i, row := range rows {
+		if i >= maxCommands {
+			fmt.Fprintf(buf, "\n*...and %d more commands*\n", len(rows)-maxCommands)
+			break
+		}
+		desc := strings.ReplaceAll(row.cmd.Description, "|", "\\|")
+		if len(row.cmd.Aliases) > 0 {
+			desc = strings.TrimSpace(desc + " (aliases: " + strings.Join(row.cmd.Aliases, ", ") + ")")
+		}
+		fmt.Fprintf(buf, "| `%s` | %s |\n", strings.ReplaceAll(cliUsage(row), "|", "\\|"), desc)
+	}
+	buf.WriteString("\n")
+
+	// Flags per command; persistent flags apply to all subcommands too
+	maxFlags := 15
+	for i, row := range rows {
+		if i >= maxCommands {
+			break
+		}
+		if len(row.cmd.Flags) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "### `%s` flags\n\n", row.path)
+		buf.WriteString("| Flag | Type | Default | Description |\n")
+		buf.WriteString("|------|------|---------|-------------|\n")
+		for j, flag := range row.cmd.Flags {
+			if j >= maxFlags {
+				fmt.Fprintf(buf, "\n*...and %d more flags*\n", len(row.cmd.Flags)-maxFlags)
+				break
+			}
+			desc := strings.ReplaceAll(flag.Usage, "|", "\\|")
+			if flag.Persistent && len(row.cmd.Subcommands) > 0 {
+				desc = strings.TrimSpace(desc + " *(global)*")
+			}
+			def := "-"
+			if flag.Default != "" {
+				def = "`" + strings.ReplaceAll(flag.Default, "|", "\\|") + "`"
+			}
+			fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", cliFlagLabel(flag), flag.Type, def, desc)
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// tentativeConfidence is the score below which a convention is flagged as
+// resting on limited evidence rather than stated as a rule
+const tentativeConfidence = 0.7
+
+// conventionText returns a convention's description, flagging weakly supported ones
+func conventionText(conv types.Convention) string {
+	if conv.Confidence > 0 && conv.Confidence < tentativeConfidence {
+		return conv.Description + " _(tentative: limited evidence)_"
+	}
+	return conv.Description
+}
+
 // titleCase converts the first letter of a string to uppercase
 func titleCase(s string) string {
 	if s == "" {
@@ -1398,6 +2773,94 @@ func (g *ClaudeGenerator) writeKeyFilesCompact(buf *bytes.Buffer, keyFiles []typ
 	buf.WriteString("\n")
 }
 
+// writeCICompact writes only the commands CI runs
+func (g *ClaudeGenerator) writeCICompact(buf *bytes.Buffer, ci *types.CIInfo) {
+	if ci == nil || len(ci.Commands) == 0 {
+		return
+	}
+
+	buf.WriteString("## CI Checks\n\n")
+	writeCIChecks(buf, ci.Commands, 8)
+}
+
+// writeReleaseCompact writes the release steps only
+func (g *ClaudeGenerator) writeReleaseCompact(buf *bytes.Buffer, release *types.ReleaseInfo) {
+	if release == nil {
+		return
+	}
+
+	buf.WriteString("## Release Process\n\n")
+	for i, step := range releaseSteps(release) {
+		fmt.Fprintf(buf, "%d. %s\n", i+1, step)
+	}
+	buf.WriteString("\n")
+}
+
+// writeTestsCompact writes how to run a single test and the largest untested areas
+func (g *ClaudeGenerator) writeTestsCompact(buf *bytes.Buffer, tests *types.TestInventory) {
+	if tests == nil || (len(tests.Runners) == 0 && len(tests.Untested) == 0) {
+		return
+	}
+
+	buf.WriteString("## Testing\n\n")
+	for _, r := range tests.Runners {
+		fmt.Fprintf(buf, "- **%s:** `%s`\n", r.Framework, r.RunSingle)
+	}
+	if len(tests.Untested) > 0 {
+		untested := tests.Untested
+		if len(untested) > 5 {
+			untested = untested[:5]
+		}
+		fmt.Fprintf(buf, "- **Untested:** %s\n", codeList(untested))
+	}
+	buf.WriteString("\n")
+}
+
+// writeInfrastructureCompact writes a short deployment summary and the
+// command that starts local services
+func (g *ClaudeGenerator) writeInfrastructureCompact(buf *bytes.Buffer, infra *types.Infrastructure) {
+	if infra == nil {
+		return
+	}
+
+	buf.WriteString("## Infrastructure\n\n")
+	for i, line := range infraProductionLines(infra) {
+		if i >= 5 {
+			break
+		}
+		fmt.Fprintf(buf, "- %s\n", line)
+	}
+	if start := composeUpCommand(infra); start != "" {
+		fmt.Fprintf(buf, "- **Local services:** `%s`\n", start)
+	}
+	buf.WriteString("\n")
+}
+
+// writeOwnershipCompact writes the ownership source and the first few rules
+func (g *ClaudeGenerator) writeOwnershipCompact(buf *bytes.Buffer, ownership *types.Ownership) {
+	if ownership == nil || len(ownership.Entries) == 0 {
+		return
+	}
+
+	source := "`" + ownership.Source + "`"
+	if ownership.Source == detector.OwnershipSourceGit {
+		source = "git history"
+	}
+	fmt.Fprintf(buf, "## Code Ownership\n\nFrom %s - suggest owners of touched paths as reviewers.\n\n", source)
+
+	maxEntries := 8
+	for i, entry := range ownership.Entries {
+		if i >= maxEntries {
+			break
+		}
+		fmt.Fprintf(buf, "- `%s` - %s\n", entry.Path, ownerList(entry.Owners))
+	}
+	if len(ownership.Entries) > maxEntries {
+		fmt.Fprintf(buf, "\n*...and %d more rules*\n", len(ownership.Entries)-maxEntries)
+	}
+	buf.WriteString("\n")
+}
+
 // writeEndpointsCompact writes only unique endpoint patterns (max 10)
 func (g *ClaudeGenerator) writeEndpointsCompact(buf *bytes.Buffer, endpoints []types.Endpoint) {
 	if len(endpoints) == 0 {
@@ -1447,6 +2910,112 @@ func (g *ClaudeGenerator) writeEndpointsCompact(buf *bytes.Buffer, endpoints []t
 	buf.WriteString("\n")
 }
 
+// writeCLIReferenceCompact writes the command list without flags (max 15)
+func (g *ClaudeGenerator) writeCLIReferenceCompact(buf *bytes.Buffer, cliInfo *types.CLIInfo) {
+	if cliInfo == nil || len(cliInfo.Commands) == 0 {
+		return
+	}
+
+	buf.WriteString("## CLI Reference\n\n")
+	rows := flattenCLICommands(cliInfo.Commands, "")
+	for i, row := range rows {
+		if i >= 15 {
+			fmt.Fprintf(buf, "*...and %d more commands*\n", len(rows)-15)
+			break
+		}
+		fmt.Fprintf(buf, "- `%s`", cliUsage(row))
+		if row.cmd.Description != "" {
+			fmt.Fprintf(buf, " - %s", row.cmd.Description)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+}
+
+// writeEventsCompact writes topic names per broker (max 5 each)
+func (g *ClaudeGenerator) writeEventsCompact(buf *bytes.Buffer, events []types.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	buf.WriteString("## Events\n\n")
+
+	var brokers []string
+	brokerTopics := make(map[string][]string)
+	for _, t := range groupEventsByTopic(events) {
+		if _, ok := brokerTopics[t.broker]; !ok {
+			brokers = append(brokers, t.broker)
+		}
+		brokerTopics[t.broker] = append(brokerTopics[t.broker], "`"+t.topic+"`")
+	}
+
+	for _, broker := range brokers {
+		topics := brokerTopics[broker]
+		fmt.Fprintf(buf, "**%s:** ", broker)
+		if len(topics) > 5 {
+			buf.WriteString(strings.Join(topics[:5], ", "))
+			fmt.Fprintf(buf, " *+%d more*", len(topics)-5)
+		} else {
+			buf.WriteString(strings.Join(topics, ", "))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+}
+
+// writeEnvVarsCompact writes variable names (max 15) and the undocumented ones
+func (g *ClaudeGenerator) writeEnvVarsCompact(buf *bytes.Buffer, envVars []types.EnvVar) {
+	if len(envVars) == 0 {
+		return
+	}
+
+	buf.WriteString("## Environment Variables\n\n")
+	var names, undocumented []string
+	for _, v := range envVars {
+		names = append(names, "`"+v.Name+"`")
+		if !v.Documented && len(v.UsedIn) > 0 {
+			undocumented = append(undocumented, "`"+v.Name+"`")
+		}
+	}
+	if len(names) > 15 {
+		fmt.Fprintf(buf, "%s *+%d more*\n", strings.Join(names[:15], ", "), len(names)-15)
+	} else {
+		buf.WriteString(strings.Join(names, ", ") + "\n")
+	}
+	if len(undocumented) > 0 {
+		fmt.Fprintf(buf, "\n**Undocumented:** %s\n", strings.Join(undocumented, ", "))
+	}
+	buf.WriteString("\n")
+}
+
+// writeDatabaseSchemaCompact writes table names (max 20) and the migration commands
+func (g *ClaudeGenerator) writeDatabaseSchemaCompact(buf *bytes.Buffer, schema *types.DatabaseSchema) {
+	if schema == nil || (len(schema.Tables) == 0 && len(schema.Commands) == 0) {
+		return
+	}
+
+	buf.WriteString("## Database Schema\n\n")
+	if len(schema.Tables) > 0 {
+		limit := len(schema.Tables)
+		if limit > 20 {
+			limit = 20
+		}
+		names := make([]string, limit)
+		for i := 0; i < limit; i++ {
+			names[i] = "`" + schema.Tables[i].Name + "`"
+		}
+		fmt.Fprintf(buf, "**Tables:** %s", strings.Join(names, ", "))
+		if len(schema.Tables) > limit {
+			fmt.Fprintf(buf, " *+%d more*", len(schema.Tables)-limit)
+		}
+		buf.WriteString("\n")
+	}
+	for _, cmd := range schema.Commands {
+		fmt.Fprintf(buf, "- %s: `%s`\n", cmd.Description, cmd.Name)
+	}
+	buf.WriteString("\n")
+}
+
 // writePatternsCompact writes only the top 5 most relevant patterns per category
 func (g *ClaudeGenerator) writePatternsCompact(buf *bytes.Buffer, patterns *types.CodePatterns) {
 	if patterns == nil {
@@ -355,3 +355,653 @@ func extractSection(content, header string) string {
 	}
 	return rest[:end]
 }
+
+func TestClaudeGenerator_Events(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Events: []types.Event{
+			{Broker: "Kafka", Role: "producer", Topic: "orders.created", File: "orders/publisher.go", Line: 12},
+			{Broker: "Kafka", Role: "consumer", Topic: "orders.created", Handler: "onOrder", File: "billing/listener.java", Line: 6},
+			{Broker: "NATS", Role: "consumer", Topic: "billing.result", File: "worker.go"},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Events",
+		"### Kafka",
+		"| `orders.created` | `orders/publisher.go:12` | `billing/listener.java:6` (`onOrder`) |",
+		"### NATS",
+		"| `billing.result` | - | `worker.go` |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_DatabaseSchema(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		DatabaseSchema: &types.DatabaseSchema{
+			Tools:         []string{"golang-migrate"},
+			MigrationDirs: []string{"db/migrations"},
+			Tables: []types.SchemaTable{
+				{
+					Name: "users",
+					Columns: []types.SchemaColumn{
+						{Name: "id", Primary: true},
+						{Name: "org_id"},
+						{Name: "email", Unique: true},
+						{Name: "name"},
+					},
+					Relations: []types.SchemaRelation{{Column: "org_id", Target: "orgs", Kind: "belongs-to"}},
+				},
+			},
+			Commands: []types.Command{
+				{Name: "migrate -path db/migrations -database \"$DATABASE_URL\" up", Description: "Apply pending migrations"},
+			},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Database Schema",
+		"**Migrations:** `db/migrations/`",
+		"| `users` | `id` PK, `org_id` FK, `email` unique, `name` | `org_id` → `orgs` |",
+		"# Apply pending migrations\nmigrate -path db/migrations",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_CLIReference(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		CLIInfo: &types.CLIInfo{
+			Framework: "Cobra",
+			Commands: []types.CLICommand{
+				{
+					Name:        "tool",
+					Description: "Tool does things",
+					Flags: []types.CLIFlag{
+						{Name: "config", Shorthand: "c", Type: "string", Usage: "config file", Persistent: true},
+					},
+					Subcommands: []types.CLICommand{
+						{
+							Name:        "serve",
+							Args:        "[addr]",
+							Description: "Start the server",
+							Flags:       []types.CLIFlag{{Name: "port", Type: "int", Default: "8080", Usage: "port to listen on"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## CLI Reference",
+		"**Framework:** Cobra",
+		"| `tool serve [addr]` | Start the server |",
+		"### `tool` flags",
+		"| `-c, --config` | string | - | config file *(global)* |",
+		"| `--port` | int | `8080` | port to listen on |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+
+	g.SetCompact(true)
+	content, err = g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(content), "- `tool serve [addr]` - Start the server") || strings.Contains(string(content), "### `tool` flags") {
+		t.Error("expected compact CLI reference without flag tables")
+	}
+}
+
+func TestClaudeGenerator_EnvVars(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		EnvVars: []types.EnvVar{
+			{Name: "DATABASE_URL", UsedIn: []string{"config/db.go:12"}, DefinedIn: []string{".env.example"}, Documented: true},
+			{Name: "PORT", Default: "8080", UsedIn: []string{"main.go:5"}},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Environment Variables",
+		"| `DATABASE_URL` | - | `config/db.go:12` | `.env.example` |",
+		"| `PORT` | `8080` | `main.go:5` | - |",
+		"**Undocumented:** `PORT`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_MermaidDiagram(t *testing.T) {
+	g := NewClaudeGenerator()
+	g.SetDiagramFormat(DiagramMermaid)
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		ArchitectureInfo: &types.ArchitectureInfo{
+			Style:  "Standard Go Layout",
+			Layers: []types.ArchitectureLayer{{Name: "cmd"}, {Name: "internal"}},
+			Dependencies: map[string][]string{
+				"cmd/app":      {"internal/store"},
+				"internal/api": {"internal/store"},
+			},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"```mermaid\nflowchart TD",
+		"n_cmd_app --> n_internal_store",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_Ownership(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Structure: types.ProjectStructure{
+			Directories: []types.Directory{
+				{Path: "internal/api", Purpose: "API handlers", Owners: []string{"@acme/backend"}},
+			},
+		},
+		KeyFiles: []types.KeyFile{
+			{Path: "go.mod", Purpose: "Go module", Owners: []string{"@acme/core"}},
+		},
+		Ownership: &types.Ownership{
+			Source: ".github/CODEOWNERS",
+			Entries: []types.OwnershipEntry{
+				{Path: "*", Owners: []string{"@acme/core"}},
+				{Path: "/internal/api/", Owners: []string{"@acme/backend"}},
+				{Path: "/vendor/"},
+			},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Code Ownership",
+		"Rules from `.github/CODEOWNERS`",
+		"| `/internal/api/` | @acme/backend |",
+		"| `/vendor/` | *unowned* |",
+		"# API handlers (owners: @acme/backend)",
+		"| File | Purpose | Description | Owners |",
+		"| `go.mod` | Go module | - | @acme/core |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_Churn(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		GitConventions: &types.GitConventions{
+			Churn: &types.ChurnInfo{
+				WindowDays:      90,
+				CommitsAnalyzed: 42,
+				Hotspots:        []types.FileChurn{{Path: "api/handler.go", Changes: 12, Recent: 5}},
+				CoChanges: []types.CoChange{
+					{Files: []string{"api/handler.go", "api/handler_test.go"}, Together: 9, Coupling: 0.75},
+				},
+			},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Frequently Changed Areas",
+		"From 42 commits in the 90 days",
+		"| `api/handler.go` | 12 | 5 |",
+		"## Files That Change Together",
+		"- `api/handler.go` ↔ `api/handler_test.go` (9 commits, 75%)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_Release(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Release: &types.ReleaseInfo{
+			Tools:        []types.ReleaseTool{{Name: "GoReleaser", ConfigFile: ".goreleaser.yml", Command: "goreleaser release --clean", CI: true}},
+			Changelog:    &types.Changelog{Path: "CHANGELOG.md", Format: "Keep a Changelog", Unreleased: true},
+			VersionFiles: []types.VersionFile{{Path: "VERSION", Version: "1.4.0"}},
+			TagPattern:   "v<major>.<minor>.<patch>",
+			LatestTag:    "v1.4.0",
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Release Process",
+		"| GoReleaser | `.goreleaser.yml` | Yes |",
+		"- **Tags:** `v<major>.<minor>.<patch>` (latest: `v1.4.0`)",
+		"- **Changelog:** `CHANGELOG.md` - Keep a Changelog format; add user-facing changes under `## [Unreleased]`",
+		"2. Bump the version in `VERSION`",
+		"3. Move the `## [Unreleased]` entries in `CHANGELOG.md` under a new `## [X.Y.Z] - YYYY-MM-DD` heading",
+		"5. Tag the release: `git tag -a vX.Y.Z -m \"vX.Y.Z\"`",
+		"7. CI runs GoReleaser on the new tag",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestReleaseSteps_ReleasePlease(t *testing.T) {
+	steps := releaseSteps(&types.ReleaseInfo{
+		Tools:      []types.ReleaseTool{{Name: "release-please", ConfigFile: "release-please-config.json", CI: true}},
+		Changelog:  &types.Changelog{Path: "CHANGELOG.md", Format: "generated", Generator: "release-please"},
+		TagPattern: "<component>-v<version>",
+	})
+
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 steps, got %v", steps)
+	}
+	if !strings.Contains(steps[2], "release-please tags `<component>-vX.Y.Z`") {
+		t.Errorf("expected release PR merge to tag the component, got %q", steps[2])
+	}
+}
+
+func TestClaudeGenerator_CI(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Commands:    []types.Command{{Name: "go test ./...", Description: "Run all tests", CIVerified: true}},
+		CIInfo: &types.CIInfo{
+			Pipelines: []types.CIPipeline{{
+				Provider: "GitHub Actions",
+				File:     ".github/workflows/ci.yml",
+				Triggers: []string{"push", "pull_request"},
+				Jobs:     []types.CIJob{{Name: "lint"}, {Name: "test"}},
+			}},
+			Commands: []types.CICommand{
+				{Category: "Test", Command: "go test ./...", Job: "test"},
+				{Category: "Lint", Command: "npm run lint", Dir: "web", Job: "lint"},
+			},
+			RequiredChecks: []string{"test"},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## CI Pipeline",
+		"CI runs these checks; run them locally before pushing:\n\n```bash\ngo test ./...\n(cd web && npm run lint)\n```",
+		"| `.github/workflows/ci.yml` | GitHub Actions | push, pull_request | lint, test |",
+		"**Required checks:** `test`",
+		"# Run all tests (CI)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_Tests(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Tests: &types.TestInventory{
+			TestFiles: 3,
+			TestCount: 12,
+			Runners:   []types.TestRunner{{Framework: "pytest", RunFile: "pytest <file>", RunSingle: "pytest <file>::<name>"}},
+			Areas: []types.TestArea{
+				{Dir: "app/services", TestDir: "tests/services", Files: 2, Tests: 9, Coverage: 81.5},
+				{Dir: "app", TestDir: "app", Files: 1, Tests: 3},
+			},
+			Untested: []string{"app/workers"},
+			Helpers:  []string{"tests/conftest.py"},
+			Mocks:    []types.MockTool{{Name: "pytest-mock", Source: "tests/test_api.py"}},
+			Coverage: &types.CoverageReport{Path: "coverage.xml", Format: "cobertura", Percent: 74.2},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Testing",
+		"12 tests in 3 files; 74.2% line coverage (`coverage.xml`).",
+		"| pytest | `pytest <file>` | `pytest <file>::<name>` |",
+		"| `app/services` | `tests/services` | 2 | 9 | 81.5% |",
+		"| `app` | colocated | 1 | 3 | - |",
+		"- **Helpers:** `tests/conftest.py`",
+		"- **Mocks:** pytest-mock (`tests/test_api.py`)",
+		"### Untested Areas",
+		"`app/workers`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_Infrastructure(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Infrastructure: &types.Infrastructure{
+			Dockerfiles: []types.Dockerfile{{
+				Path:         "Dockerfile",
+				Stages:       []types.DockerStage{{Name: "builder", Image: "golang:1.22"}, {Image: "alpine:3.19"}},
+				ExposedPorts: []string{"8080"},
+				Command:      "/app serve",
+			}},
+			ComposeServices: []types.ComposeService{
+				{Name: "api", File: "docker-compose.yml", Build: ".", Ports: []string{"8080:8080"}, DependsOn: []string{"db"}},
+				{Name: "db", File: "docker-compose.yml", Image: "postgres:16", Ports: []string{"5432:5432"}, Backing: true},
+			},
+			Kubernetes: []types.K8sResource{
+				{Kind: "Deployment", Name: "api", Path: "deploy/app.yaml", Images: []string{"acme/api:1.0"}, Replicas: 2},
+				{Kind: "Service", Name: "api", Path: "deploy/app.yaml"},
+			},
+			Terraform: &types.Terraform{Dirs: []string{"infra"}, Providers: []string{"aws"}, Backend: "s3"},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Infrastructure & Deployment",
+		"- **Docker** (`Dockerfile`): 2-stage build `golang:1.22` → `alpine:3.19`, exposes 8080, runs `/app serve`",
+		"- **Kubernetes** (`deploy/app.yaml`): Deployment `api` (2 replicas, `acme/api:1.0`), Service `api`",
+		"- **Terraform** (`infra`): providers aws; state in `s3` backend",
+		"| api | built from `.` | 8080:8080 | db |",
+		"| db | `postgres:16` | 5432:5432 |  |",
+		"`docker compose up -d db`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeGenerator_NestedContexts(t *testing.T) {
+	g := NewClaudeGenerator()
+
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		KeyFiles: []types.KeyFile{
+			{Path: "main.go", Purpose: "Entry point"},
+			{Path: "internal/api/router.go", Purpose: "Routes"},
+		},
+		NestedContexts: []types.NestedContext{
+			{Path: "internal/api", Purpose: "API endpoints"},
+			{Path: "web"},
+		},
+	}
+
+	content, err := g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## Directory Guides",
+		"- [`internal/api/`](internal/api/CLAUDE.md) - API endpoints",
+		"- [`web/`](web/CLAUDE.md)\n",
+		"| `main.go` | Entry point |",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(contentStr, exp) {
+			t.Errorf("expected output to contain %q", exp)
+		}
+	}
+	if strings.Contains(contentStr, "internal/api/router.go") {
+		t.Error("expected key files inside a nested context to be left to that file")
+	}
+
+	g.SetCompact(true)
+	content, err = g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(content), "**Directory guides:** `internal/api/CLAUDE.md`, `web/CLAUDE.md`") {
+		t.Error("expected compact output to list the directory guides")
+	}
+}
+
+func TestConventionText(t *testing.T) {
+	tests := []struct {
+		name string
+		conv types.Convention
+		want string
+	}{
+		{"unscored", types.Convention{Description: "Use tabs"}, "Use tabs"},
+		{"strong", types.Convention{Description: "Use tabs", Confidence: 0.9}, "Use tabs"},
+		{"weak", types.Convention{Description: "Use tabs", Confidence: 0.5}, "Use tabs _(tentative: limited evidence)_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conventionText(tt.conv); got != tt.want {
+				t.Errorf("conventionText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// mlWorkflowFixture is a Hydra + DVC training project
+func mlWorkflowFixture() *types.MLWorkflow {
+	return &types.MLWorkflow{
+		Trackers: []types.ExperimentTracker{
+			{Name: "MLflow", Project: "resnet-baseline", Calls: []string{"mlflow.log_metric", "mlflow.start_run"}},
+		},
+		Stages: []types.PipelineStage{
+			{Name: "prepare", Command: "python src/prepare.py", Outs: []string{"data/prepared"}},
+			{Name: "train", Command: "python src/train.py", Params: []string{"train.lr"}, Outs: []string{"models/model.pt"}, After: []string{"prepare"}},
+			{Name: "evaluate", Command: "python src/evaluate.py", Metrics: []string{"metrics.json"}, After: []string{"prepare", "train"}},
+		},
+		Config: &types.MLConfig{
+			Framework: "Hydra",
+			Dir:       "conf",
+			Primary:   "config.yaml",
+			Groups:    []types.ConfigGroup{{Name: "model", Options: []string{"resnet", "vit"}}},
+			Defaults:  []string{"model=resnet"},
+		},
+		EntryPoints: []types.MLEntryPoint{
+			{Path: "src/train.py", Kind: "train", Command: "python src/train.py", Hydra: true},
+			{Path: "src/evaluate.py", Kind: "eval", Command: "python src/evaluate.py", Args: []types.CLIFlag{{Name: "batch-size", Default: "64"}}},
+		},
+		Checkpoints: &types.CheckpointInfo{Dir: "checkpoints", Format: ".pt", Pattern: "epoch_{epoch}.pt", Saves: []string{"torch.save"}},
+		Devices: []types.DeviceSelection{
+			{Method: "CUDA with CPU fallback", Example: `device = torch.device("cuda" if torch.cuda.is_available() else "cpu")`, Files: []string{"src/train.py"}},
+			{Method: "Hard-coded CUDA", Example: "model.cuda()", Files: []string{"src/evaluate.py"}},
+		},
+	}
+}
+
+func TestClaudeGenerator_MLWorkflow(t *testing.T) {
+	analysis := &types.Analysis{ProjectName: "test-project", MLWorkflow: mlWorkflowFixture()}
+
+	content, err := NewClaudeGenerator().Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	contentStr := string(content)
+	expected := []string{
+		"## ML Workflow",
+		"| `src/train.py` | train | `python src/train.py` |",
+		"- `src/evaluate.py` flags: `--batch-size` (default `64`)",
+		"- Hydra scripts take `key=value` overrides instead of flags: `python src/train.py model=vit`",
+		"Configuration is loaded with Hydra from `conf/config.yaml`; defaults: `model=resnet`.",
+		"| `model` | `resnet`, `vit` |",
+		"| evaluate | `python src/evaluate.py` | prepare, train | `metrics.json` |",
+		"- **MLflow** (project `resnet-baseline`): `mlflow.log_metric`, `mlflow.start_run`",
+		"Checkpoints are saved with `torch.save` to `checkpoints/`, named like `epoch_{epoch}.pt`.",
+		"- **Hard-coded CUDA** in `src/evaluate.py`: `model.cuda()`. This fails on machines without a GPU",
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("expected CLAUDE.md to contain %q, got:\n%s", e, contentStr)
+		}
+	}
+
+	g := NewClaudeGenerator()
+	g.SetCompact(true)
+	content, err = g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, e := range []string{"- **Train:** `python src/train.py`", "- **Pipeline:** `dvc repro` (3 stages)", "- **Checkpoints:** `checkpoints/epoch_{epoch}.pt`"} {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("expected compact CLAUDE.md to contain %q, got:\n%s", e, content)
+		}
+	}
+}
+
+func TestClaudeGenerator_Notebooks(t *testing.T) {
+	notebooks := []types.Notebook{
+		{
+			Path:      "notebooks/01_eda.ipynb",
+			Title:     "Customer churn",
+			Sections:  []string{"Load", "Features"},
+			Imports:   []string{"pandas", "seaborn"},
+			Magics:    []string{"!pip", "%matplotlib"},
+			DataPaths: []string{"data/raw/customers.csv"},
+		},
+		{Path: "notebooks/02_model.ipynb", Imports: []string{"sklearn"}},
+	}
+	analysis := &types.Analysis{ProjectName: "test-project", Notebooks: notebooks}
+
+	content, err := NewClaudeGenerator().Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	expected := []string{
+		"## Notebooks",
+		"| Notebook | Purpose | Imports | Data |",
+		"| `notebooks/01_eda.ipynb` | Customer churn: Load, Features | `pandas`, `seaborn` | `data/raw/customers.csv` |",
+		"| `notebooks/02_model.ipynb` |  | `sklearn` |  |",
+		"Magics and shell commands in use: `!pip`, `%matplotlib`.",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("expected CLAUDE.md to contain %q, got:\n%s", e, content)
+		}
+	}
+
+	// Without summaries the purpose column is left out
+	analysis.Notebooks = []types.Notebook{{Path: "eda.ipynb", Imports: []string{"pandas"}}}
+	content, err = NewClaudeGenerator().Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(content), "| `eda.ipynb` | `pandas` |  |") || strings.Contains(string(content), "Purpose") {
+		t.Errorf("expected a table without purposes, got:\n%s", content)
+	}
+
+	g := NewClaudeGenerator()
+	g.SetCompact(true)
+	analysis.Notebooks = notebooks
+	content, err = g.Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(content), "- `notebooks/01_eda.ipynb`: Customer churn") {
+		t.Errorf("expected compact CLAUDE.md to list notebook purposes, got:\n%s", content)
+	}
+}
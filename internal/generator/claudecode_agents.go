@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Priyans-hu/argus/internal/detector"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
@@ -111,9 +112,15 @@ func goReviewerContent(analysis *types.Analysis, ctx *GeneratorContext) string {
 		}
 		content.WriteString("\n")
 	}
+	goPractices := detector.ErrorPracticesFor(analysis.ErrorHandling, "Go")
+	writeErrorPractices(&content, goPractices)
 	content.WriteString("- All errors must be checked (no `_ = err`)\n")
-	content.WriteString("- Use `%w` for error wrapping to preserve error chain\n")
-	content.WriteString("- Add context when wrapping errors\n\n")
+	if !hasErrorPractice(goPractices, detector.ErrorPracticeWrapping) {
+		content.WriteString("- Use `%w` for error wrapping to preserve error chain\n")
+		content.WriteString("- Add context when wrapping errors\n")
+	}
+	content.WriteString("\n")
+	writeLoggingSection(&content, analysis, "Go")
 
 	// Project-specific testing section
 	if ctx.HasTestingContext() {
@@ -263,6 +270,14 @@ func tsReviewerContent(analysis *types.Analysis, ctx *GeneratorContext) string {
 		content.WriteString("\n")
 	}
 
+	// Project-specific error handling and logging
+	if practices := detector.ErrorPracticesFor(analysis.ErrorHandling, "JavaScript/TypeScript"); len(practices) > 0 {
+		content.WriteString("## Error Handling\n\n")
+		writeErrorPractices(&content, practices)
+		content.WriteString("\n")
+	}
+	writeLoggingSection(&content, analysis, "JavaScript/TypeScript")
+
 	// Standard type safety guidelines
 	content.WriteString("## Type Safety (TypeScript)\n\n")
 	content.WriteString("- Avoid using 'any' type - use 'unknown' or proper types\n")
@@ -376,10 +391,12 @@ func pythonReviewerContent(analysis *types.Analysis, ctx *GeneratorContext) stri
 	content.WriteString("- Consider using TypedDict for complex dictionaries\n\n")
 
 	content.WriteString("## Error Handling\n\n")
+	writeErrorPractices(&content, detector.ErrorPracticesFor(analysis.ErrorHandling, "Python"))
 	content.WriteString("- Use specific exception types\n")
 	content.WriteString("- Don't use bare except clauses\n")
 	content.WriteString("- Use context managers (with statements) for resources\n")
 	content.WriteString("- Provide helpful error messages\n\n")
+	writeLoggingSection(&content, analysis, "Python")
 
 	content.WriteString("## Common Issues to Flag\n\n")
 	content.WriteString("- Mutable default arguments\n")
@@ -650,6 +667,27 @@ func securityReviewerContent(analysis *types.Analysis, ctx *GeneratorContext) st
 		content.WriteString("\n")
 	}
 
+	// Endpoints with no detected auth guard, for explicit review
+	if public := publicEndpoints(analysis); len(public) > 0 {
+		content.WriteString("## Public Endpoints\n\n")
+		content.WriteString("No auth guard was detected for these endpoints. Confirm each one is meant to be public:\n\n")
+		limit := len(public)
+		if limit > 20 {
+			limit = 20
+		}
+		for _, ep := range public[:limit] {
+			location := ep.File
+			if ep.Line > 0 {
+				location = fmt.Sprintf("%s:%d", ep.File, ep.Line)
+			}
+			content.WriteString(fmt.Sprintf("- `%s %s` - `%s`\n", ep.Method, ep.Path, location))
+		}
+		if len(public) > limit {
+			content.WriteString(fmt.Sprintf("- ...and %d more\n", len(public)-limit))
+		}
+		content.WriteString("\n")
+	}
+
 	// Standard security guidelines
 	content.WriteString("## Input Validation\n\n")
 	content.WriteString("- Validate all user input\n")
@@ -727,6 +765,38 @@ func hasErrorHandlingConvention(analysis *types.Analysis) bool {
 	return false
 }
 
+// hasErrorPractice reports whether a practice of the given kind was detected
+func hasErrorPractice(practices []types.ErrorPractice, kind string) bool {
+	for _, p := range practices {
+		if p.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLoggingSection writes the logger a language's code uses, if one was detected
+func writeLoggingSection(content *strings.Builder, analysis *types.Analysis, language string) {
+	logger := detector.LoggerFor(analysis.ErrorHandling, language)
+	if logger == nil {
+		return
+	}
+	content.WriteString("## Logging\n\n")
+	writeLoggerUsage(content, logger)
+	content.WriteString("\n")
+}
+
+// publicEndpoints returns endpoints for which no auth guard was detected
+func publicEndpoints(analysis *types.Analysis) []types.Endpoint {
+	var public []types.Endpoint
+	for _, ep := range analysis.Endpoints {
+		if ep.Public {
+			public = append(public, ep)
+		}
+	}
+	return public
+}
+
 // findFilesWithPattern finds files that contain a specific pattern
 // Uses the KeyFiles and CodePatterns as proxy since we don't have direct file search
 func findFilesWithPattern(analysis *types.Analysis, pattern string) []string {
@@ -2,8 +2,11 @@ package generator
 
 import (
 	"encoding/json"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/Priyans-hu/argus/internal/detector"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
@@ -52,6 +55,25 @@ func (g *ClaudeCodeGenerator) generateHooks(analysis *types.Analysis) []types.Ge
 		settings.Hooks["PreToolUse"] = preToolUseHooks
 	}
 
+	// Validate commit messages against the detected conventions
+	var scripts []types.GeneratedFile
+	if script := buildCommitMsgValidator(analysis.GitConventions); script != "" {
+		settings.Hooks["PreToolUse"] = append(settings.Hooks["PreToolUse"], HookMatcher{
+			Matcher: "Bash",
+			Hooks: []HookConfig{
+				{
+					Type:    "command",
+					Command: `python3 "$CLAUDE_PROJECT_DIR/` + commitMsgHookPath + `"`,
+					Timeout: 10,
+				},
+			},
+		})
+		scripts = append(scripts, types.GeneratedFile{
+			Path:    commitMsgHookPath,
+			Content: []byte(script),
+		})
+	}
+
 	// Only generate if we have hooks to add
 	if len(settings.Hooks) == 0 {
 		return nil
@@ -63,12 +85,12 @@ func (g *ClaudeCodeGenerator) generateHooks(analysis *types.Analysis) []types.Ge
 		return nil
 	}
 
-	return []types.GeneratedFile{
+	return append([]types.GeneratedFile{
 		{
 			Path:    ".claude/settings.json",
 			Content: content,
 		},
-	}
+	}, scripts...)
 }
 
 // buildPostToolUseHooks creates hooks that run after tool execution
@@ -180,3 +202,164 @@ except Exception as e:
     sys.exit(0)
 "`
 }
+
+// commitMsgHookPath is where the commit message validator is written
+const commitMsgHookPath = ".claude/hooks/validate-commit-msg.py"
+
+// commitWordRegex limits types and scopes embedded in the validator
+var commitWordRegex = regexp.MustCompile(`^[A-Za-z0-9_./-]+$`)
+
+// buildCommitMsgValidator returns a PreToolUse script that rejects
+// `git commit -m` messages breaking the detected commit conventions, or ""
+// when there is nothing to enforce
+func buildCommitMsgValidator(git *types.GitConventions) string {
+	if git == nil {
+		return ""
+	}
+
+	headerRe, format := commitHeaderPattern(git.CommitConvention, git.CommitLint)
+	maxLength := 0
+	if git.CommitLint != nil {
+		maxLength = git.CommitLint.HeaderMaxLength
+	}
+	if headerRe == "" && maxLength == 0 && !git.SignOff {
+		return ""
+	}
+
+	headerLiteral := "None"
+	if headerRe != "" {
+		headerLiteral = "r'" + headerRe + "'"
+	}
+	signOff := "False"
+	if git.SignOff {
+		signOff = "True"
+	}
+
+	return strings.NewReplacer(
+		"{{HEADER_RE}}", headerLiteral,
+		"{{FORMAT}}", strconv.Quote(format),
+		"{{MAX_LENGTH}}", strconv.Itoa(maxLength),
+		"{{SIGN_OFF}}", signOff,
+	).Replace(commitMsgValidatorScript)
+}
+
+// commitHeaderPattern builds the subject line regex for the commit style
+func commitHeaderPattern(cc *types.CommitConvention, lint *types.CommitLint) (string, string) {
+	if cc == nil {
+		return "", ""
+	}
+
+	switch cc.Style {
+	case "conventional", "angular":
+		// Types seen in history are only the most frequent ones, so the type
+		// enum is enforced only when commitlint configures one
+		allowed := commitWords(detector.DefaultConventionalTypes)
+		scope := `(\([^)]+\))?`
+		if lint != nil {
+			if enum := commitWords(lint.Types); len(enum) > 0 {
+				allowed = enum
+			}
+			if scopes := commitWords(lint.Scopes); len(scopes) > 0 {
+				scope = `(\((` + strings.Join(scopes, "|") + `)\))?`
+			}
+		}
+		return `^(` + strings.Join(allowed, "|") + `)` + scope + `!?: \S`, cc.Format
+	case "gitmoji":
+		return `^:[a-z0-9_+-]+:\s*\S`, cc.Format
+	case "jira":
+		return `^[A-Z][A-Z0-9]+-[0-9]+\s*\S`, cc.Format
+	}
+	return "", ""
+}
+
+// commitWords quotes the types or scopes that are safe to embed in a regex
+func commitWords(words []string) []string {
+	var quoted []string
+	for _, w := range words {
+		if commitWordRegex.MatchString(w) {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	return quoted
+}
+
+// commitMsgValidatorScript validates messages passed with -m/--message.
+// Commits that open an editor or use -F are left to the project's own hooks,
+// as are messages built by command substitution or variables (the common
+// -m "$(cat <<'EOF' ... EOF)" heredoc form) since shlex only sees the
+// unexpanded text.
+const commitMsgValidatorScript = `#!/usr/bin/env python3
+"""Reject git commit messages that break this project's commit conventions.
+
+Generated by argus from git history and commitlint config.
+"""
+import json
+import re
+import shlex
+import sys
+
+HEADER_RE = {{HEADER_RE}}
+FORMAT = {{FORMAT}}
+MAX_LENGTH = {{MAX_LENGTH}}
+SIGN_OFF = {{SIGN_OFF}}
+SKIP_PREFIXES = ('Merge ', 'Revert ', 'fixup! ', 'squash! ', 'amend! ')
+DYNAMIC_RE = re.compile(r'\$[({A-Za-z_]|` + "`" + `')
+
+
+def commit_messages(command):
+    try:
+        args = shlex.split(command)
+    except ValueError:
+        return None, False
+    for i in range(len(args) - 1):
+        if args[i] == 'git' and args[i + 1] == 'commit':
+            break
+    else:
+        return None, False
+
+    messages, signed = [], False
+    it = iter(args[i + 2:])
+    for arg in it:
+        if arg in ('&&', '||', ';', '|'):
+            break
+        if arg in ('-m', '--message'):
+            messages.append(next(it, ''))
+        elif arg.startswith('--message='):
+            messages.append(arg[len('--message='):])
+        elif arg.startswith('-m') and not arg.startswith('--'):
+            messages.append(arg[2:])
+        elif arg in ('-s', '--signoff'):
+            signed = True
+    if not messages:
+        return None, signed
+    return '\n\n'.join(messages), signed
+
+
+def main():
+    try:
+        data = json.load(sys.stdin)
+    except Exception:
+        return 0
+    message, signed = commit_messages(data.get('tool_input', {}).get('command', ''))
+    if message is None or DYNAMIC_RE.search(message):
+        return 0
+
+    header = message.splitlines()[0] if message else ''
+    errors = []
+    if not header.startswith(SKIP_PREFIXES):
+        if HEADER_RE and not re.match(HEADER_RE, header):
+            errors.append('subject must match ' + FORMAT)
+        if MAX_LENGTH and len(header) > MAX_LENGTH:
+            errors.append('subject is %d characters, max is %d' % (len(header), MAX_LENGTH))
+    if SIGN_OFF and not signed and 'Signed-off-by:' not in message:
+        errors.append('commits need a Signed-off-by trailer, use git commit -s')
+
+    if errors:
+        print('Commit message rejected: ' + '; '.join(errors), file=sys.stderr)
+        return 2
+    return 0
+
+
+if __name__ == '__main__':
+    sys.exit(main())
+`
@@ -254,6 +254,9 @@ func hasGitHubIntegration(analysis *types.Analysis) bool {
 }
 
 func hasDocker(analysis *types.Analysis) bool {
+	if infra := analysis.Infrastructure; infra != nil && (len(infra.Dockerfiles) > 0 || len(infra.ComposeServices) > 0) {
+		return true
+	}
 	// Check for Dockerfile or docker-compose
 	for _, file := range analysis.Structure.RootFiles {
 		fileLower := strings.ToLower(file)
@@ -271,6 +274,10 @@ func hasDocker(analysis *types.Analysis) bool {
 }
 
 func hasKubernetes(analysis *types.Analysis) bool {
+	if infra := analysis.Infrastructure; infra != nil &&
+		(len(infra.Kubernetes) > 0 || len(infra.HelmCharts) > 0 || len(infra.Kustomizations) > 0) {
+		return true
+	}
 	// Check for k8s manifests or helm charts
 	for _, dir := range analysis.Structure.Directories {
 		dirLower := strings.ToLower(dir.Path)
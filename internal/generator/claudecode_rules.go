@@ -2,8 +2,10 @@ package generator
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/Priyans-hu/argus/internal/detector"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
@@ -35,8 +37,13 @@ func (g *ClaudeCodeGenerator) generateRules(analysis *types.Analysis) []types.Ge
 		}
 	}
 
-	// Architecture rules (if architecture is detected)
-	if ctx.HasArchitectureContext() {
+	// Error handling and logging rules (from ErrorHandling)
+	if file := g.generateErrorHandlingRule(analysis, ctx); file != nil {
+		files = append(files, *file)
+	}
+
+	// Architecture rules (if architecture is detected or declared)
+	if ctx.HasArchitectureContext() || analysis.ArchitectureRules != nil {
 		if file := g.generateArchitectureRule(analysis, ctx); file != nil {
 			files = append(files, *file)
 		}
@@ -90,8 +97,37 @@ func (g *ClaudeCodeGenerator) generateGitWorkflowRule(analysis *types.Analysis,
 			content.WriteString("| chore | Maintenance tasks |\n")
 			content.WriteString("\n")
 		}
+
+		if len(cc.Scopes) > 0 {
+			content.WriteString("### Scopes\n\n")
+			content.WriteString("Pick the scope for the area the change touches.\n\n")
+			content.WriteString("| Scope | Directory |\n")
+			content.WriteString("|-------|-----------|\n")
+			for _, scope := range cc.Scopes {
+				dir := "-"
+				if d, ok := cc.ScopeDirs[scope]; ok {
+					dir = "`" + d + "/`"
+				}
+				content.WriteString(fmt.Sprintf("| %s | %s |\n", scope, dir))
+			}
+			content.WriteString("\n")
+		}
+
+		if cc.Style == "conventional" || cc.Style == "angular" {
+			content.WriteString("### Breaking Changes\n\n")
+			switch {
+			case slices.Contains(cc.Breaking, "!") && slices.Contains(cc.Breaking, "BREAKING CHANGE:"):
+				content.WriteString("Add `!` after the type/scope (`feat(api)!: ...`) and explain the change in a `BREAKING CHANGE:` footer.\n\n")
+			case slices.Contains(cc.Breaking, "BREAKING CHANGE:"):
+				content.WriteString("Describe breaking changes in a `BREAKING CHANGE:` footer.\n\n")
+			default:
+				content.WriteString("Mark breaking changes with `!` after the type/scope, e.g. `feat(api)!: drop v1 endpoints`.\n\n")
+			}
+		}
 	}
 
+	writeCommitRequirements(&content, analysis.GitConventions)
+
 	// Branch conventions
 	if bc := analysis.GitConventions.BranchConvention; bc != nil {
 		content.WriteString("## Branch Naming\n\n")
@@ -110,6 +146,17 @@ func (g *ClaudeCodeGenerator) generateGitWorkflowRule(analysis *types.Analysis,
 		content.WriteString("\n")
 	}
 
+	// Checks CI will run on the pushed branch
+	if analysis.CIInfo != nil && len(analysis.CIInfo.Commands) > 0 {
+		content.WriteString("## Before Pushing\n\n")
+		content.WriteString("Run the checks CI runs:\n\n")
+		content.WriteString("```bash\n")
+		for _, c := range analysis.CIInfo.Commands {
+			content.WriteString(detector.CICommandName(c) + "\n")
+		}
+		content.WriteString("```\n\n")
+	}
+
 	// Workflow
 	content.WriteString("## Workflow\n\n")
 	content.WriteString("1. Create a feature branch from main\n")
@@ -124,6 +171,52 @@ func (g *ClaudeCodeGenerator) generateGitWorkflowRule(analysis *types.Analysis,
 	}
 }
 
+// writeCommitRequirements writes ticket references, sign-off and commitlint rules
+func writeCommitRequirements(content *strings.Builder, git *types.GitConventions) {
+	if len(git.Tickets) > 0 {
+		content.WriteString("## Ticket References\n\n")
+		for _, t := range git.Tickets {
+			if t.Location == "trailer" {
+				content.WriteString(fmt.Sprintf("- Add a `%s: %s` trailer to the commit body\n", t.Trailer, t.Format))
+			} else {
+				content.WriteString(fmt.Sprintf("- Reference the ticket as `%s` in the subject line\n", t.Format))
+			}
+			if t.Example != "" {
+				content.WriteString(fmt.Sprintf("  - Example: `%s`\n", t.Example))
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	if git.SignOff {
+		content.WriteString("## Sign-off\n\n")
+		content.WriteString("Every commit needs a `Signed-off-by:` trailer (Developer Certificate of Origin). Commit with `git commit -s`.\n\n")
+	}
+
+	if lint := git.CommitLint; lint != nil {
+		content.WriteString("## Commit Linting\n\n")
+		if lint.ConfigFile != "" {
+			content.WriteString(fmt.Sprintf("- Config: `%s`\n", lint.ConfigFile))
+		}
+		if len(lint.Extends) > 0 {
+			content.WriteString(fmt.Sprintf("- Extends: `%s`\n", strings.Join(lint.Extends, "`, `")))
+		}
+		if len(lint.Types) > 0 {
+			content.WriteString(fmt.Sprintf("- Allowed types: %s\n", strings.Join(lint.Types, ", ")))
+		}
+		if len(lint.Scopes) > 0 {
+			content.WriteString(fmt.Sprintf("- Allowed scopes: %s\n", strings.Join(lint.Scopes, ", ")))
+		}
+		if lint.HeaderMaxLength > 0 {
+			content.WriteString(fmt.Sprintf("- Header max length: %d characters\n", lint.HeaderMaxLength))
+		}
+		if lint.Commitizen != "" {
+			content.WriteString(fmt.Sprintf("- Commitizen adapter: `%s`\n", lint.Commitizen))
+		}
+		content.WriteString("\n")
+	}
+}
+
 // generateTestingRule creates testing rules from detected patterns
 func (g *ClaudeCodeGenerator) generateTestingRule(analysis *types.Analysis, ctx *GeneratorContext) *types.GeneratedFile {
 	if analysis.CodePatterns == nil || len(analysis.CodePatterns.Testing) == 0 {
@@ -283,7 +376,7 @@ func (g *ClaudeCodeGenerator) generateCodingStyleRule(analysis *types.Analysis,
 		if convs, ok := categories[cat]; ok && len(convs) > 0 {
 			content.WriteString(fmt.Sprintf("## %s\n\n", formatCategoryName(cat)))
 			for _, conv := range convs {
-				content.WriteString(fmt.Sprintf("- %s\n", conv.Description))
+				content.WriteString(fmt.Sprintf("- %s\n", conventionText(conv)))
 				if conv.Example != "" {
 					content.WriteString(fmt.Sprintf("  ```\n  %s\n  ```\n", conv.Example))
 				}
@@ -326,6 +419,63 @@ func (g *ClaudeCodeGenerator) generateCodingStyleRule(analysis *types.Analysis,
 	}
 }
 
+// generateErrorHandlingRule creates per-language error handling and logging rules
+func (g *ClaudeCodeGenerator) generateErrorHandlingRule(analysis *types.Analysis, ctx *GeneratorContext) *types.GeneratedFile {
+	errorHandling := analysis.ErrorHandling
+	if errorHandling == nil {
+		return nil
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# Error Handling and Logging Rules for %s\n\n", ctx.ProjectName))
+	content.WriteString("Report errors and log the way the rest of this codebase does.\n\n")
+
+	var languages []string
+	for _, p := range errorHandling.Practices {
+		if !slices.Contains(languages, p.Language) {
+			languages = append(languages, p.Language)
+		}
+	}
+	for _, l := range errorHandling.Loggers {
+		if !slices.Contains(languages, l.Language) {
+			languages = append(languages, l.Language)
+		}
+	}
+
+	for _, language := range languages {
+		content.WriteString(fmt.Sprintf("## %s\n\n", language))
+		writeErrorPractices(&content, detector.ErrorPracticesFor(errorHandling, language))
+		if logger := detector.LoggerFor(errorHandling, language); logger != nil {
+			writeLoggerUsage(&content, logger)
+		}
+		content.WriteString("\n")
+	}
+
+	return &types.GeneratedFile{
+		Path:    ".claude/rules/error-handling.md",
+		Content: []byte(content.String()),
+	}
+}
+
+// writeErrorPractices writes one bullet per detected error practice with its examples
+func writeErrorPractices(content *strings.Builder, practices []types.ErrorPractice) {
+	for _, p := range practices {
+		content.WriteString("- " + p.Instruction)
+		if len(p.Examples) > 0 {
+			content.WriteString(" (e.g. " + codeList(p.Examples) + ")")
+		}
+		content.WriteString("\n")
+	}
+}
+
+// writeLoggerUsage writes how to log and the field keys already in use
+func writeLoggerUsage(content *strings.Builder, logger *types.LoggerUsage) {
+	content.WriteString("- " + logger.Instruction + "\n")
+	if len(logger.Fields) > 0 {
+		content.WriteString("- Reuse the existing field keys where they fit: " + codeList(logger.Fields) + "\n")
+	}
+}
+
 // generateArchitectureRule creates architecture rules from detected patterns
 func (g *ClaudeCodeGenerator) generateArchitectureRule(analysis *types.Analysis, ctx *GeneratorContext) *types.GeneratedFile {
 	var content strings.Builder
@@ -366,6 +516,11 @@ func (g *ClaudeCodeGenerator) generateArchitectureRule(analysis *types.Analysis,
 		}
 	}
 
+	// Declared layer rules from .argus.yaml
+	if rules := analysis.ArchitectureRules; rules != nil {
+		writeArchitectureRules(&content, rules)
+	}
+
 	// Key directories from structure
 	if len(analysis.Structure.Directories) > 0 {
 		content.WriteString("## Key Directories\n\n")
@@ -405,6 +560,27 @@ func (g *ClaudeCodeGenerator) generateArchitectureRule(analysis *types.Analysis,
 	}
 }
 
+// writeArchitectureRules renders the enforced layer boundaries from .argus.yaml
+func writeArchitectureRules(content *strings.Builder, rules *types.ArchitectureRules) {
+	content.WriteString("## Enforced Layer Rules\n\n")
+	content.WriteString("These boundaries are declared in `.argus.yaml` and checked by `argus arch check`. ")
+	content.WriteString("A layer may only import itself and the layers listed below; packages outside every layer are unrestricted.\n\n")
+	content.WriteString("| Layer | Paths | May import |\n")
+	content.WriteString("|-------|-------|------------|\n")
+	for _, layer := range rules.Layers {
+		allow := "*(nothing)*"
+		if len(layer.Allow) > 0 {
+			allow = strings.Join(layer.Allow, ", ")
+		}
+		content.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", layer.Name, strings.Join(layer.Paths, "`, `"), allow))
+	}
+	content.WriteString("\n")
+	if !rules.AllowCycles {
+		content.WriteString("Import cycles between packages are not allowed.\n\n")
+	}
+	content.WriteString("Run `argus arch check` after changing imports across layers.\n\n")
+}
+
 // securityRuleContent generates security rules
 func (g *ClaudeCodeGenerator) securityRuleContent(analysis *types.Analysis, ctx *GeneratorContext) string {
 	var content strings.Builder
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Priyans-hu/argus/internal/detector"
 	"github.com/Priyans-hu/argus/pkg/types"
 )
 
@@ -34,6 +35,22 @@ func (g *ClaudeCodeGenerator) generateSkills(analysis *types.Analysis) []types.G
 	frameworkSkills := g.generateFrameworkSkills(analysis, ctx, generated)
 	files = append(files, frameworkSkills...)
 
+	// Add release skill
+	if analysis.Release != nil {
+		files = append(files, types.GeneratedFile{
+			Path:    ".claude/skills/release/SKILL.md",
+			Content: []byte(releaseSkillContent(analysis, ctx)),
+		})
+	}
+
+	// Add training skill for ML projects
+	if content := trainSkillContent(analysis.MLWorkflow, ctx); content != "" {
+		files = append(files, types.GeneratedFile{
+			Path:    ".claude/skills/train/SKILL.md",
+			Content: []byte(content),
+		})
+	}
+
 	// Add project-specific tool skills
 	projectToolSkills := g.generateProjectToolSkills(analysis)
 	files = append(files, projectToolSkills...)
@@ -232,6 +249,27 @@ func testSkillContent(cmd types.Command, analysis *types.Analysis, ctx *Generato
 		content.WriteString("\n")
 	}
 
+	if tests := analysis.Tests; tests != nil && len(tests.Runners) > 0 {
+		content.WriteString("## Running One Test\n\n")
+		content.WriteString("Iterate on a single file or test before running the full suite:\n\n")
+		content.WriteString("```bash\n")
+		for _, r := range tests.Runners {
+			content.WriteString(fmt.Sprintf("%s\n%s\n", r.RunFile, r.RunSingle))
+		}
+		content.WriteString("```\n\n")
+
+		if len(tests.Fixtures) > 0 || len(tests.Helpers) > 0 {
+			content.WriteString("Reuse existing test support instead of writing new setup code:\n")
+			for _, dir := range tests.Fixtures {
+				content.WriteString(fmt.Sprintf("- `%s` (fixtures)\n", dir))
+			}
+			for _, helper := range tests.Helpers {
+				content.WriteString(fmt.Sprintf("- `%s` (helpers)\n", helper))
+			}
+			content.WriteString("\n")
+		}
+	}
+
 	content.WriteString("## On Failure\n\n")
 	content.WriteString("- Analyze the failing test output\n")
 	content.WriteString("- Identify the root cause of the failure\n")
@@ -451,6 +489,202 @@ func dockerBuildSkillContent(cmd types.Command, analysis *types.Analysis, ctx *G
 	return content.String()
 }
 
+func releaseSkillContent(analysis *types.Analysis, ctx *GeneratorContext) string {
+	release := analysis.Release
+	var content strings.Builder
+
+	// YAML frontmatter
+	content.WriteString("---\n")
+	content.WriteString("name: release\n")
+	content.WriteString("description: Cut a new release of the project. Use when publishing a new version.\n")
+	content.WriteString("allowed-tools: Bash, Read, Edit\n")
+	content.WriteString("disable-model-invocation: true\n")
+	content.WriteString("---\n\n")
+
+	content.WriteString(fmt.Sprintf("# Release - %s\n\n", ctx.ProjectName))
+	if len(release.Tools) > 0 {
+		var names []string
+		for _, tool := range release.Tools {
+			names = append(names, tool.Name)
+		}
+		content.WriteString(fmt.Sprintf("Releases are managed with %s.\n\n", strings.Join(names, " and ")))
+	} else {
+		content.WriteString("Releases are cut by hand by tagging a commit.\n\n")
+	}
+
+	content.WriteString("## Before Releasing\n\n")
+	content.WriteString("- The working tree is clean and up to date with the default branch\n")
+	if ctx.TestCommand != "" {
+		content.WriteString(fmt.Sprintf("- Tests pass: `%s`\n", ctx.TestCommand))
+	}
+	if release.LatestTag != "" {
+		content.WriteString(fmt.Sprintf("- Review changes since the last release: `git log %s..HEAD --oneline`\n", release.LatestTag))
+	}
+	content.WriteString("\n")
+
+	content.WriteString("## Steps\n\n")
+	for i, step := range releaseSteps(release) {
+		content.WriteString(fmt.Sprintf("%d. %s\n", i+1, step))
+	}
+	content.WriteString("\n")
+
+	if release.TagPattern != "" || len(release.VersionFiles) > 0 {
+		content.WriteString("## Versioning\n\n")
+		if release.TagPattern != "" {
+			content.WriteString(fmt.Sprintf("- Tag format: `%s`", release.TagPattern))
+			if release.LatestTag != "" {
+				content.WriteString(fmt.Sprintf(" (latest: `%s`)", release.LatestTag))
+			}
+			content.WriteString("\n")
+		}
+		for _, vf := range release.VersionFiles {
+			content.WriteString(fmt.Sprintf("- `%s`: %s\n", vf.Path, vf.Version))
+		}
+		content.WriteString("\n")
+	}
+
+	if release.Changelog != nil {
+		content.WriteString("## Changelog\n\n")
+		content.WriteString(fmt.Sprintf("`%s` is %s.\n\n", release.Changelog.Path, changelogGuidance(release.Changelog)))
+	}
+
+	content.WriteString("## Success Criteria\n\n")
+	content.WriteString("- The new tag exists on the remote\n")
+	content.WriteString("- The version files, changelog and tag agree on the version\n")
+
+	return content.String()
+}
+
+// trainSkillContent builds the /train skill from the detected ML workflow. It
+// returns "" when there is no training script or DVC pipeline to run.
+func trainSkillContent(wf *types.MLWorkflow, ctx *GeneratorContext) string {
+	if wf == nil {
+		return ""
+	}
+	var train, eval []types.MLEntryPoint
+	for _, e := range wf.EntryPoints {
+		if e.Kind == detector.MLEntryTrain {
+			train = append(train, e)
+		} else {
+			eval = append(eval, e)
+		}
+	}
+	trainStage, evalStage := pipelineStageFor(wf.Stages, "train"), pipelineStageFor(wf.Stages, "eval")
+	if len(train) == 0 && trainStage == nil {
+		return ""
+	}
+
+	var content strings.Builder
+
+	// YAML frontmatter
+	content.WriteString("---\n")
+	content.WriteString("name: train\n")
+	content.WriteString("description: Train and evaluate a model. Use when running training, changing hyperparameters or checking a checkpoint.\n")
+	content.WriteString("allowed-tools: Bash, Read, Edit\n")
+	content.WriteString("disable-model-invocation: true\n")
+	content.WriteString("---\n\n")
+
+	content.WriteString(fmt.Sprintf("# Train - %s\n\n", ctx.ProjectName))
+
+	content.WriteString("## Before Training\n\n")
+	for _, device := range wf.Devices {
+		if device.Method == "CUDA with CPU fallback" {
+			content.WriteString("- Check whether a GPU is visible: `python -c \"import torch; print(torch.cuda.is_available())\"`\n")
+			break
+		}
+	}
+	for _, tracker := range wf.Trackers {
+		if tracker.Project != "" {
+			content.WriteString(fmt.Sprintf("- Runs are logged to %s project `%s`\n", tracker.Name, tracker.Project))
+		} else {
+			content.WriteString(fmt.Sprintf("- Runs are logged to %s\n", tracker.Name))
+		}
+	}
+	if trainStage != nil {
+		content.WriteString("- Check which stages are stale: `dvc status`\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString("## Run Training\n\n")
+	if trainStage != nil {
+		content.WriteString("Training is a DVC stage; reproduce it so its inputs and outputs stay tracked:\n\n")
+		content.WriteString(fmt.Sprintf("```bash\ndvc repro %s\n```\n\n", trainStage.Name))
+		if len(trainStage.Params) > 0 {
+			content.WriteString(fmt.Sprintf("Change hyperparameters in `params.yaml` (%s) rather than on the command line, so DVC sees them.\n\n", codeList(trainStage.Params)))
+		}
+	}
+	for _, e := range train {
+		command := e.Command
+		if e.Hydra {
+			if example := hydraOverrideExample(wf); example != "" {
+				command = example
+			}
+		}
+		content.WriteString(fmt.Sprintf("```bash\n%s\n```\n\n", command))
+		if len(e.Args) > 0 {
+			content.WriteString(fmt.Sprintf("Flags: %s\n\n", mlArgList(e.Args)))
+		}
+	}
+	if c := wf.Config; c != nil && c.Framework == "Hydra" {
+		content.WriteString(fmt.Sprintf("Hydra composes the config from `%s/`. Override values with `key=value`, switch a group with `group=option`, and sweep with `--multirun`.\n\n", c.Dir))
+		for _, group := range c.Groups {
+			content.WriteString(fmt.Sprintf("- `%s`: %s\n", group.Name, codeList(group.Options)))
+		}
+		content.WriteString("\n")
+	}
+
+	if len(eval) > 0 || evalStage != nil {
+		content.WriteString("## Evaluate\n\n")
+		if evalStage != nil {
+			content.WriteString(fmt.Sprintf("- `dvc repro %s`\n", evalStage.Name))
+		}
+		for _, e := range eval {
+			content.WriteString(fmt.Sprintf("- `%s`", e.Command))
+			if len(e.Args) > 0 {
+				content.WriteString(fmt.Sprintf(" with %s", mlArgList(e.Args)))
+			}
+			content.WriteString("\n")
+		}
+		for _, stage := range wf.Stages {
+			if len(stage.Metrics) > 0 {
+				content.WriteString("- Compare metrics with the last commit: `dvc metrics diff`\n")
+				break
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	if wf.Checkpoints != nil {
+		content.WriteString("## Checkpoints\n\n")
+		content.WriteString(fmt.Sprintf("%s. Keep new checkpoints in the same place and naming scheme so evaluation scripts find them.\n\n", checkpointSummary(wf.Checkpoints)))
+	}
+
+	content.WriteString("## Success Criteria\n\n")
+	content.WriteString("- Training finishes without errors")
+	if ckpt := wf.Checkpoints; ckpt != nil && ckpt.Dir != "" {
+		content.WriteString(fmt.Sprintf(" and writes a checkpoint to `%s/`", strings.TrimSuffix(ckpt.Dir, "/")))
+	}
+	content.WriteString("\n")
+	if len(wf.Trackers) > 0 {
+		content.WriteString(fmt.Sprintf("- The run's metrics appear in %s\n", wf.Trackers[0].Name))
+	}
+	if trainStage != nil {
+		content.WriteString("- `dvc status` reports the pipeline up to date\n")
+	}
+
+	return content.String()
+}
+
+// pipelineStageFor returns the first DVC stage whose name mentions kind
+func pipelineStageFor(stages []types.PipelineStage, kind string) *types.PipelineStage {
+	for i := range stages {
+		if strings.Contains(strings.ToLower(stages[i].Name), kind) {
+			return &stages[i]
+		}
+	}
+	return nil
+}
+
 func prismaSkillContent() string {
 	return `---
 name: db-migrate
@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func commitConventionAnalysis() *types.Analysis {
+	return &types.Analysis{
+		ProjectName: "test-project",
+		GitConventions: &types.GitConventions{
+			CommitConvention: &types.CommitConvention{
+				Style:     "conventional",
+				Format:    "<type>(<scope>): <description>",
+				Types:     []string{"feat", "fix"},
+				Scopes:    []string{"api", "ui"},
+				Example:   "feat(api): add new feature",
+				ScopeDirs: map[string]string{"api": "internal/api"},
+				Breaking:  []string{"!"},
+			},
+			Tickets:    []types.TicketReference{{Format: "PAY-123", Location: "subject", Example: "PAY-101 add refunds"}},
+			SignOff:    true,
+			CommitLint: &types.CommitLint{ConfigFile: "commitlint.config.js", HeaderMaxLength: 72},
+		},
+		CIInfo: &types.CIInfo{Commands: []types.CICommand{{Category: "Lint", Command: "golangci-lint run", Job: "lint"}}},
+	}
+}
+
+func generatedFile(t *testing.T, files []types.GeneratedFile, path string) string {
+	t.Helper()
+	for _, f := range files {
+		if f.Path == path {
+			return string(f.Content)
+		}
+	}
+	t.Fatalf("expected %s to be generated", path)
+	return ""
+}
+
+func TestClaudeCodeGenerator_GitWorkflowRule(t *testing.T) {
+	files, err := NewClaudeCodeGenerator(nil).Generate(commitConventionAnalysis())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content := generatedFile(t, files, ".claude/rules/git-workflow.md")
+	expected := []string{
+		"| api | `internal/api/` |",
+		"| ui | - |",
+		"Mark breaking changes with `!`",
+		"- Reference the ticket as `PAY-123` in the subject line",
+		"Commit with `git commit -s`",
+		"- Header max length: 72 characters",
+		"## Before Pushing\n\nRun the checks CI runs:\n\n```bash\ngolangci-lint run\n```",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected git-workflow.md to contain %q", e)
+		}
+	}
+}
+
+func TestClaudeCodeGenerator_CommitMsgHook(t *testing.T) {
+	files, err := NewClaudeCodeGenerator(nil).Generate(commitConventionAnalysis())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	settings := generatedFile(t, files, ".claude/settings.json")
+	if !strings.Contains(settings, commitMsgHookPath) {
+		t.Errorf("expected settings.json to run the commit message hook, got %s", settings)
+	}
+	script := generatedFile(t, files, commitMsgHookPath)
+
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	scriptPath := filepath.Join(t.TempDir(), "validate-commit-msg.py")
+	if err := os.WriteFile(scriptPath, []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		command string
+		allowed bool
+	}{
+		{`git commit -s -m "feat(api): add refunds"`, true},
+		{`git add . && git commit -m "fix: typo" -m "Signed-off-by: A <a@example.com>"`, true},
+		{`git commit -s -m "added refunds"`, false},
+		{`git commit -m "feat(api): add refunds"`, false},
+		{`git commit -s -m "feat(api): ` + strings.Repeat("x", 80) + `"`, false},
+		{`git commit -s -m "perf: cache lookups"`, true},
+		{`git commit -s -m "docs(api): describe refunds"`, true},
+		{`git commit -s -m "wip: refunds"`, false},
+		{"git commit -s -m \"$(cat <<'EOF'\nfeat(api): add refunds\n\nBody text.\nEOF\n)\"", true},
+		{"git commit -s -m \"$(cat <<'EOF'\nadded refunds\nEOF\n)\"", true},
+		{`git commit -s -m "$MSG"`, true},
+		{"git commit -s -m \"`cat msg.txt`\"", true},
+		{`git commit --amend --no-edit`, true},
+		{`go test ./...`, true},
+	}
+	for _, tt := range tests {
+		input, _ := json.Marshal(map[string]interface{}{"tool_input": map[string]string{"command": tt.command}})
+		cmd := exec.Command(python, scriptPath)
+		cmd.Stdin = strings.NewReader(string(input))
+		err := cmd.Run()
+		if allowed := err == nil; allowed != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got error %v", tt.command, tt.allowed, err)
+		}
+	}
+}
+
+func TestCommitHeaderPattern_TypeEnum(t *testing.T) {
+	cc := &types.CommitConvention{Style: "conventional", Types: []string{"feat", "fix"}}
+
+	pattern, _ := commitHeaderPattern(cc, nil)
+	for _, header := range []string{"feat: a", "perf: b", "revert: c", "docs(readme): d"} {
+		if !regexp.MustCompile(pattern).MatchString(header) {
+			t.Errorf("expected %q allowed without a commitlint type-enum, pattern %s", header, pattern)
+		}
+	}
+
+	pattern, _ = commitHeaderPattern(cc, &types.CommitLint{Types: []string{"feat", "fix", "deps"}})
+	re := regexp.MustCompile(pattern)
+	if !re.MatchString("deps: bump go-git") {
+		t.Errorf("expected commitlint type deps to be allowed, pattern %s", pattern)
+	}
+	if re.MatchString("perf: cache lookups") {
+		t.Errorf("expected perf rejected by the commitlint type-enum, pattern %s", pattern)
+	}
+}
+
+func TestClaudeCodeGenerator_ReleaseSkill(t *testing.T) {
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Commands:    []types.Command{{Name: "test", Command: "go test ./..."}},
+		Release: &types.ReleaseInfo{
+			Tools:      []types.ReleaseTool{{Name: "Changesets", ConfigFile: ".changeset/config.json", Command: "npx changeset"}},
+			TagPattern: "<package>@<version>",
+			LatestTag:  "@acme/ui@2.1.0",
+		},
+	}
+	files, err := NewClaudeCodeGenerator(nil).Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content := generatedFile(t, files, ".claude/skills/release/SKILL.md")
+	expected := []string{
+		"name: release",
+		"disable-model-invocation: true",
+		"Releases are managed with Changesets.",
+		"- Review changes since the last release: `git log @acme/ui@2.1.0..HEAD --oneline`",
+		"1. Add a changeset with `npx changeset`",
+		"3. Run `npx changeset publish` to publish packages and create `<package>@X.Y.Z` tags",
+		"- Tag format: `<package>@<version>`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected release skill to contain %q, got:\n%s", e, content)
+		}
+	}
+}
+
+func TestClaudeCodeGenerator_TestSkillSingleTest(t *testing.T) {
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		Commands:    []types.Command{{Name: "test", Command: "go test ./..."}},
+		Tests: &types.TestInventory{
+			Runners:  []types.TestRunner{{Language: "Go", Framework: "go test", RunFile: "go test ./<package>", RunSingle: "go test ./<package> -run '^<name>$'"}},
+			Fixtures: []string{"internal/api/testdata"},
+		},
+	}
+	files, err := NewClaudeCodeGenerator(nil).Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content := generatedFile(t, files, ".claude/skills/test/SKILL.md")
+	expected := []string{
+		"## Running One Test",
+		"```bash\ngo test ./<package>\ngo test ./<package> -run '^<name>$'\n```",
+		"- `internal/api/testdata` (fixtures)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected test skill to contain %q, got:\n%s", e, content)
+		}
+	}
+}
+
+func TestClaudeCodeGenerator_ErrorHandling(t *testing.T) {
+	analysis := &types.Analysis{
+		ProjectName: "test-project",
+		TechStack:   types.TechStack{Languages: []types.Language{{Name: "Go"}}},
+		ErrorHandling: &types.ErrorHandling{
+			Practices: []types.ErrorPractice{
+				{Language: "Go", Kind: "wrapping", Instruction: "Wrap returned errors with `errors.Wrap(err, \"doing x\")` from github.com/pkg/errors", Examples: []string{"store/load.go:21"}},
+				{Language: "Go", Kind: "sentinel", Instruction: "Declare expected failures as package-level sentinel errors", Examples: []string{"ErrNotFound", "ErrConflict"}},
+			},
+			Loggers: []types.LoggerUsage{
+				{Language: "Go", Library: "zap", Instruction: "Log through zap with typed fields, naming fields in snake_case", Fields: []string{"user_id", "request_id"}},
+			},
+		},
+	}
+
+	files, err := NewClaudeCodeGenerator(nil).Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	rule := generatedFile(t, files, ".claude/rules/error-handling.md")
+	for _, e := range []string{
+		"## Go\n\n- Wrap returned errors with `errors.Wrap(err, \"doing x\")` from github.com/pkg/errors (e.g. `store/load.go:21`)\n",
+		"- Declare expected failures as package-level sentinel errors (e.g. `ErrNotFound`, `ErrConflict`)\n",
+		"- Log through zap with typed fields, naming fields in snake_case\n",
+		"- Reuse the existing field keys where they fit: `user_id`, `request_id`\n",
+	} {
+		if !strings.Contains(rule, e) {
+			t.Errorf("expected error-handling.md to contain %q, got:\n%s", e, rule)
+		}
+	}
+
+	reviewer := generatedFile(t, files, ".claude/agents/go-reviewer.md")
+	if !strings.Contains(reviewer, "from github.com/pkg/errors") || !strings.Contains(reviewer, "## Logging\n\n- Log through zap") {
+		t.Errorf("expected go-reviewer.md to carry the detected practices, got:\n%s", reviewer)
+	}
+	if strings.Contains(reviewer, "Use `%w` for error wrapping") {
+		t.Errorf("go-reviewer.md should not contradict the detected wrapping style")
+	}
+}
+
+func TestClaudeCodeGenerator_TrainSkill(t *testing.T) {
+	analysis := &types.Analysis{ProjectName: "test-project", MLWorkflow: mlWorkflowFixture()}
+
+	files, err := NewClaudeCodeGenerator(nil).Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content := generatedFile(t, files, ".claude/skills/train/SKILL.md")
+	for _, e := range []string{
+		"name: train",
+		"- Check whether a GPU is visible",
+		"- Runs are logged to MLflow project `resnet-baseline`",
+		"```bash\ndvc repro train\n```",
+		"Change hyperparameters in `params.yaml` (`train.lr`)",
+		"```bash\npython src/train.py model=vit\n```",
+		"- `model`: `resnet`, `vit`",
+		"- `dvc repro evaluate`",
+		"- `python src/evaluate.py` with `--batch-size` (default `64`)",
+		"- Compare metrics with the last commit: `dvc metrics diff`",
+		"- Training finishes without errors and writes a checkpoint to `checkpoints/`",
+	} {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected train skill to contain %q, got:\n%s", e, content)
+		}
+	}
+
+	analysis.MLWorkflow = &types.MLWorkflow{Trackers: analysis.MLWorkflow.Trackers}
+	files, err = NewClaudeCodeGenerator(nil).Generate(analysis)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, f := range files {
+		if f.Path == ".claude/skills/train/SKILL.md" {
+			t.Errorf("expected no train skill without a training script or pipeline")
+		}
+	}
+}
@@ -174,7 +174,7 @@ func (g *CopilotGenerator) writeCodingStandards(buf *bytes.Buffer, conventions [
 	// Extract code style conventions
 	for _, conv := range conventions {
 		if conv.Category == "code-style" || conv.Category == "naming" {
-			fmt.Fprintf(buf, "- %s\n", conv.Description)
+			fmt.Fprintf(buf, "- %s\n", conventionText(conv))
 		}
 	}
 
@@ -249,7 +249,7 @@ func (g *CopilotGenerator) writePatterns(buf *bytes.Buffer, conventions []types.
 
 		fmt.Fprintf(buf, "**%s:**\n", titleCase(strings.ReplaceAll(cat, "-", " ")))
 		for _, conv := range convs {
-			fmt.Fprintf(buf, "- %s\n", conv.Description)
+			fmt.Fprintf(buf, "- %s\n", conventionText(conv))
 		}
 		buf.WriteString("\n")
 	}
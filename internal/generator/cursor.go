@@ -173,7 +173,7 @@ func (g *CursorGenerator) writeRules(buf *bytes.Buffer, conventions []types.Conv
 		convs := byCategory[cat]
 		fmt.Fprintf(buf, "### %s\n\n", titleCase(cat))
 		for _, conv := range convs {
-			fmt.Fprintf(buf, "- %s\n", conv.Description)
+			fmt.Fprintf(buf, "- %s\n", conventionText(conv))
 		}
 		buf.WriteString("\n")
 	}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Diagram formats
+const (
+	DiagramASCII   = "ascii"
+	DiagramMermaid = "mermaid"
+	DiagramDOT     = "dot"
+)
+
+// DefaultDiagramNodes is the node budget before leaf packages are collapsed
+const DefaultDiagramNodes = 25
+
+// ValidDiagramFormats lists the supported diagram formats
+var ValidDiagramFormats = []string{DiagramASCII, DiagramMermaid, DiagramDOT}
+
+var diagramIDRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// diagramGraph is a package dependency graph grouped by top-level layer
+type diagramGraph struct {
+	nodes     []string
+	edges     map[string][]string
+	collapsed map[string]bool // nodes standing in for their subpackages
+}
+
+// RenderDiagram renders the layers and package dependency graph as Mermaid
+// or Graphviz DOT. When the graph has more than maxNodes packages the
+// deepest leaf packages are folded into their parent until it fits.
+func RenderDiagram(arch *types.ArchitectureInfo, format string, maxNodes int) (string, error) {
+	if arch == nil {
+		return "", fmt.Errorf("no architecture detected")
+	}
+
+	if format == DiagramASCII {
+		if arch.Diagram == "" {
+			return "", fmt.Errorf("no ASCII diagram available")
+		}
+		return strings.TrimSuffix(strings.TrimPrefix(arch.Diagram, "```\n"), "```\n"), nil
+	}
+	if format != DiagramMermaid && format != DiagramDOT {
+		return "", fmt.Errorf("unknown diagram format %q, must be one of: %s", format, strings.Join(ValidDiagramFormats, ", "))
+	}
+
+	graph := buildDiagramGraph(arch, maxNodes)
+	if len(graph.nodes) == 0 {
+		return "", fmt.Errorf("no packages or layers to draw")
+	}
+	if format == DiagramDOT {
+		return graph.dot(), nil
+	}
+	return graph.mermaid(), nil
+}
+
+// buildDiagramGraph prefers the import graph and falls back to the detected
+// layer packages when no imports were resolved
+func buildDiagramGraph(arch *types.ArchitectureInfo, maxNodes int) *diagramGraph {
+	graph := &diagramGraph{edges: make(map[string][]string), collapsed: make(map[string]bool)}
+
+	if len(arch.Dependencies) > 0 {
+		deps := arch.Dependencies
+		for maxNodes > 0 && len(graphNodes(deps)) > maxNodes {
+			next, ok := collapseLeaves(deps, graph.collapsed)
+			if !ok {
+				break
+			}
+			deps = next
+		}
+		graph.edges = deps
+		graph.nodes = graphNodes(deps)
+		return graph
+	}
+
+	for _, layer := range arch.Layers {
+		for _, pkg := range layer.Packages {
+			graph.nodes = append(graph.nodes, layer.Name+"/"+pkg)
+		}
+	}
+	if maxNodes > 0 && len(graph.nodes) > maxNodes {
+		graph.nodes = nil
+		for _, layer := range arch.Layers {
+			graph.nodes = append(graph.nodes, layer.Name)
+			graph.collapsed[layer.Name] = true
+		}
+	}
+	sort.Strings(graph.nodes)
+	return graph
+}
+
+// collapseLeaves folds the deepest packages into their parent directory
+func collapseLeaves(deps map[string][]string, collapsed map[string]bool) (map[string][]string, bool) {
+	deepest := 0
+	for _, node := range graphNodes(deps) {
+		if depth := strings.Count(node, "/"); depth > deepest {
+			deepest = depth
+		}
+	}
+	if deepest == 0 {
+		return deps, false
+	}
+
+	fold := func(node string) string {
+		if strings.Count(node, "/") == deepest {
+			parent := path.Dir(node)
+			collapsed[parent] = true
+			return parent
+		}
+		return node
+	}
+
+	next := make(map[string][]string)
+	for from, imports := range deps {
+		f := fold(from)
+		if _, ok := next[f]; !ok {
+			next[f] = nil // keep packages whose imports all folded away
+		}
+		for _, to := range imports {
+			t := fold(to)
+			if t != f && !slices.Contains(next[f], t) {
+				next[f] = append(next[f], t)
+			}
+		}
+	}
+	for from := range next {
+		sort.Strings(next[from])
+	}
+	return next, true
+}
+
+// graphNodes returns every package that appears in the graph, sorted
+func graphNodes(deps map[string][]string) []string {
+	seen := make(map[string]bool)
+	var nodes []string
+	for from, imports := range deps {
+		for _, node := range append([]string{from}, imports...) {
+			if !seen[node] {
+				seen[node] = true
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// groups returns nodes grouped by top-level directory, plus ungrouped nodes
+func (g *diagramGraph) groups() ([]string, map[string][]string, []string) {
+	var names, loose []string
+	members := make(map[string][]string)
+	for _, node := range g.nodes {
+		group, _, nested := strings.Cut(node, "/")
+		if !nested {
+			loose = append(loose, node)
+			continue
+		}
+		if members[group] == nil {
+			names = append(names, group)
+		}
+		members[group] = append(members[group], node)
+	}
+	return names, members, loose
+}
+
+// label names a node inside its group, marking collapsed packages
+func (g *diagramGraph) label(node string) string {
+	label := node
+	if _, rest, nested := strings.Cut(node, "/"); nested {
+		label = rest
+	}
+	if node == "." {
+		label = "(root)"
+	}
+	if g.collapsed[node] {
+		label += "/*"
+	}
+	return label
+}
+
+// mermaid renders the graph as a Mermaid flowchart
+func (g *diagramGraph) mermaid() string {
+	id := func(node string) string {
+		if node == "." {
+			return "root"
+		}
+		return "n_" + diagramIDRegex.ReplaceAllString(node, "_")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	names, members, loose := g.groups()
+	for _, node := range loose {
+		fmt.Fprintf(&sb, "    %s[\"%s\"]\n", id(node), g.label(node))
+	}
+	for _, name := range names {
+		fmt.Fprintf(&sb, "    subgraph layer_%s[\"%s\"]\n", diagramIDRegex.ReplaceAllString(name, "_"), name)
+		for _, node := range members[name] {
+			fmt.Fprintf(&sb, "        %s[\"%s\"]\n", id(node), g.label(node))
+		}
+		sb.WriteString("    end\n")
+	}
+
+	for _, from := range g.nodes {
+		for _, to := range g.edges[from] {
+			fmt.Fprintf(&sb, "    %s --> %s\n", id(from), id(to))
+		}
+	}
+
+	return sb.String()
+}
+
+// dot renders the graph as a Graphviz digraph with one cluster per layer
+func (g *diagramGraph) dot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph architecture {\n")
+	sb.WriteString("    rankdir=TB;\n")
+	sb.WriteString("    node [shape=box, fontname=\"Helvetica\"];\n")
+
+	names, members, loose := g.groups()
+	for _, node := range loose {
+		fmt.Fprintf(&sb, "    %q [label=%q];\n", node, g.label(node))
+	}
+	for _, name := range names {
+		fmt.Fprintf(&sb, "    subgraph %q {\n", "cluster_"+name)
+		fmt.Fprintf(&sb, "        label=%q;\n", name)
+		for _, node := range members[name] {
+			fmt.Fprintf(&sb, "        %q [label=%q];\n", node, g.label(node))
+		}
+		sb.WriteString("    }\n")
+	}
+
+	for _, from := range g.nodes {
+		for _, to := range g.edges[from] {
+			fmt.Fprintf(&sb, "    %q -> %q;\n", from, to)
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func diagramFixture() *types.ArchitectureInfo {
+	return &types.ArchitectureInfo{
+		Dependencies: map[string][]string{
+			"cmd/server":                 {"internal/api", "internal/store/postgres"},
+			"internal/api":               {"internal/store", "internal/store/postgres"},
+			"internal/store/postgres":    {"internal/store", "internal/store/postgres/sqlc"},
+			"internal/store/postgres/tx": {"internal/store/postgres/sqlc"},
+			"main":                       {"cmd/server"},
+		},
+	}
+}
+
+func TestRenderDiagram_Mermaid(t *testing.T) {
+	out, err := RenderDiagram(diagramFixture(), DiagramMermaid, 0)
+	if err != nil {
+		t.Fatalf("RenderDiagram failed: %v", err)
+	}
+
+	expected := []string{
+		"flowchart TD\n",
+		`    n_main["main"]`,
+		`    subgraph layer_internal["internal"]`,
+		`        n_internal_store_postgres_sqlc["store/postgres/sqlc"]`,
+		"    n_cmd_server --> n_internal_api\n",
+		"    n_main --> n_cmd_server\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected mermaid output to contain %q, got:\n%s", e, out)
+		}
+	}
+}
+
+func TestRenderDiagram_DOTCollapsesLeaves(t *testing.T) {
+	out, err := RenderDiagram(diagramFixture(), DiagramDOT, 4)
+	if err != nil {
+		t.Fatalf("RenderDiagram failed: %v", err)
+	}
+
+	expected := []string{
+		"digraph architecture {",
+		`subgraph "cluster_internal" {`,
+		`"internal/store" [label="store/*"];`,
+		`"cmd/server" -> "internal/store";`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected dot output to contain %q, got:\n%s", e, out)
+		}
+	}
+	if strings.Contains(out, "postgres") {
+		t.Errorf("expected postgres packages to be collapsed, got:\n%s", out)
+	}
+}
+
+func TestRenderDiagram_LayersWithoutImports(t *testing.T) {
+	arch := &types.ArchitectureInfo{
+		Layers: []types.ArchitectureLayer{{Name: "internal", Packages: []string{"api", "store"}}},
+	}
+	out, err := RenderDiagram(arch, DiagramMermaid, 0)
+	if err != nil {
+		t.Fatalf("RenderDiagram failed: %v", err)
+	}
+	if !strings.Contains(out, `n_internal_store["store"]`) {
+		t.Errorf("expected layer packages as nodes, got:\n%s", out)
+	}
+}
+
+func TestRenderDiagram_UnknownFormat(t *testing.T) {
+	if _, err := RenderDiagram(diagramFixture(), "svg", 0); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Priyans-hu/argus/pkg/types"
@@ -72,11 +73,20 @@ func (g *MonorepoOverviewGenerator) Generate(rootAnalysis *types.Analysis, works
 		fmt.Fprintln(&buf, "Each workspace has its own generated context files with detailed analysis:")
 		fmt.Fprintln(&buf)
 		for _, ws := range workspaces {
+			// the root workspace's context is this file
+			if ws.Path == "." {
+				continue
+			}
 			fmt.Fprintf(&buf, "- `%s/CLAUDE.md`\n", ws.Path)
 		}
 		fmt.Fprintln(&buf)
 	}
 
+	// Internal dependencies between workspaces
+	if rootAnalysis.MonorepoInfo != nil && len(rootAnalysis.MonorepoInfo.Dependencies) > 0 {
+		g.writeWorkspaceDependencies(&buf, rootAnalysis.MonorepoInfo.Dependencies, workspaces)
+	}
+
 	// Quick reference: root-level commands
 	if len(rootAnalysis.Commands) > 0 {
 		fmt.Fprintln(&buf, "## Root Commands")
@@ -126,6 +136,67 @@ func (g *MonorepoOverviewGenerator) Generate(rootAnalysis *types.Analysis, works
 	return buf.Bytes(), nil
 }
 
+func (g *MonorepoOverviewGenerator) writeWorkspaceDependencies(buf *bytes.Buffer, deps []types.WorkspaceDependency, workspaces []WorkspaceInfo) {
+	names := make(map[string]string)
+	for _, ws := range workspaces {
+		names[ws.Path] = ws.Name
+	}
+	label := func(path string) string {
+		if name := names[path]; name != "" {
+			return name
+		}
+		return path
+	}
+	id := func(path string) string {
+		return "ws_" + diagramIDRegex.ReplaceAllString(path, "_")
+	}
+
+	dependsOn := make(map[string][]string)
+	usedBy := make(map[string][]string)
+	var order []string
+	for _, dep := range deps {
+		for _, path := range []string{dep.From, dep.To} {
+			if _, ok := dependsOn[path]; !ok {
+				dependsOn[path] = nil
+				order = append(order, path)
+			}
+		}
+		dependsOn[dep.From] = append(dependsOn[dep.From], label(dep.To))
+		usedBy[dep.To] = append(usedBy[dep.To], label(dep.From))
+	}
+	sort.Strings(order)
+
+	fmt.Fprintln(buf, "## Workspace Dependencies")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "```mermaid")
+	fmt.Fprintln(buf, "graph LR")
+	for _, path := range order {
+		fmt.Fprintf(buf, "    %s[\"%s\"]\n", id(path), label(path))
+	}
+	for _, dep := range deps {
+		fmt.Fprintf(buf, "    %s --> %s\n", id(dep.From), id(dep.To))
+	}
+	fmt.Fprintln(buf, "```")
+	fmt.Fprintln(buf)
+
+	fmt.Fprintln(buf, "| Workspace | Depends on | Used by |")
+	fmt.Fprintln(buf, "|-----------|------------|---------|")
+	for _, path := range order {
+		fmt.Fprintf(buf, "| %s | %s | %s |\n", label(path), joinOrDash(dependsOn[path]), joinOrDash(usedBy[path]))
+	}
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "Run `argus affected --since <ref>` to list the workspaces a change touches, their dependents, and the tests to run.")
+	fmt.Fprintln(buf)
+}
+
+// joinOrDash joins values with commas, or returns "-" for an empty table cell
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
 func (g *MonorepoOverviewGenerator) writeTechStackSummary(buf *bytes.Buffer, analysis *types.Analysis) {
 	ts := analysis.TechStack
 	if len(ts.Languages) == 0 && len(ts.Frameworks) == 0 {
@@ -166,3 +166,41 @@ func TestMonorepoOverviewGenerator_WithGitConventions(t *testing.T) {
 		t.Error("missing commit style")
 	}
 }
+
+func TestMonorepoOverviewGenerator_WorkspaceDependencies(t *testing.T) {
+	analysis := &types.Analysis{
+		ProjectName: "deps",
+		MonorepoInfo: &types.MonorepoInfo{
+			IsMonorepo: true,
+			Dependencies: []types.WorkspaceDependency{
+				{From: "apps/web", To: "packages/ui", Kind: "npm"},
+				{From: "packages/ui", To: "packages/config", Kind: "npm"},
+			},
+		},
+	}
+	workspaces := []WorkspaceInfo{
+		{Path: "apps/web", Name: "web"},
+		{Path: "packages/ui", Name: "@acme/ui"},
+		{Path: "packages/config", Name: "@acme/config"},
+	}
+
+	out, err := NewMonorepoOverviewGenerator("claude").Generate(analysis, workspaces)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	content := string(out)
+
+	for _, want := range []string{
+		"## Workspace Dependencies",
+		"graph LR",
+		`ws_apps_web["web"]`,
+		"ws_apps_web --> ws_packages_ui",
+		"| @acme/ui | @acme/config | web |",
+		"| @acme/config | - | @acme/ui |",
+		"argus affected --since <ref>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected overview to contain %q\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+// Limits that keep a nested context file short
+const (
+	nestedMaxLayout    = 12
+	nestedMaxKeyFiles  = 10
+	nestedMaxEndpoints = 15
+)
+
+// NestedContextGenerator creates the concise CLAUDE.md written into a
+// significant subdirectory of a single-module project. It only covers what
+// is local to the directory and links back to the root file for the rest.
+type NestedContextGenerator struct{}
+
+// NewNestedContextGenerator creates a new nested context generator
+func NewNestedContextGenerator() *NestedContextGenerator {
+	return &NestedContextGenerator{}
+}
+
+// OutputFile returns the file name written into each nested directory
+func (g *NestedContextGenerator) OutputFile() string {
+	return "CLAUDE.md"
+}
+
+// Generate creates the local context file for one directory from its
+// scoped analysis, leaving out anything the root analysis already covers
+func (g *NestedContextGenerator) Generate(nested types.NestedContext, local, root *types.Analysis) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", nested.Path)
+	if nested.Purpose != "" {
+		fmt.Fprintf(&buf, "%s.\n\n", strings.TrimSuffix(nested.Purpose, "."))
+	}
+	rootLink := strings.Repeat("../", strings.Count(nested.Path, "/")+1) + "CLAUDE.md"
+	fmt.Fprintf(&buf, "> Local context for `%s/`. Project-wide stack, commands and conventions are in [the root CLAUDE.md](%s).\n\n", nested.Path, rootLink)
+
+	g.writeStack(&buf, local)
+	g.writeLayout(&buf, local.Structure.Directories)
+	g.writeKeyFiles(&buf, local.KeyFiles)
+	g.writeCommands(&buf, local.Commands, root.Commands)
+	g.writeEndpoints(&buf, local.Endpoints)
+	g.writeTests(&buf, local.Tests)
+	g.writeConventions(&buf, local.Conventions, root.Conventions)
+
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n'), nil
+}
+
+func (g *NestedContextGenerator) writeStack(buf *bytes.Buffer, local *types.Analysis) {
+	var langs, fws []string
+	for _, l := range local.TechStack.Languages {
+		langs = append(langs, l.Name)
+	}
+	for _, f := range local.TechStack.Frameworks {
+		fws = append(fws, f.Name)
+	}
+	if len(langs) > 0 {
+		fmt.Fprintf(buf, "**Languages:** %s\n", strings.Join(langs, ", "))
+	}
+	if len(fws) > 0 {
+		fmt.Fprintf(buf, "**Frameworks:** %s\n", strings.Join(fws, ", "))
+	}
+	if len(langs) > 0 || len(fws) > 0 {
+		fmt.Fprintln(buf)
+	}
+}
+
+func (g *NestedContextGenerator) writeLayout(buf *bytes.Buffer, dirs []types.Directory) {
+	if len(dirs) == 0 {
+		return
+	}
+	sorted := make([]types.Directory, len(dirs))
+	copy(sorted, dirs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	if len(sorted) > nestedMaxLayout {
+		sorted = sorted[:nestedMaxLayout]
+	}
+
+	fmt.Fprintln(buf, "## Layout")
+	fmt.Fprintln(buf)
+	for _, dir := range sorted {
+		if dir.Purpose != "" {
+			fmt.Fprintf(buf, "- `%s/` - %s (%d files)\n", dir.Path, dir.Purpose, dir.FileCount)
+		} else {
+			fmt.Fprintf(buf, "- `%s/` (%d files)\n", dir.Path, dir.FileCount)
+		}
+	}
+	fmt.Fprintln(buf)
+}
+
+func (g *NestedContextGenerator) writeKeyFiles(buf *bytes.Buffer, keyFiles []types.KeyFile) {
+	if len(keyFiles) == 0 {
+		return
+	}
+	if len(keyFiles) > nestedMaxKeyFiles {
+		keyFiles = keyFiles[:nestedMaxKeyFiles]
+	}
+
+	fmt.Fprintln(buf, "## Key Files")
+	fmt.Fprintln(buf)
+	for _, kf := range keyFiles {
+		if kf.Description != "" {
+			fmt.Fprintf(buf, "- `%s` - %s: %s\n", kf.Path, kf.Purpose, kf.Description)
+		} else {
+			fmt.Fprintf(buf, "- `%s` - %s\n", kf.Path, kf.Purpose)
+		}
+	}
+	fmt.Fprintln(buf)
+}
+
+// writeCommands lists commands defined locally, e.g. by a package.json in
+// the directory, skipping any the root file already documents
+func (g *NestedContextGenerator) writeCommands(buf *bytes.Buffer, commands, rootCommands []types.Command) {
+	known := make(map[string]bool)
+	for _, cmd := range rootCommands {
+		known[cmd.Name] = true
+	}
+	var local []types.Command
+	for _, cmd := range commands {
+		if !known[cmd.Name] {
+			local = append(local, cmd)
+		}
+	}
+	if len(local) == 0 {
+		return
+	}
+
+	fmt.Fprintln(buf, "## Commands")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "Run from this directory:")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "```bash")
+	for _, cmd := range local {
+		if cmd.Description != "" {
+			fmt.Fprintf(buf, "# %s\n", cmd.Description)
+		}
+		fmt.Fprintln(buf, cmd.Name)
+	}
+	fmt.Fprintln(buf, "```")
+	fmt.Fprintln(buf)
+}
+
+func (g *NestedContextGenerator) writeEndpoints(buf *bytes.Buffer, endpoints []types.Endpoint) {
+	if len(endpoints) == 0 {
+		return
+	}
+
+	fmt.Fprintln(buf, "## Endpoints")
+	fmt.Fprintln(buf)
+	for i, ep := range endpoints {
+		if i == nestedMaxEndpoints {
+			fmt.Fprintf(buf, "- ...and %d more\n", len(endpoints)-nestedMaxEndpoints)
+			break
+		}
+		fmt.Fprintf(buf, "- `%s %s` in `%s`\n", ep.Method, ep.Path, ep.File)
+	}
+	fmt.Fprintln(buf)
+}
+
+func (g *NestedContextGenerator) writeTests(buf *bytes.Buffer, tests *types.TestInventory) {
+	if tests == nil || tests.TestFiles == 0 {
+		return
+	}
+
+	fmt.Fprintln(buf, "## Tests")
+	fmt.Fprintln(buf)
+	if tests.TestCount > 0 {
+		fmt.Fprintf(buf, "- %d test files, %d tests\n", tests.TestFiles, tests.TestCount)
+	} else {
+		fmt.Fprintf(buf, "- %d test files\n", tests.TestFiles)
+	}
+	if len(tests.Untested) > 0 {
+		fmt.Fprintf(buf, "- Untested: %s\n", codeList(tests.Untested))
+	}
+	fmt.Fprintln(buf)
+}
+
+// writeConventions keeps only conventions that differ from the root file's
+func (g *NestedContextGenerator) writeConventions(buf *bytes.Buffer, conventions, rootConventions []types.Convention) {
+	known := make(map[string]bool)
+	for _, conv := range rootConventions {
+		known[conv.Description] = true
+	}
+	var local []string
+	for _, conv := range conventions {
+		if !known[conv.Description] {
+			local = append(local, conv.Description)
+		}
+	}
+	if len(local) == 0 {
+		return
+	}
+
+	fmt.Fprintln(buf, "## Local Conventions")
+	fmt.Fprintln(buf)
+	for _, desc := range local {
+		fmt.Fprintf(buf, "- %s\n", desc)
+	}
+	fmt.Fprintln(buf)
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Priyans-hu/argus/pkg/types"
+)
+
+func TestNestedContextGenerator_Generate(t *testing.T) {
+	root := &types.Analysis{
+		Commands:    []types.Command{{Name: "make test"}},
+		Conventions: []types.Convention{{Description: "Use gofmt"}},
+	}
+	local := &types.Analysis{
+		TechStack: types.TechStack{
+			Languages:  []types.Language{{Name: "TypeScript"}},
+			Frameworks: []types.Framework{{Name: "React"}},
+		},
+		Structure: types.ProjectStructure{Directories: []types.Directory{
+			{Path: "src/components", Purpose: "UI components", FileCount: 30},
+			{Path: "src/hooks", FileCount: 4},
+		}},
+		KeyFiles:    []types.KeyFile{{Path: "src/main.tsx", Purpose: "Entry point"}},
+		Commands:    []types.Command{{Name: "make test"}, {Name: "npm run dev", Description: "Start dev server"}},
+		Endpoints:   []types.Endpoint{{Method: "GET", Path: "/api/health", File: "src/api.ts"}},
+		Tests:       &types.TestInventory{TestFiles: 5, TestCount: 40, Untested: []string{"src/hooks"}},
+		Conventions: []types.Convention{{Description: "Use gofmt"}, {Description: "Components use PascalCase file names"}},
+	}
+
+	out, err := NewNestedContextGenerator().Generate(types.NestedContext{Path: "apps/web", Purpose: "Web frontend"}, local, root)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content := string(out)
+
+	expected := []string{
+		"# apps/web\n\nWeb frontend.\n",
+		"[the root CLAUDE.md](../../CLAUDE.md)",
+		"**Languages:** TypeScript",
+		"**Frameworks:** React",
+		"- `src/components/` - UI components (30 files)",
+		"- `src/hooks/` (4 files)",
+		"- `src/main.tsx` - Entry point",
+		"# Start dev server\nnpm run dev",
+		"- `GET /api/health` in `src/api.ts`",
+		"- 5 test files, 40 tests",
+		"- Untested: `src/hooks`",
+		"- Components use PascalCase file names",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(content, exp) {
+			t.Errorf("expected output to contain %q\n%s", exp, content)
+		}
+	}
+
+	// Anything already in the root file stays out of the local one
+	for _, dup := range []string{"make test", "Use gofmt"} {
+		if strings.Contains(content, dup) {
+			t.Errorf("expected %q to be left to the root file", dup)
+		}
+	}
+	if !strings.HasSuffix(content, "PascalCase file names\n") {
+		t.Error("expected a single trailing newline")
+	}
+}
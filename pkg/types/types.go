@@ -2,26 +2,45 @@ package types
 
 // Analysis represents the complete analysis of a codebase
 type Analysis struct {
-	ProjectName      string            `json:"project_name"`
-	RootPath         string            `json:"root_path"`
-	TechStack        TechStack         `json:"tech_stack"`
-	Structure        ProjectStructure  `json:"structure"`
-	Conventions      []Convention      `json:"conventions"`
-	Dependencies     []Dependency      `json:"dependencies"`
-	Commands         []Command         `json:"commands"`
-	KeyFiles         []KeyFile         `json:"key_files"`
-	Endpoints        []Endpoint        `json:"endpoints,omitempty"`
-	ReadmeContent    *ReadmeContent    `json:"readme_content,omitempty"`
-	MonorepoInfo     *MonorepoInfo     `json:"monorepo_info,omitempty"`
-	CodePatterns     *CodePatterns     `json:"code_patterns,omitempty"`
-	GitConventions   *GitConventions   `json:"git_conventions,omitempty"`
-	ArchitectureInfo *ArchitectureInfo `json:"architecture_info,omitempty"`
-	DevelopmentInfo  *DevelopmentInfo  `json:"development_info,omitempty"`
-	ConfigFiles      []ConfigFileInfo  `json:"config_files,omitempty"`
-	CLIInfo          *CLIInfo          `json:"cli_info,omitempty"`
-	ProjectTools     []ProjectTool     `json:"project_tools,omitempty"`
-	UsageInsights    *UsageInsights    `json:"usage_insights,omitempty"`
-	AIEnrichment     *AIEnrichment     `json:"ai_enrichment,omitempty"`
+	ProjectName       string             `json:"project_name"`
+	RootPath          string             `json:"root_path"`
+	TechStack         TechStack          `json:"tech_stack"`
+	Structure         ProjectStructure   `json:"structure"`
+	Conventions       []Convention       `json:"conventions"`
+	Dependencies      []Dependency       `json:"dependencies"`
+	Commands          []Command          `json:"commands"`
+	KeyFiles          []KeyFile          `json:"key_files"`
+	Endpoints         []Endpoint         `json:"endpoints,omitempty"`
+	Events            []Event            `json:"events,omitempty"`
+	DatabaseSchema    *DatabaseSchema    `json:"database_schema,omitempty"`
+	EnvVars           []EnvVar           `json:"env_vars,omitempty"`
+	ReadmeContent     *ReadmeContent     `json:"readme_content,omitempty"`
+	MonorepoInfo      *MonorepoInfo      `json:"monorepo_info,omitempty"`
+	CodePatterns      *CodePatterns      `json:"code_patterns,omitempty"`
+	GitConventions    *GitConventions    `json:"git_conventions,omitempty"`
+	ArchitectureInfo  *ArchitectureInfo  `json:"architecture_info,omitempty"`
+	ArchitectureRules *ArchitectureRules `json:"architecture_rules,omitempty"`
+	Ownership         *Ownership         `json:"ownership,omitempty"`
+	Release           *ReleaseInfo       `json:"release,omitempty"`
+	CIInfo            *CIInfo            `json:"ci_info,omitempty"`
+	Infrastructure    *Infrastructure    `json:"infrastructure,omitempty"`
+	Tests             *TestInventory     `json:"tests,omitempty"`
+	ErrorHandling     *ErrorHandling     `json:"error_handling,omitempty"`
+	MLWorkflow        *MLWorkflow        `json:"ml_workflow,omitempty"`
+	Notebooks         []Notebook         `json:"notebooks,omitempty"`
+	DevelopmentInfo   *DevelopmentInfo   `json:"development_info,omitempty"`
+	ConfigFiles       []ConfigFileInfo   `json:"config_files,omitempty"`
+	CLIInfo           *CLIInfo           `json:"cli_info,omitempty"`
+	ProjectTools      []ProjectTool      `json:"project_tools,omitempty"`
+	NestedContexts    []NestedContext    `json:"nested_contexts,omitempty"`
+	UsageInsights     *UsageInsights     `json:"usage_insights,omitempty"`
+	AIEnrichment      *AIEnrichment      `json:"ai_enrichment,omitempty"`
+}
+
+// NestedContext is a subdirectory that has its own local context file
+type NestedContext struct {
+	Path    string `json:"path"`
+	Purpose string `json:"purpose,omitempty"`
 }
 
 // ReadmeContent represents parsed README information
@@ -40,11 +59,20 @@ type ReadmeContent struct {
 
 // MonorepoInfo represents monorepo/workspace configuration
 type MonorepoInfo struct {
-	IsMonorepo     bool               `json:"is_monorepo"`
-	Tool           string             `json:"tool,omitempty"`            // Turborepo, Lerna, Nx, etc.
-	PackageManager string             `json:"package_manager,omitempty"` // npm, yarn, pnpm, bun
-	WorkspacePaths []string           `json:"workspace_paths,omitempty"`
-	Packages       []WorkspacePackage `json:"packages,omitempty"`
+	IsMonorepo     bool                  `json:"is_monorepo"`
+	Tool           string                `json:"tool,omitempty"`            // Turborepo, Lerna, Nx, etc.
+	PackageManager string                `json:"package_manager,omitempty"` // npm, yarn, pnpm, bun
+	WorkspacePaths []string              `json:"workspace_paths,omitempty"`
+	ExcludePaths   []string              `json:"exclude_paths,omitempty"` // Workspace globs to leave out, e.g. Cargo exclude
+	Packages       []WorkspacePackage    `json:"packages,omitempty"`
+	Dependencies   []WorkspaceDependency `json:"dependencies,omitempty"` // Internal dependencies between workspaces
+}
+
+// WorkspaceDependency is an edge in the internal workspace dependency graph
+type WorkspaceDependency struct {
+	From string `json:"from"` // Path of the workspace that depends on another
+	To   string `json:"to"`   // Path of the workspace it depends on
+	Kind string `json:"kind"` // npm, go, cargo, python, gradle
 }
 
 // WorkspacePackage represents a package in a monorepo
@@ -75,12 +103,23 @@ type CodePatterns struct {
 
 // PatternInfo represents a detected pattern
 type PatternInfo struct {
-	Name        string   `json:"name"`
-	Category    string   `json:"category"`
-	Description string   `json:"description"`
-	FileCount   int      `json:"file_count"`
-	Examples    []string `json:"examples,omitempty"`
-	Usage       string   `json:"usage,omitempty"`
+	Name        string    `json:"name"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	FileCount   int       `json:"file_count"`
+	Examples    []string  `json:"examples,omitempty"`
+	Usage       string    `json:"usage,omitempty"`
+	Confidence  float64   `json:"confidence,omitempty"` // 0-1, how strongly the codebase supports the pattern
+	Evidence    *Evidence `json:"evidence,omitempty"`
+}
+
+// Evidence records why a convention or pattern was reported
+type Evidence struct {
+	Source  string   `json:"source,omitempty"`  // Config file the finding was read from
+	Matches int      `json:"matches,omitempty"` // Occurrences that support the finding
+	Total   int      `json:"total,omitempty"`   // Occurrences examined, including ones that disagree
+	Files   int      `json:"files,omitempty"`   // Files the finding was seen in
+	Samples []string `json:"samples,omitempty"` // Example locations as path:line
 }
 
 // Endpoint represents an API endpoint
@@ -90,10 +129,62 @@ type Endpoint struct {
 	Handler     string `json:"handler,omitempty"`
 	File        string `json:"file"`
 	Line        int    `json:"line,omitempty"`
-	Auth        string `json:"auth,omitempty"`
+	Auth        string `json:"auth,omitempty"`   // Guard mechanism, e.g. "requireAuth", "Depends(get_current_user)"
+	Public      bool   `json:"public,omitempty"` // No auth guard detected (or explicitly marked public)
 	Description string `json:"description,omitempty"`
 }
 
+// Event represents a message producer or consumer on a queue or event bus
+type Event struct {
+	Broker  string `json:"broker"`            // Kafka, NATS, RabbitMQ, SQS, ...
+	Role    string `json:"role"`              // producer or consumer
+	Topic   string `json:"topic"`             // Topic, subject, queue or exchange/routing-key
+	Handler string `json:"handler,omitempty"` // Consumer handler function
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// DatabaseSchema represents tables parsed from migrations and ORM model definitions
+type DatabaseSchema struct {
+	Tools         []string      `json:"tools"`                    // Prisma, golang-migrate, Alembic, Django, TypeORM, Rails, ...
+	MigrationDirs []string      `json:"migration_dirs,omitempty"` // Where migration files live
+	Tables        []SchemaTable `json:"tables,omitempty"`
+	Commands      []Command     `json:"commands,omitempty"` // Create/apply migration commands
+}
+
+// SchemaTable represents a database table or ORM model
+type SchemaTable struct {
+	Name      string           `json:"name"`
+	Model     string           `json:"model,omitempty"` // ORM model name when it differs from the table name
+	File      string           `json:"file"`
+	Columns   []SchemaColumn   `json:"columns,omitempty"`
+	Relations []SchemaRelation `json:"relations,omitempty"`
+}
+
+// SchemaColumn represents a table column
+type SchemaColumn struct {
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Unique  bool   `json:"unique,omitempty"`
+}
+
+// SchemaRelation represents a foreign key or ORM relation to another table
+type SchemaRelation struct {
+	Column string `json:"column,omitempty"` // Local foreign key column, if any
+	Target string `json:"target"`           // Referenced table
+	Kind   string `json:"kind,omitempty"`   // belongs-to, has-many, one-to-one, many-to-many
+}
+
+// EnvVar represents an environment variable read or configured by the project
+type EnvVar struct {
+	Name       string   `json:"name"`
+	Default    string   `json:"default,omitempty"`
+	UsedIn     []string `json:"used_in,omitempty"`    // Source locations reading the variable (file:line)
+	DefinedIn  []string `json:"defined_in,omitempty"` // .env.example, docker-compose and Kubernetes files setting it
+	Documented bool     `json:"documented"`           // Listed in an env template or the README
+}
+
 // TechStack represents detected technologies
 type TechStack struct {
 	Languages  []Language  `json:"languages"`
@@ -124,16 +215,19 @@ type ProjectStructure struct {
 
 // Directory represents a directory in the project
 type Directory struct {
-	Path      string `json:"path"`
-	Purpose   string `json:"purpose,omitempty"`
-	FileCount int    `json:"file_count"`
+	Path      string   `json:"path"`
+	Purpose   string   `json:"purpose,omitempty"`
+	FileCount int      `json:"file_count"`
+	Owners    []string `json:"owners,omitempty"`
 }
 
 // Convention represents a detected coding convention
 type Convention struct {
-	Category    string `json:"category"` // naming, imports, structure, etc.
-	Description string `json:"description"`
-	Example     string `json:"example,omitempty"`
+	Category    string    `json:"category"` // naming, imports, structure, etc.
+	Description string    `json:"description"`
+	Example     string    `json:"example,omitempty"`
+	Confidence  float64   `json:"confidence,omitempty"` // 0-1, how strongly the codebase supports the convention
+	Evidence    *Evidence `json:"evidence,omitempty"`
 }
 
 // Dependency represents a project dependency
@@ -148,6 +242,7 @@ type Command struct {
 	Name        string `json:"name"`
 	Command     string `json:"command"`
 	Description string `json:"description,omitempty"`
+	CIVerified  bool   `json:"ci_verified,omitempty"` // CI runs this command
 }
 
 // ProjectTool represents a project-specific CLI tool that deserves a skill
@@ -164,9 +259,307 @@ type ProjectTool struct {
 
 // KeyFile represents an important file in the project
 type KeyFile struct {
-	Path        string `json:"path"`
-	Purpose     string `json:"purpose"`
-	Description string `json:"description,omitempty"`
+	Path        string   `json:"path"`
+	Purpose     string   `json:"purpose"`
+	Description string   `json:"description,omitempty"`
+	Owners      []string `json:"owners,omitempty"`
+}
+
+// Ownership represents who owns which paths, from CODEOWNERS or git history
+type Ownership struct {
+	Source  string           `json:"source"` // CODEOWNERS file path, or "git history"
+	Entries []OwnershipEntry `json:"entries,omitempty"`
+}
+
+// OwnershipEntry maps a path pattern to its owners
+type OwnershipEntry struct {
+	Path    string   `json:"path"`
+	Owners  []string `json:"owners,omitempty"`  // Empty means explicitly unowned
+	Section string   `json:"section,omitempty"` // GitLab CODEOWNERS section
+}
+
+// MLWorkflow describes how a machine learning project trains, tracks and stores models
+type MLWorkflow struct {
+	Trackers    []ExperimentTracker `json:"trackers,omitempty"`
+	Stages      []PipelineStage     `json:"stages,omitempty"` // DVC pipeline stages in dvc.yaml order
+	Config      *MLConfig           `json:"config,omitempty"`
+	EntryPoints []MLEntryPoint      `json:"entry_points,omitempty"`
+	Checkpoints *CheckpointInfo     `json:"checkpoints,omitempty"`
+	Devices     []DeviceSelection   `json:"devices,omitempty"`
+}
+
+// ExperimentTracker is an experiment tracking tool and the calls the code logs through
+type ExperimentTracker struct {
+	Name    string   `json:"name"`              // MLflow, Weights & Biases, DVC
+	Project string   `json:"project,omitempty"` // W&B project or MLflow experiment name
+	Calls   []string `json:"calls,omitempty"`   // e.g. mlflow.log_metric, wandb.init
+	Files   []string `json:"files,omitempty"`
+}
+
+// PipelineStage is a stage of a DVC pipeline
+type PipelineStage struct {
+	Name    string   `json:"name"`
+	Command string   `json:"command"`
+	Deps    []string `json:"deps,omitempty"`
+	Outs    []string `json:"outs,omitempty"`
+	Params  []string `json:"params,omitempty"`
+	Metrics []string `json:"metrics,omitempty"`
+	After   []string `json:"after,omitempty"` // Stages whose outputs this stage depends on
+}
+
+// MLConfig is a Hydra or OmegaConf configuration tree
+type MLConfig struct {
+	Framework string        `json:"framework"`         // Hydra or OmegaConf
+	Dir       string        `json:"dir,omitempty"`     // Config root, e.g. conf
+	Primary   string        `json:"primary,omitempty"` // Primary config file
+	Groups    []ConfigGroup `json:"groups,omitempty"`
+	Defaults  []string      `json:"defaults,omitempty"` // group=option entries of the primary defaults list
+}
+
+// ConfigGroup is a config group directory and the options it offers
+type ConfigGroup struct {
+	Name    string   `json:"name"`
+	Options []string `json:"options"`
+}
+
+// MLEntryPoint is a training or evaluation script
+type MLEntryPoint struct {
+	Path    string    `json:"path"`
+	Kind    string    `json:"kind"` // train or eval
+	Command string    `json:"command"`
+	Hydra   bool      `json:"hydra,omitempty"` // Configured with Hydra overrides rather than flags
+	Args    []CLIFlag `json:"args,omitempty"`
+}
+
+// CheckpointInfo describes where and how model checkpoints are saved
+type CheckpointInfo struct {
+	Dir     string   `json:"dir,omitempty"`     // Directory checkpoints are written to
+	Format  string   `json:"format,omitempty"`  // File extension, e.g. .pt
+	Pattern string   `json:"pattern,omitempty"` // File name pattern, e.g. epoch_{epoch}.pt
+	Saves   []string `json:"saves,omitempty"`   // Save calls, e.g. torch.save
+	Files   []string `json:"files,omitempty"`
+}
+
+// DeviceSelection is one way the code picks the device it runs on
+type DeviceSelection struct {
+	Method  string   `json:"method"`            // e.g. CUDA with CPU fallback, Accelerate
+	Example string   `json:"example,omitempty"` // A representative line
+	Files   []string `json:"files,omitempty"`
+}
+
+// Notebook is a Jupyter notebook and what its code cells use
+type Notebook struct {
+	Path      string   `json:"path"`
+	Cells     int      `json:"cells"`                // Code cells
+	Title     string   `json:"title,omitempty"`      // First heading; kept when notebook summaries are enabled
+	Sections  []string `json:"sections,omitempty"`   // Second-level headings; kept when notebook summaries are enabled
+	Imports   []string `json:"imports,omitempty"`    // Top-level modules imported
+	Magics    []string `json:"magics,omitempty"`     // IPython magics and shell escapes, e.g. %matplotlib, !pip
+	DataPaths []string `json:"data_paths,omitempty"` // Data files read or written
+	Functions []string `json:"functions,omitempty"`  // Functions defined in the notebook
+}
+
+// ErrorHandling describes how the codebase reports errors and logs
+type ErrorHandling struct {
+	Practices []ErrorPractice `json:"practices,omitempty"`
+	Loggers   []LoggerUsage   `json:"loggers,omitempty"`
+}
+
+// ErrorPractice is an error handling habit of one language in the codebase
+type ErrorPractice struct {
+	Language    string    `json:"language"`
+	Kind        string    `json:"kind"`               // wrapping, sentinel, custom-type, panic, hierarchy, chaining, result, throw
+	Instruction string    `json:"instruction"`        // What new code should do
+	Examples    []string  `json:"examples,omitempty"` // Names or path:line locations from the codebase
+	Files       int       `json:"files"`
+	Confidence  float64   `json:"confidence,omitempty"` // 0-1, how strongly the codebase supports the practice
+	Evidence    *Evidence `json:"evidence,omitempty"`
+}
+
+// LoggerUsage is the logger one language's code logs through
+type LoggerUsage struct {
+	Language    string    `json:"language"`
+	Library     string    `json:"library"`               // slog, zap, zerolog, logrus, structlog, logging, pino, winston
+	FieldStyle  string    `json:"field_style,omitempty"` // snake_case, camelCase, dot.case
+	Instruction string    `json:"instruction"`
+	Fields      []string  `json:"fields,omitempty"` // Common field keys
+	Files       int       `json:"files"`
+	Confidence  float64   `json:"confidence,omitempty"` // 0-1, how strongly the codebase supports the logger
+	Evidence    *Evidence `json:"evidence,omitempty"`
+}
+
+// ReleaseInfo describes how the project versions and publishes releases
+type ReleaseInfo struct {
+	Tools        []ReleaseTool `json:"tools,omitempty"`
+	Changelog    *Changelog    `json:"changelog,omitempty"`
+	VersionFiles []VersionFile `json:"version_files,omitempty"`
+	TagPattern   string        `json:"tag_pattern,omitempty"` // e.g. v<major>.<minor>.<patch>
+	LatestTag    string        `json:"latest_tag,omitempty"`
+}
+
+// ReleaseTool represents a release automation tool and how it is run
+type ReleaseTool struct {
+	Name       string `json:"name"` // GoReleaser, semantic-release, Changesets, release-please, cargo-release
+	ConfigFile string `json:"config_file,omitempty"`
+	Command    string `json:"command,omitempty"`
+	CI         bool   `json:"ci,omitempty"` // Runs from a CI workflow
+}
+
+// Changelog represents the project's changelog file
+type Changelog struct {
+	Path       string `json:"path"`
+	Format     string `json:"format"`               // "Keep a Changelog", "generated", "custom"
+	Generator  string `json:"generator,omitempty"`  // Tool that writes it, when generated
+	Unreleased bool   `json:"unreleased,omitempty"` // Has an [Unreleased] section
+}
+
+// VersionFile is a file that records the project version
+type VersionFile struct {
+	Path    string `json:"path"`
+	Version string `json:"version,omitempty"`
+}
+
+// CIInfo describes the project's CI pipelines
+type CIInfo struct {
+	Pipelines      []CIPipeline `json:"pipelines,omitempty"`
+	Commands       []CICommand  `json:"commands,omitempty"`        // Build, test, lint and format commands CI runs
+	RequiredChecks []string     `json:"required_checks,omitempty"` // From branch protection in .github/settings.yml
+}
+
+// CIPipeline represents one CI configuration file
+type CIPipeline struct {
+	Provider string   `json:"provider"` // GitHub Actions, GitLab CI, CircleCI, Jenkins, Azure Pipelines
+	File     string   `json:"file"`
+	Name     string   `json:"name,omitempty"`
+	Triggers []string `json:"triggers,omitempty"` // push, pull_request, schedule, tags, ...
+	Jobs     []CIJob  `json:"jobs,omitempty"`
+}
+
+// CIJob represents a job and the shell commands it runs
+type CIJob struct {
+	Name     string   `json:"name"`
+	Stage    string   `json:"stage,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// CICommand is a build, test, lint or format command run by CI
+type CICommand struct {
+	Category string `json:"category"` // Build, Test, Lint, Format
+	Command  string `json:"command"`
+	Dir      string `json:"dir,omitempty"` // Working directory, relative to the root
+	Job      string `json:"job"`
+	File     string `json:"file"`
+}
+
+// Infrastructure describes how the project is packaged, deployed and run
+type Infrastructure struct {
+	Dockerfiles     []Dockerfile     `json:"dockerfiles,omitempty"`
+	ComposeServices []ComposeService `json:"compose_services,omitempty"`
+	Kubernetes      []K8sResource    `json:"kubernetes,omitempty"`
+	HelmCharts      []HelmChart      `json:"helm_charts,omitempty"`
+	Kustomizations  []string         `json:"kustomizations,omitempty"` // Directories with a kustomization.yaml
+	Terraform       *Terraform       `json:"terraform,omitempty"`
+}
+
+// Dockerfile represents a parsed Dockerfile
+type Dockerfile struct {
+	Path         string        `json:"path"`
+	Stages       []DockerStage `json:"stages"`
+	ExposedPorts []string      `json:"exposed_ports,omitempty"`
+	Command      string        `json:"command,omitempty"` // ENTRYPOINT/CMD of the final stage
+}
+
+// DockerStage is one FROM stage of a Dockerfile
+type DockerStage struct {
+	Name  string `json:"name,omitempty"`
+	Image string `json:"image"`
+}
+
+// ComposeService represents a docker-compose service
+type ComposeService struct {
+	Name      string   `json:"name"`
+	File      string   `json:"file"`
+	Image     string   `json:"image,omitempty"`
+	Build     string   `json:"build,omitempty"` // Build context, when built from source
+	Ports     []string `json:"ports,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	Backing   bool     `json:"backing,omitempty"` // Database, cache, queue or other backing service
+}
+
+// K8sResource represents a Kubernetes object from a manifest
+type K8sResource struct {
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Images   []string `json:"images,omitempty"`
+	Replicas int      `json:"replicas,omitempty"`
+}
+
+// HelmChart represents a Helm chart in the repository
+type HelmChart struct {
+	Name         string   `json:"name"`
+	Path         string   `json:"path"`
+	Version      string   `json:"version,omitempty"`
+	AppVersion   string   `json:"app_version,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// Terraform summarizes Terraform configuration
+type Terraform struct {
+	Dirs      []string          `json:"dirs"`
+	Providers []string          `json:"providers,omitempty"`
+	Modules   []TerraformModule `json:"modules,omitempty"`
+	Backend   string            `json:"backend,omitempty"`
+}
+
+// TerraformModule is a module block and its source
+type TerraformModule struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// TestInventory describes where tests live, how many there are and how to run them
+type TestInventory struct {
+	TestFiles int             `json:"test_files"`
+	TestCount int             `json:"test_count"`
+	Runners   []TestRunner    `json:"runners,omitempty"`
+	Areas     []TestArea      `json:"areas,omitempty"`
+	Untested  []string        `json:"untested,omitempty"` // Source directories without tests, largest first
+	Fixtures  []string        `json:"fixtures,omitempty"` // testdata and fixture directories
+	Helpers   []string        `json:"helpers,omitempty"`  // Shared test setup and helper files
+	Mocks     []MockTool      `json:"mocks,omitempty"`
+	Coverage  *CoverageReport `json:"coverage,omitempty"`
+}
+
+// TestRunner describes how to run part of the suite with one test framework.
+// Commands use <file>, <package> and <name> placeholders.
+type TestRunner struct {
+	Language  string `json:"language"`
+	Framework string `json:"framework"`
+	RunFile   string `json:"run_file"`
+	RunSingle string `json:"run_single"`
+}
+
+// TestArea maps a source directory to the tests that cover it
+type TestArea struct {
+	Dir      string  `json:"dir"`      // Source directory under test
+	TestDir  string  `json:"test_dir"` // Where its tests live; same as Dir when colocated
+	Files    int     `json:"files"`
+	Tests    int     `json:"tests"`
+	Coverage float64 `json:"coverage,omitempty"` // Line coverage percentage, when a report exists
+}
+
+// MockTool is a mocking library or generator and where it is configured or used
+type MockTool struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// CoverageReport summarizes an existing coverage report
+type CoverageReport struct {
+	Path    string  `json:"path"`
+	Format  string  `json:"format"` // go, lcov or cobertura
+	Percent float64 `json:"percent"`
 }
 
 // Config represents Argus configuration
@@ -229,6 +622,50 @@ type GitConventions struct {
 	BranchConvention *BranchConvention `json:"branch_convention,omitempty"`
 	Repository       *GitRepository    `json:"repository,omitempty"`
 	RecentCommits    []GitCommit       `json:"recent_commits,omitempty"`
+	Churn            *ChurnInfo        `json:"churn,omitempty"`
+	Tickets          []TicketReference `json:"tickets,omitempty"`     // How commits reference issues
+	SignOff          bool              `json:"sign_off,omitempty"`    // Commits need a Signed-off-by trailer (DCO)
+	CommitLint       *CommitLint       `json:"commit_lint,omitempty"` // commitlint / commitizen config
+}
+
+// TicketReference represents how commits reference tickets or issues
+type TicketReference struct {
+	Format   string `json:"format"`            // e.g. "ABC-123", "#123"
+	Location string `json:"location"`          // subject or trailer
+	Trailer  string `json:"trailer,omitempty"` // Trailer key, e.g. "Refs"
+	Example  string `json:"example,omitempty"`
+}
+
+// CommitLint represents commit message rules from commitlint or commitizen config
+type CommitLint struct {
+	ConfigFile      string   `json:"config_file"`
+	Extends         []string `json:"extends,omitempty"`
+	Types           []string `json:"types,omitempty"`  // type-enum
+	Scopes          []string `json:"scopes,omitempty"` // scope-enum
+	HeaderMaxLength int      `json:"header_max_length,omitempty"`
+	Commitizen      string   `json:"commitizen,omitempty"` // Commitizen adapter, e.g. cz-conventional-changelog
+}
+
+// ChurnInfo represents change frequency and co-change coupling over a window of history
+type ChurnInfo struct {
+	WindowDays      int         `json:"window_days"`
+	CommitsAnalyzed int         `json:"commits_analyzed"`
+	Hotspots        []FileChurn `json:"hotspots,omitempty"`   // Most frequently changed files
+	CoChanges       []CoChange  `json:"co_changes,omitempty"` // Files that change together
+}
+
+// FileChurn represents how often a file changed in the window
+type FileChurn struct {
+	Path    string `json:"path"`
+	Changes int    `json:"changes"` // Commits touching the file in the window
+	Recent  int    `json:"recent"`  // Commits touching the file in the last 30 days of the window
+}
+
+// CoChange represents two files that are usually committed together
+type CoChange struct {
+	Files    []string `json:"files"`
+	Together int      `json:"together"` // Commits touching both files
+	Coupling float64  `json:"coupling"` // Together / changes of the less frequently changed file
 }
 
 // CommitConvention represents detected commit message conventions
@@ -238,6 +675,9 @@ type CommitConvention struct {
 	Types   []string `json:"types,omitempty"`  // feat, fix, docs, etc.
 	Scopes  []string `json:"scopes,omitempty"` // api, ui, core, etc.
 	Example string   `json:"example"`
+
+	ScopeDirs map[string]string `json:"scope_dirs,omitempty"` // Scope -> directory its commits touch
+	Breaking  []string          `json:"breaking,omitempty"`   // Breaking change markers in use: "!", "BREAKING CHANGE:"
 }
 
 // BranchConvention represents detected branch naming conventions
@@ -249,10 +689,11 @@ type BranchConvention struct {
 
 // ArchitectureInfo represents detected architecture
 type ArchitectureInfo struct {
-	Style      string              `json:"style,omitempty"`       // layered, modular, clean, etc.
-	Layers     []ArchitectureLayer `json:"layers,omitempty"`      // detected layers
-	EntryPoint string              `json:"entry_point,omitempty"` // main entry point
-	Diagram    string              `json:"diagram,omitempty"`     // text-based diagram
+	Style        string              `json:"style,omitempty"`        // layered, modular, clean, etc.
+	Layers       []ArchitectureLayer `json:"layers,omitempty"`       // detected layers
+	EntryPoint   string              `json:"entry_point,omitempty"`  // main entry point
+	Diagram      string              `json:"diagram,omitempty"`      // text-based diagram
+	Dependencies map[string][]string `json:"dependencies,omitempty"` // module -> imported modules, excluding tests
 }
 
 // ArchitectureLayer represents an architectural layer
@@ -263,6 +704,19 @@ type ArchitectureLayer struct {
 	DependsOn []string `json:"depends_on,omitempty"`
 }
 
+// ArchitectureRules represents layer boundaries declared in .argus.yaml
+type ArchitectureRules struct {
+	Layers      []ArchitectureRule `json:"layers"`
+	AllowCycles bool               `json:"allow_cycles,omitempty"`
+}
+
+// ArchitectureRule declares a layer and the layers it may depend on
+type ArchitectureRule struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths"`           // package path globs
+	Allow []string `json:"allow,omitempty"` // layers this layer may import
+}
+
 // GeneratedFile represents a file to be written by a multi-file generator
 type GeneratedFile struct {
 	Path    string // Relative path, e.g., ".claude/agents/go-reviewer.md"
@@ -309,9 +763,32 @@ type ConfigFileInfo struct {
 
 // CLIInfo represents CLI tool information
 type CLIInfo struct {
-	VerboseFlag string      `json:"verbose_flag,omitempty"`
-	DryRunFlag  string      `json:"dry_run_flag,omitempty"`
-	Indicators  []Indicator `json:"indicators,omitempty"`
+	VerboseFlag string       `json:"verbose_flag,omitempty"`
+	DryRunFlag  string       `json:"dry_run_flag,omitempty"`
+	Indicators  []Indicator  `json:"indicators,omitempty"`
+	Framework   string       `json:"framework,omitempty"` // Cobra, urfave/cli, Click, Typer, argparse, Clap
+	Commands    []CLICommand `json:"commands,omitempty"`  // Root commands with their subcommand trees
+}
+
+// CLICommand represents a command in a CLI command tree
+type CLICommand struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Args        string       `json:"args,omitempty"` // Positional arguments usage, e.g. "[path]"
+	Aliases     []string     `json:"aliases,omitempty"`
+	Flags       []CLIFlag    `json:"flags,omitempty"`
+	File        string       `json:"file,omitempty"`
+	Subcommands []CLICommand `json:"subcommands,omitempty"`
+}
+
+// CLIFlag represents a command-line flag
+type CLIFlag struct {
+	Name       string `json:"name"` // Long name without dashes
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type,omitempty"` // bool, string, int, stringSlice, ...
+	Default    string `json:"default,omitempty"`
+	Usage      string `json:"usage,omitempty"`
+	Persistent bool   `json:"persistent,omitempty"` // Inherited by subcommands
 }
 
 // Indicator represents a CLI output indicator